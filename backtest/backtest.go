@@ -0,0 +1,198 @@
+/*
+	import "github.com/jaybutera/gotrade/backtest"
+
+	Package backtest evaluates trading strategies historically, by replaying the bars of a feed
+	through a price stream and simulating the orders the strategy places on each bar.
+	A backtest follows the sequence:
+		- the strategy attaches its indicators to the price stream in Init.
+		- for each bar, the pending orders the bar trades through are filled, at the open
+		- for market orders and at the limit or stop price, or the open when the bar gaps beyond it.
+		- the strategy's OnBar is called once the attached indicators have received the bar.
+		- market orders placed during OnBar fill at the next bar's open, or at the bar's close
+		- with the same bar close fill model.
+		- the equity at the bar's close is added to the equity curve.
+		- the config's warm up bars at the start of the feed are only received by the indicators.
+
+	e.g.
+
+		type smaCross struct {
+			fast, slow *indicators.Sma
+		}
+
+		func (s *smaCross) Init(priceStream gotrade.DOHLCVStreamSubscriber, broker *backtest.Broker) (err error) {
+			s.fast, err = indicators.NewSmaForStream(priceStream, 10, gotrade.UseClosePrice)
+			...
+		}
+
+		func (s *smaCross) OnBar(bar gotrade.DOHLCV, streamBarIndex int, broker *backtest.Broker) {
+			if !backtest.HasValueForBar(s.slow, streamBarIndex) {
+				return
+			}
+			...
+			broker.Buy(100)
+		}
+
+		result, err := backtest.NewBacktest(csvFeed, &smaCross{}, backtest.DefaultConfig()).Run()
+*/
+package backtest
+
+import (
+	"github.com/jaybutera/gotrade"
+	"time"
+)
+
+// A Feed fills a price stream with its bars, such as a CSVFileFeed
+type Feed interface {
+	FillDOHLCVStream(priceStream gotrade.DOHLCVStreamTickReceiver) error
+}
+
+// A Strategy decides the orders to place on each bar
+type Strategy interface {
+	// Init is called before the first bar, to attach the indicators the strategy uses to the price stream
+	Init(priceStream gotrade.DOHLCVStreamSubscriber, broker *Broker) error
+	// OnBar is called once the bar has been received by the attached indicators and the pending orders filled
+	OnBar(bar gotrade.DOHLCV, streamBarIndex int, broker *Broker)
+}
+
+// A Series is an indicator or operator whose values start at a bar of the price stream
+type Series interface {
+	// the source data bar number from which the series is valid, starts at bar 1.
+	ValidFromBar() int
+	// the number of values in the series
+	Length() int
+}
+
+// HasValueForBar returns true when the latest value of the series is for the bar, a strategy's OnBar
+// checks it before reading the series, which has no value for the bars of its lookback period
+func HasValueForBar(series Series, streamBarIndex int) bool {
+	return series.Length() > 0 && series.ValidFromBar()+series.Length()-1 == streamBarIndex
+}
+
+// The Config of a backtest
+type Config struct {
+	InitialCash float64
+	FillModel   FillModel
+	Commission  CommissionModel
+	Slippage    SlippageModel
+	// creates the price stream the feed fills, a daily stream when nil. The stream must notify its
+	// subscribers synchronously so that the strategy's indicators receive each bar before the strategy.
+	NewPriceStream func() *gotrade.DOHLCVStream
+	// the bars at the start of the feed that only warm up the strategy's indicators, the strategy is not
+	// called for them, no order is filled on them and they are not in the equity curve
+	WarmUpBars int
+}
+
+// DefaultConfig returns a config of 100000 initial cash, filling market orders at the next bar's open
+// without commission or slippage
+func DefaultConfig() Config {
+	return Config{InitialCash: 100000.0, FillModel: NextBarOpen, Commission: NoCommission{}, Slippage: NoSlippage{}}
+}
+
+// An EquityPoint is the value of the account at the close of a bar
+type EquityPoint struct {
+	StreamBarIndex int
+	Date           time.Time
+	Cash           float64
+	Position       float64
+	Equity         float64
+}
+
+// The Result of a backtest
+type Result struct {
+	InitialCash float64
+	// the equity at the close of the last bar, including the value of any open position
+	FinalEquity float64
+	// the closed trades in the order they closed
+	Trades []*Trade
+	// the trade still open at the end of the backtest, nil when flat
+	OpenTrade *Trade
+	// every order placed, in the order placed
+	Orders []*Order
+	// the equity at the close of every bar
+	EquityCurve []EquityPoint
+}
+
+// A Backtest runs a strategy over the bars of a feed
+type Backtest struct {
+	feed     Feed
+	strategy Strategy
+	config   Config
+}
+
+// NewBacktest creates a backtest of the strategy over the feed, nil models in the config are
+// taken as no commission and no slippage
+func NewBacktest(feed Feed, strategy Strategy, config Config) *Backtest {
+	if config.Commission == nil {
+		config.Commission = NoCommission{}
+	}
+
+	if config.Slippage == nil {
+		config.Slippage = NoSlippage{}
+	}
+
+	if config.NewPriceStream == nil {
+		config.NewPriceStream = func() *gotrade.DOHLCVStream {
+			return gotrade.NewDailyDOHLCVStream().DOHLCVStream
+		}
+	}
+
+	b := Backtest{feed: feed, strategy: strategy, config: config}
+	return &b
+}
+
+// Run replays the feed through a new price stream and broker, a strategy holding state between
+// runs must reset it in Init
+func (b *Backtest) Run() (result *Result, err error) {
+	priceStream := b.config.NewPriceStream()
+	r := runner{strategy: b.strategy, broker: newBroker(b.config), warmUpBars: b.config.WarmUpBars}
+
+	if err = b.strategy.Init(priceStream, r.broker); err != nil {
+		return nil, err
+	}
+
+	// subscribing after the strategy's indicators, so that they receive each bar first
+	priceStream.AddTickSubscription(&r)
+
+	if err = b.feed.FillDOHLCVStream(priceStream); err != nil {
+		return nil, err
+	}
+
+	// publish the last bar if the feed ended part way through its period
+	priceStream.Flush()
+
+	result = &Result{
+		InitialCash: b.config.InitialCash,
+		FinalEquity: r.broker.Equity(),
+		Trades:      r.broker.trades,
+		OpenTrade:   r.broker.trade,
+		Orders:      r.broker.orders,
+		EquityCurve: r.equityCurve,
+	}
+	return result, nil
+}
+
+// runner drives the broker and the strategy from the bars of the price stream
+type runner struct {
+	strategy    Strategy
+	broker      *Broker
+	warmUpBars  int
+	equityCurve []EquityPoint
+}
+
+func (r *runner) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	// the stream's bars are counted from 1
+	if streamBarIndex <= r.warmUpBars {
+		return
+	}
+
+	r.broker.openBar(tickData, streamBarIndex)
+	r.strategy.OnBar(tickData, streamBarIndex, r.broker)
+
+	r.equityCurve = append(r.equityCurve, EquityPoint{
+		StreamBarIndex: streamBarIndex,
+		Date:           tickData.D(),
+		Cash:           r.broker.cash,
+		Position:       r.broker.position,
+		Equity:         r.broker.Equity(),
+	})
+}
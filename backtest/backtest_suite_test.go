@@ -0,0 +1,75 @@
+package backtest_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/jaybutera/gotrade"
+	"github.com/jaybutera/gotrade/backtest"
+	"github.com/jaybutera/gotrade/feeds"
+	"testing"
+	"time"
+)
+
+var (
+	csvFeed *feeds.CSVFileFeed
+)
+
+func TestBacktest(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Backtest Suite")
+}
+
+var _ = BeforeSuite(func() {
+	csvFeed = feeds.NewCSVFileFeedWithDOHLCVFormat("../testdata/JSETOPI.ALL.data",
+		feeds.DashedYearDayMonthDateParserForLocation(time.Local))
+})
+
+var _ = AfterSuite(func() {
+	csvFeed = nil
+})
+
+// barFeed fills a stream with bars given in the test
+type barFeed []gotrade.DOHLCV
+
+func (f barFeed) FillDOHLCVStream(priceStream gotrade.DOHLCVStreamTickReceiver) error {
+	for _, bar := range f {
+		priceStream.ReceiveTick(bar)
+	}
+	return nil
+}
+
+// bars creates a bar for each day from the open, high, low and close of each
+func bars(ohlc ...[4]float64) barFeed {
+	start := time.Date(2014, time.January, 1, 0, 0, 0, 0, time.UTC)
+	feed := make(barFeed, len(ohlc))
+	for i, prices := range ohlc {
+		feed[i] = gotrade.NewDOHLCVDataItem(start.AddDate(0, 0, i), prices[0], prices[1], prices[2], prices[3], 1000.0)
+	}
+	return feed
+}
+
+// scriptedStrategy calls the function given for a bar, if any, with the broker
+type scriptedStrategy struct {
+	init  func(priceStream gotrade.DOHLCVStreamSubscriber) error
+	onBar map[int]func(broker *backtest.Broker)
+}
+
+func (s *scriptedStrategy) Init(priceStream gotrade.DOHLCVStreamSubscriber, broker *backtest.Broker) error {
+	if s.init != nil {
+		return s.init(priceStream)
+	}
+	return nil
+}
+
+func (s *scriptedStrategy) OnBar(bar gotrade.DOHLCV, streamBarIndex int, broker *backtest.Broker) {
+	if action, ok := s.onBar[streamBarIndex]; ok {
+		action(broker)
+	}
+}
+
+// barsConfig is the default config over a stream publishing every bar it receives
+func barsConfig() backtest.Config {
+	config := backtest.DefaultConfig()
+	config.NewPriceStream = gotrade.NewDOHLCVStream
+	return config
+}
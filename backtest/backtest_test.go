@@ -0,0 +1,214 @@
+package backtest_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/jaybutera/gotrade"
+	"github.com/jaybutera/gotrade/backtest"
+	"github.com/jaybutera/gotrade/indicators"
+	"github.com/jaybutera/gotrade/operators"
+)
+
+// smaCross holds a long position whilst the fast sma is above the slow sma
+type smaCross struct {
+	fast         *indicators.Sma
+	slow         *indicators.Sma
+	crossesAbove *operators.CrossesAbove
+	crossesBelow *operators.CrossesBelow
+	quantity     float64
+}
+
+func (s *smaCross) Init(priceStream gotrade.DOHLCVStreamSubscriber, broker *backtest.Broker) (err error) {
+	if s.fast, err = indicators.NewSmaForStream(priceStream, 10, gotrade.UseClosePrice); err != nil {
+		return err
+	}
+	if s.slow, err = indicators.NewSmaForStream(priceStream, 30, gotrade.UseClosePrice); err != nil {
+		return err
+	}
+	if s.crossesAbove, err = operators.NewCrossesAboveForStream(s.fast, s.slow); err != nil {
+		return err
+	}
+	s.crossesBelow, err = operators.NewCrossesBelowForStream(s.fast, s.slow)
+	return err
+}
+
+func (s *smaCross) OnBar(bar gotrade.DOHLCV, streamBarIndex int, broker *backtest.Broker) {
+	if !backtest.HasValueForBar(s.crossesAbove, streamBarIndex) {
+		return
+	}
+
+	if s.crossesAbove.Data[len(s.crossesAbove.Data)-1] && broker.Position() == 0.0 {
+		broker.Buy(s.quantity)
+	} else if s.crossesBelow.Data[len(s.crossesBelow.Data)-1] && broker.Position() > 0.0 {
+		broker.ClosePosition()
+	}
+}
+
+var _ = Describe("when backtesting with the full history", func() {
+	var (
+		priceStream *gotrade.InterDayDOHLCVStream
+	)
+
+	BeforeEach(func() {
+		priceStream = gotrade.NewDailyDOHLCVStream()
+		csvFeed.FillDOHLCVStream(priceStream)
+	})
+
+	It("should hold a position bought on the first bar to the end", func() {
+		strategy := &scriptedStrategy{onBar: map[int]func(broker *backtest.Broker){
+			1: func(broker *backtest.Broker) { broker.Buy(1) },
+		}}
+		result, err := backtest.NewBacktest(csvFeed, strategy, backtest.DefaultConfig()).Run()
+		Expect(err).NotTo(HaveOccurred())
+
+		last := priceStream.Data[len(priceStream.Data)-1]
+		Expect(result.EquityCurve).To(HaveLen(len(priceStream.Data)))
+		Expect(result.Trades).To(BeEmpty())
+		Expect(result.OpenTrade.EntryPrice).To(Equal(priceStream.Data[1].O()))
+		Expect(result.FinalEquity).To(Equal(result.InitialCash - priceStream.Data[1].O() + last.C()))
+	})
+
+	It("should trade the crossings of a fast and slow sma", func() {
+		result, err := backtest.NewBacktest(csvFeed, &smaCross{quantity: 2}, backtest.DefaultConfig()).Run()
+		Expect(err).NotTo(HaveOccurred())
+
+		// replay the crossings independently, entering and exiting at the next bar's open
+		fast, _ := indicators.NewSma(10, gotrade.UseClosePrice)
+		slow, _ := indicators.NewSma(30, gotrade.UseClosePrice)
+		for i, bar := range priceStream.Data {
+			fast.ReceiveDOHLCVTick(bar, i+1)
+			slow.ReceiveDOHLCVTick(bar, i+1)
+		}
+
+		var entries, exits []float64
+		profit := 0.0
+		held := false
+		offset := len(fast.Data) - len(slow.Data)
+		for k := 1; k < len(slow.Data); k++ {
+			bar := slow.ValidFromBar() + k
+			if bar >= len(priceStream.Data) {
+				break
+			}
+			fastBefore, fastNow := fast.Data[offset+k-1], fast.Data[offset+k]
+			slowBefore, slowNow := slow.Data[k-1], slow.Data[k]
+			nextOpen := priceStream.Data[bar].O()
+
+			if !held && fastBefore <= slowBefore && fastNow > slowNow {
+				entries = append(entries, nextOpen)
+				held = true
+			} else if held && fastBefore >= slowBefore && fastNow < slowNow {
+				exits = append(exits, nextOpen)
+				profit += 2 * (nextOpen - entries[len(entries)-1])
+				held = false
+			}
+		}
+
+		Expect(len(result.Trades)).To(BeNumerically(">", 10))
+		Expect(result.Trades).To(HaveLen(len(exits)))
+		for i, trade := range result.Trades {
+			Expect(trade.EntryPrice).To(Equal(entries[i]))
+			Expect(trade.ExitPrice).To(Equal(exits[i]))
+			Expect(trade.Quantity).To(Equal(2.0))
+		}
+
+		closedProfit := 0.0
+		for _, trade := range result.Trades {
+			closedProfit += trade.ProfitLoss
+		}
+		Expect(closedProfit).To(BeNumerically("~", profit, 1e-6))
+
+		openValue := 0.0
+		if held {
+			Expect(result.OpenTrade).NotTo(BeNil())
+			openValue = 2 * (priceStream.Data[len(priceStream.Data)-1].C() - entries[len(entries)-1])
+		}
+		Expect(result.FinalEquity).To(BeNumerically("~", result.InitialCash+profit+openValue, 1e-6))
+	})
+
+	It("should give the same result when run again", func() {
+		b := backtest.NewBacktest(csvFeed, &smaCross{quantity: 1}, backtest.DefaultConfig())
+		first, _ := b.Run()
+		second, _ := b.Run()
+		Expect(second.FinalEquity).To(Equal(first.FinalEquity))
+		Expect(second.Trades).To(HaveLen(len(first.Trades)))
+	})
+})
+
+var _ = Describe("when backtesting after warm up bars", func() {
+	var (
+		priceStream *gotrade.InterDayDOHLCVStream
+		sma         *indicators.Sma
+		result      *backtest.Result
+		err         error
+	)
+
+	BeforeEach(func() {
+		priceStream = gotrade.NewDailyDOHLCVStream()
+		csvFeed.FillDOHLCVStream(priceStream)
+
+		strategy := &scriptedStrategy{
+			init: func(priceStream gotrade.DOHLCVStreamSubscriber) (err error) {
+				sma, err = indicators.NewSmaForStream(priceStream, 30, gotrade.UseClosePrice)
+				return err
+			},
+			onBar: map[int]func(broker *backtest.Broker){
+				10: func(broker *backtest.Broker) { broker.Buy(1) },
+				41: func(broker *backtest.Broker) { broker.Buy(2) },
+			}}
+		config := backtest.DefaultConfig()
+		config.WarmUpBars = 40
+		result, err = backtest.NewBacktest(csvFeed, strategy, config).Run()
+	})
+
+	It("should warm up the indicators on the warm up bars", func() {
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sma.ValidFromBar()).To(Equal(30))
+		Expect(sma.Data).To(HaveLen(len(priceStream.Data) - 29))
+	})
+
+	It("should only trade and record the equity after the warm up bars", func() {
+		Expect(result.EquityCurve).To(HaveLen(len(priceStream.Data) - 40))
+		Expect(result.EquityCurve[0].StreamBarIndex).To(Equal(41))
+		Expect(result.EquityCurve[0].Equity).To(Equal(result.InitialCash))
+		Expect(result.Orders).To(HaveLen(1))
+		Expect(result.OpenTrade.Quantity).To(Equal(2.0))
+		Expect(result.OpenTrade.EntryBar).To(Equal(42))
+		Expect(result.OpenTrade.EntryPrice).To(Equal(priceStream.Data[41].O()))
+	})
+})
+
+var _ = Describe("when a strategy fails to initialise", func() {
+	It("should return the error", func() {
+		strategy := &scriptedStrategy{init: func(priceStream gotrade.DOHLCVStreamSubscriber) error {
+			_, err := indicators.NewSmaForStream(priceStream, 1, gotrade.UseClosePrice)
+			return err
+		}}
+		_, err := backtest.NewBacktest(csvFeed, strategy, backtest.DefaultConfig()).Run()
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+// seriesValueReceiver records whether the series has a value for each bar it receives after the series
+type seriesValueReceiver struct {
+	series   backtest.Series
+	hasValue []bool
+}
+
+func (r *seriesValueReceiver) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	r.hasValue = append(r.hasValue, backtest.HasValueForBar(r.series, streamBarIndex))
+}
+
+var _ = Describe("when checking whether a series has a value for the bar", func() {
+	It("should only be true for the bars the series has received since its lookback period", func() {
+		priceStream := gotrade.NewDailyDOHLCVStream()
+		sma, err := indicators.NewSmaForStream(priceStream, 3, gotrade.UseClosePrice)
+		Expect(err).To(BeNil())
+
+		receiver := &seriesValueReceiver{series: sma}
+		priceStream.AddTickSubscription(receiver)
+		csvFeed.FillDOHLCVStream(priceStream)
+
+		Expect(receiver.hasValue[:4]).To(Equal([]bool{false, false, true, true}))
+		Expect(backtest.HasValueForBar(sma, 2)).To(BeFalse())
+	})
+})
@@ -0,0 +1,307 @@
+package backtest
+
+import (
+	"github.com/jaybutera/gotrade"
+	"math"
+	"time"
+)
+
+// A Trade is a round trip from a flat position back to flat, a position reversed by a single order
+// closes one trade and opens another
+type Trade struct {
+	// Buy for a long trade, Sell for a short one
+	Side Side
+	// the largest position held during the trade
+	Quantity float64
+
+	EntryBar  int
+	EntryDate time.Time
+	// the average price of the fills increasing the position
+	EntryPrice float64
+
+	ExitBar  int
+	ExitDate time.Time
+	// the average price of the fills reducing the position
+	ExitPrice float64
+
+	Commission float64
+	// the profit or loss net of commission
+	ProfitLoss float64
+
+	// the most adverse and the most favourable excursion, as a loss and a profit from the entry,
+	// from the lows and highs of the bars whilst the trade was open
+	MAE float64
+	MFE float64
+
+	// running totals whilst open
+	entryQuantity float64
+	entryValue    float64
+	exitQuantity  float64
+	exitValue     float64
+	realized      float64
+}
+
+// the fraction of an order's quantity within which a position is taken as flat, so that fractional
+// quantities closing a position do not leave a rounding residual open
+const positionTolerance = 1e-9
+
+// A Broker simulates the execution of a strategy's orders against the bars of a backtest and
+// tracks the resulting cash, position and trades. No cash or margin limits are enforced.
+type Broker struct {
+	fillModel  FillModel
+	commission CommissionModel
+	slippage   SlippageModel
+
+	cash     float64
+	position float64
+	orders   []*Order
+	pending  []*Order
+	trade    *Trade
+	trades   []*Trade
+
+	// the bar being processed
+	bar            gotrade.DOHLCV
+	streamBarIndex int
+}
+
+func newBroker(config Config) *Broker {
+	b := Broker{
+		fillModel:  config.FillModel,
+		commission: config.Commission,
+		slippage:   config.Slippage,
+		cash:       config.InitialCash,
+	}
+	return &b
+}
+
+// Cash returns the cash held, after the cost of the position and commission
+func (b *Broker) Cash() float64 {
+	return b.cash
+}
+
+// Position returns the quantity held, negative for a short position
+func (b *Broker) Position() float64 {
+	return b.position
+}
+
+// Equity returns the cash plus the value of the position at the close of the current bar
+func (b *Broker) Equity() float64 {
+	if b.bar == nil {
+		return b.cash
+	}
+	return b.cash + b.position*b.bar.C()
+}
+
+// OpenTrade returns the trade of the current position, nil when flat
+func (b *Broker) OpenTrade() *Trade {
+	return b.trade
+}
+
+// Trades returns the trades closed so far
+func (b *Broker) Trades() []*Trade {
+	return b.trades
+}
+
+// PendingOrders returns the orders waiting to fill
+func (b *Broker) PendingOrders() []*Order {
+	return b.pending
+}
+
+// Buy places a market order to buy
+func (b *Broker) Buy(quantity float64) (order *Order, err error) {
+	return b.PlaceOrder(MarketOrder, Buy, quantity, 0.0)
+}
+
+// Sell places a market order to sell
+func (b *Broker) Sell(quantity float64) (order *Order, err error) {
+	return b.PlaceOrder(MarketOrder, Sell, quantity, 0.0)
+}
+
+// BuyLimit places an order to buy at the price or lower
+func (b *Broker) BuyLimit(quantity float64, price float64) (order *Order, err error) {
+	return b.PlaceOrder(LimitOrder, Buy, quantity, price)
+}
+
+// SellLimit places an order to sell at the price or higher
+func (b *Broker) SellLimit(quantity float64, price float64) (order *Order, err error) {
+	return b.PlaceOrder(LimitOrder, Sell, quantity, price)
+}
+
+// BuyStop places an order to buy once the price is reached or exceeded
+func (b *Broker) BuyStop(quantity float64, price float64) (order *Order, err error) {
+	return b.PlaceOrder(StopOrder, Buy, quantity, price)
+}
+
+// SellStop places an order to sell once the price is reached or undercut
+func (b *Broker) SellStop(quantity float64, price float64) (order *Order, err error) {
+	return b.PlaceOrder(StopOrder, Sell, quantity, price)
+}
+
+// ClosePosition places a market order flattening the position, it returns nil when already flat
+func (b *Broker) ClosePosition() (order *Order, err error) {
+	switch {
+	case b.position > 0:
+		return b.Sell(b.position)
+	case b.position < 0:
+		return b.Buy(-b.position)
+	}
+	return nil, nil
+}
+
+// PlaceOrder places an order, the price is the limit or stop price and is ignored for a market order.
+// With the same bar close fill model a market order placed during a bar fills immediately.
+func (b *Broker) PlaceOrder(orderType OrderType, side Side, quantity float64, price float64) (order *Order, err error) {
+	if quantity <= 0.0 {
+		return nil, ErrQuantityIsNotPositive
+	}
+
+	if orderType != MarketOrder && price <= 0.0 {
+		return nil, ErrPriceIsNotPositive
+	}
+
+	order = &Order{
+		ID:        len(b.orders) + 1,
+		Type:      orderType,
+		Side:      side,
+		Quantity:  quantity,
+		Price:     price,
+		Status:    OrderPending,
+		PlacedBar: b.streamBarIndex,
+	}
+	if b.bar != nil {
+		order.PlacedDate = b.bar.D()
+	}
+	b.orders = append(b.orders, order)
+
+	if orderType == MarketOrder && b.fillModel == SameBarClose && b.bar != nil {
+		b.fill(order, b.slippage.Slip(side, b.bar.C()))
+		return order, nil
+	}
+
+	b.pending = append(b.pending, order)
+	return order, nil
+}
+
+// Cancel cancels a pending order
+func (b *Broker) Cancel(order *Order) error {
+	for i := range b.pending {
+		if b.pending[i] == order {
+			order.Status = OrderCancelled
+			b.pending = append(b.pending[:i:i], b.pending[i+1:]...)
+			return nil
+		}
+	}
+	return ErrOrderIsNotPending
+}
+
+// CancelAll cancels every pending order
+func (b *Broker) CancelAll() {
+	for _, order := range b.pending {
+		order.Status = OrderCancelled
+	}
+	b.pending = nil
+}
+
+// openBar starts a new bar, filling the pending orders the bar trades through in the order they were placed
+// and then updating the excursions of the open trade
+func (b *Broker) openBar(bar gotrade.DOHLCV, streamBarIndex int) {
+	b.bar = bar
+	b.streamBarIndex = streamBarIndex
+
+	pending := b.pending
+	b.pending = nil
+	for _, order := range pending {
+		price, ok := order.fillPrice(bar.O(), bar.H(), bar.L())
+		if !ok {
+			b.pending = append(b.pending, order)
+			continue
+		}
+
+		if order.Type != LimitOrder {
+			price = b.slippage.Slip(order.Side, price)
+		}
+		b.fill(order, price)
+	}
+
+	if b.trade != nil {
+		b.updateExcursions(bar)
+	}
+}
+
+func (b *Broker) fill(order *Order, price float64) {
+	order.Status = OrderFilled
+	order.FillBar = b.streamBarIndex
+	order.FillDate = b.bar.D()
+	order.FillPrice = price
+	order.Commission = b.commission.Commission(order.Quantity, price)
+
+	b.cash -= order.Side.direction()*order.Quantity*price + order.Commission
+
+	quantity := order.Quantity
+	commission := order.Commission
+	tolerance := order.Quantity * positionTolerance
+
+	// reduce, or close, the position of the open trade
+	if b.trade != nil && b.trade.Side != order.Side {
+		closing := math.Min(quantity, math.Abs(b.position))
+		closingCommission := commission * closing / quantity
+
+		t := b.trade
+		t.exitQuantity += closing
+		t.exitValue += closing * price
+		t.realized += t.Side.direction() * (price - t.EntryPrice) * closing
+		t.Commission += closingCommission
+		b.position += order.Side.direction() * closing
+
+		quantity -= closing
+		commission -= closingCommission
+
+		if math.Abs(b.position) <= tolerance {
+			b.position = 0.0
+			b.closeTrade()
+		}
+	}
+
+	if quantity <= tolerance {
+		return
+	}
+
+	// open, or add to, the position of the open trade
+	if b.trade == nil {
+		b.trade = &Trade{Side: order.Side, EntryBar: b.streamBarIndex, EntryDate: b.bar.D()}
+	}
+
+	t := b.trade
+	t.entryQuantity += quantity
+	t.entryValue += quantity * price
+	t.EntryPrice = t.entryValue / t.entryQuantity
+	t.Commission += commission
+	b.position += order.Side.direction() * quantity
+	t.Quantity = math.Max(t.Quantity, math.Abs(b.position))
+}
+
+func (b *Broker) closeTrade() {
+	t := b.trade
+	t.ExitBar = b.streamBarIndex
+	t.ExitDate = b.bar.D()
+	t.ExitPrice = t.exitValue / t.exitQuantity
+	t.ProfitLoss = t.realized - t.Commission
+
+	b.trades = append(b.trades, t)
+	b.trade = nil
+}
+
+func (b *Broker) updateExcursions(bar gotrade.DOHLCV) {
+	t := b.trade
+	held := math.Abs(b.position)
+
+	best, worst := bar.H(), bar.L()
+	if t.Side == Sell {
+		best, worst = worst, best
+	}
+
+	favourable := t.realized + t.Side.direction()*(best-t.EntryPrice)*held
+	adverse := t.realized + t.Side.direction()*(worst-t.EntryPrice)*held
+	t.MFE = math.Max(t.MFE, favourable)
+	t.MAE = math.Max(t.MAE, -adverse)
+}
@@ -0,0 +1,197 @@
+package backtest_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/jaybutera/gotrade/backtest"
+)
+
+var _ = Describe("when the broker fills orders", func() {
+	var (
+		feed     barFeed
+		config   backtest.Config
+		strategy *scriptedStrategy
+		result   *backtest.Result
+		err      error
+	)
+
+	BeforeEach(func() {
+		feed = bars(
+			[4]float64{10.0, 11.0, 9.0, 10.0},
+			[4]float64{10.5, 12.0, 10.0, 11.0},
+			[4]float64{11.5, 13.0, 11.0, 12.0},
+			[4]float64{11.0, 11.5, 8.0, 9.0},
+			[4]float64{9.5, 10.0, 7.0, 8.0})
+		config = barsConfig()
+		strategy = &scriptedStrategy{onBar: map[int]func(broker *backtest.Broker){}}
+	})
+
+	run := func() {
+		result, err = backtest.NewBacktest(feed, strategy, config).Run()
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	It("should fill a market order at the next bar's open", func() {
+		strategy.onBar[1] = func(broker *backtest.Broker) { broker.Buy(10) }
+		strategy.onBar[3] = func(broker *backtest.Broker) { broker.ClosePosition() }
+		run()
+
+		Expect(result.Trades).To(HaveLen(1))
+		trade := result.Trades[0]
+		Expect(trade.Side).To(Equal(backtest.Buy))
+		Expect(trade.EntryBar).To(Equal(2))
+		Expect(trade.EntryPrice).To(Equal(10.5))
+		Expect(trade.ExitBar).To(Equal(4))
+		Expect(trade.ExitPrice).To(Equal(11.0))
+		Expect(trade.ProfitLoss).To(Equal(5.0))
+		Expect(result.FinalEquity).To(Equal(100005.0))
+	})
+
+	It("should fill a market order at the same bar's close", func() {
+		config.FillModel = backtest.SameBarClose
+		strategy.onBar[1] = func(broker *backtest.Broker) { broker.Buy(10) }
+		strategy.onBar[3] = func(broker *backtest.Broker) { broker.Sell(10) }
+		run()
+
+		Expect(result.Trades).To(HaveLen(1))
+		Expect(result.Trades[0].EntryPrice).To(Equal(10.0))
+		Expect(result.Trades[0].ExitPrice).To(Equal(12.0))
+		Expect(result.Trades[0].ProfitLoss).To(Equal(20.0))
+	})
+
+	It("should fill a limit order at the limit, or the open when the bar opens beyond it", func() {
+		strategy.onBar[1] = func(broker *backtest.Broker) {
+			broker.BuyLimit(1, 8.5)
+			broker.SellLimit(1, 12.5)
+		}
+		run()
+
+		Expect(result.Orders[0].Status).To(Equal(backtest.OrderFilled))
+		Expect(result.Orders[0].FillBar).To(Equal(4))
+		Expect(result.Orders[0].FillPrice).To(Equal(8.5))
+		Expect(result.Orders[1].FillBar).To(Equal(3))
+		Expect(result.Orders[1].FillPrice).To(Equal(12.5))
+
+		strategy.onBar[1] = func(broker *backtest.Broker) { broker.BuyLimit(1, 11.0) }
+		run()
+		Expect(result.Orders[0].FillBar).To(Equal(2))
+		Expect(result.Orders[0].FillPrice).To(Equal(10.5))
+	})
+
+	It("should fill a stop order once the stop is traded through", func() {
+		strategy.onBar[1] = func(broker *backtest.Broker) { broker.BuyStop(1, 12.5) }
+		strategy.onBar[3] = func(broker *backtest.Broker) { broker.SellStop(1, 9.75) }
+		run()
+
+		Expect(result.Orders[0].FillBar).To(Equal(3))
+		Expect(result.Orders[0].FillPrice).To(Equal(12.5))
+		Expect(result.Orders[1].FillBar).To(Equal(4))
+		Expect(result.Orders[1].FillPrice).To(Equal(9.75))
+
+		// the bar gaps through the stop
+		strategy.onBar[3] = func(broker *backtest.Broker) { broker.SellStop(1, 11.25) }
+		run()
+		Expect(result.Orders[1].FillPrice).To(Equal(11.0))
+	})
+
+	It("should leave unfilled orders pending and cancel them on request", func() {
+		strategy.onBar[1] = func(broker *backtest.Broker) {
+			broker.BuyLimit(1, 5.0)
+			order, _ := broker.SellLimit(1, 20.0)
+			Expect(broker.Cancel(order)).To(Succeed())
+			Expect(broker.Cancel(order)).To(Equal(backtest.ErrOrderIsNotPending))
+		}
+		run()
+
+		Expect(result.Orders[0].Status).To(Equal(backtest.OrderPending))
+		Expect(result.Orders[1].Status).To(Equal(backtest.OrderCancelled))
+		Expect(result.FinalEquity).To(Equal(config.InitialCash))
+	})
+
+	It("should charge commission and slippage", func() {
+		config.Commission = backtest.PercentCommission{Percent: 1.0, Minimum: 1.0}
+		config.Slippage = backtest.FixedSlippage{Amount: 0.5}
+		strategy.onBar[1] = func(broker *backtest.Broker) { broker.Buy(10) }
+		strategy.onBar[3] = func(broker *backtest.Broker) { broker.Sell(10) }
+		run()
+
+		trade := result.Trades[0]
+		Expect(trade.EntryPrice).To(Equal(11.0))
+		Expect(trade.ExitPrice).To(Equal(10.5))
+		Expect(trade.Commission).To(BeNumerically("~", 1.1+1.05, 1e-9))
+		Expect(trade.ProfitLoss).To(BeNumerically("~", -5.0-2.15, 1e-9))
+		Expect(result.FinalEquity).To(BeNumerically("~", config.InitialCash+trade.ProfitLoss, 1e-9))
+	})
+
+	It("should close one trade and open another when an order reverses the position", func() {
+		strategy.onBar[1] = func(broker *backtest.Broker) { broker.Buy(10) }
+		strategy.onBar[2] = func(broker *backtest.Broker) { broker.Sell(30) }
+		run()
+
+		Expect(result.Trades).To(HaveLen(1))
+		Expect(result.Trades[0].ProfitLoss).To(Equal(10.0))
+		Expect(result.OpenTrade).NotTo(BeNil())
+		Expect(result.OpenTrade.Side).To(Equal(backtest.Sell))
+		Expect(result.OpenTrade.Quantity).To(Equal(20.0))
+		Expect(result.OpenTrade.EntryPrice).To(Equal(11.5))
+		Expect(result.FinalEquity).To(Equal(config.InitialCash + 10.0 + (11.5-8.0)*20.0))
+	})
+
+	It("should close a position of fractional quantities without a rounding residual", func() {
+		strategy.onBar[1] = func(broker *backtest.Broker) { broker.Buy(0.3) }
+		strategy.onBar[2] = func(broker *backtest.Broker) { broker.Sell(0.1) }
+		strategy.onBar[3] = func(broker *backtest.Broker) { broker.Sell(0.2) }
+		run()
+
+		Expect(result.Trades).To(HaveLen(1))
+		Expect(result.OpenTrade).To(BeNil())
+		Expect(result.EquityCurve[len(result.EquityCurve)-1].Position).To(Equal(0.0))
+	})
+
+	It("should average the entry price of a position added to", func() {
+		strategy.onBar[1] = func(broker *backtest.Broker) { broker.Buy(10) }
+		strategy.onBar[2] = func(broker *backtest.Broker) { broker.Buy(10) }
+		strategy.onBar[4] = func(broker *backtest.Broker) { broker.ClosePosition() }
+		run()
+
+		trade := result.Trades[0]
+		Expect(trade.Quantity).To(Equal(20.0))
+		Expect(trade.EntryPrice).To(Equal(11.0))
+		Expect(trade.ExitPrice).To(Equal(9.5))
+		Expect(trade.ProfitLoss).To(Equal(-30.0))
+	})
+
+	It("should track the most adverse and favourable excursions of a trade", func() {
+		strategy.onBar[1] = func(broker *backtest.Broker) { broker.Buy(10) }
+		strategy.onBar[4] = func(broker *backtest.Broker) { broker.ClosePosition() }
+		run()
+
+		trade := result.Trades[0]
+		// entered at 10.5, whilst open the highest high is 13.0 and the lowest low 8.0
+		Expect(trade.MFE).To(Equal(25.0))
+		Expect(trade.MAE).To(Equal(25.0))
+	})
+
+	It("should record the equity at the close of every bar", func() {
+		strategy.onBar[1] = func(broker *backtest.Broker) { broker.Buy(10) }
+		run()
+
+		Expect(result.EquityCurve).To(HaveLen(5))
+		Expect(result.EquityCurve[0].Equity).To(Equal(config.InitialCash))
+		Expect(result.EquityCurve[1].Position).To(Equal(10.0))
+		Expect(result.EquityCurve[1].Cash).To(Equal(config.InitialCash - 105.0))
+		Expect(result.EquityCurve[2].Equity).To(Equal(config.InitialCash + 15.0))
+		Expect(result.EquityCurve[4].Date).To(Equal(feed[4].D()))
+	})
+
+	It("should reject orders without a quantity or price", func() {
+		strategy.onBar[1] = func(broker *backtest.Broker) {
+			_, err := broker.Buy(0)
+			Expect(err).To(Equal(backtest.ErrQuantityIsNotPositive))
+			_, err = broker.BuyLimit(1, 0.0)
+			Expect(err).To(Equal(backtest.ErrPriceIsNotPositive))
+		}
+		run()
+		Expect(result.Orders).To(BeEmpty())
+	})
+})
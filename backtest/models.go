@@ -0,0 +1,84 @@
+package backtest
+
+import (
+	"math"
+)
+
+// The FillModel determines when and at what price market orders fill
+type FillModel int
+
+const (
+	// market orders fill at the open of the bar after they are placed
+	NextBarOpen FillModel = iota
+	// market orders fill at the close of the bar they are placed during
+	SameBarClose
+)
+
+func (m FillModel) String() string {
+	if m == SameBarClose {
+		return "same bar close"
+	}
+	return "next bar open"
+}
+
+// A CommissionModel determines the commission charged for a fill
+type CommissionModel interface {
+	Commission(quantity float64, price float64) float64
+}
+
+// NoCommission charges nothing
+type NoCommission struct{}
+
+func (c NoCommission) Commission(quantity float64, price float64) float64 {
+	return 0.0
+}
+
+// FixedCommission charges the same amount for every fill
+type FixedCommission struct {
+	Amount float64
+}
+
+func (c FixedCommission) Commission(quantity float64, price float64) float64 {
+	return c.Amount
+}
+
+// PercentCommission charges a percentage of the value of a fill, with a minimum amount per fill
+type PercentCommission struct {
+	Percent float64
+	Minimum float64
+}
+
+func (c PercentCommission) Commission(quantity float64, price float64) float64 {
+	return math.Max(quantity*price*c.Percent/100.0, c.Minimum)
+}
+
+// A SlippageModel determines the price a market or stop order fills at, given the price it would fill at
+// without slippage. Limit orders do not slip.
+type SlippageModel interface {
+	Slip(side Side, price float64) float64
+}
+
+// NoSlippage fills at the price without slippage
+type NoSlippage struct{}
+
+func (s NoSlippage) Slip(side Side, price float64) float64 {
+	return price
+}
+
+// FixedSlippage fills an amount worse than the price, higher when buying and lower when selling
+type FixedSlippage struct {
+	Amount float64
+}
+
+func (s FixedSlippage) Slip(side Side, price float64) float64 {
+	return price + side.direction()*s.Amount
+}
+
+// PercentSlippage fills a percentage worse than the price, higher when buying and lower when selling
+type PercentSlippage struct {
+	Percent float64
+}
+
+func (s PercentSlippage) Slip(side Side, price float64) float64 {
+	return price * (1.0 + side.direction()*s.Percent/100.0)
+}
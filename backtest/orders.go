@@ -0,0 +1,138 @@
+package backtest
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrQuantityIsNotPositive = errors.New("quantity must be greater than 0")
+	ErrPriceIsNotPositive    = errors.New("price must be greater than 0")
+	ErrOrderIsNotPending     = errors.New("order is not pending")
+)
+
+// The Side of an order, or of a trade, buying for a long position and selling for a short one
+type Side int
+
+const (
+	Buy Side = iota
+	Sell
+)
+
+func (s Side) String() string {
+	if s == Buy {
+		return "buy"
+	}
+	return "sell"
+}
+
+// direction returns 1 for buying and -1 for selling
+func (s Side) direction() float64 {
+	if s == Buy {
+		return 1.0
+	}
+	return -1.0
+}
+
+// The OrderType determines the price an order fills at
+type OrderType int
+
+const (
+	// fills at the price given by the fill model
+	MarketOrder OrderType = iota
+	// fills at the limit price or better, from the bar after it is placed
+	LimitOrder
+	// becomes a market order once the stop price is traded through, from the bar after it is placed
+	StopOrder
+)
+
+func (t OrderType) String() string {
+	switch t {
+	case LimitOrder:
+		return "limit"
+	case StopOrder:
+		return "stop"
+	}
+	return "market"
+}
+
+type OrderStatus int
+
+const (
+	OrderPending OrderStatus = iota
+	OrderFilled
+	OrderCancelled
+)
+
+func (s OrderStatus) String() string {
+	switch s {
+	case OrderFilled:
+		return "filled"
+	case OrderCancelled:
+		return "cancelled"
+	}
+	return "pending"
+}
+
+// An Order to buy or sell, orders remain pending until they fill or are cancelled
+type Order struct {
+	ID       int
+	Type     OrderType
+	Side     Side
+	Quantity float64
+	// the limit price of a limit order, or the stop price of a stop order
+	Price  float64
+	Status OrderStatus
+
+	// the bar during which the order was placed, bar 0 for orders placed before the first bar
+	PlacedBar  int
+	PlacedDate time.Time
+
+	// the details of the fill, once filled
+	FillBar    int
+	FillDate   time.Time
+	FillPrice  float64
+	Commission float64
+}
+
+// fillPrice returns the price an order placed before the bar fills at during the bar, if it fills.
+// A limit or stop price that the bar opens beyond fills at the open.
+func (o *Order) fillPrice(open float64, high float64, low float64) (price float64, ok bool) {
+	switch o.Type {
+	case MarketOrder:
+		return open, true
+	case LimitOrder:
+		if o.Side == Buy {
+			if open <= o.Price {
+				return open, true
+			}
+			if low <= o.Price {
+				return o.Price, true
+			}
+		} else {
+			if open >= o.Price {
+				return open, true
+			}
+			if high >= o.Price {
+				return o.Price, true
+			}
+		}
+	case StopOrder:
+		if o.Side == Buy {
+			if open >= o.Price {
+				return open, true
+			}
+			if high >= o.Price {
+				return o.Price, true
+			}
+		} else {
+			if open <= o.Price {
+				return open, true
+			}
+			if low <= o.Price {
+				return o.Price, true
+			}
+		}
+	}
+	return 0.0, false
+}
@@ -0,0 +1,64 @@
+package charts_test
+
+import (
+	"encoding/csv"
+	"fmt"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/jaybutera/gotrade"
+	"github.com/jaybutera/gotrade/feeds"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	csvFeed *feeds.CSVFileFeed
+)
+
+func TestCharts(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Charts Suite")
+}
+
+var _ = BeforeSuite(func() {
+	csvFeed = feeds.NewCSVFileFeedWithDOHLCVFormat("../testdata/JSETOPI.2013.data",
+		feeds.DashedYearDayMonthDateParserForLocation(time.Local))
+})
+
+var _ = AfterSuite(func() {
+	csvFeed = nil
+})
+
+func LoadCSVOHLCDataFromFile(fileName string) (results []gotrade.OHLC, err error) {
+	file, err := os.Open("../testdata/" + fileName)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return nil, err
+	}
+	defer file.Close()
+	reader := csv.NewReader(file)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			fmt.Println("Error:", err)
+			return nil, err
+		}
+
+		var prices [4]float64
+		for i := range prices {
+			prices[i], err = strconv.ParseFloat(strings.TrimSpace(record[i]), 64)
+			if err != nil {
+				fmt.Println("Error:", err)
+				return nil, err
+			}
+		}
+		results = append(results, gotrade.NewDOHLCVDataItem(time.Time{}, prices[0], prices[1], prices[2], prices[3], 0.0))
+	}
+	return results, nil
+}
@@ -0,0 +1,56 @@
+// Heiken Ashi (HeikenAshi)
+package charts
+
+import (
+	"github.com/jaybutera/gotrade"
+	"math"
+)
+
+// HaClose = (Open[0] + High[0] + Low[0] + Close[0]) / 4
+// HaOpen = (HaOpen[-1] + HaClose[-1]) / 2, the first bar uses (Open[0] + Close[0]) / 2
+// HaHigh = Max(High[0], HaOpen[0], HaClose[0])
+// HaLow = Min(Low[0], HaOpen[0], HaClose[0])
+
+// A Heiken Ashi candle stream, derived from a source price stream, that can itself be subscribed to
+// by any indicator. Each Heiken Ashi bar keeps the date and volume of its source bar.
+type HeikenAshi struct {
+	*gotrade.DOHLCVStream
+
+	// private variables
+	previousOpen  float64
+	previousClose float64
+	hasPrevious   bool
+}
+
+// NewHeikenAshi creates a Heiken Ashi candle stream
+func NewHeikenAshi() *HeikenAshi {
+	return &HeikenAshi{DOHLCVStream: gotrade.NewDOHLCVStream()}
+}
+
+// NewHeikenAshiForStream creates a Heiken Ashi candle stream attached to a source data stream
+func NewHeikenAshiForStream(priceStream gotrade.DOHLCVStreamSubscriber) *HeikenAshi {
+	s := NewHeikenAshi()
+	priceStream.AddTickSubscription(s)
+	return s
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick and publishes the equivalent Heiken Ashi bar
+func (s *HeikenAshi) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	haClose := (tickData.O() + tickData.H() + tickData.L() + tickData.C()) / 4.0
+
+	var haOpen float64
+	if s.hasPrevious {
+		haOpen = (s.previousOpen + s.previousClose) / 2.0
+	} else {
+		haOpen = (tickData.O() + tickData.C()) / 2.0
+		s.hasPrevious = true
+	}
+
+	haHigh := math.Max(tickData.H(), math.Max(haOpen, haClose))
+	haLow := math.Min(tickData.L(), math.Min(haOpen, haClose))
+
+	s.previousOpen = haOpen
+	s.previousClose = haClose
+
+	s.ReceiveTick(gotrade.NewDOHLCVDataItem(tickData.D(), haOpen, haHigh, haLow, haClose, tickData.V()))
+}
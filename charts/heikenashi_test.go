@@ -0,0 +1,57 @@
+package charts_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/jaybutera/gotrade"
+	"github.com/jaybutera/gotrade/charts"
+	"github.com/jaybutera/gotrade/indicators"
+)
+
+var _ = Describe("when executing the gotrade heiken ashi stream with a years data and known output", func() {
+	var (
+		heikenAshi      *charts.HeikenAshi
+		expectedResults []gotrade.OHLC
+		priceStream     *gotrade.InterDayDOHLCVStream
+		sma             *indicators.Sma
+	)
+
+	BeforeEach(func() {
+		// load the expected results data
+		expectedResults, _ = LoadCSVOHLCDataFromFile("heikenashi_expectedresult.data")
+		priceStream = gotrade.NewDailyDOHLCVStream()
+		heikenAshi = charts.NewHeikenAshiForStream(priceStream)
+		sma, _ = indicators.NewSmaForStream(heikenAshi, 10, gotrade.UseClosePrice)
+		csvFeed.FillDOHLCVStream(priceStream)
+	})
+
+	It("the result set should have a length equal to the source data length", func() {
+		Expect(len(heikenAshi.Data)).To(Equal(len(priceStream.Data)))
+	})
+
+	It("it should have correctly calculated the heiken ashi bars for each item in the result set accurate to two decimal places", func() {
+		Expect(len(expectedResults)).To(Equal(len(heikenAshi.Data)))
+		for k := range expectedResults {
+			Expect(expectedResults[k].O()).To(BeNumerically("~", heikenAshi.Data[k].O(), 0.01))
+			Expect(expectedResults[k].H()).To(BeNumerically("~", heikenAshi.Data[k].H(), 0.01))
+			Expect(expectedResults[k].L()).To(BeNumerically("~", heikenAshi.Data[k].L(), 0.01))
+			Expect(expectedResults[k].C()).To(BeNumerically("~", heikenAshi.Data[k].C(), 0.01))
+		}
+	})
+
+	It("it should have kept the date and volume of each source bar", func() {
+		for k := range priceStream.Data {
+			Expect(heikenAshi.Data[k].D()).To(Equal(priceStream.Data[k].D()))
+			Expect(heikenAshi.Data[k].V()).To(Equal(priceStream.Data[k].V()))
+		}
+	})
+
+	It("it should publish the heiken ashi bars to indicators attached to it", func() {
+		Expect(len(sma.Data)).To(Equal(len(heikenAshi.Data) - sma.GetLookbackPeriod()))
+		total := 0.0
+		for k := 0; k < 10; k++ {
+			total += expectedResults[k].C()
+		}
+		Expect(sma.Data[0]).To(BeNumerically("~", total/10.0, 0.01))
+	})
+})
@@ -0,0 +1,329 @@
+// Point and Figure (PointAndFigure)
+package charts
+
+import (
+	"github.com/jaybutera/gotrade"
+	"github.com/jaybutera/gotrade/indicators"
+	"time"
+)
+
+// the minimum number of boxes a column must extend beyond the previous column of the same type to form a pole
+const poleBoxCount int = 3
+
+// The type of a point and figure trend line
+type TrendLineType int
+
+const (
+	// a 45 degree line rising one box per column from below a low
+	BullishSupportLine TrendLineType = iota
+	// a 45 degree line falling one box per column from above a high
+	BearishResistanceLine
+)
+
+// A 45 degree point and figure trend line
+type TrendLine struct {
+	LineType TrendLineType
+	// the column in which the line starts
+	StartColumnIndex int
+	// the box the line passes through in its start column
+	StartBoxIndex int
+	// the column in which the line was broken, -1 whilst the line is still active
+	EndColumnIndex int
+}
+
+// BoxIndexAt returns the box the trend line passes through in the given column
+func (l *TrendLine) BoxIndexAt(columnIndex int) int {
+	if l.LineType == BullishSupportLine {
+		return l.StartBoxIndex + (columnIndex - l.StartColumnIndex)
+	}
+	return l.StartBoxIndex - (columnIndex - l.StartColumnIndex)
+}
+
+// IsActive returns true if the trend line has not been broken
+func (l *TrendLine) IsActive() bool {
+	return l.EndColumnIndex == -1
+}
+
+// The type of a point and figure signal
+type PnFSignalType int
+
+const (
+	noSignal PnFSignalType = iota - 1
+	// an X column rises above the top of the previous X column
+	DoubleTopBuy
+	// an O column falls below the bottom of the previous O column
+	DoubleBottomSell
+	// an X column rises above the equal tops of the previous two X columns
+	TripleTopBuy
+	// an O column falls below the equal bottoms of the previous two O columns
+	TripleBottomSell
+	// an X column rises above two equal tops separated by a lower X column
+	SpreadTripleTopBuy
+	// an O column falls below two equal bottoms separated by a higher O column
+	SpreadTripleBottomSell
+	// a triple top buy, a pullback without a sell signal, then a double top buy
+	BullishCatapult
+	// a triple bottom sell, a rally without a buy signal, then a double bottom sell
+	BearishCatapult
+	// an X column rising at least 3 boxes above the previous X column is followed by an O column retracing more than half of it
+	HighPole
+	// an O column falling at least 3 boxes below the previous O column is followed by an X column retracing more than half of it
+	LowPole
+)
+
+// A signal detected on a point and figure chart
+type PnFSignal struct {
+	SignalType  PnFSignalType
+	ColumnIndex int
+	// the box which completed the pattern
+	Box gotrade.PnFBox
+}
+
+// Date returns the date of the bar that completed the pattern
+func (s PnFSignal) Date() time.Time {
+	return s.Box.Date
+}
+
+type ValueAvailableActionPnFSignal func(signal PnFSignal, streamBarIndex int)
+
+// A Point and Figure chart model with trend lines and pattern detection, no storage of signals
+type PointAndFigureWithoutStorage struct {
+	// public variables
+	Columns    []*gotrade.PnFColumn
+	TrendLines []*TrendLine
+
+	// private variables
+	valueAvailableAction ValueAvailableActionPnFSignal
+	breakoutSignals      []PnFSignalType
+	poleSignalled        []bool
+	// the index in the column stream of the first column received
+	firstColumnIndex int
+}
+
+// NewPointAndFigureWithoutStorage creates a Point and Figure chart model without storage of signals
+func NewPointAndFigureWithoutStorage(valueAvailableAction ValueAvailableActionPnFSignal) (chart *PointAndFigureWithoutStorage, err error) {
+
+	// a chart without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, indicators.ErrValueAvailableActionIsNil
+	}
+
+	c := PointAndFigureWithoutStorage{
+		valueAvailableAction: valueAvailableAction,
+	}
+	return &c, nil
+}
+
+// A Point and Figure chart model with trend lines and pattern detection
+type PointAndFigure struct {
+	*PointAndFigureWithoutStorage
+
+	// public variables
+	Signals []PnFSignal
+}
+
+// NewPointAndFigure creates a Point and Figure chart model
+func NewPointAndFigure() (chart *PointAndFigure, err error) {
+	c := PointAndFigure{}
+	c.PointAndFigureWithoutStorage, err = NewPointAndFigureWithoutStorage(func(signal PnFSignal, streamBarIndex int) {
+		c.Signals = append(c.Signals, signal)
+	})
+	return &c, err
+}
+
+// NewPointAndFigureForStream creates a Point and Figure chart model attached to a point and figure column stream
+func NewPointAndFigureForStream(columnStream gotrade.PnFColumnSubscriber) (chart *PointAndFigure, err error) {
+	c, err := NewPointAndFigure()
+	if err != nil {
+		return nil, err
+	}
+	c.receiveExistingColumns(columnStream)
+	columnStream.AddColumnSubscription(c)
+	return c, nil
+}
+
+// NewPointAndFigureWithoutStorageForStream creates a Point and Figure chart model without storage of signals
+// attached to a point and figure column stream
+func NewPointAndFigureWithoutStorageForStream(columnStream gotrade.PnFColumnSubscriber, valueAvailableAction ValueAvailableActionPnFSignal) (chart *PointAndFigureWithoutStorage, err error) {
+	c, err := NewPointAndFigureWithoutStorage(valueAvailableAction)
+	if err != nil {
+		return nil, err
+	}
+	c.receiveExistingColumns(columnStream)
+	columnStream.AddColumnSubscription(c)
+	return c, nil
+}
+
+// receiveExistingColumns consumes the columns a point and figure stream built before the chart was attached
+func (c *PointAndFigureWithoutStorage) receiveExistingColumns(columnStream gotrade.PnFColumnSubscriber) {
+	if pnfStream, ok := columnStream.(*gotrade.PnFStream); ok {
+		for columnIndex, column := range pnfStream.Columns {
+			c.ReceivePnFColumn(column, columnIndex)
+		}
+	}
+}
+
+// ActiveTrendLine returns the trend line that has not yet been broken, or nil if there is none yet
+func (c *PointAndFigureWithoutStorage) ActiveTrendLine() *TrendLine {
+	if len(c.TrendLines) == 0 {
+		return nil
+	}
+	return c.TrendLines[len(c.TrendLines)-1]
+}
+
+// ReceivePnFColumn consumes a new or extended point and figure column, a chart subscribed to a stream that
+// already has columns starts from the first column it receives, which becomes its column 0
+func (c *PointAndFigureWithoutStorage) ReceivePnFColumn(column *gotrade.PnFColumn, columnIndex int) {
+	if len(c.Columns) == 0 {
+		c.firstColumnIndex = columnIndex
+	}
+
+	columnIndex -= c.firstColumnIndex
+	if columnIndex < 0 || columnIndex > len(c.Columns) {
+		return
+	}
+
+	if columnIndex == len(c.Columns) {
+		c.Columns = append(c.Columns, column)
+		c.breakoutSignals = append(c.breakoutSignals, noSignal)
+		c.poleSignalled = append(c.poleSignalled, false)
+
+		// the first reversal starts the first trend line from the extreme of the first column
+		if columnIndex == 1 {
+			c.startTrendLineAfter(0)
+		}
+	}
+
+	c.updateTrendLines(columnIndex)
+
+	if column.ColumnType == gotrade.XColumn {
+		c.checkBreakout(columnIndex, DoubleTopBuy, TripleTopBuy, SpreadTripleTopBuy, BullishCatapult,
+			func(col *gotrade.PnFColumn) int { return col.HighBox().BoxIndex })
+		c.checkPole(columnIndex, LowPole)
+	} else {
+		// negate the box indexes so that falling O columns can share the X column logic
+		c.checkBreakout(columnIndex, DoubleBottomSell, TripleBottomSell, SpreadTripleBottomSell, BearishCatapult,
+			func(col *gotrade.PnFColumn) int { return -col.LowBox().BoxIndex })
+		c.checkPole(columnIndex, HighPole)
+	}
+}
+
+func (c *PointAndFigureWithoutStorage) startTrendLineAfter(extremeColumnIndex int) {
+	extremeColumn := c.Columns[extremeColumnIndex]
+	line := TrendLine{StartColumnIndex: extremeColumnIndex + 1, EndColumnIndex: -1}
+	if extremeColumn.ColumnType == gotrade.XColumn {
+		line.LineType = BearishResistanceLine
+		line.StartBoxIndex = extremeColumn.HighBox().BoxIndex + 1
+	} else {
+		line.LineType = BullishSupportLine
+		line.StartBoxIndex = extremeColumn.LowBox().BoxIndex - 1
+	}
+	c.TrendLines = append(c.TrendLines, &line)
+}
+
+func (c *PointAndFigureWithoutStorage) updateTrendLines(columnIndex int) {
+	line := c.ActiveTrendLine()
+	if line == nil || columnIndex < line.StartColumnIndex {
+		return
+	}
+
+	column := c.Columns[columnIndex]
+	lineBoxIndex := line.BoxIndexAt(columnIndex)
+
+	var broken bool
+	var extremeType gotrade.PnFColumnType
+	if line.LineType == BullishSupportLine {
+		broken = column.ColumnType == gotrade.OColumn && column.LowBox().BoxIndex <= lineBoxIndex
+		extremeType = gotrade.XColumn
+	} else {
+		broken = column.ColumnType == gotrade.XColumn && column.HighBox().BoxIndex >= lineBoxIndex
+		extremeType = gotrade.OColumn
+	}
+
+	if !broken {
+		return
+	}
+	line.EndColumnIndex = columnIndex
+
+	// the new opposing line starts from the extreme column formed whilst the broken line was active
+	extremeColumnIndex := columnIndex - 1
+	for i := line.StartColumnIndex - 1; i < columnIndex; i++ {
+		candidate := c.Columns[i]
+		if candidate.ColumnType != extremeType {
+			continue
+		}
+		extreme := c.Columns[extremeColumnIndex]
+		if (extremeType == gotrade.XColumn && candidate.HighBox().BoxIndex > extreme.HighBox().BoxIndex) ||
+			(extremeType == gotrade.OColumn && candidate.LowBox().BoxIndex < extreme.LowBox().BoxIndex) {
+			extremeColumnIndex = i
+		}
+	}
+	c.startTrendLineAfter(extremeColumnIndex)
+}
+
+// checkBreakout looks for the current column breaking beyond the previous columns of the same type,
+// extremeBox returns the box index of a columns extreme, increasing in the direction of the breakout
+func (c *PointAndFigureWithoutStorage) checkBreakout(columnIndex int, doubleSignal PnFSignalType,
+	tripleSignal PnFSignalType, spreadTripleSignal PnFSignalType, catapultSignal PnFSignalType,
+	extremeBox func(col *gotrade.PnFColumn) int) {
+
+	if columnIndex < 2 || c.breakoutSignals[columnIndex] != noSignal {
+		return
+	}
+
+	current := extremeBox(c.Columns[columnIndex])
+	previous := extremeBox(c.Columns[columnIndex-2])
+	if current <= previous {
+		return
+	}
+
+	signalType := doubleSignal
+	if columnIndex >= 4 {
+		secondPrevious := extremeBox(c.Columns[columnIndex-4])
+		if secondPrevious == previous {
+			signalType = tripleSignal
+		} else if columnIndex >= 6 && secondPrevious < previous && extremeBox(c.Columns[columnIndex-6]) == previous {
+			signalType = spreadTripleSignal
+		}
+	}
+
+	c.breakoutSignals[columnIndex] = signalType
+	c.signal(signalType, columnIndex)
+
+	// a catapult is a double breakout following a triple breakout with no opposing signal in between
+	if signalType == doubleSignal && columnIndex >= 4 && c.breakoutSignals[columnIndex-1] == noSignal &&
+		(c.breakoutSignals[columnIndex-2] == tripleSignal || c.breakoutSignals[columnIndex-2] == spreadTripleSignal) {
+		c.signal(catapultSignal, columnIndex)
+	}
+}
+
+// checkPole looks for the current column retracing more than half of a pole in the previous column
+func (c *PointAndFigureWithoutStorage) checkPole(columnIndex int, poleSignal PnFSignalType) {
+	if columnIndex < 3 || c.poleSignalled[columnIndex] {
+		return
+	}
+
+	pole := c.Columns[columnIndex-1]
+	beforePole := c.Columns[columnIndex-3]
+	current := c.Columns[columnIndex]
+
+	var extension, retracement int
+	if pole.ColumnType == gotrade.XColumn {
+		extension = pole.HighBox().BoxIndex - beforePole.HighBox().BoxIndex
+		retracement = pole.HighBox().BoxIndex - current.LowBox().BoxIndex
+	} else {
+		extension = beforePole.LowBox().BoxIndex - pole.LowBox().BoxIndex
+		retracement = current.HighBox().BoxIndex - pole.LowBox().BoxIndex
+	}
+
+	if extension >= poleBoxCount && retracement*2 > pole.BoxCount() {
+		c.poleSignalled[columnIndex] = true
+		c.signal(poleSignal, columnIndex)
+	}
+}
+
+func (c *PointAndFigureWithoutStorage) signal(signalType PnFSignalType, columnIndex int) {
+	column := c.Columns[columnIndex]
+	box := column.Boxes[len(column.Boxes)-1]
+	c.valueAvailableAction(PnFSignal{SignalType: signalType, ColumnIndex: columnIndex, Box: box}, box.StreamBarIndex)
+}
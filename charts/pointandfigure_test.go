@@ -0,0 +1,254 @@
+package charts_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/jaybutera/gotrade"
+	"github.com/jaybutera/gotrade/charts"
+	"github.com/jaybutera/gotrade/indicators"
+	"time"
+)
+
+func buildPointAndFigure(closePrices []float64) (*gotrade.PnFStream, *charts.PointAndFigure) {
+	pnfStream, _ := gotrade.NewFixedBoxPnFStream(1.0, 1, gotrade.CloseOnlyConstruction)
+	chart, _ := charts.NewPointAndFigureForStream(pnfStream)
+	startDate := time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, closePrice := range closePrices {
+		pnfStream.ReceiveDOHLCVTick(gotrade.NewDOHLCVDataItem(startDate.AddDate(0, 0, i), closePrice, closePrice, closePrice, closePrice, 0.0), i+1)
+	}
+	return pnfStream, chart
+}
+
+func signalTypes(signals []charts.PnFSignal) []charts.PnFSignalType {
+	var types []charts.PnFSignalType
+	for _, signal := range signals {
+		types = append(types, signal.SignalType)
+	}
+	return types
+}
+
+var _ = Describe("when creating a point and figure chart without storage", func() {
+	var (
+		chart    *charts.PointAndFigureWithoutStorage
+		chartErr error
+	)
+
+	Context("and the chart was not given a value available action", func() {
+		BeforeEach(func() {
+			chart, chartErr = charts.NewPointAndFigureWithoutStorage(nil)
+		})
+
+		It("the chart should not be created and return the appropriate error message", func() {
+			Expect(chart).To(BeNil())
+			Expect(chartErr).To(Equal(indicators.ErrValueAvailableActionIsNil))
+		})
+	})
+})
+
+var _ = Describe("when detecting point and figure patterns", func() {
+	var (
+		pnfStream *gotrade.PnFStream
+		chart     *charts.PointAndFigure
+	)
+
+	Context("given an X column that rises above the previous X column", func() {
+		BeforeEach(func() {
+			pnfStream, chart = buildPointAndFigure([]float64{10, 13, 11, 14})
+		})
+
+		It("should share the columns of the point and figure stream", func() {
+			Expect(chart.Columns).To(Equal(pnfStream.Columns))
+		})
+
+		It("should signal a double top buy on the breakout box", func() {
+			Expect(signalTypes(chart.Signals)).To(Equal([]charts.PnFSignalType{charts.DoubleTopBuy}))
+			Expect(chart.Signals[0].ColumnIndex).To(Equal(2))
+			Expect(chart.Signals[0].Box.Price).To(Equal(14.0))
+			Expect(chart.Signals[0].Date()).To(Equal(time.Date(2014, 1, 4, 0, 0, 0, 0, time.UTC)))
+		})
+	})
+
+	Context("given an O column that falls below the previous O column", func() {
+		BeforeEach(func() {
+			_, chart = buildPointAndFigure([]float64{20, 17, 19, 16})
+		})
+
+		It("should signal a double bottom sell", func() {
+			Expect(signalTypes(chart.Signals)).To(Equal([]charts.PnFSignalType{charts.DoubleBottomSell}))
+		})
+	})
+
+	Context("given an X column that rises above two equal X column tops", func() {
+		BeforeEach(func() {
+			_, chart = buildPointAndFigure([]float64{10, 13, 11, 13, 11, 14})
+		})
+
+		It("should signal a triple top buy", func() {
+			Expect(signalTypes(chart.Signals)).To(Equal([]charts.PnFSignalType{charts.TripleTopBuy}))
+		})
+	})
+
+	Context("given an O column that falls below two equal O column bottoms", func() {
+		BeforeEach(func() {
+			_, chart = buildPointAndFigure([]float64{20, 17, 19, 17, 19, 16})
+		})
+
+		It("should signal a triple bottom sell", func() {
+			Expect(signalTypes(chart.Signals)).To(Equal([]charts.PnFSignalType{charts.TripleBottomSell}))
+		})
+	})
+
+	Context("given an X column that rises above two equal tops separated by a lower top", func() {
+		BeforeEach(func() {
+			_, chart = buildPointAndFigure([]float64{10, 13, 11, 12, 11, 13, 11, 14})
+		})
+
+		It("should signal a spread triple top buy", func() {
+			Expect(signalTypes(chart.Signals)).To(Equal([]charts.PnFSignalType{charts.DoubleTopBuy, charts.SpreadTripleTopBuy}))
+		})
+	})
+
+	Context("given a triple top buy followed by a pullback and a double top buy", func() {
+		BeforeEach(func() {
+			_, chart = buildPointAndFigure([]float64{10, 13, 11, 13, 11, 14, 12, 15})
+		})
+
+		It("should signal a bullish catapult", func() {
+			Expect(signalTypes(chart.Signals)).To(Equal([]charts.PnFSignalType{charts.TripleTopBuy, charts.DoubleTopBuy, charts.BullishCatapult}))
+		})
+	})
+
+	Context("given a triple bottom sell followed by a rally and a double bottom sell", func() {
+		BeforeEach(func() {
+			_, chart = buildPointAndFigure([]float64{20, 17, 19, 17, 19, 16, 18, 15})
+		})
+
+		It("should signal a bearish catapult", func() {
+			Expect(signalTypes(chart.Signals)).To(Equal([]charts.PnFSignalType{charts.TripleBottomSell, charts.DoubleBottomSell, charts.BearishCatapult}))
+		})
+	})
+
+	Context("given a tall X column followed by an O column retracing more than half of it", func() {
+		BeforeEach(func() {
+			_, chart = buildPointAndFigure([]float64{10, 12, 11, 16, 13})
+		})
+
+		It("should signal a high pole", func() {
+			Expect(signalTypes(chart.Signals)).To(Equal([]charts.PnFSignalType{charts.DoubleTopBuy, charts.HighPole}))
+		})
+	})
+
+	Context("given a deep O column followed by an X column retracing more than half of it", func() {
+		BeforeEach(func() {
+			_, chart = buildPointAndFigure([]float64{20, 18, 19, 14, 17})
+		})
+
+		It("should signal a low pole", func() {
+			Expect(signalTypes(chart.Signals)).To(Equal([]charts.PnFSignalType{charts.DoubleBottomSell, charts.LowPole}))
+		})
+	})
+})
+
+var _ = Describe("when drawing point and figure trend lines", func() {
+	var (
+		chart *charts.PointAndFigure
+	)
+
+	Context("given a first X column that reverses", func() {
+		BeforeEach(func() {
+			_, chart = buildPointAndFigure([]float64{10, 14, 12, 13, 11})
+		})
+
+		It("should draw a bearish resistance line from the box above the first column", func() {
+			Expect(len(chart.TrendLines)).To(Equal(1))
+			line := chart.ActiveTrendLine()
+			Expect(line.LineType).To(Equal(charts.BearishResistanceLine))
+			Expect(line.StartColumnIndex).To(Equal(1))
+			Expect(line.BoxIndexAt(1)).To(Equal(15))
+			Expect(line.BoxIndexAt(3)).To(Equal(13))
+			Expect(line.IsActive()).To(BeTrue())
+		})
+
+		Context("and an X column rises through the bearish resistance line", func() {
+			BeforeEach(func() {
+				_, chart = buildPointAndFigure([]float64{10, 14, 12, 13, 11, 15})
+			})
+
+			It("should end the bearish resistance line", func() {
+				Expect(chart.TrendLines[0].IsActive()).To(BeFalse())
+				Expect(chart.TrendLines[0].EndColumnIndex).To(Equal(4))
+			})
+
+			It("should draw a bullish support line from the box below the lowest O column", func() {
+				line := chart.ActiveTrendLine()
+				Expect(line.LineType).To(Equal(charts.BullishSupportLine))
+				Expect(line.StartColumnIndex).To(Equal(4))
+				Expect(line.BoxIndexAt(4)).To(Equal(10))
+				Expect(line.BoxIndexAt(6)).To(Equal(12))
+			})
+		})
+	})
+})
+
+var _ = Describe("when attaching a point and figure chart to a stream that already has columns", func() {
+	var (
+		pnfStream *gotrade.PnFStream
+		// a triple top buy followed by a pullback and a double top buy, the chart is attached before the pullback
+		closePrices = []float64{10, 13, 11, 13, 11, 14, 12, 15}
+		attachAt    = 6
+	)
+
+	receiveClosePrices := func(from int, to int) {
+		startDate := time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC)
+		for i := from; i < to; i++ {
+			closePrice := closePrices[i]
+			pnfStream.ReceiveDOHLCVTick(gotrade.NewDOHLCVDataItem(startDate.AddDate(0, 0, i), closePrice, closePrice, closePrice, closePrice, 0.0), i+1)
+		}
+	}
+
+	BeforeEach(func() {
+		pnfStream, _ = gotrade.NewFixedBoxPnFStream(1.0, 1, gotrade.CloseOnlyConstruction)
+		receiveClosePrices(0, attachAt)
+	})
+
+	Context("and the chart is created for the stream", func() {
+		var (
+			chart *charts.PointAndFigure
+		)
+
+		BeforeEach(func() {
+			chart, _ = charts.NewPointAndFigureForStream(pnfStream)
+			receiveClosePrices(attachAt, len(closePrices))
+		})
+
+		It("should include the columns built before it was attached", func() {
+			Expect(chart.Columns).To(Equal(pnfStream.Columns))
+		})
+
+		It("should signal the same patterns as a chart attached from the start", func() {
+			Expect(signalTypes(chart.Signals)).To(Equal([]charts.PnFSignalType{charts.TripleTopBuy, charts.DoubleTopBuy, charts.BullishCatapult}))
+		})
+	})
+
+	Context("and the chart is subscribed to the stream", func() {
+		var (
+			chart *charts.PointAndFigure
+		)
+
+		BeforeEach(func() {
+			chart, _ = charts.NewPointAndFigure()
+			pnfStream.AddColumnSubscription(chart)
+			receiveClosePrices(attachAt, len(closePrices))
+		})
+
+		It("should start from the first column it receives", func() {
+			Expect(chart.Columns).To(Equal(pnfStream.Columns[5:]))
+		})
+
+		It("should only look for patterns in the columns it received", func() {
+			Expect(chart.Signals).To(BeEmpty())
+			Expect(len(chart.TrendLines)).To(Equal(1))
+			Expect(chart.ActiveTrendLine().LineType).To(Equal(charts.BullishSupportLine))
+		})
+	})
+})
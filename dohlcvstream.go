@@ -4,7 +4,9 @@
 package gotrade
 
 import (
+	"io"
 	"math"
+	"runtime"
 	"sync"
 	"time"
 )
@@ -14,7 +16,12 @@ type DOHLCVStreamTickReceiver interface {
 }
 
 type DOHLCVStreamSubscriber interface {
-	AddTickSubscription(subscriber DOHLCVTickReceiver)
+	AddTickSubscription(subscriber DOHLCVTickReceiver) io.Closer
+}
+
+// Consumer of the partial bar a stream is currently aggregating
+type DOHLCVFormingBarReceiver interface {
+	ReceiveDOHLCVFormingBar(formingBar DOHLCV, streamBarIndex int)
 }
 
 type DataStreamHolder interface {
@@ -38,12 +45,43 @@ const (
 	MonthlyBar
 )
 
+// How a stream notifies its subscribers of a new bar
+type DispatchStrategy int
+
+const (
+	// notify the subscribers one after another on the publishing goroutine, in the order they subscribed
+	SynchronousDispatch DispatchStrategy = iota
+	// notify the subscribers concurrently on a fixed pool of worker goroutines and wait for them all, the workers
+	// are started when the strategy is selected and keep running until the stream's workers are stopped
+	WorkerPoolDispatch
+	// notify every subscriber on its own goroutine and wait for them all
+	FanOutDispatch
+)
+
+// returns the start and end of the bar period containing date
+type barPeriodFunc func(date time.Time) (periodStart time.Time, periodEnd time.Time)
+
 type DOHLCVStream struct {
-	Data           []DOHLCV
-	subscribers    []DOHLCVTickReceiver
-	streamBarIndex int
-	minValue       float64
-	maxValue       float64
+	Data                  []DOHLCV
+	subscriberMutex       sync.RWMutex
+	subscribers           []DOHLCVTickReceiver
+	formingBarSubscribers []DOHLCVFormingBarReceiver
+	streamBarIndex        int
+	minValue              float64
+	maxValue              float64
+
+	// subscriber notification
+	dispatchStrategy DispatchStrategy
+	workerCount      int
+	workers          *dispatchWorkerPool
+
+	// bar aggregation, a nil barPeriod publishes every tick as a bar
+	barPeriod        barPeriodFunc
+	formingBar       *DOHLCVDataItem
+	formingBarEnd    time.Time
+	previousTickDate time.Time
+	tickDuration     time.Duration
+	tickDurationSet  bool
 }
 
 type InterDayDOHLCVStream struct {
@@ -51,27 +89,178 @@ type InterDayDOHLCVStream struct {
 	streamBarType interDayBarType
 }
 
+// NewDOHLCVStream creates a stream that publishes every tick it receives, for use in derived streams
+func NewDOHLCVStream() *DOHLCVStream {
+	s := DOHLCVStream{streamBarIndex: 0,
+		minValue:         math.MaxFloat64,
+		maxValue:         math.SmallestNonzeroFloat64,
+		dispatchStrategy: SynchronousDispatch,
+		workerCount:      runtime.NumCPU()}
+	return &s
+}
+
+// SetDispatchStrategy changes how the subscribers are notified of new bars, synchronous dispatch is the default
+// and the only strategy in which the subscribers are notified in a deterministic order. Selecting the worker pool
+// dispatch starts the workers, which keep running until StopWorkers is called or another strategy is selected.
+func (p *DOHLCVStream) SetDispatchStrategy(strategy DispatchStrategy) {
+	p.stopWorkerPool()
+	p.dispatchStrategy = strategy
+	if strategy == WorkerPoolDispatch {
+		p.workers = newDispatchWorkerPool(p.workerCount)
+	}
+}
+
+// DispatchStrategy returns how the subscribers are notified of new bars
+func (p *DOHLCVStream) DispatchStrategy() DispatchStrategy {
+	return p.dispatchStrategy
+}
+
+// SetWorkerCount sets the number of goroutines in the pool of the worker pool dispatch strategy,
+// restarting the pool if it is running. A count below 1 is taken as 1. The default is the number of CPUs.
+func (p *DOHLCVStream) SetWorkerCount(workerCount int) {
+	if workerCount < 1 {
+		workerCount = 1
+	}
+	p.workerCount = workerCount
+	if p.workers != nil {
+		p.stopWorkerPool()
+		p.workers = newDispatchWorkerPool(p.workerCount)
+	}
+}
+
+// StopWorkers stops the workers of the worker pool dispatch strategy once they have finished notifying the
+// subscribers, after which the stream notifies its subscribers synchronously until a strategy is selected again
+func (p *DOHLCVStream) StopWorkers() {
+	if p.workers == nil {
+		return
+	}
+	p.stopWorkerPool()
+	p.dispatchStrategy = SynchronousDispatch
+}
+
+func (p *DOHLCVStream) stopWorkerPool() {
+	if p.workers != nil {
+		p.workers.stop()
+		p.workers = nil
+	}
+}
+
+// SetTickDuration sets how long each tick received lasts, so that every bar, the first included, is published as
+// soon as the tick covering the end of its period arrives, e.g. a day for daily bars received in a daily stream.
+// A duration of 0 restores learning the duration as the smallest gap seen between ticks, the default.
+func (p *DOHLCVStream) SetTickDuration(tickDuration time.Duration) {
+	p.tickDuration = tickDuration
+	p.tickDurationSet = tickDuration > 0
+}
+
+// NewInterDayDOHLCVStream creates a stream that aggregates the ticks it receives into daily, weekly or monthly bars.
+// The tick duration is learnt from the gaps between ticks, so a first bar of a single tick, such as the first daily
+// bar received in a daily stream, is only published once a second tick arrives, see SetTickDuration.
 func NewInterDayDOHLCVStream(streamBarType interDayBarType) *InterDayDOHLCVStream {
-	s := InterDayDOHLCVStream{DOHLCVStream: &DOHLCVStream{streamBarIndex: 0,
-		minValue: math.MaxFloat64,
-		maxValue: math.SmallestNonzeroFloat64},
+	s := InterDayDOHLCVStream{DOHLCVStream: NewDOHLCVStream(),
 		streamBarType: streamBarType}
+
+	switch streamBarType {
+	case WeeklyBar:
+		s.barPeriod = weeklyBarPeriod
+	case MonthlyBar:
+		s.barPeriod = monthlyBarPeriod
+	default:
+		s.barPeriod = dailyBarPeriod
+	}
 	return &s
 }
 
+// NewDailyDOHLCVStream creates a stream of daily bars, holding back the first bar as NewInterDayDOHLCVStream does
 func NewDailyDOHLCVStream() *InterDayDOHLCVStream {
 	return NewInterDayDOHLCVStream(DailyBar)
 }
 
+// NewWeeklyDOHLCVStream creates a stream of weekly bars, holding back the first bar as NewInterDayDOHLCVStream does
 func NewWeeklyDOHLCVStream() *InterDayDOHLCVStream {
 	return NewInterDayDOHLCVStream(WeeklyBar)
 }
 
+// NewMonthlyDOHLCVStream creates a stream of monthly bars, holding back the first bar as NewInterDayDOHLCVStream does
 func NewMonthlyDOHLCVStream() *InterDayDOHLCVStream {
 	return NewInterDayDOHLCVStream(MonthlyBar)
 }
 
+// BarType returns the period of the stream's bars, DailyBar, WeeklyBar or MonthlyBar
+func (p *InterDayDOHLCVStream) BarType() interDayBarType {
+	return p.streamBarType
+}
+
+// ReceiveTick consumes a tick, aggregating it into the bar for its period. A bar is published once
+// a tick from a later period arrives, or as soon as a tick covering the end of its period arrives,
+// where a tick is taken to last its set duration, or else as long as the smallest gap seen between ticks.
 func (p *DOHLCVStream) ReceiveTick(tickData DOHLCV) {
+	if p.barPeriod == nil {
+		p.publishBar(tickData)
+		return
+	}
+
+	// learn the duration of the incoming ticks
+	if !p.tickDurationSet && !p.previousTickDate.IsZero() {
+		gap := tickData.D().Sub(p.previousTickDate)
+		if gap > 0 && (p.tickDuration == 0 || gap < p.tickDuration) {
+			p.tickDuration = gap
+		}
+	}
+	p.previousTickDate = tickData.D()
+
+	periodStart, periodEnd := p.barPeriod(tickData.D())
+
+	// a tick from another period closes the forming bar
+	if p.formingBar != nil && !p.formingBar.date.Equal(periodStart) {
+		p.Flush()
+	}
+
+	if p.formingBar == nil {
+		p.formingBar = NewDOHLCVDataItem(periodStart, tickData.O(), tickData.H(), tickData.L(), tickData.C(), tickData.V())
+		p.formingBarEnd = periodEnd
+	} else {
+		p.formingBar.highPrice = math.Max(p.formingBar.highPrice, tickData.H())
+		p.formingBar.lowPrice = math.Min(p.formingBar.lowPrice, tickData.L())
+		p.formingBar.closePrice = tickData.C()
+		p.formingBar.volumePrice += tickData.V()
+	}
+
+	// a tick that covers the end of the period completes the bar
+	if p.tickDuration > 0 && !tickData.D().Add(p.tickDuration).Before(p.formingBarEnd) {
+		p.Flush()
+		return
+	}
+
+	p.publishFormingBar(p.formingBar)
+}
+
+// ReceiveTrade consumes a trade print as a tick with its price as the open, high, low and close and its size as the volume,
+// so that trades can be aggregated into time bars
+func (p *DOHLCVStream) ReceiveTrade(trade Trade) {
+	p.ReceiveTick(NewDOHLCVDataItem(trade.D(), trade.P(), trade.P(), trade.P(), trade.P(), trade.S()))
+}
+
+// Flush publishes the partially aggregated bar, if any, e.g. at the end of a historical feed
+func (p *DOHLCVStream) Flush() {
+	if p.formingBar == nil {
+		return
+	}
+
+	bar := p.formingBar
+	p.formingBar = nil
+	p.publishBar(bar)
+}
+
+// FormingBar returns the partially aggregated bar, or nil if there is none
+func (p *DOHLCVStream) FormingBar() DOHLCV {
+	if p.formingBar == nil {
+		return nil
+	}
+	return p.formingBar
+}
+
+func (p *DOHLCVStream) publishBar(tickData DOHLCV) {
 	p.streamBarIndex++
 	p.Data = append(p.Data, tickData)
 
@@ -83,12 +272,30 @@ func (p *DOHLCVStream) ReceiveTick(tickData DOHLCV) {
 		p.maxValue = tickData.H()
 	}
 
+	// notify the subscribers at the time of publishing, subscriptions may change whilst they are being notified
+	p.subscriberMutex.RLock()
+	subscribers := p.subscribers
+	p.subscriberMutex.RUnlock()
+
+	switch p.dispatchStrategy {
+	case WorkerPoolDispatch:
+		p.workers.dispatch(subscribers, tickData, p.streamBarIndex)
+	case FanOutDispatch:
+		p.dispatchFanOut(subscribers, tickData)
+	default:
+		for _, subscriber := range subscribers {
+			subscriber.ReceiveDOHLCVTick(tickData, p.streamBarIndex)
+		}
+	}
+}
+
+func (p *DOHLCVStream) dispatchFanOut(subscribers []DOHLCVTickReceiver, tickData DOHLCV) {
 	var waitGroup sync.WaitGroup
 
 	// notify all the subscribers and wait
-	for subscriberIndex := range p.subscribers {
+	for subscriberIndex := range subscribers {
 		waitGroup.Add(1)
-		var subscriber DOHLCVTickReceiver = p.subscribers[subscriberIndex]
+		var subscriber DOHLCVTickReceiver = subscribers[subscriberIndex]
 		go func(subscriber DOHLCVTickReceiver) {
 			defer waitGroup.Done()
 			subscriber.ReceiveDOHLCVTick(tickData, p.streamBarIndex)
@@ -99,6 +306,65 @@ func (p *DOHLCVStream) ReceiveTick(tickData DOHLCV) {
 	waitGroup.Wait()
 }
 
+// a subscriber to notify of a bar, and the wait group of the bar's notifications
+type dispatchJob struct {
+	subscriber     DOHLCVTickReceiver
+	tickData       DOHLCV
+	streamBarIndex int
+	notified       *sync.WaitGroup
+}
+
+// a fixed set of goroutines notifying the subscribers sent to them over a channel
+type dispatchWorkerPool struct {
+	jobs    chan dispatchJob
+	stopped sync.WaitGroup
+}
+
+func newDispatchWorkerPool(workerCount int) *dispatchWorkerPool {
+	pool := dispatchWorkerPool{jobs: make(chan dispatchJob, workerCount)}
+	pool.stopped.Add(workerCount)
+	for worker := 0; worker < workerCount; worker++ {
+		go pool.work()
+	}
+	return &pool
+}
+
+func (pool *dispatchWorkerPool) work() {
+	defer pool.stopped.Done()
+	for job := range pool.jobs {
+		job.subscriber.ReceiveDOHLCVTick(job.tickData, job.streamBarIndex)
+		job.notified.Done()
+	}
+}
+
+// dispatch hands every subscriber to the workers and waits until they have all been notified
+func (pool *dispatchWorkerPool) dispatch(subscribers []DOHLCVTickReceiver, tickData DOHLCV, streamBarIndex int) {
+	var notified sync.WaitGroup
+	notified.Add(len(subscribers))
+	for _, subscriber := range subscribers {
+		pool.jobs <- dispatchJob{subscriber: subscriber, tickData: tickData, streamBarIndex: streamBarIndex, notified: &notified}
+	}
+	notified.Wait()
+}
+
+// stop closes the jobs channel and waits for the workers to exit
+func (pool *dispatchWorkerPool) stop() {
+	close(pool.jobs)
+	pool.stopped.Wait()
+}
+
+// publishFormingBar notifies all the forming bar subscribers with a copy of the partial bar
+func (p *DOHLCVStream) publishFormingBar(partialBar *DOHLCVDataItem) {
+	p.subscriberMutex.RLock()
+	subscribers := p.formingBarSubscribers
+	p.subscriberMutex.RUnlock()
+
+	formingBar := *partialBar
+	for _, subscriber := range subscribers {
+		subscriber.ReceiveDOHLCVFormingBar(&formingBar, p.streamBarIndex+1)
+	}
+}
+
 func (p *DOHLCVStream) MinDate() time.Time {
 	// do some checks here, return an error object too
 	return p.Data[0].D()
@@ -117,12 +383,84 @@ func (p *DOHLCVStream) MaxValue() float64 {
 	return p.maxValue
 }
 
-func (p *DOHLCVStream) AddTickSubscription(subscriber DOHLCVTickReceiver) {
-	p.subscribers = append(p.subscribers, subscriber)
+// AddTickSubscription attaches a subscriber to the stream, it is safe to call whilst ticks are being received.
+// Closing the returned subscription detaches the subscriber.
+func (p *DOHLCVStream) AddTickSubscription(subscriber DOHLCVTickReceiver) io.Closer {
+	p.subscriberMutex.Lock()
+	defer p.subscriberMutex.Unlock()
+
+	// copy on write, so that bars being published keep the subscribers they started with
+	subscribers := make([]DOHLCVTickReceiver, len(p.subscribers), len(p.subscribers)+1)
+	copy(subscribers, p.subscribers)
+	p.subscribers = append(subscribers, subscriber)
+
+	return newSubscription(func() { p.RemoveTickSubscription(subscriber) })
 }
 
+// RemoveTickSubscription detaches a subscriber from the stream, it is safe to call whilst ticks are being received.
+// A subscriber being notified of a bar as it is removed still receives that bar.
 func (p *DOHLCVStream) RemoveTickSubscription(subscriber DOHLCVTickReceiver) {
+	p.subscriberMutex.Lock()
+	defer p.subscriberMutex.Unlock()
+
+	for i := range p.subscribers {
+		if p.subscribers[i] == subscriber {
+			subscribers := make([]DOHLCVTickReceiver, 0, len(p.subscribers)-1)
+			subscribers = append(subscribers, p.subscribers[:i]...)
+			p.subscribers = append(subscribers, p.subscribers[i+1:]...)
+			return
+		}
+	}
+}
+
+// TickSubscriberCount returns the number of subscribers attached to the stream
+func (p *DOHLCVStream) TickSubscriberCount() int {
+	p.subscriberMutex.RLock()
+	defer p.subscriberMutex.RUnlock()
+	return len(p.subscribers)
+}
+
+// AddFormingBarSubscription attaches a forming bar subscriber to the stream, closing the returned subscription detaches it
+func (p *DOHLCVStream) AddFormingBarSubscription(subscriber DOHLCVFormingBarReceiver) io.Closer {
+	p.subscriberMutex.Lock()
+	defer p.subscriberMutex.Unlock()
+
+	subscribers := make([]DOHLCVFormingBarReceiver, len(p.formingBarSubscribers), len(p.formingBarSubscribers)+1)
+	copy(subscribers, p.formingBarSubscribers)
+	p.formingBarSubscribers = append(subscribers, subscriber)
+
+	return newSubscription(func() { p.RemoveFormingBarSubscription(subscriber) })
+}
+
+// RemoveFormingBarSubscription detaches a forming bar subscriber from the stream
+func (p *DOHLCVStream) RemoveFormingBarSubscription(subscriber DOHLCVFormingBarReceiver) {
+	p.subscriberMutex.Lock()
+	defer p.subscriberMutex.Unlock()
+
+	for i := range p.formingBarSubscribers {
+		if p.formingBarSubscribers[i] == subscriber {
+			subscribers := make([]DOHLCVFormingBarReceiver, 0, len(p.formingBarSubscribers)-1)
+			subscribers = append(subscribers, p.formingBarSubscribers[:i]...)
+			p.formingBarSubscribers = append(subscribers, p.formingBarSubscribers[i+1:]...)
+			return
+		}
+	}
+}
+
+// A handle to a stream subscription, closing it more than once has no further effect
+type subscription struct {
+	once        sync.Once
+	unsubscribe func()
+}
+
+func newSubscription(unsubscribe func()) *subscription {
+	return &subscription{unsubscribe: unsubscribe}
+}
 
+// Close detaches the subscriber from the stream
+func (s *subscription) Close() error {
+	s.once.Do(s.unsubscribe)
+	return nil
 }
 
 type IntraDayDOHLCVStream struct {
@@ -130,10 +468,51 @@ type IntraDayDOHLCVStream struct {
 	intraDayBarInterval int
 }
 
+// NewIntraDayDOHLCVStream creates a stream that aggregates the ticks it receives into bars of barIntervalInMins,
+// bar periods are aligned to midnight and intervals below 1 minute are treated as 1 minute. The tick duration is
+// learnt from the gaps between ticks, so a first bar of a single tick is only published once a second tick
+// arrives, see SetTickDuration.
 func NewIntraDayDOHLCVStream(barIntervalInMins int) *IntraDayDOHLCVStream {
-	s := IntraDayDOHLCVStream{DOHLCVStream: &DOHLCVStream{streamBarIndex: 0,
-		minValue: math.MaxFloat64,
-		maxValue: math.SmallestNonzeroFloat64},
+	if barIntervalInMins < 1 {
+		barIntervalInMins = 1
+	}
+
+	s := IntraDayDOHLCVStream{DOHLCVStream: NewDOHLCVStream(),
 		intraDayBarInterval: barIntervalInMins}
+
+	s.barPeriod = func(date time.Time) (periodStart time.Time, periodEnd time.Time) {
+		midnight := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+		minutes := date.Hour()*60 + date.Minute()
+		periodStart = midnight.Add(time.Duration(minutes-minutes%barIntervalInMins) * time.Minute)
+		periodEnd = periodStart.Add(time.Duration(barIntervalInMins) * time.Minute)
+
+		// a period can not run past midnight
+		if nextMidnight := midnight.AddDate(0, 0, 1); periodEnd.After(nextMidnight) {
+			periodEnd = nextMidnight
+		}
+		return periodStart, periodEnd
+	}
 	return &s
 }
+
+// BarInterval returns the period of the stream's bars in minutes
+func (p *IntraDayDOHLCVStream) BarInterval() int {
+	return p.intraDayBarInterval
+}
+
+func dailyBarPeriod(date time.Time) (periodStart time.Time, periodEnd time.Time) {
+	periodStart = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	return periodStart, periodStart.AddDate(0, 0, 1)
+}
+
+// weeks start on a monday
+func weeklyBarPeriod(date time.Time) (periodStart time.Time, periodEnd time.Time) {
+	daysSinceMonday := (int(date.Weekday()) + 6) % 7
+	periodStart = time.Date(date.Year(), date.Month(), date.Day()-daysSinceMonday, 0, 0, 0, 0, date.Location())
+	return periodStart, periodStart.AddDate(0, 0, 7)
+}
+
+func monthlyBarPeriod(date time.Time) (periodStart time.Time, periodEnd time.Time) {
+	periodStart = time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, date.Location())
+	return periodStart, periodStart.AddDate(0, 1, 0)
+}
@@ -1 +1,612 @@
 package gotrade_test
+
+import (
+	. "github.com/jaybutera/gotrade"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"io"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type fakeFormingBarReceiver struct {
+	formingBars     []DOHLCV
+	streamBarIndexs []int
+}
+
+func (f *fakeFormingBarReceiver) ReceiveDOHLCVFormingBar(formingBar DOHLCV, streamBarIndex int) {
+	f.formingBars = append(f.formingBars, formingBar)
+	f.streamBarIndexs = append(f.streamBarIndexs, streamBarIndex)
+}
+
+type fakeTickReceiver struct {
+	streamBarIndexs []int
+}
+
+func (f *fakeTickReceiver) ReceiveDOHLCVTick(tickData DOHLCV, streamBarIndex int) {
+	f.streamBarIndexs = append(f.streamBarIndexs, streamBarIndex)
+}
+
+// minuteBars creates count one minute bars from start, each bar opens at the previous close and moves by 1
+func minuteBars(start time.Time, count int) []DOHLCV {
+	var bars []DOHLCV
+	for i := 0; i < count; i++ {
+		open := 100.0 + float64(i)
+		bars = append(bars, NewDOHLCVDataItem(start.Add(time.Duration(i)*time.Minute), open, open+2.0, open-1.0, open+1.0, 10.0))
+	}
+	return bars
+}
+
+var _ = Describe("when aggregating one minute bars into a fifteen minute stream", func() {
+	var (
+		priceStream *IntraDayDOHLCVStream
+		formingBars *fakeFormingBarReceiver
+		subscriber  *fakeTickReceiver
+		start       time.Time
+	)
+
+	BeforeEach(func() {
+		priceStream = NewIntraDayDOHLCVStream(15)
+		formingBars = &fakeFormingBarReceiver{}
+		subscriber = &fakeTickReceiver{}
+		priceStream.AddFormingBarSubscription(formingBars)
+		priceStream.AddTickSubscription(subscriber)
+		start = time.Date(2014, 3, 3, 9, 0, 0, 0, time.UTC)
+		for _, bar := range minuteBars(start, 40) {
+			priceStream.ReceiveTick(bar)
+		}
+	})
+
+	It("should publish a bar for each completed fifteen minute period", func() {
+		Expect(len(priceStream.Data)).To(Equal(2))
+		Expect(subscriber.streamBarIndexs).To(Equal([]int{1, 2}))
+	})
+
+	It("should date each bar at the start of its period", func() {
+		Expect(priceStream.Data[0].D()).To(Equal(start))
+		Expect(priceStream.Data[1].D()).To(Equal(start.Add(15 * time.Minute)))
+	})
+
+	It("should take the first open, highest high, lowest low, last close and summed volume", func() {
+		Expect(priceStream.Data[1].O()).To(Equal(115.0))
+		Expect(priceStream.Data[1].H()).To(Equal(131.0))
+		Expect(priceStream.Data[1].L()).To(Equal(114.0))
+		Expect(priceStream.Data[1].C()).To(Equal(130.0))
+		Expect(priceStream.Data[1].V()).To(Equal(150.0))
+	})
+
+	It("should make the partial bar visible through the forming bar subscription", func() {
+		formingBar := formingBars.formingBars[len(formingBars.formingBars)-1]
+		Expect(formingBar.D()).To(Equal(start.Add(30 * time.Minute)))
+		Expect(formingBar.O()).To(Equal(130.0))
+		Expect(formingBar.C()).To(Equal(140.0))
+		Expect(formingBar.V()).To(Equal(100.0))
+		Expect(formingBars.streamBarIndexs[len(formingBars.streamBarIndexs)-1]).To(Equal(3))
+		Expect(priceStream.FormingBar().C()).To(Equal(140.0))
+	})
+
+	Context("and the stream is flushed", func() {
+		BeforeEach(func() {
+			priceStream.Flush()
+		})
+
+		It("should publish the partial bar", func() {
+			Expect(len(priceStream.Data)).To(Equal(3))
+			Expect(priceStream.Data[2].C()).To(Equal(140.0))
+			Expect(priceStream.FormingBar()).To(BeNil())
+		})
+	})
+})
+
+var _ = Describe("when receiving bars of a set duration", func() {
+	var (
+		start time.Time
+	)
+
+	BeforeEach(func() {
+		start = time.Date(2014, 3, 3, 0, 0, 0, 0, time.UTC)
+	})
+
+	It("should publish the first daily bar in a daily stream as soon as it is received", func() {
+		priceStream := NewDailyDOHLCVStream()
+		priceStream.SetTickDuration(24 * time.Hour)
+		priceStream.ReceiveTick(NewDOHLCVDataItem(start, 10, 12, 9, 11, 100))
+
+		Expect(len(priceStream.Data)).To(Equal(1))
+		Expect(priceStream.FormingBar()).To(BeNil())
+	})
+
+	It("should publish the first fifteen minute bar on its last minute bar", func() {
+		priceStream := NewIntraDayDOHLCVStream(15)
+		priceStream.SetTickDuration(time.Minute)
+		for _, bar := range minuteBars(start.Add(9*time.Hour), 15) {
+			priceStream.ReceiveTick(bar)
+		}
+
+		Expect(len(priceStream.Data)).To(Equal(1))
+		Expect(priceStream.Data[0].D()).To(Equal(start.Add(9 * time.Hour)))
+	})
+
+	It("should hold the first bar back again once the duration is cleared", func() {
+		priceStream := NewDailyDOHLCVStream()
+		priceStream.SetTickDuration(24 * time.Hour)
+		priceStream.SetTickDuration(0)
+		priceStream.ReceiveTick(NewDOHLCVDataItem(start, 10, 12, 9, 11, 100))
+
+		Expect(len(priceStream.Data)).To(Equal(0))
+	})
+})
+
+var _ = Describe("when aggregating one minute bars into an hourly stream", func() {
+	var (
+		priceStream *IntraDayDOHLCVStream
+	)
+
+	BeforeEach(func() {
+		priceStream = NewIntraDayDOHLCVStream(60)
+		start := time.Date(2014, 3, 3, 9, 30, 0, 0, time.UTC)
+		for _, bar := range minuteBars(start, 45) {
+			priceStream.ReceiveTick(bar)
+		}
+	})
+
+	It("should close the bar when the hour rolls over", func() {
+		Expect(len(priceStream.Data)).To(Equal(1))
+		Expect(priceStream.Data[0].D()).To(Equal(time.Date(2014, 3, 3, 9, 0, 0, 0, time.UTC)))
+		Expect(priceStream.Data[0].O()).To(Equal(100.0))
+		Expect(priceStream.Data[0].C()).To(Equal(130.0))
+		Expect(priceStream.Data[0].V()).To(Equal(300.0))
+	})
+})
+
+var _ = Describe("when aggregating one minute bars into a daily stream", func() {
+	var (
+		priceStream *InterDayDOHLCVStream
+	)
+
+	BeforeEach(func() {
+		priceStream = NewDailyDOHLCVStream()
+		for _, bar := range minuteBars(time.Date(2014, 3, 3, 23, 50, 0, 0, time.UTC), 20) {
+			priceStream.ReceiveTick(bar)
+		}
+	})
+
+	It("should publish a bar for the completed day dated at midnight", func() {
+		Expect(len(priceStream.Data)).To(Equal(1))
+		Expect(priceStream.Data[0].D()).To(Equal(time.Date(2014, 3, 3, 0, 0, 0, 0, time.UTC)))
+		Expect(priceStream.Data[0].H()).To(Equal(111.0))
+		Expect(priceStream.Data[0].V()).To(Equal(100.0))
+	})
+})
+
+var _ = Describe("when receiving daily bars in a daily stream", func() {
+	var (
+		priceStream *InterDayDOHLCVStream
+		start       time.Time
+	)
+
+	BeforeEach(func() {
+		priceStream = NewDailyDOHLCVStream()
+		start = time.Date(2014, 3, 3, 0, 0, 0, 0, time.UTC)
+		priceStream.ReceiveTick(NewDOHLCVDataItem(start, 10, 12, 9, 11, 100))
+	})
+
+	It("should hold the first bar until its duration is known", func() {
+		Expect(len(priceStream.Data)).To(Equal(0))
+	})
+
+	Context("and more daily bars are received", func() {
+		BeforeEach(func() {
+			priceStream.ReceiveTick(NewDOHLCVDataItem(start.AddDate(0, 0, 1), 11, 13, 10, 12, 200))
+			priceStream.ReceiveTick(NewDOHLCVDataItem(start.AddDate(0, 0, 2), 12, 14, 11, 13, 300))
+		})
+
+		It("should publish every bar as soon as it is received", func() {
+			Expect(len(priceStream.Data)).To(Equal(3))
+			Expect(priceStream.Data[2].D()).To(Equal(start.AddDate(0, 0, 2)))
+			Expect(priceStream.Data[2].V()).To(Equal(300.0))
+			Expect(priceStream.FormingBar()).To(BeNil())
+		})
+	})
+})
+
+var _ = Describe("when receiving bars of a set duration", func() {
+	var (
+		start time.Time
+	)
+
+	BeforeEach(func() {
+		start = time.Date(2014, 3, 3, 0, 0, 0, 0, time.UTC)
+	})
+
+	It("should publish the first daily bar in a daily stream as soon as it is received", func() {
+		priceStream := NewDailyDOHLCVStream()
+		priceStream.SetTickDuration(24 * time.Hour)
+		priceStream.ReceiveTick(NewDOHLCVDataItem(start, 10, 12, 9, 11, 100))
+
+		Expect(len(priceStream.Data)).To(Equal(1))
+		Expect(priceStream.FormingBar()).To(BeNil())
+	})
+
+	It("should publish the first fifteen minute bar on its last minute bar", func() {
+		priceStream := NewIntraDayDOHLCVStream(15)
+		priceStream.SetTickDuration(time.Minute)
+		for _, bar := range minuteBars(start.Add(9*time.Hour), 15) {
+			priceStream.ReceiveTick(bar)
+		}
+
+		Expect(len(priceStream.Data)).To(Equal(1))
+		Expect(priceStream.Data[0].D()).To(Equal(start.Add(9 * time.Hour)))
+	})
+
+	It("should keep the set duration when ticks arrive closer together", func() {
+		priceStream := NewDailyDOHLCVStream()
+		priceStream.SetTickDuration(24 * time.Hour)
+		priceStream.ReceiveTick(NewDOHLCVDataItem(start, 10, 12, 9, 11, 100))
+		priceStream.ReceiveTick(NewDOHLCVDataItem(start.AddDate(0, 0, 1), 11, 13, 10, 12, 200))
+		priceStream.ReceiveTick(NewDOHLCVDataItem(start.AddDate(0, 0, 1).Add(time.Hour), 12, 14, 11, 13, 300))
+
+		Expect(len(priceStream.Data)).To(Equal(3))
+	})
+})
+
+var _ = Describe("when aggregating daily bars into weekly and monthly streams", func() {
+	var (
+		weeklyStream  *InterDayDOHLCVStream
+		monthlyStream *InterDayDOHLCVStream
+	)
+
+	BeforeEach(func() {
+		weeklyStream = NewWeeklyDOHLCVStream()
+		monthlyStream = NewMonthlyDOHLCVStream()
+
+		// weekdays from wednesday the 26th of february to tuesday the 11th of march 2014
+		date := time.Date(2014, 2, 26, 0, 0, 0, 0, time.UTC)
+		for i := 0; date.Before(time.Date(2014, 3, 12, 0, 0, 0, 0, time.UTC)); date = date.AddDate(0, 0, 1) {
+			if date.Weekday() == time.Saturday || date.Weekday() == time.Sunday {
+				continue
+			}
+			price := 100.0 + float64(i)
+			bar := NewDOHLCVDataItem(date, price, price+1, price-1, price+0.5, 1)
+			weeklyStream.ReceiveTick(bar)
+			monthlyStream.ReceiveTick(bar)
+			i++
+		}
+	})
+
+	It("should publish weekly bars starting on a monday", func() {
+		Expect(len(weeklyStream.Data)).To(Equal(2))
+		Expect(weeklyStream.Data[0].D()).To(Equal(time.Date(2014, 2, 24, 0, 0, 0, 0, time.UTC)))
+		Expect(weeklyStream.Data[0].V()).To(Equal(3.0))
+		Expect(weeklyStream.Data[1].D()).To(Equal(time.Date(2014, 3, 3, 0, 0, 0, 0, time.UTC)))
+		Expect(weeklyStream.Data[1].O()).To(Equal(103.0))
+		Expect(weeklyStream.Data[1].H()).To(Equal(108.0))
+		Expect(weeklyStream.Data[1].L()).To(Equal(102.0))
+		Expect(weeklyStream.Data[1].C()).To(Equal(107.5))
+		Expect(weeklyStream.FormingBar().V()).To(Equal(2.0))
+	})
+
+	It("should publish monthly bars starting on the first of the month", func() {
+		Expect(len(monthlyStream.Data)).To(Equal(1))
+		Expect(monthlyStream.Data[0].D()).To(Equal(time.Date(2014, 2, 1, 0, 0, 0, 0, time.UTC)))
+		Expect(monthlyStream.Data[0].O()).To(Equal(100.0))
+		Expect(monthlyStream.Data[0].C()).To(Equal(102.5))
+		Expect(monthlyStream.FormingBar().D()).To(Equal(time.Date(2014, 3, 1, 0, 0, 0, 0, time.UTC)))
+	})
+})
+
+var _ = Describe("when creating streams of a bar period", func() {
+	It("should report the bar type of an inter day stream", func() {
+		Expect(NewDailyDOHLCVStream().BarType()).To(Equal(DailyBar))
+		Expect(NewWeeklyDOHLCVStream().BarType()).To(Equal(WeeklyBar))
+		Expect(NewMonthlyDOHLCVStream().BarType()).To(Equal(MonthlyBar))
+	})
+
+	It("should report the bar interval of an intra day stream", func() {
+		Expect(NewIntraDayDOHLCVStream(15).BarInterval()).To(Equal(15))
+		Expect(NewIntraDayDOHLCVStream(0).BarInterval()).To(Equal(1))
+	})
+})
+
+type countingTickReceiver struct {
+	count int64
+}
+
+func (f *countingTickReceiver) ReceiveDOHLCVTick(tickData DOHLCV, streamBarIndex int) {
+	atomic.AddInt64(&f.count, 1)
+}
+
+var _ = Describe("when removing subscribers from a stream", func() {
+	var (
+		priceStream  *DOHLCVStream
+		first        *fakeTickReceiver
+		second       *fakeTickReceiver
+		subscription io.Closer
+		bars         []DOHLCV
+	)
+
+	BeforeEach(func() {
+		priceStream = NewDOHLCVStream()
+		first = &fakeTickReceiver{}
+		second = &fakeTickReceiver{}
+		subscription = priceStream.AddTickSubscription(first)
+		priceStream.AddTickSubscription(second)
+		bars = minuteBars(time.Date(2014, 3, 3, 9, 0, 0, 0, time.UTC), 4)
+		priceStream.ReceiveTick(bars[0])
+		priceStream.ReceiveTick(bars[1])
+	})
+
+	Context("and a subscriber is removed", func() {
+		BeforeEach(func() {
+			priceStream.RemoveTickSubscription(first)
+			priceStream.ReceiveTick(bars[2])
+		})
+
+		It("should no longer notify the removed subscriber", func() {
+			Expect(first.streamBarIndexs).To(Equal([]int{1, 2}))
+			Expect(second.streamBarIndexs).To(Equal([]int{1, 2, 3}))
+			Expect(priceStream.TickSubscriberCount()).To(Equal(1))
+		})
+	})
+
+	Context("and a subscription is closed twice", func() {
+		BeforeEach(func() {
+			Expect(subscription.Close()).To(Succeed())
+			Expect(subscription.Close()).To(Succeed())
+			priceStream.ReceiveTick(bars[2])
+		})
+
+		It("should detach only its own subscriber", func() {
+			Expect(first.streamBarIndexs).To(Equal([]int{1, 2}))
+			Expect(second.streamBarIndexs).To(Equal([]int{1, 2, 3}))
+			Expect(priceStream.TickSubscriberCount()).To(Equal(1))
+		})
+	})
+
+	Context("and a forming bar subscription is closed", func() {
+		var (
+			intraDayStream *IntraDayDOHLCVStream
+			formingBars    *fakeFormingBarReceiver
+		)
+
+		BeforeEach(func() {
+			intraDayStream = NewIntraDayDOHLCVStream(15)
+			formingBars = &fakeFormingBarReceiver{}
+			formingBarSubscription := intraDayStream.AddFormingBarSubscription(formingBars)
+			intraDayStream.ReceiveTick(bars[0])
+			formingBarSubscription.Close()
+			intraDayStream.ReceiveTick(bars[1])
+		})
+
+		It("should no longer notify the forming bar subscriber", func() {
+			Expect(len(formingBars.formingBars)).To(Equal(1))
+		})
+	})
+})
+
+var _ = Describe("when attaching and detaching subscribers whilst ticks are flowing", func() {
+	var (
+		priceStream *DOHLCVStream
+		permanent   *countingTickReceiver
+	)
+
+	BeforeEach(func() {
+		priceStream = NewDOHLCVStream()
+		permanent = &countingTickReceiver{}
+		priceStream.AddTickSubscription(permanent)
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for _, bar := range minuteBars(time.Date(2014, 3, 3, 9, 0, 0, 0, time.UTC), 500) {
+				priceStream.ReceiveTick(bar)
+			}
+		}()
+
+		for i := 0; i < 100; i++ {
+			subscription := priceStream.AddTickSubscription(&countingTickReceiver{})
+			subscription.Close()
+		}
+		<-done
+	})
+
+	It("should keep notifying the subscribers that remain attached", func() {
+		Expect(atomic.LoadInt64(&permanent.count)).To(Equal(int64(500)))
+		Expect(priceStream.TickSubscriberCount()).To(Equal(1))
+	})
+})
+
+type orderRecordingTickReceiver struct {
+	id    int
+	order *[]int
+}
+
+func (f *orderRecordingTickReceiver) ReceiveDOHLCVTick(tickData DOHLCV, streamBarIndex int) {
+	*f.order = append(*f.order, f.id)
+}
+
+var _ = Describe("when dispatching bars to subscribers", func() {
+	var (
+		priceStream *DOHLCVStream
+		bars        []DOHLCV
+	)
+
+	BeforeEach(func() {
+		priceStream = NewDOHLCVStream()
+		bars = minuteBars(time.Date(2014, 3, 3, 9, 0, 0, 0, time.UTC), 20)
+	})
+
+	It("should dispatch synchronously by default", func() {
+		Expect(priceStream.DispatchStrategy()).To(Equal(SynchronousDispatch))
+	})
+
+	Context("and the dispatch is synchronous", func() {
+		var (
+			order []int
+		)
+
+		BeforeEach(func() {
+			order = nil
+			for i := 0; i < 5; i++ {
+				priceStream.AddTickSubscription(&orderRecordingTickReceiver{id: i, order: &order})
+			}
+			priceStream.ReceiveTick(bars[0])
+			priceStream.ReceiveTick(bars[1])
+		})
+
+		It("should notify the subscribers in the order they subscribed", func() {
+			Expect(order).To(Equal([]int{0, 1, 2, 3, 4, 0, 1, 2, 3, 4}))
+		})
+	})
+
+	for _, strategy := range []DispatchStrategy{WorkerPoolDispatch, FanOutDispatch} {
+		strategy := strategy
+
+		Context("and the dispatch is concurrent", func() {
+			var (
+				receivers []*countingTickReceiver
+			)
+
+			BeforeEach(func() {
+				priceStream.SetWorkerCount(3)
+				priceStream.SetDispatchStrategy(strategy)
+				receivers = nil
+				for i := 0; i < 10; i++ {
+					receiver := &countingTickReceiver{}
+					receivers = append(receivers, receiver)
+					priceStream.AddTickSubscription(receiver)
+				}
+				for _, bar := range bars {
+					priceStream.ReceiveTick(bar)
+				}
+			})
+
+			AfterEach(func() {
+				priceStream.StopWorkers()
+			})
+
+			It("should notify every subscriber of every bar before publishing the next", func() {
+				for _, receiver := range receivers {
+					Expect(atomic.LoadInt64(&receiver.count)).To(Equal(int64(len(bars))))
+				}
+			})
+		})
+	}
+
+	Context("and the dispatch is by a worker pool", func() {
+		var (
+			receiver *goroutineRecordingTickReceiver
+			order    []int
+		)
+
+		BeforeEach(func() {
+			priceStream.SetWorkerCount(3)
+			priceStream.SetDispatchStrategy(WorkerPoolDispatch)
+			receiver = &goroutineRecordingTickReceiver{goroutines: map[string]bool{}}
+			for i := 0; i < 5; i++ {
+				priceStream.AddTickSubscription(receiver)
+			}
+			for _, bar := range bars {
+				priceStream.ReceiveTick(bar)
+			}
+		})
+
+		AfterEach(func() {
+			priceStream.StopWorkers()
+		})
+
+		It("should notify the subscribers of every bar on the same workers", func() {
+			Expect(len(receiver.goroutines)).To(BeNumerically("<=", 3))
+			Expect(runningWorkerCount()).To(Equal(3))
+		})
+
+		It("should restart the workers when the worker count changes", func() {
+			priceStream.SetWorkerCount(5)
+			Eventually(runningWorkerCount).Should(Equal(5))
+		})
+
+		Context("and the workers are stopped", func() {
+			BeforeEach(func() {
+				priceStream.StopWorkers()
+				order = nil
+				for i := 0; i < 3; i++ {
+					priceStream.AddTickSubscription(&orderRecordingTickReceiver{id: i, order: &order})
+				}
+				priceStream.ReceiveTick(bars[0])
+			})
+
+			It("should stop the worker goroutines", func() {
+				Eventually(runningWorkerCount).Should(Equal(0))
+			})
+
+			It("should notify the subscribers synchronously", func() {
+				Expect(priceStream.DispatchStrategy()).To(Equal(SynchronousDispatch))
+				Expect(order).To(Equal([]int{0, 1, 2}))
+			})
+		})
+	})
+})
+
+// goroutineRecordingTickReceiver records the goroutines it is notified on
+type goroutineRecordingTickReceiver struct {
+	mutex      sync.Mutex
+	goroutines map[string]bool
+}
+
+func (f *goroutineRecordingTickReceiver) ReceiveDOHLCVTick(tickData DOHLCV, streamBarIndex int) {
+	stack := make([]byte, 64)
+	stack = stack[:runtime.Stack(stack, false)]
+	f.mutex.Lock()
+	f.goroutines[strings.Fields(string(stack))[1]] = true
+	f.mutex.Unlock()
+}
+
+// runningWorkerCount counts the goroutines of the dispatch worker pools that are running
+func runningWorkerCount() int {
+	stacks := make([]byte, 1<<20)
+	stacks = stacks[:runtime.Stack(stacks, true)]
+	return strings.Count(string(stacks), "created by github.com/jaybutera/gotrade.newDispatchWorkerPool")
+}
+
+func benchmarkDispatch(b *testing.B, strategy DispatchStrategy, subscriberCount int) {
+	priceStream := NewDOHLCVStream()
+	priceStream.SetDispatchStrategy(strategy)
+	defer priceStream.StopWorkers()
+	for i := 0; i < subscriberCount; i++ {
+		priceStream.AddTickSubscription(&countingTickReceiver{})
+	}
+	bar := NewDOHLCVDataItem(time.Date(2014, 3, 3, 9, 0, 0, 0, time.UTC), 100, 102, 99, 101, 10)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		priceStream.ReceiveTick(bar)
+	}
+}
+
+func BenchmarkSynchronousDispatch10Subscribers(b *testing.B) {
+	benchmarkDispatch(b, SynchronousDispatch, 10)
+}
+
+func BenchmarkWorkerPoolDispatch10Subscribers(b *testing.B) {
+	benchmarkDispatch(b, WorkerPoolDispatch, 10)
+}
+
+func BenchmarkFanOutDispatch10Subscribers(b *testing.B) {
+	benchmarkDispatch(b, FanOutDispatch, 10)
+}
+
+func BenchmarkSynchronousDispatch100Subscribers(b *testing.B) {
+	benchmarkDispatch(b, SynchronousDispatch, 100)
+}
+
+func BenchmarkWorkerPoolDispatch100Subscribers(b *testing.B) {
+	benchmarkDispatch(b, WorkerPoolDispatch, 100)
+}
+
+func BenchmarkFanOutDispatch100Subscribers(b *testing.B) {
+	benchmarkDispatch(b, FanOutDispatch, 100)
+}
@@ -0,0 +1,339 @@
+package feeds
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The bar file format stores a series of bars compactly for fast reloading, as:
+//	- a header of the magic number, the format version, the symbol, the bar type, the timezone and
+//	- the decimal places the prices and volumes are stored to.
+//	- blocks of up to BarFileBlockSize bars, each holding the columns of the dates, opens, highs, lows,
+//	- closes and volumes. The dates are nanoseconds since the unix epoch, from the year 1678 to 2262, and
+//	- are delta of delta encoded, so regular bars take a byte each, and the prices and volumes are delta
+//	- encoded as whole numbers of their decimal places, all as varints.
+//	- an index of the offset, bar count and date range of each block, for seeking by date.
+//	- a trailer of the offset of the index and the magic number.
+
+const (
+	// the version of the bar file format written
+	BarFileVersion = 1
+	// the most bars stored in a block
+	BarFileBlockSize = 4096
+
+	barFileMagic       = "GTBARS"
+	barFileTrailerSize = 8 + len(barFileMagic)
+
+	// the largest whole number a float64 holds exactly
+	maxExactFloatInteger = 1 << 53
+
+	// the prefix of the timezone of a fixed zone, stored as its offset in seconds and its name
+	barFileFixedZonePrefix = "fixed:"
+)
+
+var (
+	ErrBarFileMagic        = errors.New("the file is not a bar file")
+	ErrBarFileVersion      = errors.New("the bar file version is not supported")
+	ErrBarFileCorrupt      = errors.New("the bar file is corrupt")
+	ErrBarFileDateOrder    = errors.New("bars must be written in date order")
+	ErrBarFileValue        = errors.New("the value cannot be stored in the bar file")
+	ErrBarFileWriterClosed = errors.New("the bar file writer is closed")
+)
+
+// the earliest and latest dates a bar file stores as nanoseconds since the unix epoch
+var (
+	minBarFileDate = time.Unix(0, math.MinInt64)
+	maxBarFileDate = time.Unix(0, math.MaxInt64)
+)
+
+// The BarFileBarType is the period of the bars of a bar file
+type BarFileBarType uint8
+
+const (
+	UnknownBars BarFileBarType = iota
+	DailyBars
+	WeeklyBars
+	MonthlyBars
+	// bars of the interval of the header in minutes
+	IntraDayBars
+)
+
+func (t BarFileBarType) String() string {
+	switch t {
+	case DailyBars:
+		return "daily"
+	case WeeklyBars:
+		return "weekly"
+	case MonthlyBars:
+		return "monthly"
+	case IntraDayBars:
+		return "intra day"
+	}
+	return "unknown"
+}
+
+// The BarFileHeader describes the bars of a bar file
+type BarFileHeader struct {
+	Symbol  string
+	BarType BarFileBarType
+	// the minutes of an intra day bar
+	Interval int
+	// the timezone the dates of the bars are read in, UTC when nil. It is stored by its name for a timezone
+	// loaded from the IANA database, or by its offset for a fixed zone, other locations cannot be stored.
+	Location *time.Location
+	// the decimal places the prices and volumes are stored to, values are rounded to them
+	PriceDecimals  int
+	VolumeDecimals int
+}
+
+// DefaultBarFileHeader returns the header of a daily bar file of the symbol in UTC, storing prices to
+// 6 decimal places and volumes to 2
+func DefaultBarFileHeader(symbol string) BarFileHeader {
+	return BarFileHeader{Symbol: symbol, BarType: DailyBars, Location: time.UTC, PriceDecimals: 6, VolumeDecimals: 2}
+}
+
+func (h *BarFileHeader) validate() error {
+	switch {
+	case h.BarType > IntraDayBars:
+		return fmt.Errorf("barType %d is not a bar type", h.BarType)
+	case h.Interval < 0:
+		return errors.New("interval is less than the minimum (0)")
+	case h.PriceDecimals < 0 || h.PriceDecimals > 12:
+		return errors.New("priceDecimals is not between 0 and 12")
+	case h.VolumeDecimals < 0 || h.VolumeDecimals > 12:
+		return errors.New("volumeDecimals is not between 0 and 12")
+	}
+
+	_, err := h.zone()
+	return err
+}
+
+func (h *BarFileHeader) location() *time.Location {
+	if h.Location == nil {
+		return time.UTC
+	}
+	return h.Location
+}
+
+// zone returns the timezone the location is stored as, the name of a timezone of the IANA database with the
+// same offsets or, for a location with a single offset, the offset and name of the fixed zone
+func (h *BarFileHeader) zone() (string, error) {
+	location := h.location()
+	name := location.String()
+	if name != "Local" {
+		if loaded, err := time.LoadLocation(name); err == nil && sameOffsets(loaded, location) {
+			return name, nil
+		}
+	}
+
+	if sameOffsets(time.FixedZone("", offsetAt(location, zoneSamples[0])), location) {
+		abbreviation, offset := zoneSamples[0].In(location).Zone()
+		return barFileFixedZonePrefix + strconv.Itoa(offset) + ":" + abbreviation, nil
+	}
+	return "", fmt.Errorf("location %q is neither a timezone of the IANA database nor a fixed zone", name)
+}
+
+// readBarFileZone returns the location of a timezone stored by zone
+func readBarFileZone(zone string) (*time.Location, error) {
+	if !strings.HasPrefix(zone, barFileFixedZonePrefix) {
+		return time.LoadLocation(zone)
+	}
+
+	fields := strings.SplitN(strings.TrimPrefix(zone, barFileFixedZonePrefix), ":", 2)
+	offset, err := strconv.Atoi(fields[0])
+	if err != nil || len(fields) != 2 {
+		return nil, ErrBarFileCorrupt
+	}
+
+	if offset == 0 && fields[1] == "UTC" {
+		return time.UTC, nil
+	}
+	return time.FixedZone(fields[1], offset), nil
+}
+
+// zoneSamples are the instants the offsets of locations are compared at, the middle of winter and
+// summer of each year from 1900 to 2100
+var zoneSamples = func() []time.Time {
+	var samples []time.Time
+	for year := 1900; year <= 2100; year++ {
+		samples = append(samples, time.Date(year, 1, 15, 0, 0, 0, 0, time.UTC), time.Date(year, 7, 15, 0, 0, 0, 0, time.UTC))
+	}
+	return samples
+}()
+
+func offsetAt(location *time.Location, instant time.Time) int {
+	_, offset := instant.In(location).Zone()
+	return offset
+}
+
+// sameOffsets returns whether the locations have the same offset at each of the zone samples
+func sameOffsets(a *time.Location, b *time.Location) bool {
+	for _, instant := range zoneSamples {
+		if offsetAt(a, instant) != offsetAt(b, instant) {
+			return false
+		}
+	}
+	return true
+}
+
+func (h *BarFileHeader) appendTo(buffer []byte) []byte {
+	buffer = append(buffer, barFileMagic...)
+	buffer = binary.LittleEndian.AppendUint16(buffer, BarFileVersion)
+	buffer = appendString(buffer, h.Symbol)
+	buffer = append(buffer, byte(h.BarType))
+	buffer = binary.AppendUvarint(buffer, uint64(h.Interval))
+	// the header is validated before it is written, so its location can be stored
+	zone, _ := h.zone()
+	buffer = appendString(buffer, zone)
+	buffer = append(buffer, byte(h.PriceDecimals), byte(h.VolumeDecimals))
+	return buffer
+}
+
+func readBarFileHeader(data []byte) (header BarFileHeader, err error) {
+	if len(data) < len(barFileMagic)+2 || string(data[:len(barFileMagic)]) != barFileMagic {
+		return header, ErrBarFileMagic
+	}
+
+	decoder := barFileDecoder{data: data[len(barFileMagic):]}
+	if decoder.uint16() != BarFileVersion {
+		return header, ErrBarFileVersion
+	}
+
+	header.Symbol = decoder.string()
+	header.BarType = BarFileBarType(decoder.byte())
+	header.Interval = int(decoder.uvarint())
+	zone := decoder.string()
+	header.PriceDecimals = int(decoder.byte())
+	header.VolumeDecimals = int(decoder.byte())
+	if decoder.err != nil {
+		return header, decoder.err
+	}
+
+	if header.Location, err = readBarFileZone(zone); err != nil {
+		return header, err
+	}
+	return header, header.validate()
+}
+
+// a barFileBlock is the index entry of a block
+type barFileBlock struct {
+	offset    int64
+	count     int
+	firstDate int64
+	lastDate  int64
+}
+
+func appendString(buffer []byte, value string) []byte {
+	buffer = binary.AppendUvarint(buffer, uint64(len(value)))
+	return append(buffer, value...)
+}
+
+// scaled returns the value as a whole number of its decimal places
+// barFileDate returns the date as nanoseconds since the unix epoch, or ErrBarFileValue for a date the bar file
+// cannot store, such as the zero date of the bars of a csv feed without a date column
+func barFileDate(date time.Time) (int64, error) {
+	if date.Before(minBarFileDate) || date.After(maxBarFileDate) {
+		return 0, ErrBarFileValue
+	}
+	return date.UnixNano(), nil
+}
+
+// boundedBarFileDate returns the date as nanoseconds since the unix epoch, limited to the dates of a bar file
+func boundedBarFileDate(date time.Time) int64 {
+	switch {
+	case date.Before(minBarFileDate):
+		return math.MinInt64
+	case date.After(maxBarFileDate):
+		return math.MaxInt64
+	}
+	return date.UnixNano()
+}
+
+func scaled(value float64, scale float64) (int64, error) {
+	whole := math.Round(value * scale)
+	if math.IsNaN(whole) || math.Abs(whole) > maxExactFloatInteger {
+		return 0, ErrBarFileValue
+	}
+	return int64(whole), nil
+}
+
+// barFileDecoder reads the values of a bar file, keeping the first error
+type barFileDecoder struct {
+	data []byte
+	err  error
+}
+
+func (d *barFileDecoder) fail() {
+	if d.err == nil {
+		d.err = ErrBarFileCorrupt
+	}
+	d.data = nil
+}
+
+func (d *barFileDecoder) byte() byte {
+	if len(d.data) < 1 {
+		d.fail()
+		return 0
+	}
+	value := d.data[0]
+	d.data = d.data[1:]
+	return value
+}
+
+func (d *barFileDecoder) uint16() uint16 {
+	if len(d.data) < 2 {
+		d.fail()
+		return 0
+	}
+	value := binary.LittleEndian.Uint16(d.data)
+	d.data = d.data[2:]
+	return value
+}
+
+func (d *barFileDecoder) uvarint() uint64 {
+	value, n := binary.Uvarint(d.data)
+	if n <= 0 {
+		d.fail()
+		return 0
+	}
+	d.data = d.data[n:]
+	return value
+}
+
+func (d *barFileDecoder) varint() int64 {
+	value, n := binary.Varint(d.data)
+	if n <= 0 {
+		d.fail()
+		return 0
+	}
+	d.data = d.data[n:]
+	return value
+}
+
+func (d *barFileDecoder) string() string {
+	length := d.uvarint()
+	if uint64(len(d.data)) < length {
+		d.fail()
+		return ""
+	}
+	value := string(d.data[:length])
+	d.data = d.data[length:]
+	return value
+}
+
+// bytes returns the next length prefixed bytes
+func (d *barFileDecoder) bytes() []byte {
+	length := d.uvarint()
+	if uint64(len(d.data)) < length {
+		d.fail()
+		return nil
+	}
+	value := d.data[:length]
+	d.data = d.data[length:]
+	return value
+}
@@ -0,0 +1,370 @@
+package feeds_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"bytes"
+	"encoding/binary"
+	"github.com/jaybutera/gotrade"
+	"github.com/jaybutera/gotrade/feeds"
+	"math"
+	"os"
+	"time"
+)
+
+var _ = Describe("when storing bars in a bar file", func() {
+	var (
+		fileName string
+		csvFeed  *feeds.CSVFileFeed
+		header   feeds.BarFileHeader
+	)
+
+	// minuteBars returns bars a minute apart from 9:00, with a missing minute every 100 bars
+	minuteBars := func(count int) []gotrade.DOHLCV {
+		bars := make([]gotrade.DOHLCV, count)
+		date := time.Date(2014, 1, 31, 9, 0, 0, 0, time.UTC)
+		for i := range bars {
+			price := 100.0 + float64(i%50)*0.25
+			bars[i] = gotrade.NewDOHLCVDataItem(date, price, price+0.5, price-0.5, price+0.25, float64(1000+i))
+			date = date.Add(time.Minute)
+			if i%100 == 99 {
+				date = date.Add(time.Minute)
+			}
+		}
+		return bars
+	}
+
+	BeforeEach(func() {
+		file, err := os.CreateTemp("", "barfile*.bars")
+		Expect(err).To(BeNil())
+		file.Close()
+		fileName = file.Name()
+
+		johannesburg, _ := time.LoadLocation("Africa/Johannesburg")
+		csvFeed = feeds.NewCSVFileFeedWithDOHLCVFormat("../testdata/JSETOPI.ALL.data",
+			feeds.DashedYearMonthDayDateParserForLocation(johannesburg))
+		header = feeds.DefaultBarFileHeader("JSETOPI")
+		header.Location = johannesburg
+	})
+
+	AfterEach(func() {
+		os.Remove(fileName)
+	})
+
+	Context("converted from a csv file", func() {
+		var csvStream *gotrade.DOHLCVStream
+
+		BeforeEach(func() {
+			Expect(feeds.WriteBarFile(fileName, header, csvFeed.FillDOHLCVStream)).To(BeNil())
+
+			csvStream = gotrade.NewDOHLCVStream()
+			csvFeed.FillDOHLCVStream(csvStream)
+		})
+
+		It("should fill a stream with the same bars as the csv feed", func() {
+			feed, err := feeds.NewBarFileFeed(fileName)
+			Expect(err).To(BeNil())
+			Expect(feed.Len()).To(Equal(len(csvStream.Data)))
+
+			priceStream := gotrade.NewDOHLCVStream()
+			Expect(feed.FillDOHLCVStream(priceStream)).To(BeNil())
+			Expect(priceStream.Data).To(Equal(csvStream.Data))
+		})
+
+		It("should keep the header", func() {
+			feed, _ := feeds.NewBarFileFeed(fileName)
+			Expect(feed.Header()).To(Equal(header))
+
+			first, last := feed.Dates()
+			Expect(first).To(Equal(csvStream.Data[0].D()))
+			Expect(last).To(Equal(csvStream.Data[len(csvStream.Data)-1].D()))
+		})
+
+		It("should be smaller than the csv file", func() {
+			csvInfo, _ := os.Stat("../testdata/JSETOPI.ALL.data")
+			barInfo, _ := os.Stat(fileName)
+			Expect(barInfo.Size()).To(BeNumerically("<", csvInfo.Size()/2))
+		})
+
+		It("should fill a stream with the bars of a date range", func() {
+			from := csvStream.Data[10].D()
+			to := csvStream.Data[20].D()
+
+			feed, _ := feeds.NewBarFileFeed(fileName)
+			feed.SetDateRange(from, to)
+			priceStream := gotrade.NewDOHLCVStream()
+			Expect(feed.FillDOHLCVStream(priceStream)).To(BeNil())
+			Expect(priceStream.Data).To(Equal(csvStream.Data[10:20]))
+		})
+	})
+
+	It("should write the bars of a price stream it subscribes to", func() {
+		writer, err := feeds.CreateBarFile(fileName, header)
+		Expect(err).To(BeNil())
+
+		priceStream := gotrade.NewDOHLCVStream()
+		priceStream.AddTickSubscription(writer)
+		csvFeed.FillDOHLCVStream(priceStream)
+		Expect(writer.Close()).To(BeNil())
+		Expect(writer.Bars()).To(Equal(len(priceStream.Data)))
+
+		feed, _ := feeds.NewBarFileFeed(fileName)
+		barStream := gotrade.NewDOHLCVStream()
+		feed.FillDOHLCVStream(barStream)
+		Expect(barStream.Data).To(Equal(priceStream.Data))
+	})
+
+	Context("of intra day bars spanning many blocks", func() {
+		var (
+			bars []gotrade.DOHLCV
+			feed *feeds.BarFileFeed
+		)
+
+		BeforeEach(func() {
+			bars = minuteBars(3*feeds.BarFileBlockSize + 10)
+			header = feeds.BarFileHeader{Symbol: "ABC", BarType: feeds.IntraDayBars, Interval: 1, PriceDecimals: 2}
+
+			var buffer bytes.Buffer
+			writer, err := feeds.NewBarFileWriter(&buffer, header)
+			Expect(err).To(BeNil())
+			Expect(feeds.NewMemoryFeed(bars).FillDOHLCVStream(writer)).To(BeNil())
+			Expect(writer.Close()).To(BeNil())
+
+			feed, err = feeds.NewBarReaderFeed(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+			Expect(err).To(BeNil())
+		})
+
+		It("should read every bar", func() {
+			Expect(feed.Header().BarType).To(Equal(feeds.IntraDayBars))
+			Expect(feed.Header().Location).To(Equal(time.UTC))
+
+			priceStream := gotrade.NewDOHLCVStream()
+			Expect(feed.FillDOHLCVStream(priceStream)).To(BeNil())
+			Expect(priceStream.Data).To(Equal(bars))
+		})
+
+		It("should read the bars of a date range crossing blocks", func() {
+			start := feeds.BarFileBlockSize - 5
+			end := 2*feeds.BarFileBlockSize + 5
+			feed.SetDateRange(bars[start].D(), bars[end].D())
+
+			priceStream := gotrade.NewDOHLCVStream()
+			Expect(feed.FillDOHLCVStream(priceStream)).To(BeNil())
+			Expect(priceStream.Data).To(Equal(bars[start:end]))
+		})
+
+		It("should read the bars from a date to the end", func() {
+			start := len(bars) - 3
+			feed.SetDateRange(bars[start].D(), time.Time{})
+
+			priceStream := gotrade.NewDOHLCVStream()
+			feed.FillDOHLCVStream(priceStream)
+			Expect(priceStream.Data).To(Equal(bars[start:]))
+		})
+
+		It("should read every bar of a date range beyond the dates that can be stored", func() {
+			feed.SetDateRange(time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(3000, 1, 1, 0, 0, 0, 0, time.UTC))
+
+			priceStream := gotrade.NewDOHLCVStream()
+			Expect(feed.FillDOHLCVStream(priceStream)).To(BeNil())
+			Expect(priceStream.Data).To(Equal(bars))
+		})
+
+		It("should read no bars from a date range before the first bar", func() {
+			feed.SetDateRange(time.Time{}, bars[0].D())
+
+			priceStream := gotrade.NewDOHLCVStream()
+			Expect(feed.FillDOHLCVStream(priceStream)).To(BeNil())
+			Expect(priceStream.Data).To(BeEmpty())
+		})
+	})
+
+	It("should write and read a file with no bars", func() {
+		Expect(feeds.WriteBarFile(fileName, header, feeds.NewMemoryFeed(nil).FillDOHLCVStream)).To(BeNil())
+
+		feed, err := feeds.NewBarFileFeed(fileName)
+		Expect(err).To(BeNil())
+		Expect(feed.Len()).To(Equal(0))
+
+		first, last := feed.Dates()
+		Expect(first.IsZero()).To(BeTrue())
+		Expect(last.IsZero()).To(BeTrue())
+	})
+
+	It("should round prices to the decimal places of the header", func() {
+		header.PriceDecimals = 1
+		bars := []gotrade.DOHLCV{gotrade.NewDOHLCVDataItem(time.Date(2014, 1, 2, 0, 0, 0, 0, time.UTC), 10.04, 10.06, 9.94, 10.0, 100)}
+		Expect(feeds.WriteBarFile(fileName, header, feeds.NewMemoryFeed(bars).FillDOHLCVStream)).To(BeNil())
+
+		feed, _ := feeds.NewBarFileFeed(fileName)
+		priceStream := gotrade.NewDOHLCVStream()
+		feed.FillDOHLCVStream(priceStream)
+		bar := priceStream.Data[0]
+		Expect([]float64{bar.O(), bar.H(), bar.L(), bar.C(), bar.V()}).To(Equal([]float64{10.0, 10.1, 9.9, 10.0, 100.0}))
+	})
+
+	Context("with the timezone of the header", func() {
+		// readLocation writes a bar at 9:00 in the location and returns the location and date read back
+		readLocation := func(location *time.Location) (*time.Location, time.Time) {
+			header.Location = location
+			bars := []gotrade.DOHLCV{gotrade.NewDOHLCVDataItem(time.Date(2014, 1, 2, 9, 0, 0, 0, location), 10.0, 10.0, 10.0, 10.0, 100)}
+			Expect(feeds.WriteBarFile(fileName, header, feeds.NewMemoryFeed(bars).FillDOHLCVStream)).To(BeNil())
+
+			feed, err := feeds.NewBarFileFeed(fileName)
+			Expect(err).To(BeNil())
+			priceStream := gotrade.NewDOHLCVStream()
+			Expect(feed.FillDOHLCVStream(priceStream)).To(BeNil())
+			return feed.Header().Location, priceStream.Data[0].D()
+		}
+
+		// changingZone returns a location of the name that changes from UTC to an hour ahead in 2000, which is
+		// not in the IANA database
+		changingZone := func(name string) *time.Location {
+			data := []byte("TZif")
+			data = append(data, make([]byte, 16)...)
+			for _, count := range []uint32{0, 0, 0, 1, 2, 8} {
+				data = binary.BigEndian.AppendUint32(data, count)
+			}
+			data = binary.BigEndian.AppendUint32(data, uint32(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC).Unix()))
+			data = append(data, 1)
+			data = append(data, 0, 0, 0, 0, 0, 0)
+			data = binary.BigEndian.AppendUint32(data, 3600)
+			data = append(data, 0, 4)
+			data = append(data, "AAA\x00BBB\x00"...)
+
+			location, err := time.LoadLocationFromTZData(name, data)
+			Expect(err).To(BeNil())
+			return location
+		}
+
+		It("should read a timezone of the IANA database by its name", func() {
+			newYork, _ := time.LoadLocation("America/New_York")
+			location, date := readLocation(newYork)
+			Expect(location.String()).To(Equal("America/New_York"))
+			Expect(date).To(Equal(time.Date(2014, 1, 2, 9, 0, 0, 0, newYork)))
+		})
+
+		It("should read a named fixed zone with its offset", func() {
+			location, date := readLocation(time.FixedZone("JST", 9*60*60))
+			Expect(location.String()).To(Equal("JST"))
+			Expect(date.Format("15:04 MST -0700")).To(Equal("09:00 JST +0900"))
+		})
+
+		It("should read a named fixed zone whose name is another timezone with its own offset", func() {
+			location, date := readLocation(time.FixedZone("EST", -4*60*60))
+			Expect(location.String()).To(Equal("EST"))
+			Expect(date.Format("15:04 MST -0700")).To(Equal("09:00 EST -0400"))
+		})
+
+		It("should read an unnamed fixed zone with its offset", func() {
+			_, date := readLocation(time.FixedZone("", 2*60*60))
+			_, offset := date.Zone()
+			Expect(offset).To(Equal(2 * 60 * 60))
+			Expect(date.Hour()).To(Equal(9))
+		})
+
+		It("should read UTC as UTC", func() {
+			location, _ := readLocation(time.UTC)
+			Expect(location).To(Equal(time.UTC))
+		})
+
+		It("should read the local timezone as the same instants", func() {
+			date := time.Date(2014, 1, 2, 9, 0, 0, 0, time.Local)
+			header.Location = time.Local
+			_, err := feeds.CreateBarFile(fileName, header)
+			if err != nil {
+				Expect(err).To(MatchError(`location "Local" is neither a timezone of the IANA database nor a fixed zone`))
+				return
+			}
+
+			_, read := readLocation(time.Local)
+			Expect(read.Equal(date)).To(BeTrue())
+			Expect(read.Format("15:04 -0700")).To(Equal(date.Format("15:04 -0700")))
+		})
+
+		It("should return an error for a local timezone that changes its offset", func() {
+			header.Location = changingZone("Local")
+			_, err := feeds.CreateBarFile(fileName, header)
+			Expect(err).To(MatchError(`location "Local" is neither a timezone of the IANA database nor a fixed zone`))
+		})
+
+		It("should return an error for a timezone that is not in the IANA database", func() {
+			header.Location = changingZone("Custom/Zone")
+			_, err := feeds.CreateBarFile(fileName, header)
+			Expect(err).To(MatchError(`location "Custom/Zone" is neither a timezone of the IANA database nor a fixed zone`))
+		})
+	})
+
+	Context("with invalid bars", func() {
+		It("should return an error for bars out of date order", func() {
+			bars := minuteBars(2)
+			bars[0], bars[1] = bars[1], bars[0]
+			Expect(feeds.WriteBarFile(fileName, header, feeds.NewMemoryFeed(bars).FillDOHLCVStream)).To(Equal(feeds.ErrBarFileDateOrder))
+		})
+
+		It("should return an error for a price that cannot be stored", func() {
+			bars := []gotrade.DOHLCV{gotrade.NewDOHLCVDataItem(time.Now(), math.NaN(), 1, 1, 1, 1)}
+			Expect(feeds.WriteBarFile(fileName, header, feeds.NewMemoryFeed(bars).FillDOHLCVStream)).To(Equal(feeds.ErrBarFileValue))
+		})
+
+		It("should return an error for a date after the latest date that can be stored", func() {
+			bars := []gotrade.DOHLCV{gotrade.NewDOHLCVDataItem(time.Date(2263, 1, 1, 0, 0, 0, 0, time.UTC), 1, 1, 1, 1, 1)}
+			Expect(feeds.WriteBarFile(fileName, header, feeds.NewMemoryFeed(bars).FillDOHLCVStream)).To(Equal(feeds.ErrBarFileValue))
+		})
+
+		It("should return an error for the zero date of bars without a date column", func() {
+			bars := []gotrade.DOHLCV{gotrade.NewDOHLCVDataItem(time.Time{}, 1, 1, 1, 1, 1)}
+			Expect(feeds.WriteBarFile(fileName, header, feeds.NewMemoryFeed(bars).FillDOHLCVStream)).To(Equal(feeds.ErrBarFileValue))
+		})
+
+		It("should return an error for bars received after closing", func() {
+			writer, _ := feeds.CreateBarFile(fileName, header)
+			writer.Close()
+			writer.ReceiveTick(minuteBars(1)[0])
+			Expect(writer.Err()).To(Equal(feeds.ErrBarFileWriterClosed))
+		})
+
+		It("should return an error for an invalid header", func() {
+			header.PriceDecimals = 13
+			_, err := feeds.CreateBarFile(fileName, header)
+			Expect(err).To(MatchError("priceDecimals is not between 0 and 12"))
+		})
+	})
+
+	Context("when reading a file that is not a valid bar file", func() {
+		var data []byte
+
+		BeforeEach(func() {
+			Expect(feeds.WriteBarFile(fileName, header, csvFeed.FillDOHLCVStream)).To(BeNil())
+			data, _ = os.ReadFile(fileName)
+		})
+
+		It("should return an error for a csv file", func() {
+			_, err := feeds.NewBarFileFeed("../testdata/JSETOPI.ALL.data")
+			Expect(err).To(Equal(feeds.ErrBarFileMagic))
+		})
+
+		It("should return an error for an unsupported version", func() {
+			data[len("GTBARS")] = 99
+			_, err := feeds.NewBarReaderFeed(bytes.NewReader(data), int64(len(data)))
+			Expect(err).To(Equal(feeds.ErrBarFileVersion))
+		})
+
+		It("should return an error for a truncated file", func() {
+			data = data[:len(data)/2]
+			_, err := feeds.NewBarReaderFeed(bytes.NewReader(data), int64(len(data)))
+			Expect(err).To(Equal(feeds.ErrBarFileMagic))
+		})
+
+		It("should return an error for a corrupt index", func() {
+			data[len(data)-len("GTBARS")-1] = 0xff
+			_, err := feeds.NewBarReaderFeed(bytes.NewReader(data), int64(len(data)))
+			Expect(err).To(Equal(feeds.ErrBarFileCorrupt))
+		})
+
+		It("should return an error for a file that does not exist", func() {
+			_, err := feeds.NewBarFileFeed("../testdata/missing.bars")
+			Expect(os.IsNotExist(err)).To(BeTrue())
+		})
+	})
+})
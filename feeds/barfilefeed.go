@@ -0,0 +1,257 @@
+package feeds
+
+import (
+	"encoding/binary"
+	"github.com/jaybutera/gotrade"
+	"io"
+	"math"
+	"os"
+	"time"
+)
+
+// A BarFileFeed reads the bars of a bar file, reading only the blocks of its date range
+type BarFileFeed struct {
+	open        func() (reader *io.SectionReader, closer io.Closer, err error)
+	header      BarFileHeader
+	index       []barFileBlock
+	indexOffset int64
+	from        time.Time
+	to          time.Time
+}
+
+// NewBarFileFeed creates a feed of the bar file, reading its header and index. The file is opened again
+// each time a stream is filled.
+func NewBarFileFeed(fileName string) (feed *BarFileFeed, err error) {
+	open := func() (*io.SectionReader, io.Closer, error) {
+		file, err := os.Open(fileName)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		info, err := file.Stat()
+		if err != nil {
+			file.Close()
+			return nil, nil, err
+		}
+		return io.NewSectionReader(file, 0, info.Size()), file, nil
+	}
+	return newBarFileFeed(open)
+}
+
+// NewBarReaderFeed creates a feed of the bar file data of the reader, the size being the length of the data
+func NewBarReaderFeed(reader io.ReaderAt, size int64) (feed *BarFileFeed, err error) {
+	open := func() (*io.SectionReader, io.Closer, error) {
+		return io.NewSectionReader(reader, 0, size), io.NopCloser(nil), nil
+	}
+	return newBarFileFeed(open)
+}
+
+func newBarFileFeed(open func() (*io.SectionReader, io.Closer, error)) (feed *BarFileFeed, err error) {
+	reader, closer, err := open()
+	if err != nil {
+		return nil, err
+	}
+	defer closer.Close()
+
+	feed = &BarFileFeed{open: open}
+	if err = feed.readIndex(reader); err != nil {
+		return nil, err
+	}
+	return feed, nil
+}
+
+// Header returns the header of the bar file
+func (barFF *BarFileFeed) Header() BarFileHeader {
+	return barFF.header
+}
+
+// Len returns the number of bars in the file
+func (barFF *BarFileFeed) Len() (bars int) {
+	for _, block := range barFF.index {
+		bars += block.count
+	}
+	return bars
+}
+
+// Dates returns the dates of the first and last bars of the file, both zero when it has no bars
+func (barFF *BarFileFeed) Dates() (first time.Time, last time.Time) {
+	if len(barFF.index) == 0 {
+		return first, last
+	}
+	location := barFF.header.location()
+	return time.Unix(0, barFF.index[0].firstDate).In(location), time.Unix(0, barFF.index[len(barFF.index)-1].lastDate).In(location)
+}
+
+// SetDateRange limits the bars filled to those from the from date up to, but excluding, the to date, a zero
+// date leaving that end of the range open
+func (barFF *BarFileFeed) SetDateRange(from time.Time, to time.Time) {
+	barFF.from = from
+	barFF.to = to
+}
+
+func (barFF *BarFileFeed) FillDOHLCVStream(priceStream gotrade.DOHLCVStreamTickReceiver) (err error) {
+	reader, closer, err := barFF.open()
+	if err != nil {
+		return err
+	}
+	defer closer.Close()
+
+	from, to := int64(math.MinInt64), int64(math.MaxInt64)
+	if !barFF.from.IsZero() {
+		from = boundedBarFileDate(barFF.from)
+	}
+	if !barFF.to.IsZero() {
+		to = boundedBarFileDate(barFF.to)
+	}
+
+	for i, block := range barFF.index {
+		if block.lastDate < from || block.firstDate >= to {
+			continue
+		}
+
+		end := barFF.indexOffset
+		if i+1 < len(barFF.index) {
+			end = barFF.index[i+1].offset
+		}
+
+		data := make([]byte, end-block.offset)
+		if _, err = reader.ReadAt(data, block.offset); err != nil {
+			return err
+		}
+
+		bars, err := barFF.decodeBlock(data, block.count)
+		if err != nil {
+			return err
+		}
+
+		for _, bar := range bars {
+			if date := bar.D().UnixNano(); date >= from && date < to {
+				priceStream.ReceiveTick(bar)
+			}
+		}
+	}
+	return nil
+}
+
+// readIndex reads the trailer, the index and the header of the file
+func (barFF *BarFileFeed) readIndex(reader *io.SectionReader) (err error) {
+	size := reader.Size()
+	if size < int64(barFileTrailerSize) {
+		return ErrBarFileMagic
+	}
+
+	trailer := make([]byte, barFileTrailerSize)
+	if _, err = reader.ReadAt(trailer, size-int64(barFileTrailerSize)); err != nil {
+		return err
+	}
+
+	if string(trailer[8:]) != barFileMagic {
+		return ErrBarFileMagic
+	}
+
+	barFF.indexOffset = int64(binary.LittleEndian.Uint64(trailer))
+	if barFF.indexOffset < 0 || barFF.indexOffset > size-int64(barFileTrailerSize) {
+		return ErrBarFileCorrupt
+	}
+
+	data := make([]byte, size-int64(barFileTrailerSize)-barFF.indexOffset)
+	if _, err = reader.ReadAt(data, barFF.indexOffset); err != nil {
+		return err
+	}
+
+	decoder := barFileDecoder{data: data}
+	blocks := decoder.uvarint()
+	if blocks > uint64(len(data)) {
+		return ErrBarFileCorrupt
+	}
+
+	barFF.index = make([]barFileBlock, blocks)
+	headerEnd := barFF.indexOffset
+	for i := range barFF.index {
+		block := &barFF.index[i]
+		block.offset = int64(decoder.uvarint())
+		block.count = int(decoder.uvarint())
+		block.firstDate = decoder.varint()
+		block.lastDate = decoder.varint()
+
+		if block.count < 1 || block.count > BarFileBlockSize ||
+			block.offset >= barFF.indexOffset || (i > 0 && block.offset <= barFF.index[i-1].offset) {
+			return ErrBarFileCorrupt
+		}
+
+		if i == 0 {
+			headerEnd = block.offset
+		}
+	}
+
+	if decoder.err != nil {
+		return decoder.err
+	}
+
+	header := make([]byte, headerEnd)
+	if _, err = reader.ReadAt(header, 0); err != nil {
+		return err
+	}
+
+	barFF.header, err = readBarFileHeader(header)
+	return err
+}
+
+func (barFF *BarFileFeed) decodeBlock(data []byte, count int) (bars []gotrade.DOHLCV, err error) {
+	decoder := barFileDecoder{data: data}
+	if int(decoder.uvarint()) != count {
+		return nil, ErrBarFileCorrupt
+	}
+
+	// the dates
+	column := barFileDecoder{data: decoder.bytes()}
+	dates := make([]int64, count)
+	var previousDelta int64
+	for i := range dates {
+		switch i {
+		case 0:
+			dates[i] = column.varint()
+		case 1:
+			previousDelta = column.varint()
+			dates[i] = dates[i-1] + previousDelta
+		default:
+			previousDelta += column.varint()
+			dates[i] = dates[i-1] + previousDelta
+		}
+	}
+	if column.err != nil {
+		return nil, column.err
+	}
+
+	// the prices and volume
+	var values [5][]float64
+	for i := range values {
+		scale := math.Pow10(barFF.header.PriceDecimals)
+		if i == 4 {
+			scale = math.Pow10(barFF.header.VolumeDecimals)
+		}
+
+		column = barFileDecoder{data: decoder.bytes()}
+		values[i] = make([]float64, count)
+		var whole int64
+		for j := range values[i] {
+			whole += column.varint()
+			values[i][j] = float64(whole) / scale
+		}
+		if column.err != nil {
+			return nil, column.err
+		}
+	}
+
+	if decoder.err != nil {
+		return nil, decoder.err
+	}
+
+	location := barFF.header.location()
+	bars = make([]gotrade.DOHLCV, count)
+	for i := range bars {
+		bars[i] = gotrade.NewDOHLCVDataItem(time.Unix(0, dates[i]).In(location),
+			values[0][i], values[1][i], values[2][i], values[3][i], values[4][i])
+	}
+	return bars, nil
+}
@@ -0,0 +1,253 @@
+package feeds
+
+import (
+	"encoding/binary"
+	"github.com/jaybutera/gotrade"
+	"io"
+	"math"
+	"os"
+)
+
+// A BarFileWriter writes the bars it receives to a bar file, subscribe it to a price stream to store the
+// stream's bars, or fill it from a feed. As the receiver methods cannot return errors, the first error is
+// kept and returned by Close, which must be called to complete the file.
+type BarFileWriter struct {
+	writer      io.Writer
+	closer      io.Closer
+	header      BarFileHeader
+	priceScale  float64
+	volumeScale float64
+	offset      int64
+	bars        int
+	block       []gotrade.DOHLCV
+	index       []barFileBlock
+	lastDate    int64
+	err         error
+	closed      bool
+}
+
+// NewBarFileWriter creates a writer of a bar file to the writer, writing the header
+func NewBarFileWriter(writer io.Writer, header BarFileHeader) (barFileWriter *BarFileWriter, err error) {
+	if err = header.validate(); err != nil {
+		return nil, err
+	}
+
+	barFileWriter = &BarFileWriter{writer: writer,
+		header:      header,
+		priceScale:  math.Pow10(header.PriceDecimals),
+		volumeScale: math.Pow10(header.VolumeDecimals),
+		block:       make([]gotrade.DOHLCV, 0, BarFileBlockSize)}
+	barFileWriter.header.Location = header.location()
+
+	if err = barFileWriter.write(header.appendTo(nil)); err != nil {
+		return nil, err
+	}
+	return barFileWriter, nil
+}
+
+// CreateBarFile creates the bar file, writing the header, the file is closed by the writer's Close
+func CreateBarFile(fileName string, header BarFileHeader) (barFileWriter *BarFileWriter, err error) {
+	if err = header.validate(); err != nil {
+		return nil, err
+	}
+
+	file, err := os.Create(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	if barFileWriter, err = NewBarFileWriter(file, header); err != nil {
+		file.Close()
+		return nil, err
+	}
+	barFileWriter.closer = file
+	return barFileWriter, nil
+}
+
+// WriteBarFile writes every bar from the fill function of another feed to the bar file, e.g. to convert
+// a csv file
+//
+//	err := feeds.WriteBarFile("JSETOPI.bars", feeds.DefaultBarFileHeader("JSETOPI"), csvFeed.FillDOHLCVStream)
+func WriteBarFile(fileName string, header BarFileHeader, fill func(priceStream gotrade.DOHLCVStreamTickReceiver) error) (err error) {
+	barFileWriter, err := CreateBarFile(fileName, header)
+	if err != nil {
+		return err
+	}
+
+	if err = fill(barFileWriter); err != nil {
+		barFileWriter.Close()
+		return err
+	}
+	return barFileWriter.Close()
+}
+
+// Header returns the header of the file written
+func (w *BarFileWriter) Header() BarFileHeader {
+	return w.header
+}
+
+// Bars returns the number of bars written
+func (w *BarFileWriter) Bars() int {
+	return w.bars
+}
+
+// Err returns the first error of the writer
+func (w *BarFileWriter) Err() error {
+	return w.err
+}
+
+func (w *BarFileWriter) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	w.ReceiveTick(tickData)
+}
+
+func (w *BarFileWriter) ReceiveTick(tickData gotrade.DOHLCV) {
+	if w.closed {
+		w.fail(ErrBarFileWriterClosed)
+		return
+	}
+
+	if w.err != nil {
+		return
+	}
+
+	date, err := barFileDate(tickData.D())
+	if err != nil {
+		w.fail(err)
+		return
+	}
+
+	if w.bars > 0 && date < w.lastDate {
+		w.fail(ErrBarFileDateOrder)
+		return
+	}
+
+	w.block = append(w.block, tickData)
+	w.lastDate = date
+	w.bars++
+
+	if len(w.block) == BarFileBlockSize {
+		w.fail(w.flush())
+	}
+}
+
+// Close writes the remaining bars and the index of the file, closing the file of CreateBarFile, and
+// returns the first error of the writer
+func (w *BarFileWriter) Close() error {
+	if w.closed {
+		return w.err
+	}
+	w.closed = true
+
+	if w.err == nil {
+		w.fail(w.flush())
+	}
+
+	if w.err == nil {
+		w.fail(w.writeIndex())
+	}
+
+	if w.closer != nil {
+		w.fail(w.closer.Close())
+	}
+	return w.err
+}
+
+func (w *BarFileWriter) fail(err error) {
+	if err != nil && w.err == nil {
+		w.err = err
+	}
+}
+
+func (w *BarFileWriter) write(data []byte) error {
+	n, err := w.writer.Write(data)
+	w.offset += int64(n)
+	return err
+}
+
+// flush writes the bars of the block
+func (w *BarFileWriter) flush() error {
+	if len(w.block) == 0 {
+		return nil
+	}
+
+	data, err := w.encodeBlock()
+	if err != nil {
+		return err
+	}
+
+	w.index = append(w.index, barFileBlock{offset: w.offset,
+		count:     len(w.block),
+		firstDate: w.block[0].D().UnixNano(),
+		lastDate:  w.block[len(w.block)-1].D().UnixNano()})
+	w.block = w.block[:0]
+
+	return w.write(data)
+}
+
+func (w *BarFileWriter) encodeBlock() (data []byte, err error) {
+	data = binary.AppendUvarint(nil, uint64(len(w.block)))
+
+	// the dates as the first date, the first delta, then the delta of each delta
+	var column []byte
+	var previousDate, previousDelta int64
+	for i, bar := range w.block {
+		date := bar.D().UnixNano()
+		switch i {
+		case 0:
+			column = binary.AppendVarint(column, date)
+		case 1:
+			column = binary.AppendVarint(column, date-previousDate)
+		default:
+			column = binary.AppendVarint(column, date-previousDate-previousDelta)
+		}
+		if i > 0 {
+			previousDelta = date - previousDate
+		}
+		previousDate = date
+	}
+	data = appendColumn(data, column)
+
+	// the prices and volume as the first value then the delta of each value
+	columns := [5]func(gotrade.DOHLCV) float64{gotrade.UseOpenPrice, gotrade.UseHighPrice, gotrade.UseLowPrice, gotrade.UseClosePrice, gotrade.UseVolume}
+	for i, value := range columns {
+		scale := w.priceScale
+		if i == 4 {
+			scale = w.volumeScale
+		}
+
+		column = column[:0]
+		var previous int64
+		for _, bar := range w.block {
+			whole, err := scaled(value(bar), scale)
+			if err != nil {
+				return nil, err
+			}
+			column = binary.AppendVarint(column, whole-previous)
+			previous = whole
+		}
+		data = appendColumn(data, column)
+	}
+	return data, nil
+}
+
+func appendColumn(data []byte, column []byte) []byte {
+	data = binary.AppendUvarint(data, uint64(len(column)))
+	return append(data, column...)
+}
+
+// writeIndex writes the index of the blocks and the trailer
+func (w *BarFileWriter) writeIndex() error {
+	indexOffset := w.offset
+
+	data := binary.AppendUvarint(nil, uint64(len(w.index)))
+	for _, block := range w.index {
+		data = binary.AppendUvarint(data, uint64(block.offset))
+		data = binary.AppendUvarint(data, uint64(block.count))
+		data = binary.AppendVarint(data, block.firstDate)
+		data = binary.AppendVarint(data, block.lastDate)
+	}
+
+	data = binary.LittleEndian.AppendUint64(data, uint64(indexOffset))
+	data = append(data, barFileMagic...)
+	return w.write(data)
+}
@@ -1,33 +1,262 @@
 package feeds
 
 import (
-   "github.com/jaybutera/gotrade"
-   "github.com/toorop/go-bittrex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/jaybutera/gotrade"
+	"net/http"
+	"net/url"
+	"time"
 )
 
+const (
+	// the public Bittrex market data api
+	BtrxDefaultBaseURL = "https://bittrex.com/Api/v2.0"
+
+	btrxDateLayout = "2006-01-02T15:04:05"
+)
+
+var (
+	ErrBtrxUnknownInterval = errors.New("Interval must be one of oneMin, fiveMin, thirtyMin, hour or day")
+)
+
+// the candle intervals supported by Bittrex and their duration
+var btrxIntervals = map[string]time.Duration{
+	"oneMin":    time.Minute,
+	"fiveMin":   5 * time.Minute,
+	"thirtyMin": 30 * time.Minute,
+	"hour":      time.Hour,
+	"day":       24 * time.Hour,
+}
+
+// BtrxIntervalDuration returns the duration of a Bittrex candle interval
+func BtrxIntervalDuration(interval string) (duration time.Duration, err error) {
+	duration, ok := btrxIntervals[interval]
+	if !ok {
+		return 0, ErrBtrxUnknownInterval
+	}
+	return duration, nil
+}
+
+type btrxTick struct {
+	O float64
+	H float64
+	L float64
+	C float64
+	V float64
+	T string
+}
+
+type btrxTicksResponse struct {
+	Success bool
+	Message string
+	Result  []btrxTick
+}
+
+// A client for the Bittrex REST market data api
+type BtrxClient struct {
+	httpClient *http.Client
+	baseURL    string
+}
+
+// NewBtrxClient creates a Bittrex client that makes requests with httpClient against baseURL,
+// a nil httpClient uses http.DefaultClient
+func NewBtrxClient(httpClient *http.Client, baseURL string) *BtrxClient {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &BtrxClient{httpClient: httpClient, baseURL: baseURL}
+}
+
+// NewDefaultBtrxClient creates a Bittrex client for the public Bittrex api
+func NewDefaultBtrxClient() *BtrxClient {
+	return NewBtrxClient(nil, BtrxDefaultBaseURL)
+}
+
+// GetTicks returns all the available candles for a market, oldest first
+func (c *BtrxClient) GetTicks(market string, interval string) (ticks []gotrade.DOHLCV, err error) {
+	return c.getTicks("/pub/market/GetTicks", market, interval)
+}
+
+// GetLatestTick returns the most recent candle for a market
+func (c *BtrxClient) GetLatestTick(market string, interval string) (tick gotrade.DOHLCV, err error) {
+	ticks, err := c.getTicks("/pub/market/GetLatestTick", market, interval)
+	if err != nil {
+		return nil, err
+	}
+	if len(ticks) == 0 {
+		return nil, fmt.Errorf("no ticks returned for market %s", market)
+	}
+	return ticks[len(ticks)-1], nil
+}
+
+func (c *BtrxClient) getTicks(path string, market string, interval string) (ticks []gotrade.DOHLCV, err error) {
+	if _, err = BtrxIntervalDuration(interval); err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	query.Set("marketName", market)
+	query.Set("tickInterval", interval)
+
+	resp, err := c.httpClient.Get(c.baseURL + path + "?" + query.Encode())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("bittrex request for market %s failed with status %s", market, resp.Status)
+	}
+
+	var response btrxTicksResponse
+	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+
+	if !response.Success {
+		return nil, errors.New(response.Message)
+	}
+
+	for _, tick := range response.Result {
+		date, err := time.ParseInLocation(btrxDateLayout, tick.T, time.UTC)
+		if err != nil {
+			return nil, err
+		}
+		ticks = append(ticks, gotrade.NewDOHLCVDataItem(date, tick.O, tick.H, tick.L, tick.C, tick.V))
+	}
+
+	return ticks, nil
+}
+
+// A historical Bittrex feed of the closed candles for a market. The latest candle Bittrex returns is still
+// forming until its interval has ended, so it is left out until then.
 type BtrxHistFeed struct {
-   market string
-   interval string
-   ticks []DOHLCVDataItem
+	client           *BtrxClient
+	market           string
+	interval         string
+	intervalDuration time.Duration
+}
+
+// NewBtrxHistFeed creates a historical Bittrex feed
+// Market format ex. "BTC-USD"
+// Interval can be -> ["oneMin", "fiveMin", "thirtyMin", "hour", "day"]
+func NewBtrxHistFeed(market string, interval string, client *BtrxClient) (feed *BtrxHistFeed, err error) {
+	intervalDuration, err := BtrxIntervalDuration(interval)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BtrxHistFeed{client: client, market: market, interval: interval, intervalDuration: intervalDuration}, nil
 }
 
+func (btrxHF *BtrxHistFeed) FillDOHLCVStream(priceStream gotrade.DOHLCVStreamTickReceiver) (err error) {
+	ticks, err := btrxHF.client.GetTicks(btrxHF.market, btrxHF.interval)
+	if err != nil {
+		return err
+	}
+
+	// leave out the forming candle
+	if len(ticks) > 0 && ticks[len(ticks)-1].D().Add(btrxHF.intervalDuration).After(time.Now()) {
+		ticks = ticks[:len(ticks)-1]
+	}
+
+	for _, tick := range ticks {
+		priceStream.ReceiveTick(tick)
+	}
+	return nil
+}
+
+// A live Bittrex feed that polls for new candles for a market. The latest candle Bittrex returns is still
+// forming, so it is held back and updated by each poll, then sent once a newer candle shows its interval closed.
 type BtrxLiveFeed struct {
-   bittrex *Bittrex
-   market string
-   interval string
+	client       *BtrxClient
+	market       string
+	interval     string
+	pollInterval time.Duration
+	lastTickDate time.Time
+	formingTick  gotrade.DOHLCV
 }
 
+// NewBtrxLiveFeed creates a live Bittrex feed which polls once per candle interval
 // Market format ex. "BTC-USD"
 // Interval can be -> ["oneMin", "fiveMin", "thirtyMin", "hour", "day"]
-NewBtrxHistFeed(market string, interval string, bittrex *Bittrex) (*BtrxHistFeed) {
-   ticks, err := bittrex.GetTicks(market, interval)
-   if err != nil {
-      return nil, err
-   }
+func NewBtrxLiveFeed(market string, interval string, client *BtrxClient) (feed *BtrxLiveFeed, err error) {
+	pollInterval, err := BtrxIntervalDuration(interval)
+	if err != nil {
+		return nil, err
+	}
 
-   return &BtrxHistFeed{market, interval, ticks}, nil
+	return &BtrxLiveFeed{client: client, market: market, interval: interval, pollInterval: pollInterval}, nil
 }
 
-NewBtrxLiveFeed(market string, interval string, bittrex *Bittrex) *BtrxLiveFeed {
-   return &BtrxLiveFeed{bittrex, market, interval}
+// SetPollInterval changes how often Run polls for new candles
+func (btrxLF *BtrxLiveFeed) SetPollInterval(pollInterval time.Duration) {
+	btrxLF.pollInterval = pollInterval
+}
+
+// FillDOHLCVStream sends the closed candles of the market's history, the forming candle is held back and
+// sent by Poll or Run once it has closed
+func (btrxLF *BtrxLiveFeed) FillDOHLCVStream(priceStream gotrade.DOHLCVStreamTickReceiver) (err error) {
+	ticks, err := btrxLF.client.GetTicks(btrxLF.market, btrxLF.interval)
+	if err != nil {
+		return err
+	}
+
+	for _, tick := range ticks {
+		btrxLF.receiveTick(tick, priceStream)
+	}
+	return nil
+}
+
+// Poll fetches the latest candle once, received is true when the latest candle closed the forming candle
+// and the forming candle was sent
+func (btrxLF *BtrxLiveFeed) Poll(priceStream gotrade.DOHLCVStreamTickReceiver) (received bool, err error) {
+	tick, err := btrxLF.client.GetLatestTick(btrxLF.market, btrxLF.interval)
+	if err != nil {
+		return false, err
+	}
+
+	return btrxLF.receiveTick(tick, priceStream), nil
+}
+
+// Run polls for new candles until stop is closed or a poll fails
+func (btrxLF *BtrxLiveFeed) Run(priceStream gotrade.DOHLCVStreamTickReceiver, stop <-chan struct{}) (err error) {
+	ticker := time.NewTicker(btrxLF.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return nil
+		case <-ticker.C:
+			if _, err = btrxLF.Poll(priceStream); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// receiveTick replaces the forming candle with a candle of the same interval, and sends the forming candle
+// when a candle of a later interval starts
+func (btrxLF *BtrxLiveFeed) receiveTick(tick gotrade.DOHLCV, priceStream gotrade.DOHLCVStreamTickReceiver) (sent bool) {
+	if !tick.D().After(btrxLF.lastTickDate) {
+		return false
+	}
+
+	if btrxLF.formingTick != nil {
+		if tick.D().Before(btrxLF.formingTick.D()) {
+			return false
+		}
+
+		if tick.D().After(btrxLF.formingTick.D()) {
+			btrxLF.lastTickDate = btrxLF.formingTick.D()
+			priceStream.ReceiveTick(btrxLF.formingTick)
+			sent = true
+		}
+	}
+
+	btrxLF.formingTick = tick
+	return sent
 }
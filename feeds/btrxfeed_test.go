@@ -0,0 +1,260 @@
+package feeds_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"fmt"
+	"github.com/jaybutera/gotrade"
+	"github.com/jaybutera/gotrade/feeds"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+// chanTickReceiver passes the ticks it receives to the channel
+type chanTickReceiver chan gotrade.DOHLCV
+
+func (c chanTickReceiver) ReceiveTick(tickData gotrade.DOHLCV) {
+	c <- tickData
+}
+
+// newRecordedBtrxServer serves the recorded bittrex responses from the testdata folder
+func newRecordedBtrxServer(latestTickFile string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("marketName") != "BTC-LTC" || r.URL.Query().Get("tickInterval") != "hour" {
+			w.Write([]byte(`{"success":false,"message":"INVALID_MARKET","result":null}`))
+			return
+		}
+
+		switch r.URL.Path {
+		case "/pub/market/GetTicks":
+			http.ServeFile(w, r, "../testdata/btrx_getticks_btc-ltc_hour.json")
+		case "/pub/market/GetLatestTick":
+			http.ServeFile(w, r, "../testdata/"+latestTickFile)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+var _ = Describe("when mapping bittrex intervals", func() {
+	It("should map each bittrex interval to its candle duration", func() {
+		expected := map[string]time.Duration{
+			"oneMin":    time.Minute,
+			"fiveMin":   5 * time.Minute,
+			"thirtyMin": 30 * time.Minute,
+			"hour":      time.Hour,
+			"day":       24 * time.Hour,
+		}
+		for interval, duration := range expected {
+			Expect(feeds.BtrxIntervalDuration(interval)).To(Equal(duration))
+		}
+	})
+
+	It("should return an error for an unknown interval", func() {
+		_, err := feeds.BtrxIntervalDuration("week")
+		Expect(err).To(Equal(feeds.ErrBtrxUnknownInterval))
+	})
+})
+
+var _ = Describe("when creating a bittrex feed with an unknown interval", func() {
+	It("the historical feed should not be created and return the appropriate error message", func() {
+		feed, err := feeds.NewBtrxHistFeed("BTC-LTC", "week", feeds.NewDefaultBtrxClient())
+		Expect(feed).To(BeNil())
+		Expect(err).To(Equal(feeds.ErrBtrxUnknownInterval))
+	})
+
+	It("the live feed should not be created and return the appropriate error message", func() {
+		feed, err := feeds.NewBtrxLiveFeed("BTC-LTC", "week", feeds.NewDefaultBtrxClient())
+		Expect(feed).To(BeNil())
+		Expect(err).To(Equal(feeds.ErrBtrxUnknownInterval))
+	})
+})
+
+var _ = Describe("when filling a price stream from a bittrex historical feed", func() {
+	var (
+		server      *httptest.Server
+		priceStream *gotrade.IntraDayDOHLCVStream
+		fillErr     error
+	)
+
+	BeforeEach(func() {
+		server = newRecordedBtrxServer("btrx_getlatesttick_btc-ltc_hour.json")
+		priceStream = gotrade.NewIntraDayDOHLCVStream(60)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Context("and the market exists", func() {
+		BeforeEach(func() {
+			feed, _ := feeds.NewBtrxHistFeed("BTC-LTC", "hour", feeds.NewBtrxClient(server.Client(), server.URL))
+			fillErr = feed.FillDOHLCVStream(priceStream)
+		})
+
+		It("should not return an error", func() {
+			Expect(fillErr).To(BeNil())
+		})
+
+		It("should send every recorded candle to the stream in order", func() {
+			Expect(len(priceStream.Data)).To(Equal(4))
+			Expect(priceStream.MinDate()).To(Equal(time.Date(2017, 11, 20, 0, 0, 0, 0, time.UTC)))
+			Expect(priceStream.MaxDate()).To(Equal(time.Date(2017, 11, 20, 3, 0, 0, 0, time.UTC)))
+		})
+
+		It("should parse the open, high, low, close and volume of each candle", func() {
+			Expect(priceStream.Data[1].O()).To(Equal(0.00937061))
+			Expect(priceStream.Data[1].H()).To(Equal(0.00944999))
+			Expect(priceStream.Data[1].L()).To(Equal(0.00936100))
+			Expect(priceStream.Data[1].C()).To(Equal(0.00944000))
+			Expect(priceStream.Data[1].V()).To(Equal(1810.43127865))
+		})
+	})
+
+	Context("and the latest candle is still forming", func() {
+		var (
+			ticks chanTickReceiver
+		)
+
+		BeforeEach(func() {
+			forming := time.Now().UTC().Truncate(time.Hour)
+			closed := forming.Add(-time.Hour)
+			response := fmt.Sprintf(`{"success":true,"message":"","result":[`+
+				`{"O":1.0,"H":1.5,"L":0.5,"C":1.2,"V":100.0,"T":"%s"},`+
+				`{"O":1.2,"H":1.3,"L":1.1,"C":1.2,"V":10.0,"T":"%s"}]}`,
+				closed.Format("2006-01-02T15:04:05"), forming.Format("2006-01-02T15:04:05"))
+			server.Close()
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(response))
+			}))
+
+			ticks = make(chanTickReceiver, 2)
+			feed, _ := feeds.NewBtrxHistFeed("BTC-LTC", "hour", feeds.NewBtrxClient(server.Client(), server.URL))
+			fillErr = feed.FillDOHLCVStream(ticks)
+		})
+
+		It("should only send the closed candles", func() {
+			Expect(fillErr).To(BeNil())
+			Expect(ticks).To(HaveLen(1))
+			Expect((<-ticks).V()).To(Equal(100.0))
+		})
+	})
+
+	Context("and the market does not exist", func() {
+		BeforeEach(func() {
+			feed, _ := feeds.NewBtrxHistFeed("BTC-XXX", "hour", feeds.NewBtrxClient(server.Client(), server.URL))
+			fillErr = feed.FillDOHLCVStream(priceStream)
+		})
+
+		It("should return the bittrex error message", func() {
+			Expect(fillErr).To(MatchError("INVALID_MARKET"))
+			Expect(len(priceStream.Data)).To(Equal(0))
+		})
+	})
+})
+
+var _ = Describe("when polling a bittrex live feed", func() {
+	var (
+		server      *httptest.Server
+		priceStream *gotrade.IntraDayDOHLCVStream
+		feed        *feeds.BtrxLiveFeed
+	)
+
+	BeforeEach(func() {
+		priceStream = gotrade.NewIntraDayDOHLCVStream(60)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Context("and the latest candle is newer than the history", func() {
+		BeforeEach(func() {
+			server = newRecordedBtrxServer("btrx_getlatesttick_btc-ltc_hour.json")
+			feed, _ = feeds.NewBtrxLiveFeed("BTC-LTC", "hour", feeds.NewBtrxClient(server.Client(), server.URL))
+			feed.FillDOHLCVStream(priceStream)
+		})
+
+		It("should hold back the forming candle of the history", func() {
+			Expect(len(priceStream.Data)).To(Equal(3))
+			Expect(priceStream.MaxDate()).To(Equal(time.Date(2017, 11, 20, 2, 0, 0, 0, time.UTC)))
+		})
+
+		It("should send the closed candle once", func() {
+			received, err := feed.Poll(priceStream)
+			Expect(err).To(BeNil())
+			Expect(received).To(BeTrue())
+
+			received, err = feed.Poll(priceStream)
+			Expect(err).To(BeNil())
+			Expect(received).To(BeFalse())
+
+			Expect(len(priceStream.Data)).To(Equal(4))
+			Expect(priceStream.MaxDate()).To(Equal(time.Date(2017, 11, 20, 3, 0, 0, 0, time.UTC)))
+		})
+
+		It("should send new candles until stopped when running", func() {
+			stop := make(chan struct{})
+			done := make(chan error)
+			ticks := make(chanTickReceiver, 1)
+			feed.SetPollInterval(time.Millisecond)
+			go func() {
+				done <- feed.Run(ticks, stop)
+			}()
+			var tick gotrade.DOHLCV
+			Eventually(ticks).Should(Receive(&tick))
+			Expect(tick.D()).To(Equal(time.Date(2017, 11, 20, 3, 0, 0, 0, time.UTC)))
+			close(stop)
+			Eventually(done).Should(Receive(BeNil()))
+		})
+	})
+
+	Context("and the latest candle has already been sent", func() {
+		BeforeEach(func() {
+			server = newRecordedBtrxServer("btrx_getticks_btc-ltc_hour.json")
+			feed, _ = feeds.NewBtrxLiveFeed("BTC-LTC", "hour", feeds.NewBtrxClient(server.Client(), server.URL))
+			feed.FillDOHLCVStream(priceStream)
+		})
+
+		It("should not send the candle again", func() {
+			received, err := feed.Poll(priceStream)
+			Expect(err).To(BeNil())
+			Expect(received).To(BeFalse())
+			Expect(len(priceStream.Data)).To(Equal(3))
+		})
+	})
+
+	Context("and the latest candle is updated before it closes", func() {
+		BeforeEach(func() {
+			latestTicks := []string{
+				`{"success":true,"message":"","result":[{"O":1.0,"H":1.5,"L":0.5,"C":1.2,"V":100.0,"T":"2017-11-20T04:00:00"}]}`,
+				`{"success":true,"message":"","result":[{"O":1.0,"H":2.0,"L":0.5,"C":1.8,"V":250.0,"T":"2017-11-20T04:00:00"}]}`,
+				`{"success":true,"message":"","result":[{"O":1.8,"H":1.9,"L":1.7,"C":1.7,"V":10.0,"T":"2017-11-20T05:00:00"}]}`,
+			}
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/pub/market/GetTicks" {
+					http.ServeFile(w, r, "../testdata/btrx_getticks_btc-ltc_hour.json")
+					return
+				}
+				w.Write([]byte(latestTicks[0]))
+				latestTicks = latestTicks[1:]
+			}))
+			feed, _ = feeds.NewBtrxLiveFeed("BTC-LTC", "hour", feeds.NewBtrxClient(server.Client(), server.URL))
+			feed.FillDOHLCVStream(priceStream)
+		})
+
+		It("should send the candle with its values when it closed", func() {
+			for _, expectReceived := range []bool{true, false, true} {
+				received, err := feed.Poll(priceStream)
+				Expect(err).To(BeNil())
+				Expect(received).To(Equal(expectReceived))
+			}
+
+			Expect(len(priceStream.Data)).To(Equal(5))
+			tick := priceStream.Data[4]
+			Expect(tick.D()).To(Equal(time.Date(2017, 11, 20, 4, 0, 0, 0, time.UTC)))
+			Expect([]float64{tick.O(), tick.H(), tick.L(), tick.C(), tick.V()}).To(Equal([]float64{1.0, 2.0, 0.5, 1.8, 250.0}))
+		})
+	})
+})
@@ -0,0 +1,153 @@
+package feeds
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"github.com/klauspost/compress/zstd"
+	"io"
+	"os"
+)
+
+const (
+	// the bytes buffered at the start of a reader, from which a reader feed detects the date format
+	readerSampleSize = 64 * 1024
+)
+
+var (
+	ErrReaderAlreadyRead = errors.New("the reader has already been read, a reader feed can only fill one stream")
+
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// DecompressReader returns a reader of the data of a reader, decompressing it as it is read when it starts
+// with the magic number of gzip or zstd data. Closing the returned reader does not close the reader given.
+func DecompressReader(reader io.Reader) (decompressed io.ReadCloser, err error) {
+	return decompressBufferedReader(bufio.NewReader(reader))
+}
+
+func decompressBufferedReader(buffered *bufio.Reader) (decompressed io.ReadCloser, err error) {
+	// a short read leaves too few bytes for a magic number, which is not an error here
+	magic, _ := buffered.Peek(len(zstdMagic))
+
+	switch {
+	case bytes.HasPrefix(magic, gzipMagic):
+		return gzip.NewReader(buffered)
+	case bytes.HasPrefix(magic, zstdMagic):
+		decoder, err := zstd.NewReader(buffered, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+		return decoder.IOReadCloser(), nil
+	}
+	return io.NopCloser(buffered), nil
+}
+
+// a csvSource provides the csv data of a feed
+type csvSource interface {
+	// open returns the data to fill a stream from
+	open() (io.ReadCloser, error)
+	// sample returns the start of the data without consuming it
+	sample() (io.ReadCloser, error)
+}
+
+// a fileSource opens the file each time its data is read
+type fileSource string
+
+func (fileName fileSource) open() (io.ReadCloser, error) {
+	file, err := os.Open(string(fileName))
+	if err != nil {
+		return nil, err
+	}
+
+	decompressed, err := DecompressReader(file)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &closers{Reader: decompressed, closers: []io.Closer{decompressed, file}}, nil
+}
+
+func (fileName fileSource) sample() (io.ReadCloser, error) {
+	return fileName.open()
+}
+
+// a readerSource streams the data of a reader once, buffering its start for sampling
+type readerSource struct {
+	reader       io.Reader
+	decompressed *bufio.Reader
+	closer       io.Closer
+	opened       bool
+}
+
+func newReaderSource(reader io.Reader) *readerSource {
+	return &readerSource{reader: reader}
+}
+
+func (s *readerSource) decompress() error {
+	if s.decompressed != nil {
+		return nil
+	}
+
+	decompressed, err := DecompressReader(s.reader)
+	if err != nil {
+		return err
+	}
+
+	s.decompressed = bufio.NewReaderSize(decompressed, readerSampleSize)
+	s.closer = decompressed
+	return nil
+}
+
+func (s *readerSource) open() (io.ReadCloser, error) {
+	if s.opened {
+		return nil, ErrReaderAlreadyRead
+	}
+
+	if err := s.decompress(); err != nil {
+		return nil, err
+	}
+
+	s.opened = true
+	return &closers{Reader: s.decompressed, closers: []io.Closer{s.closer}}, nil
+}
+
+func (s *readerSource) sample() (io.ReadCloser, error) {
+	if s.opened {
+		return nil, ErrReaderAlreadyRead
+	}
+
+	if err := s.decompress(); err != nil {
+		return nil, err
+	}
+
+	// the complete lines of the buffered start of the data
+	buffered, err := s.decompressed.Peek(readerSampleSize)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return nil, err
+	}
+
+	if err == nil || err == bufio.ErrBufferFull {
+		if end := bytes.LastIndexByte(buffered, '\n'); end >= 0 {
+			buffered = buffered[:end+1]
+		}
+	}
+	return io.NopCloser(bytes.NewReader(buffered)), nil
+}
+
+// closers closes each of its closers in order, returning the first error
+type closers struct {
+	io.Reader
+	closers []io.Closer
+}
+
+func (c *closers) Close() (err error) {
+	for _, closer := range c.closers {
+		if closeErr := closer.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
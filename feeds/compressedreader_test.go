@@ -0,0 +1,153 @@
+package feeds_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"bytes"
+	"compress/gzip"
+	"github.com/jaybutera/gotrade"
+	"github.com/jaybutera/gotrade/feeds"
+	"github.com/klauspost/compress/zstd"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// gzipped compresses the data with gzip
+func gzipped(data []byte) []byte {
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	writer.Write(data)
+	writer.Close()
+	return compressed.Bytes()
+}
+
+// zstandard compresses the data with zstd
+func zstandard(data []byte) []byte {
+	encoder, _ := zstd.NewWriter(nil)
+	defer encoder.Close()
+	return encoder.EncodeAll(data, nil)
+}
+
+var _ = Describe("when decompressing a reader", func() {
+	var (
+		data []byte
+	)
+
+	BeforeEach(func() {
+		data, _ = os.ReadFile("../testdata/JSETOPI.2013.data")
+	})
+
+	decompressed := func(compressed []byte) []byte {
+		reader, err := feeds.DecompressReader(bytes.NewReader(compressed))
+		Expect(err).To(BeNil())
+		defer reader.Close()
+
+		read, err := io.ReadAll(reader)
+		Expect(err).To(BeNil())
+		return read
+	}
+
+	It("should read uncompressed data unchanged", func() {
+		Expect(decompressed(data)).To(Equal(data))
+		Expect(decompressed([]byte{0x1f})).To(Equal([]byte{0x1f}))
+		Expect(decompressed(nil)).To(BeEmpty())
+	})
+
+	It("should decompress gzip data", func() {
+		Expect(decompressed(gzipped(data))).To(Equal(data))
+	})
+
+	It("should decompress zstd data", func() {
+		Expect(decompressed(zstandard(data))).To(Equal(data))
+	})
+
+	It("should return an error for a corrupt gzip header", func() {
+		_, err := feeds.DecompressReader(bytes.NewReader([]byte{0x1f, 0x8b, 0x00}))
+		Expect(err).ToNot(BeNil())
+	})
+})
+
+var _ = Describe("when reading csv data from a reader", func() {
+	var (
+		data           []byte
+		expectedStream *gotrade.DOHLCVStream
+		priceStream    *gotrade.DOHLCVStream
+		dateParser     feeds.TextDateParser
+	)
+
+	BeforeEach(func() {
+		data, _ = os.ReadFile("../testdata/JSETOPI.2013.data")
+		dateParser = feeds.DashedYearMonthDayDateParser()
+
+		expectedStream = gotrade.NewDOHLCVStream()
+		feeds.NewCSVFileFeedWithDOHLCVFormat("../testdata/JSETOPI.2013.data", dateParser).FillDOHLCVStream(expectedStream)
+		priceStream = gotrade.NewDOHLCVStream()
+	})
+
+	It("should read the same bars as the file feed", func() {
+		feed := feeds.NewCSVReaderFeedWithDOHLCVFormat(bytes.NewReader(data), dateParser)
+		Expect(feed.FillDOHLCVStream(priceStream)).To(BeNil())
+		Expect(priceStream.Data).To(Equal(expectedStream.Data))
+	})
+
+	It("should read gzip and zstd compressed data", func() {
+		for _, compressed := range [][]byte{gzipped(data), zstandard(data)} {
+			priceStream = gotrade.NewDOHLCVStream()
+			feed := feeds.NewCSVReaderFeedWithDOHLCVFormat(bytes.NewReader(compressed), dateParser)
+			Expect(feed.FillDOHLCVStream(priceStream)).To(BeNil())
+			Expect(priceStream.Data).To(Equal(expectedStream.Data))
+		}
+	})
+
+	It("should publish each bar as its line arrives", func() {
+		reader, writer := io.Pipe()
+		received := make(chan gotrade.DOHLCV)
+		done := make(chan error)
+
+		go func() {
+			done <- feeds.NewCSVReaderFeedWithDOHLCVFormat(reader, dateParser).FillDOHLCVStream(chanTickReceiver(received))
+		}()
+
+		// the first bar arrives before the second line is written
+		writer.Write([]byte("2014-01-02,9,11,8,10,1000\n"))
+		Expect((<-received).C()).To(Equal(10.0))
+
+		writer.Write([]byte("2014-01-03,10,12,9,11,2000\n"))
+		writer.Close()
+		Expect((<-received).C()).To(Equal(11.0))
+		Expect(<-done).To(BeNil())
+	})
+
+	It("should only fill one stream", func() {
+		feed := feeds.NewCSVReaderFeedWithDOHLCVFormat(bytes.NewReader(data), dateParser)
+		feed.FillDOHLCVStream(priceStream)
+		Expect(feed.FillDOHLCVStream(gotrade.NewDOHLCVStream())).To(Equal(feeds.ErrReaderAlreadyRead))
+	})
+
+	It("should detect the date format without consuming the rows", func() {
+		newYork, _ := time.LoadLocation("America/New_York")
+		csvData := "Date,Open,High,Low,Close,Volume\n" +
+			"1391178600,10,11,9,10,100\n" +
+			"1391178660,10,12,10,11,200\n"
+
+		feed := feeds.NewCSVReaderFeedWithHeader(strings.NewReader(csvData), feeds.DefaultCSVColumnNames(), nil)
+		Expect(feed.DetectDateParser(10, newYork)).To(BeNil())
+		Expect(feed.FillDOHLCVStream(priceStream)).To(BeNil())
+
+		Expect(len(priceStream.Data)).To(Equal(2))
+		Expect(priceStream.Data[1].D()).To(Equal(time.Date(2014, 1, 31, 9, 31, 0, 0, newYork)))
+	})
+
+	It("should read a gzip compressed file", func() {
+		file, _ := os.CreateTemp("", "csvfeed*.csv.gz")
+		file.Write(gzipped(data))
+		file.Close()
+		defer os.Remove(file.Name())
+
+		feed := feeds.NewCSVFileFeedWithDOHLCVFormat(file.Name(), dateParser)
+		Expect(feed.FillDOHLCVStream(priceStream)).To(BeNil())
+		Expect(priceStream.Data).To(Equal(expectedStream.Data))
+	})
+})
@@ -2,14 +2,70 @@ package feeds
 
 import (
 	"encoding/csv"
+	"errors"
+	"fmt"
 	"github.com/jaybutera/gotrade"
 	"io"
-	"os"
+	"strings"
+	"time"
 )
 
-type CSVFileFeed struct {
+var (
+	ErrCSVHeaderColumnNotFound = errors.New("the header has no such column")
+)
+
+// A CSVLineError is the failure to read or parse a line of a csv file
+type CSVLineError struct {
+	// the 1 based line of the record
+	Line int
+	// the 1 based column of the field, 0 when the whole line failed
+	Column int
+	Err    error
+}
+
+func (e *CSVLineError) Error() string {
+	// a field error reports its own column
+	var fieldErr *CSVFieldError
+	if e.Column == 0 || errors.As(e.Err, &fieldErr) {
+		return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+	}
+	return fmt.Sprintf("line %d, column %d: %v", e.Line, e.Column, e.Err)
+}
+
+func (e *CSVLineError) Unwrap() error {
+	return e.Err
+}
+
+// A CSVReport summarises the lines read by a csv feed
+type CSVReport struct {
+	// the records read, excluding the header
+	Records int
+	// the bars published
+	Bars int
+	// the lines skipped by a lenient feed, in the order they were read
+	Skipped []*CSVLineError
+}
+
+// The CSVColumnNames of the fields in the header of a csv file, an empty name marks a field as
+// absent from the file. Names are matched ignoring case and surrounding spaces.
+type CSVColumnNames struct {
+	Date   string
+	Open   string
+	High   string
+	Low    string
+	Close  string
+	Volume string
+}
+
+// DefaultCSVColumnNames returns the column names Date, Open, High, Low, Close and Volume
+func DefaultCSVColumnNames() CSVColumnNames {
+	return CSVColumnNames{Date: "Date", Open: "Open", High: "High", Low: "Low", Close: "Close", Volume: "Volume"}
+}
+
+// A CSVReaderFeed reads bars from csv data, decompressing gzip or zstd data as it is read
+type CSVReaderFeed struct {
 	*CSVDOHLCVRecordParser
-	fileName              string
+	source                csvSource
 	dateColumnIndex       int
 	openPriceColumnIndex  int
 	highPriceColumnIndex  int
@@ -17,22 +73,75 @@ type CSVFileFeed struct {
 	closePriceColumnIndex int
 	volumeColumnIndex     int
 	dateParser            TextDateParser
+
+	delimiter   rune
+	hasHeader   bool
+	columnNames *CSVColumnNames
+	lenient     bool
+	report      *CSVReport
+}
+
+func newCSVReaderFeed(source csvSource, columnIndexes [6]int, dateParser TextDateParser) *CSVReaderFeed {
+	return &CSVReaderFeed{CSVDOHLCVRecordParser: &CSVDOHLCVRecordParser{},
+		source:                source,
+		dateColumnIndex:       columnIndexes[0],
+		openPriceColumnIndex:  columnIndexes[1],
+		highPriceColumnIndex:  columnIndexes[2],
+		lowPriceColumnIndex:   columnIndexes[3],
+		closePriceColumnIndex: columnIndexes[4],
+		volumeColumnIndex:     columnIndexes[5],
+		dateParser:            dateParser,
+		delimiter:             ',',
+	}
+}
+
+// NewCSVReaderFeed creates a feed of the csv data of a reader, such as stdin or an http response body, with the
+// fields at the column indexes given, -1 marking a field as absent from the data. The reader is read as the
+// stream is filled, so a reader feed can only fill one stream.
+func NewCSVReaderFeed(reader io.Reader,
+	dateColumnIndex int,
+	openPriceColumnIndex int,
+	highPriceColumnIndex int,
+	lowPriceColumnIndex int,
+	closePriceColumnIndex int,
+	volumeColumnIndex int,
+	dateParser TextDateParser) *CSVReaderFeed {
+
+	return newCSVReaderFeed(newReaderSource(reader),
+		[6]int{dateColumnIndex, openPriceColumnIndex, highPriceColumnIndex, lowPriceColumnIndex, closePriceColumnIndex, volumeColumnIndex},
+		dateParser)
+}
+
+// NewCSVReaderFeedWithDOHLCVFormat creates a feed of the csv data of a reader with the columns date, open, high,
+// low, close and volume
+func NewCSVReaderFeedWithDOHLCVFormat(reader io.Reader, dateParser TextDateParser) *CSVReaderFeed {
+	return NewCSVReaderFeed(reader, 0, 1, 2, 3, 4, 5, dateParser)
+}
+
+// NewCSVReaderFeedWithHeader creates a feed of the csv data of a reader whose first line is a header naming
+// the columns, the fields are found by their column names
+func NewCSVReaderFeedWithHeader(reader io.Reader, columnNames CSVColumnNames, dateParser TextDateParser) *CSVReaderFeed {
+	feed := NewCSVReaderFeed(reader, -1, -1, -1, -1, -1, -1, dateParser)
+	feed.hasHeader = true
+	feed.columnNames = &columnNames
+	return feed
+}
+
+// A CSVFileFeed reads bars from a csv file, which may be compressed with gzip or zstd. The file is
+// opened each time a stream is filled.
+type CSVFileFeed struct {
+	*CSVReaderFeed
+	fileName string
 }
 
 func NewCSVFileFeedWithDOHLCVFormat(fileName string,
 	dateParser TextDateParser) *CSVFileFeed {
 
-	return &CSVFileFeed{&CSVDOHLCVRecordParser{},
-		fileName,
-		0,
-		1,
-		2,
-		3,
-		4,
-		5,
-		dateParser}
+	return NewCSVFileFeed(fileName, 0, 1, 2, 3, 4, 5, dateParser)
 }
 
+// NewCSVFileFeed creates a feed of a csv file with the fields at the column indexes given, -1 marking
+// a field as absent from the file
 func NewCSVFileFeed(fileName string,
 	dateColumnIndex int,
 	openPriceColumnIndex int,
@@ -42,51 +151,227 @@ func NewCSVFileFeed(fileName string,
 	volumeColumnIndex int,
 	dateParser TextDateParser) *CSVFileFeed {
 
-	return &CSVFileFeed{&CSVDOHLCVRecordParser{},
-		fileName,
-		dateColumnIndex,
-		openPriceColumnIndex,
-		highPriceColumnIndex,
-		lowPriceColumnIndex,
-		closePriceColumnIndex,
-		volumeColumnIndex,
-		dateParser}
+	return &CSVFileFeed{
+		CSVReaderFeed: newCSVReaderFeed(fileSource(fileName),
+			[6]int{dateColumnIndex, openPriceColumnIndex, highPriceColumnIndex, lowPriceColumnIndex, closePriceColumnIndex, volumeColumnIndex},
+			dateParser),
+		fileName: fileName,
+	}
+}
+
+// FileName returns the name of the file the feed reads
+func (csvFPSF *CSVFileFeed) FileName() string {
+	return csvFPSF.fileName
+}
+
+// NewCSVFileFeedWithHeader creates a feed of a csv file whose first line is a header naming the columns,
+// the fields are found by their column names
+func NewCSVFileFeedWithHeader(fileName string, columnNames CSVColumnNames, dateParser TextDateParser) *CSVFileFeed {
+	feed := NewCSVFileFeed(fileName, -1, -1, -1, -1, -1, -1, dateParser)
+	feed.hasHeader = true
+	feed.columnNames = &columnNames
+	return feed
+}
+
+// SetDelimiter sets the character separating the fields, a ',' by default
+func (csvRF *CSVReaderFeed) SetDelimiter(delimiter rune) {
+	csvRF.delimiter = delimiter
+}
+
+// SetDecimalSeparator sets the decimal separator of the prices and volume, a '.' by default
+func (csvRF *CSVReaderFeed) SetDecimalSeparator(separator rune) {
+	csvRF.DecimalSeparator = separator
 }
 
-func (csvFPSF *CSVFileFeed) FillDOHLCVStream(priceStream gotrade.DOHLCVStreamTickReceiver) (err error) {
+// SetThousandsSeparator sets the separator grouping the thousands of the prices and volume, which is removed
+// before parsing them, none by default
+func (csvRF *CSVReaderFeed) SetThousandsSeparator(separator rune) {
+	csvRF.ThousandsSeparator = separator
+}
+
+// SetHasHeader sets whether the first line is a header to skip, a feed created with column names always has a header
+func (csvRF *CSVReaderFeed) SetHasHeader(hasHeader bool) {
+	csvRF.hasHeader = hasHeader || csvRF.columnNames != nil
+}
 
-	file, err := os.Open(csvFPSF.fileName)
+// SetLenient sets whether lines that cannot be read or parsed are skipped and reported rather than
+// stopping the feed, off by default
+func (csvRF *CSVReaderFeed) SetLenient(lenient bool) {
+	csvRF.lenient = lenient
+}
+
+// Report returns the report of the last fill of a stream, nil before the first
+func (csvRF *CSVReaderFeed) Report() *CSVReport {
+	return csvRF.report
+}
+
+func (csvRF *CSVReaderFeed) FillDOHLCVStream(priceStream gotrade.DOHLCVStreamTickReceiver) (err error) {
+
+	data, err := csvRF.source.open()
 	if err != nil {
 		return err
 	}
-	defer file.Close()
-	reader := csv.NewReader(file)
-	var lineNumber int = 0
-	for {
+	defer data.Close()
+	reader := csvRF.newReader(data)
 
-		// increment the linenumbers
-		lineNumber++
+	report := &CSVReport{}
+	csvRF.report = report
 
-		// read the record from the file
+	columnIndexes := [6]int{csvRF.dateColumnIndex,
+		csvRF.openPriceColumnIndex,
+		csvRF.highPriceColumnIndex,
+		csvRF.lowPriceColumnIndex,
+		csvRF.closePriceColumnIndex,
+		csvRF.volumeColumnIndex}
+
+	headerRead := !csvRF.hasHeader
+	for {
+
+		// read the record from the data
 		record, err := reader.Read()
 		if err == io.EOF {
 			break
 		} else if err != nil {
-			return err
+			parseErr, ok := err.(*csv.ParseError)
+			if !ok {
+				return err
+			}
+
+			// a header that cannot be read leaves the columns unknown
+			lineErr := &CSVLineError{Line: parseErr.StartLine, Column: parseErr.Column, Err: parseErr.Err}
+			if !csvRF.lenient || !headerRead {
+				return lineErr
+			}
+			report.Skipped = append(report.Skipped, lineErr)
+			continue
+		}
+
+		if !headerRead {
+			headerRead = true
+			if csvRF.columnNames != nil {
+				if columnIndexes, err = csvRF.columnNames.Indexes(record); err != nil {
+					line, _ := reader.FieldPos(0)
+					return &CSVLineError{Line: line, Err: err}
+				}
+			}
+			continue
 		}
 
-		dohlcv, err := csvFPSF.ParseRecord(record, csvFPSF.dateColumnIndex,
-			csvFPSF.openPriceColumnIndex,
-			csvFPSF.highPriceColumnIndex,
-			csvFPSF.lowPriceColumnIndex,
-			csvFPSF.closePriceColumnIndex,
-			csvFPSF.volumeColumnIndex,
-			csvFPSF.dateParser)
+		// the line the record started on, as a quoted field can span lines
+		lineNumber, _ := reader.FieldPos(0)
+		report.Records++
+
+		dohlcv, err := csvRF.ParseRecord(record, columnIndexes[0],
+			columnIndexes[1],
+			columnIndexes[2],
+			columnIndexes[3],
+			columnIndexes[4],
+			columnIndexes[5],
+			csvRF.dateParser)
 
 		if err != nil {
-			return err
+			lineErr := &CSVLineError{Line: lineNumber, Err: err}
+			if fieldErr, ok := err.(*CSVFieldError); ok {
+				lineErr.Column = fieldErr.Column
+			}
+
+			if !csvRF.lenient {
+				return lineErr
+			}
+			report.Skipped = append(report.Skipped, lineErr)
+			continue
 		}
 		priceStream.ReceiveTick(dohlcv)
+		report.Bars++
 	}
 	return nil
 }
+
+// DetectDateParser sets the date parser to the first of the formats supported by DetectDateParserForLocation
+// that parses the dates of the first rows of the data, the header and the rows that cannot be read are
+// not counted. A reader feed detects from the rows buffered at the start of the reader without consuming them.
+func (csvRF *CSVReaderFeed) DetectDateParser(rows int, location *time.Location) (err error) {
+	data, err := csvRF.source.sample()
+	if err != nil {
+		return err
+	}
+	defer data.Close()
+	reader := csvRF.newReader(data)
+
+	dateColumnIndex := csvRF.dateColumnIndex
+	headerRead := !csvRF.hasHeader
+	var samples []string
+	for len(samples) < rows {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			if _, ok := err.(*csv.ParseError); !ok || !headerRead {
+				return err
+			}
+			continue
+		}
+
+		if !headerRead {
+			headerRead = true
+			if csvRF.columnNames != nil {
+				columnIndexes, err := csvRF.columnNames.Indexes(record)
+				if err != nil {
+					line, _ := reader.FieldPos(0)
+					return &CSVLineError{Line: line, Err: err}
+				}
+				dateColumnIndex = columnIndexes[0]
+			}
+			continue
+		}
+
+		if dateColumnIndex >= 0 && dateColumnIndex < len(record) {
+			samples = append(samples, record[dateColumnIndex])
+		}
+	}
+
+	dateParser, err := DetectDateParserForLocation(samples, location)
+	if err != nil {
+		return err
+	}
+
+	csvRF.dateParser = dateParser
+	return nil
+}
+
+func (csvRF *CSVReaderFeed) newReader(data io.Reader) *csv.Reader {
+	reader := csv.NewReader(data)
+	reader.Comma = csvRF.delimiter
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	return reader
+}
+
+// Indexes finds the column index of each field in the header, in the order date, open, high, low, close and
+// volume, -1 for a field without a name
+func (names *CSVColumnNames) Indexes(header []string) (columnIndexes [6]int, err error) {
+	fields := [6]string{"date", "open", "high", "low", "close", "volume"}
+	for i, name := range [6]string{names.Date, names.Open, names.High, names.Low, names.Close, names.Volume} {
+		columnIndexes[i] = -1
+		if name == "" {
+			continue
+		}
+
+		for column, heading := range header {
+			// a utf-8 byte order mark precedes the first heading of some exported files
+			if column == 0 {
+				heading = strings.TrimPrefix(heading, "\ufeff")
+			}
+
+			if strings.EqualFold(strings.TrimSpace(heading), strings.TrimSpace(name)) {
+				columnIndexes[i] = column
+				break
+			}
+		}
+
+		if columnIndexes[i] == -1 {
+			return columnIndexes, fmt.Errorf("%s column %q: %w", fields[i], name, ErrCSVHeaderColumnNotFound)
+		}
+	}
+	return columnIndexes, nil
+}
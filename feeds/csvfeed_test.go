@@ -1 +1,211 @@
 package feeds_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"errors"
+	"github.com/jaybutera/gotrade"
+	"github.com/jaybutera/gotrade/feeds"
+	"os"
+	"time"
+)
+
+var _ = Describe("when reading a csv file", func() {
+	var (
+		fileName    string
+		priceStream *gotrade.DOHLCVStream
+		dateParser  feeds.TextDateParser
+	)
+
+	// writeCSV writes the content to the file read by the feed
+	writeCSV := func(content string) {
+		file, err := os.CreateTemp("", "csvfeed*.csv")
+		Expect(err).To(BeNil())
+		defer file.Close()
+
+		file.WriteString(content)
+		fileName = file.Name()
+	}
+
+	closes := func() []float64 {
+		closePrices := make([]float64, len(priceStream.Data))
+		for i, bar := range priceStream.Data {
+			closePrices[i] = bar.C()
+		}
+		return closePrices
+	}
+
+	BeforeEach(func() {
+		priceStream = gotrade.NewDOHLCVStream()
+		dateParser = feeds.DashedYearMonthDayDateParser()
+	})
+
+	AfterEach(func() {
+		os.Remove(fileName)
+	})
+
+	Context("with a header naming the columns", func() {
+		It("should find the columns by name in any order", func() {
+			writeCSV("\ufeffdate,Volume, close ,Open,High,Low,Adj Close\n" +
+				"2014-01-02,1000,10,9,11,8,10\n" +
+				"2014-01-03,2000,12,10,13,9,12\n")
+
+			feed := feeds.NewCSVFileFeedWithHeader(fileName, feeds.DefaultCSVColumnNames(), dateParser)
+			Expect(feed.FillDOHLCVStream(priceStream)).To(BeNil())
+
+			Expect(len(priceStream.Data)).To(Equal(2))
+			bar := priceStream.Data[1]
+			Expect(bar.D()).To(Equal(time.Date(2014, 1, 3, 0, 0, 0, 0, time.UTC)))
+			Expect([]float64{bar.O(), bar.H(), bar.L(), bar.C(), bar.V()}).To(Equal([]float64{10.0, 13.0, 9.0, 12.0, 2000.0}))
+		})
+
+		It("should leave a column without a name absent", func() {
+			writeCSV("Date,Close\n2014-01-02,10\n")
+
+			feed := feeds.NewCSVFileFeedWithHeader(fileName, feeds.CSVColumnNames{Date: "Date", Close: "Close"}, dateParser)
+			Expect(feed.FillDOHLCVStream(priceStream)).To(BeNil())
+			Expect(closes()).To(Equal([]float64{10.0}))
+			Expect(priceStream.Data[0].V()).To(Equal(0.0))
+		})
+
+		It("should return an error for a column missing from the header", func() {
+			writeCSV("Date,Open,High,Low,Close\n2014-01-02,9,11,8,10\n")
+
+			err := feeds.NewCSVFileFeedWithHeader(fileName, feeds.DefaultCSVColumnNames(), dateParser).FillDOHLCVStream(priceStream)
+			Expect(err).To(MatchError(`line 1: volume column "Volume": the header has no such column`))
+			Expect(errors.Is(err, feeds.ErrCSVHeaderColumnNotFound)).To(BeTrue())
+		})
+	})
+
+	Context("with a semicolon delimiter and decimal comma", func() {
+		It("should parse the quoted numbers", func() {
+			writeCSV("Date;Open;High;Low;Close;Volume\n" +
+				"2014-01-02;\"9,5\";\"11,25\";8;\"10,75\";1000\n")
+
+			feed := feeds.NewCSVFileFeed(fileName, 0, 1, 2, 3, 4, 5, dateParser)
+			feed.SetDelimiter(';')
+			feed.SetDecimalSeparator(',')
+			feed.SetHasHeader(true)
+			Expect(feed.FillDOHLCVStream(priceStream)).To(BeNil())
+
+			bar := priceStream.Data[0]
+			Expect([]float64{bar.O(), bar.H(), bar.L(), bar.C(), bar.V()}).To(Equal([]float64{9.5, 11.25, 8.0, 10.75, 1000.0}))
+		})
+
+		It("should parse numbers grouped by a thousands separator", func() {
+			writeCSV("Date;Open;High;Low;Close;Volume\n" +
+				"2014-01-02;1.234,5;1.300;1.200,25;1.250,75;1.234.567\n")
+
+			feed := feeds.NewCSVFileFeed(fileName, 0, 1, 2, 3, 4, 5, dateParser)
+			feed.SetDelimiter(';')
+			feed.SetDecimalSeparator(',')
+			feed.SetThousandsSeparator('.')
+			feed.SetHasHeader(true)
+			Expect(feed.FillDOHLCVStream(priceStream)).To(BeNil())
+
+			bar := priceStream.Data[0]
+			Expect([]float64{bar.O(), bar.H(), bar.L(), bar.C(), bar.V()}).To(Equal([]float64{1234.5, 1300.0, 1200.25, 1250.75, 1234567.0}))
+		})
+
+		It("should return an error for numbers grouped by thousands without a thousands separator", func() {
+			writeCSV("Date;Open;High;Low;Close;Volume\n" +
+				"2014-01-02;1.234,5;1.300;1.200,25;1.250,75;1000\n")
+
+			feed := feeds.NewCSVFileFeed(fileName, 0, 1, 2, 3, 4, 5, dateParser)
+			feed.SetDelimiter(';')
+			feed.SetDecimalSeparator(',')
+			feed.SetHasHeader(true)
+			Expect(feed.FillDOHLCVStream(priceStream)).To(MatchError(`line 2: column 2 (open): cannot parse "1.234,5": invalid syntax`))
+		})
+	})
+
+	Context("with bad rows", func() {
+		BeforeEach(func() {
+			writeCSV("2014-01-02,9,11,8,10,1000\n" +
+				"2014-01-03,9,11,x,10,1000\n" +
+				"2014-01-04,9,11,8,10,1000\n" +
+				"2014-13,9,11,8,10,1000\n" +
+				"2014-01-06,9,\"11\"x,8,10,1000\n" +
+				"2014-01-07,9,11,8\n" +
+				"2014-01-08,9,11,8,12,1000\n")
+		})
+
+		It("should stop at the first bad row with its line and column", func() {
+			feed := feeds.NewCSVFileFeedWithDOHLCVFormat(fileName, dateParser)
+			err := feed.FillDOHLCVStream(priceStream)
+
+			Expect(err).To(MatchError(`line 2: column 4 (low): cannot parse "x": invalid syntax`))
+			Expect(err.(*feeds.CSVLineError).Line).To(Equal(2))
+			Expect(err.(*feeds.CSVLineError).Column).To(Equal(4))
+			Expect(closes()).To(Equal([]float64{10.0}))
+		})
+
+		It("should skip and report the bad rows when lenient", func() {
+			feed := feeds.NewCSVFileFeedWithDOHLCVFormat(fileName, dateParser)
+			feed.SetLenient(true)
+			Expect(feed.FillDOHLCVStream(priceStream)).To(BeNil())
+
+			Expect(closes()).To(Equal([]float64{10.0, 10.0, 12.0}))
+
+			report := feed.Report()
+			Expect(report.Bars).To(Equal(3))
+			Expect(report.Records).To(Equal(6))
+
+			lines := make([]int, len(report.Skipped))
+			columns := make([]int, len(report.Skipped))
+			for i, skipped := range report.Skipped {
+				lines[i], columns[i] = skipped.Line, skipped.Column
+			}
+			Expect(lines).To(Equal([]int{2, 4, 5, 6}))
+			Expect(columns).To(Equal([]int{4, 1, 17, 5}))
+			Expect(report.Skipped[2]).To(MatchError(`line 5, column 17: extraneous or missing " in quoted-field`))
+			Expect(report.Skipped[3]).To(MatchError(`line 6: column 5 (close): the record has no such column`))
+		})
+	})
+
+	Context("with intra day bars", func() {
+		BeforeEach(func() {
+			writeCSV("Time,Open,High,Low,Close,Volume\n" +
+				"01/31/2014 09:30,10,11,9,10,100\n" +
+				"01/31/2014 09:31,10,12,10,11,200\n" +
+				"01/31/2014 09:32,11,11,8,9,300\n" +
+				"01/31/2014 09:33,9,10,9,10,400\n")
+		})
+
+		It("should detect the date format from the first rows", func() {
+			newYork, _ := time.LoadLocation("America/New_York")
+			columnNames := feeds.DefaultCSVColumnNames()
+			columnNames.Date = "Time"
+
+			feed := feeds.NewCSVFileFeedWithHeader(fileName, columnNames, nil)
+			Expect(feed.DetectDateParser(2, newYork)).To(BeNil())
+
+			intraDayStream := gotrade.NewIntraDayDOHLCVStream(2)
+			Expect(feed.FillDOHLCVStream(intraDayStream)).To(BeNil())
+			intraDayStream.Flush()
+
+			Expect(len(intraDayStream.Data)).To(Equal(2))
+			bar := intraDayStream.Data[0]
+			Expect(bar.D()).To(Equal(time.Date(2014, 1, 31, 9, 30, 0, 0, newYork)))
+			Expect([]float64{bar.O(), bar.H(), bar.L(), bar.C(), bar.V()}).To(Equal([]float64{10.0, 12.0, 9.0, 11.0, 300.0}))
+		})
+
+		It("should return an error for a date column missing from the header", func() {
+			feed := feeds.NewCSVFileFeedWithHeader(fileName, feeds.DefaultCSVColumnNames(), nil)
+			Expect(feed.DetectDateParser(2, time.UTC)).To(MatchError(`line 1: date column "Date": the header has no such column`))
+		})
+
+		It("should return an error without dates to detect from", func() {
+			feed := feeds.NewCSVFileFeed(fileName, 6, 1, 2, 3, 4, 5, nil)
+			feed.SetHasHeader(true)
+			Expect(feed.DetectDateParser(2, time.UTC)).To(Equal(feeds.ErrNoDateSamples))
+		})
+	})
+
+	It("should read the existing files without a header", func() {
+		feed := feeds.NewCSVFileFeedWithDOHLCVFormat("../testdata/JSETOPI.2013.data", dateParser)
+		Expect(feed.FillDOHLCVStream(priceStream)).To(BeNil())
+		Expect(feed.Report().Bars).To(Equal(len(priceStream.Data)))
+		Expect(feed.Report().Skipped).To(BeEmpty())
+	})
+})
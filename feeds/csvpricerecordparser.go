@@ -1,14 +1,49 @@
 package feeds
 
 import (
+	"errors"
+	"fmt"
 	"github.com/jaybutera/gotrade"
 	"strconv"
+	"strings"
 	"time"
 )
 
+var (
+	ErrCSVColumnMissing = errors.New("the record has no such column")
+)
+
+// A CSVFieldError is the failure to parse a field of a csv record
+type CSVFieldError struct {
+	// the 1 based column of the field, as for encoding/csv
+	Column int
+	// the name of the field, date, open, high, low, close or volume
+	Field string
+	Value string
+	Err   error
+}
+
+func (e *CSVFieldError) Error() string {
+	if e.Err == ErrCSVColumnMissing {
+		return fmt.Sprintf("column %d (%s): %v", e.Column, e.Field, e.Err)
+	}
+	return fmt.Sprintf("column %d (%s): cannot parse %q: %v", e.Column, e.Field, e.Value, e.Err)
+}
+
+func (e *CSVFieldError) Unwrap() error {
+	return e.Err
+}
+
+// A CSVDOHLCVRecordParser parses the fields of a csv record into a bar, a column index of -1 marks a field
+// as absent from the record, leaving it zero in the bar
 type CSVDOHLCVRecordParser struct {
+	// the decimal separator of the prices and volume, a '.' when 0
+	DecimalSeparator rune
+	// the separator grouping the thousands of the prices and volume, such as the '.' of 1.234,56, none when 0
+	ThousandsSeparator rune
 }
 
+// ParseRecord parses a csv record into a bar, returning a CSVFieldError for the first field that cannot be parsed
 func (csvFPSP *CSVDOHLCVRecordParser) ParseRecord(csvRecord []string,
 	dateColumnIndex int,
 	openPriceColumnIndex int,
@@ -18,47 +53,65 @@ func (csvFPSP *CSVDOHLCVRecordParser) ParseRecord(csvRecord []string,
 	volumeColumnIndex int,
 	dateParser TextDateParser) (dholcv gotrade.DOHLCV, err error) {
 
-	// parse the record based on the supplied indexes and date func
-	recordLength := len(csvRecord)
-
 	// date
 	var date time.Time
-	if dateColumnIndex != -1 && recordLength > dateColumnIndex {
+	if dateColumnIndex != -1 {
+		value, err := csvField(csvRecord, dateColumnIndex, "date")
+		if err != nil {
+			return nil, err
+		}
 
-		date, err = dateParser(csvRecord[dateColumnIndex])
+		if date, err = dateParser(strings.TrimSpace(value)); err != nil {
+			return nil, &CSVFieldError{Column: dateColumnIndex + 1, Field: "date", Value: value, Err: err}
+		}
 	}
 
-	// open
-	var open float64
-	if openPriceColumnIndex != -1 && recordLength > openPriceColumnIndex {
-		open, err = strconv.ParseFloat(csvRecord[openPriceColumnIndex], 64)
-	}
+	// open, high, low, close and volume
+	var values [5]float64
+	columnIndexes := [5]int{openPriceColumnIndex, highPriceColumnIndex, lowPriceColumnIndex, closePriceColumnIndex, volumeColumnIndex}
+	for i, field := range [5]string{"open", "high", "low", "close", "volume"} {
+		if columnIndexes[i] == -1 {
+			continue
+		}
 
-	// high
-	var high float64
-	if recordLength > highPriceColumnIndex {
-		high, err = strconv.ParseFloat(csvRecord[highPriceColumnIndex], 64)
+		if values[i], err = csvFPSP.parseFloatField(csvRecord, columnIndexes[i], field); err != nil {
+			return nil, err
+		}
 	}
 
-	// low
-	var low float64
-	if recordLength > lowPriceColumnIndex {
-		low, err = strconv.ParseFloat(csvRecord[lowPriceColumnIndex], 64)
-	}
+	dohlcv := gotrade.NewDOHLCVDataItem(date, values[0], values[1], values[2], values[3], values[4])
+
+	return dohlcv, nil
+}
 
-	// close
-	var close float64
-	if recordLength > closePriceColumnIndex {
-		close, err = strconv.ParseFloat(csvRecord[closePriceColumnIndex], 64)
+func (csvFPSP *CSVDOHLCVRecordParser) parseFloatField(csvRecord []string, columnIndex int, field string) (value float64, err error) {
+	text, err := csvField(csvRecord, columnIndex, field)
+	if err != nil {
+		return 0.0, err
 	}
 
-	// volume
-	var volume float64
-	if recordLength > volumeColumnIndex {
-		volume, err = strconv.ParseFloat(csvRecord[volumeColumnIndex], 64)
+	number := strings.TrimSpace(text)
+	if csvFPSP.ThousandsSeparator != 0 {
+		number = strings.ReplaceAll(number, string(csvFPSP.ThousandsSeparator), "")
+	}
+	if csvFPSP.DecimalSeparator != 0 && csvFPSP.DecimalSeparator != '.' {
+		number = strings.Replace(number, string(csvFPSP.DecimalSeparator), ".", 1)
 	}
 
-	dohlcv := gotrade.NewDOHLCVDataItem(date, open, high, low, close, volume)
+	value, err = strconv.ParseFloat(number, 64)
+	if err != nil {
+		// report the syntax or range error without repeating the value
+		if numError, ok := err.(*strconv.NumError); ok {
+			err = numError.Err
+		}
+		return 0.0, &CSVFieldError{Column: columnIndex + 1, Field: field, Value: text, Err: err}
+	}
+	return value, nil
+}
 
-	return dohlcv, err
+func csvField(csvRecord []string, columnIndex int, field string) (value string, err error) {
+	if columnIndex < 0 || columnIndex >= len(csvRecord) {
+		return "", &CSVFieldError{Column: columnIndex + 1, Field: field, Err: ErrCSVColumnMissing}
+	}
+	return csvRecord[columnIndex], nil
 }
@@ -1 +1,65 @@
 package feeds_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/jaybutera/gotrade"
+	"github.com/jaybutera/gotrade/feeds"
+	"strconv"
+	"time"
+)
+
+var _ = Describe("when parsing a csv record", func() {
+	var (
+		parser     *feeds.CSVDOHLCVRecordParser
+		dateParser feeds.TextDateParser
+		bar        gotrade.DOHLCV
+		parseErr   error
+	)
+
+	BeforeEach(func() {
+		parser = &feeds.CSVDOHLCVRecordParser{}
+		dateParser = feeds.DashedYearMonthDayDateParser()
+	})
+
+	It("should parse every field", func() {
+		bar, parseErr = parser.ParseRecord([]string{"2014-01-02", "10.5", "11", "9.5", "10", "1000"}, 0, 1, 2, 3, 4, 5, dateParser)
+		Expect(parseErr).To(BeNil())
+		Expect(bar.D()).To(Equal(time.Date(2014, 1, 2, 0, 0, 0, 0, time.UTC)))
+		Expect([]float64{bar.O(), bar.H(), bar.L(), bar.C(), bar.V()}).To(Equal([]float64{10.5, 11.0, 9.5, 10.0, 1000.0}))
+	})
+
+	It("should leave every absent field zero", func() {
+		bar, parseErr = parser.ParseRecord([]string{"2014-01-02", "10"}, 0, -1, -1, -1, 1, -1, dateParser)
+		Expect(parseErr).To(BeNil())
+		Expect([]float64{bar.O(), bar.H(), bar.L(), bar.C(), bar.V()}).To(Equal([]float64{0.0, 0.0, 0.0, 10.0, 0.0}))
+
+		bar, parseErr = parser.ParseRecord([]string{"10"}, -1, -1, -1, -1, 0, -1, dateParser)
+		Expect(parseErr).To(BeNil())
+		Expect(bar.D().IsZero()).To(BeTrue())
+	})
+
+	It("should report a bad date even when the later fields parse", func() {
+		bar, parseErr = parser.ParseRecord([]string{"02/01/2014", "10.5", "11", "9.5", "10", "1000"}, 0, 1, 2, 3, 4, 5, dateParser)
+		Expect(bar).To(BeNil())
+		Expect(parseErr).To(MatchError(&feeds.CSVFieldError{Column: 1, Field: "date", Value: "02/01/2014", Err: feeds.ErrDateFormat}))
+	})
+
+	It("should report the first field that cannot be parsed", func() {
+		bar, parseErr = parser.ParseRecord([]string{"2014-01-02", "10.5", "x", "9.5", "y", "1000"}, 0, 1, 2, 3, 4, 5, dateParser)
+		Expect(parseErr).To(MatchError(`column 3 (high): cannot parse "x": invalid syntax`))
+		Expect(parseErr.(*feeds.CSVFieldError).Err).To(Equal(strconv.ErrSyntax))
+	})
+
+	It("should report a column missing from the record", func() {
+		bar, parseErr = parser.ParseRecord([]string{"2014-01-02", "10.5", "11", "9.5"}, 0, 1, 2, 3, 4, 5, dateParser)
+		Expect(parseErr).To(MatchError("column 5 (close): the record has no such column"))
+	})
+
+	It("should parse the decimal separator given", func() {
+		parser.DecimalSeparator = ','
+		bar, parseErr = parser.ParseRecord([]string{"2014-01-02", " 10,5", "11", "9,25", "10,0 ", "1000"}, 0, 1, 2, 3, 4, 5, dateParser)
+		Expect(parseErr).To(BeNil())
+		Expect([]float64{bar.O(), bar.H(), bar.L(), bar.C()}).To(Equal([]float64{10.5, 11.0, 9.25, 10.0}))
+	})
+})
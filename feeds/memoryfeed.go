@@ -0,0 +1,56 @@
+package feeds
+
+import (
+	"github.com/jaybutera/gotrade"
+)
+
+// A MemoryFeed replays bars held in memory, so that bars parsed once can fill many price streams,
+// e.g. the runs of an optimization. The bars are shared, not copied, and must not be modified.
+type MemoryFeed struct {
+	bars []gotrade.DOHLCV
+}
+
+// NewMemoryFeed creates a feed of the bars
+func NewMemoryFeed(bars []gotrade.DOHLCV) *MemoryFeed {
+	return &MemoryFeed{bars: bars}
+}
+
+// LoadMemoryFeed reads every bar from the fill function of another feed into memory, e.g.
+//
+//	memoryFeed, err := feeds.LoadMemoryFeed(csvFeed.FillDOHLCVStream)
+func LoadMemoryFeed(fill func(priceStream gotrade.DOHLCVStreamTickReceiver) error) (feed *MemoryFeed, err error) {
+	var receiver barRecorder
+	if err = fill(&receiver); err != nil {
+		return nil, err
+	}
+	return NewMemoryFeed(receiver), nil
+}
+
+// Bars returns the bars of the feed
+func (memoryF *MemoryFeed) Bars() []gotrade.DOHLCV {
+	return memoryF.bars
+}
+
+// Len returns the number of bars in the feed
+func (memoryF *MemoryFeed) Len() int {
+	return len(memoryF.bars)
+}
+
+// Slice returns a feed of the bars from the start index up to, but excluding, the end index
+func (memoryF *MemoryFeed) Slice(start int, end int) *MemoryFeed {
+	return NewMemoryFeed(memoryF.bars[start:end:end])
+}
+
+func (memoryF *MemoryFeed) FillDOHLCVStream(priceStream gotrade.DOHLCVStreamTickReceiver) (err error) {
+	for _, bar := range memoryF.bars {
+		priceStream.ReceiveTick(bar)
+	}
+	return nil
+}
+
+// barRecorder records the ticks it receives
+type barRecorder []gotrade.DOHLCV
+
+func (r *barRecorder) ReceiveTick(tickData gotrade.DOHLCV) {
+	*r = append(*r, tickData)
+}
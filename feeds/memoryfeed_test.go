@@ -0,0 +1,60 @@
+package feeds_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"errors"
+	"github.com/jaybutera/gotrade"
+	"github.com/jaybutera/gotrade/feeds"
+	"time"
+)
+
+var _ = Describe("when loading a csv feed into memory", func() {
+	var (
+		csvFeed    *feeds.CSVFileFeed
+		memoryFeed *feeds.MemoryFeed
+		loadErr    error
+	)
+
+	BeforeEach(func() {
+		csvFeed = feeds.NewCSVFileFeedWithDOHLCVFormat("../testdata/JSETOPI.ALL.data",
+			feeds.DashedYearMonthDayDateParserForLocation(time.Local))
+		memoryFeed, loadErr = feeds.LoadMemoryFeed(csvFeed.FillDOHLCVStream)
+	})
+
+	It("should fill a stream with the same bars as the csv feed", func() {
+		Expect(loadErr).To(BeNil())
+
+		csvStream := gotrade.NewDOHLCVStream()
+		csvFeed.FillDOHLCVStream(csvStream)
+		memoryStream := gotrade.NewDOHLCVStream()
+		memoryFeed.FillDOHLCVStream(memoryStream)
+
+		Expect(memoryFeed.Len()).To(Equal(len(csvStream.Data)))
+		Expect(memoryStream.Data).To(Equal(csvStream.Data))
+	})
+
+	It("should fill many streams from the bars read once", func() {
+		first := gotrade.NewDOHLCVStream()
+		memoryFeed.FillDOHLCVStream(first)
+		second := gotrade.NewDOHLCVStream()
+		memoryFeed.FillDOHLCVStream(second)
+
+		Expect(second.Data).To(Equal(first.Data))
+	})
+
+	It("should slice the bars into a smaller feed", func() {
+		slice := memoryFeed.Slice(10, 20)
+		Expect(slice.Len()).To(Equal(10))
+		Expect(slice.Bars()[0]).To(Equal(memoryFeed.Bars()[10]))
+		Expect(slice.Bars()[9]).To(Equal(memoryFeed.Bars()[19]))
+	})
+
+	It("should return the error of the feed", func() {
+		memoryFeed, loadErr = feeds.LoadMemoryFeed(func(priceStream gotrade.DOHLCVStreamTickReceiver) error {
+			return errors.New("unreadable")
+		})
+		Expect(memoryFeed).To(BeNil())
+		Expect(loadErr).To(MatchError("unreadable"))
+	})
+})
@@ -1,41 +1,251 @@
 package feeds
 
 import (
+	"errors"
 	"strconv"
 	"strings"
 	"time"
 )
 
+var (
+	ErrDateFormat            = errors.New("date does not match the format")
+	ErrDateFormatNotDetected = errors.New("no date format matches every sample")
+	ErrNoDateSamples         = errors.New("samples must contain at least 1 date")
+)
+
 type TextDateParser func(textDate string) (date time.Time, err error)
 
-func DashedYearDayMonthDateParser() TextDateParser {
+// DashedYearMonthDayDateParser parses dates such as 2014-01-31 in UTC
+func DashedYearMonthDayDateParser() TextDateParser {
+	return DashedYearMonthDayDateParserForLocation(time.UTC)
+}
+
+// DashedYearMonthDayDateParserForLocation parses dates such as 2014-01-31 in the location
+func DashedYearMonthDayDateParserForLocation(location *time.Location) TextDateParser {
 	return func(textDate string) (date time.Time, err error) {
-		return dashedYearDayMonthDateParser(textDate, time.UTC)
+		return yearMonthDayDateParser(textDate, "-", location)
 	}
 }
 
+// DashedYearDayMonthDateParser parses dates such as 2014-01-31 in UTC.
+//
+// Deprecated: the format is year, month and day, use DashedYearMonthDayDateParser.
+func DashedYearDayMonthDateParser() TextDateParser {
+	return DashedYearMonthDayDateParser()
+}
+
+// DashedYearDayMonthDateParserForLocation parses dates such as 2014-01-31 in the location.
+//
+// Deprecated: the format is year, month and day, use DashedYearMonthDayDateParserForLocation.
 func DashedYearDayMonthDateParserForLocation(location *time.Location) TextDateParser {
+	return DashedYearMonthDayDateParserForLocation(location)
+}
+
+// SlashedYearMonthDayDateParser parses dates such as 2014/01/31 in UTC
+func SlashedYearMonthDayDateParser() TextDateParser {
+	return SlashedYearMonthDayDateParserForLocation(time.UTC)
+}
+
+// SlashedYearMonthDayDateParserForLocation parses dates such as 2014/01/31 in the location
+func SlashedYearMonthDayDateParserForLocation(location *time.Location) TextDateParser {
+	return func(textDate string) (date time.Time, err error) {
+		return yearMonthDayDateParser(textDate, "/", location)
+	}
+}
+
+func yearMonthDayDateParser(textDate string, separator string, location *time.Location) (date time.Time, err error) {
+	splits := strings.Split(textDate, separator)
+	if len(splits) != 3 {
+		return date, ErrDateFormat
+	}
+
+	var parts [3]int64
+	for i, split := range splits {
+		if parts[i], err = strconv.ParseInt(split, 10, 0); err != nil {
+			return date, ErrDateFormat
+		}
+	}
+
+	// a day past the end of its month, such as 2014-02-31, is normalized into the next month
+	date = time.Date(int(parts[0]), time.Month(parts[1]), int(parts[2]), 0, 0, 0, 0, location)
+	if int64(date.Year()) != parts[0] || int64(date.Month()) != parts[1] || int64(date.Day()) != parts[2] {
+		return time.Time{}, ErrDateFormat
+	}
+
+	return date, nil
+}
+
+// LayoutDateParser parses dates in any of the layouts of the time package, in UTC unless the layout has a zone
+func LayoutDateParser(layouts ...string) TextDateParser {
+	return LayoutDateParserForLocation(time.UTC, layouts...)
+}
+
+// LayoutDateParserForLocation parses dates in any of the layouts of the time package, in the location
+// unless the layout has a zone. The layouts are tried in the order given.
+func LayoutDateParserForLocation(location *time.Location, layouts ...string) TextDateParser {
 	return func(textDate string) (date time.Time, err error) {
-		return dashedYearDayMonthDateParser(textDate, location)
+		for _, layout := range layouts {
+			if date, err = time.ParseInLocation(layout, textDate, location); err == nil {
+				return date, nil
+			}
+		}
+		return time.Time{}, ErrDateFormat
 	}
 }
 
-func dashedYearDayMonthDateParser(textDate string, location *time.Location) (date time.Time, err error) {
-	splits := strings.Split(textDate, "-")
-	year, err := strconv.ParseInt(splits[0], 10, 0)
-	month, err := strconv.ParseInt(splits[1], 10, 0)
-	day, err := strconv.ParseInt(splits[2], 10, 0)
-	date = time.Date(int(year), time.Month(month), int(day), 0, 0, 0, 0, location)
+// DashedYearMonthDayTimeDateParser parses intra day timestamps such as 2014-01-31 09:30 or 2014-01-31 09:30:15 in UTC
+func DashedYearMonthDayTimeDateParser() TextDateParser {
+	return DashedYearMonthDayTimeDateParserForLocation(time.UTC)
+}
+
+// DashedYearMonthDayTimeDateParserForLocation parses intra day timestamps such as 2014-01-31 09:30 or
+// 2014-01-31 09:30:15 in the location
+func DashedYearMonthDayTimeDateParserForLocation(location *time.Location) TextDateParser {
+	return LayoutDateParserForLocation(location, "2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02T15:04:05", "2006-01-02T15:04")
+}
 
-	return date, err
+// SlashedMonthDayYearTimeDateParser parses intra day timestamps such as 01/31/2014 09:30 or 1/31/2014 9:30 in UTC
+func SlashedMonthDayYearTimeDateParser() TextDateParser {
+	return SlashedMonthDayYearTimeDateParserForLocation(time.UTC)
 }
 
-func slashedYearDayMonthDateParser(textDate string) (date time.Time, err error) {
-	splits := strings.Split(textDate, "/")
-	year, err := strconv.ParseInt(splits[0], 10, 0)
-	month, err := strconv.ParseInt(splits[1], 10, 0)
-	day, err := strconv.ParseInt(splits[2], 10, 0)
-	date = time.Date(int(year), time.Month(month), int(day), 0, 0, 0, 0, time.UTC)
+// SlashedMonthDayYearTimeDateParserForLocation parses intra day timestamps such as 01/31/2014 09:30 or
+// 1/31/2014 9:30 in the location
+func SlashedMonthDayYearTimeDateParserForLocation(location *time.Location) TextDateParser {
+	return LayoutDateParserForLocation(location, "1/2/2006 15:04", "1/2/2006 15:04:05")
+}
+
+// SlashedMonthDayYearDateParser parses dates such as 01/31/2014 or 1/31/2014 in UTC
+func SlashedMonthDayYearDateParser() TextDateParser {
+	return SlashedMonthDayYearDateParserForLocation(time.UTC)
+}
+
+// SlashedMonthDayYearDateParserForLocation parses dates such as 01/31/2014 or 1/31/2014 in the location
+func SlashedMonthDayYearDateParserForLocation(location *time.Location) TextDateParser {
+	return LayoutDateParserForLocation(location, "1/2/2006")
+}
+
+// RFC3339DateParser parses timestamps such as 2014-01-31T09:30:00Z or 2014-01-31T09:30:00.5+02:00, keeping their offset
+func RFC3339DateParser() TextDateParser {
+	return LayoutDateParser(time.RFC3339Nano)
+}
 
-	return date, err
+// RFC3339DateParserForLocation parses timestamps such as 2014-01-31T09:30:00Z or 2014-01-31T09:30:00.5+02:00,
+// converting them to the location
+func RFC3339DateParserForLocation(location *time.Location) TextDateParser {
+	parser := RFC3339DateParser()
+	return func(textDate string) (date time.Time, err error) {
+		if date, err = parser(textDate); err != nil {
+			return date, err
+		}
+		return date.In(location), nil
+	}
+}
+
+// EpochSecondsDateParser parses the seconds since 1970-01-01 UTC, returning the time in UTC
+func EpochSecondsDateParser() TextDateParser {
+	return EpochSecondsDateParserForLocation(time.UTC)
+}
+
+// EpochSecondsDateParserForLocation parses the seconds since 1970-01-01 UTC, returning the time in the location
+func EpochSecondsDateParserForLocation(location *time.Location) TextDateParser {
+	return func(textDate string) (date time.Time, err error) {
+		seconds, err := strconv.ParseInt(textDate, 10, 64)
+		if err != nil {
+			return date, ErrDateFormat
+		}
+		return time.Unix(seconds, 0).In(location), nil
+	}
+}
+
+// EpochMillisecondsDateParser parses the milliseconds since 1970-01-01 UTC, returning the time in UTC
+func EpochMillisecondsDateParser() TextDateParser {
+	return EpochMillisecondsDateParserForLocation(time.UTC)
+}
+
+// EpochMillisecondsDateParserForLocation parses the milliseconds since 1970-01-01 UTC, returning the time in the location
+func EpochMillisecondsDateParserForLocation(location *time.Location) TextDateParser {
+	return func(textDate string) (date time.Time, err error) {
+		milliseconds, err := strconv.ParseInt(textDate, 10, 64)
+		if err != nil {
+			return date, ErrDateFormat
+		}
+		return time.Unix(0, milliseconds*int64(time.Millisecond)).In(location), nil
+	}
+}
+
+// epochMillisecondsThreshold separates epoch milliseconds from epoch seconds when detecting the format,
+// as seconds this is in the year 5138 and as milliseconds in 1973
+const epochMillisecondsThreshold = 100000000000
+
+// a date format that can be detected, with the samples it must accept
+type detectableDateFormat struct {
+	newParser func(location *time.Location) TextDateParser
+	accepts   func(textDate string) bool
+}
+
+func acceptsAny(textDate string) bool {
+	return true
+}
+
+// notCompactYearMonthDay rejects eight digits of a valid year, month and day such as 20140131, which
+// would otherwise be taken as seconds in 1970
+func notCompactYearMonthDay(textDate string) bool {
+	if len(textDate) != 8 {
+		return true
+	}
+	_, err := time.Parse("20060102", textDate)
+	return err != nil
+}
+
+// the formats tried when detecting, in order, an ambiguous date such as 01/02/2014 being taken as month, day and year
+var detectableDateFormats = []detectableDateFormat{
+	{RFC3339DateParserForLocation, acceptsAny},
+	{DashedYearMonthDayTimeDateParserForLocation, acceptsAny},
+	{DashedYearMonthDayDateParserForLocation, acceptsAny},
+	{SlashedYearMonthDayDateParserForLocation, acceptsAny},
+	{SlashedMonthDayYearTimeDateParserForLocation, acceptsAny},
+	{SlashedMonthDayYearDateParserForLocation, acceptsAny},
+	{EpochMillisecondsDateParserForLocation, func(textDate string) bool {
+		value, err := strconv.ParseInt(textDate, 10, 64)
+		return err == nil && (value >= epochMillisecondsThreshold || value <= -epochMillisecondsThreshold)
+	}},
+	{EpochSecondsDateParserForLocation, notCompactYearMonthDay},
+}
+
+// DetectDateParser returns the parser of the first of the supported formats that parses every sample, in UTC
+func DetectDateParser(samples []string) (parser TextDateParser, err error) {
+	return DetectDateParserForLocation(samples, time.UTC)
+}
+
+// DetectDateParserForLocation returns the parser of the first of the supported formats that parses every sample,
+// in the location. The formats are tried in the order RFC3339, year-month-day with a time, year-month-day,
+// year/month/day, month/day/year with a time, month/day/year, epoch milliseconds and epoch seconds, which
+// excludes eight digit dates such as 20140131.
+func DetectDateParserForLocation(samples []string, location *time.Location) (parser TextDateParser, err error) {
+	if len(samples) == 0 {
+		return nil, ErrNoDateSamples
+	}
+
+	for _, format := range detectableDateFormats {
+		parser = format.newParser(location)
+		if parsesEverySample(parser, format.accepts, samples) {
+			return parser, nil
+		}
+	}
+	return nil, ErrDateFormatNotDetected
+}
+
+func parsesEverySample(parser TextDateParser, accepts func(textDate string) bool, samples []string) bool {
+	for _, sample := range samples {
+		textDate := strings.TrimSpace(sample)
+		if !accepts(textDate) {
+			return false
+		}
+
+		if _, err := parser(textDate); err != nil {
+			return false
+		}
+	}
+	return true
 }
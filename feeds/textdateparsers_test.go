@@ -1 +1,128 @@
 package feeds_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/jaybutera/gotrade/feeds"
+	"time"
+)
+
+var _ = Describe("when parsing text dates", func() {
+	var (
+		newYork *time.Location
+	)
+
+	BeforeEach(func() {
+		newYork, _ = time.LoadLocation("America/New_York")
+	})
+
+	It("should parse dashed and slashed year, month and day dates", func() {
+		Expect(feeds.DashedYearMonthDayDateParser()("2014-01-31")).To(Equal(time.Date(2014, 1, 31, 0, 0, 0, 0, time.UTC)))
+		Expect(feeds.SlashedYearMonthDayDateParser()("2014/01/31")).To(Equal(time.Date(2014, 1, 31, 0, 0, 0, 0, time.UTC)))
+		Expect(feeds.SlashedYearMonthDayDateParserForLocation(newYork)("2014/01/31")).To(Equal(time.Date(2014, 1, 31, 0, 0, 0, 0, newYork)))
+	})
+
+	It("should return an error for a date not in the format", func() {
+		for _, textDate := range []string{"2014-01", "2014-1x-01", "2014/01/31", "2014-31-01", "2014-02-31", "2014-04-31", "2014-00-10", ""} {
+			_, err := feeds.DashedYearMonthDayDateParser()(textDate)
+			Expect(err).To(Equal(feeds.ErrDateFormat), textDate)
+		}
+	})
+
+	It("should parse the 29th of february of leap years only", func() {
+		Expect(feeds.DashedYearMonthDayDateParser()("2016-02-29")).To(Equal(time.Date(2016, 2, 29, 0, 0, 0, 0, time.UTC)))
+		_, err := feeds.SlashedYearMonthDayDateParser()("2014/02/29")
+		Expect(err).To(Equal(feeds.ErrDateFormat))
+	})
+
+	It("should parse intra day timestamps in the location", func() {
+		parser := feeds.DashedYearMonthDayTimeDateParserForLocation(newYork)
+		Expect(parser("2014-01-31 09:30")).To(Equal(time.Date(2014, 1, 31, 9, 30, 0, 0, newYork)))
+		Expect(parser("2014-01-31 09:30:15")).To(Equal(time.Date(2014, 1, 31, 9, 30, 15, 0, newYork)))
+		Expect(parser("2014-01-31T09:30")).To(Equal(time.Date(2014, 1, 31, 9, 30, 0, 0, newYork)))
+	})
+
+	It("should parse month, day and year timestamps", func() {
+		parser := feeds.SlashedMonthDayYearTimeDateParser()
+		Expect(parser("01/31/2014 09:30")).To(Equal(time.Date(2014, 1, 31, 9, 30, 0, 0, time.UTC)))
+		Expect(parser("1/31/2014 9:30")).To(Equal(time.Date(2014, 1, 31, 9, 30, 0, 0, time.UTC)))
+		Expect(feeds.SlashedMonthDayYearDateParser()("1/31/2014")).To(Equal(time.Date(2014, 1, 31, 0, 0, 0, 0, time.UTC)))
+
+		_, err := parser("31/01/2014 09:30")
+		Expect(err).To(Equal(feeds.ErrDateFormat))
+	})
+
+	It("should parse RFC3339 timestamps keeping or converting their offset", func() {
+		date, err := feeds.RFC3339DateParser()("2014-01-31T16:30:00.5+02:00")
+		Expect(err).To(BeNil())
+		Expect(date.Equal(time.Date(2014, 1, 31, 14, 30, 0, 500000000, time.UTC))).To(BeTrue())
+		_, offset := date.Zone()
+		Expect(offset).To(Equal(2 * 60 * 60))
+
+		Expect(feeds.RFC3339DateParserForLocation(newYork)("2014-01-31T14:30:00Z")).To(Equal(time.Date(2014, 1, 31, 9, 30, 0, 0, newYork)))
+	})
+
+	It("should parse epoch seconds and milliseconds", func() {
+		Expect(feeds.EpochSecondsDateParser()("1391178600")).To(Equal(time.Date(2014, 1, 31, 14, 30, 0, 0, time.UTC)))
+		Expect(feeds.EpochMillisecondsDateParser()("1391178600250")).To(Equal(time.Date(2014, 1, 31, 14, 30, 0, 250000000, time.UTC)))
+		Expect(feeds.EpochSecondsDateParserForLocation(newYork)("1391178600")).To(Equal(time.Date(2014, 1, 31, 9, 30, 0, 0, newYork)))
+
+		_, err := feeds.EpochSecondsDateParser()("1391178600.5")
+		Expect(err).To(Equal(feeds.ErrDateFormat))
+	})
+
+	It("should parse any of the layouts given", func() {
+		parser := feeds.LayoutDateParser("02.01.2006", "2006.01.02")
+		Expect(parser("31.01.2014")).To(Equal(time.Date(2014, 1, 31, 0, 0, 0, 0, time.UTC)))
+		Expect(parser("2014.01.31")).To(Equal(time.Date(2014, 1, 31, 0, 0, 0, 0, time.UTC)))
+	})
+})
+
+var _ = Describe("when detecting the format of text dates", func() {
+	detected := func(samples ...string) time.Time {
+		parser, err := feeds.DetectDateParser(samples)
+		Expect(err).To(BeNil())
+		date, err := parser(samples[0])
+		Expect(err).To(BeNil())
+		return date
+	}
+
+	It("should detect each supported format", func() {
+		Expect(detected("2014-01-31T14:30:00Z")).To(Equal(time.Date(2014, 1, 31, 14, 30, 0, 0, time.UTC)))
+		Expect(detected("2014-01-31 14:30", "2014-01-31 14:31")).To(Equal(time.Date(2014, 1, 31, 14, 30, 0, 0, time.UTC)))
+		Expect(detected("2014-01-31")).To(Equal(time.Date(2014, 1, 31, 0, 0, 0, 0, time.UTC)))
+		Expect(detected("2014/01/31")).To(Equal(time.Date(2014, 1, 31, 0, 0, 0, 0, time.UTC)))
+		Expect(detected("01/31/2014 14:30")).To(Equal(time.Date(2014, 1, 31, 14, 30, 0, 0, time.UTC)))
+		Expect(detected("01/31/2014")).To(Equal(time.Date(2014, 1, 31, 0, 0, 0, 0, time.UTC)))
+		Expect(detected("1391178600000")).To(Equal(time.Date(2014, 1, 31, 14, 30, 0, 0, time.UTC)))
+		Expect(detected("1391178600")).To(Equal(time.Date(2014, 1, 31, 14, 30, 0, 0, time.UTC)))
+	})
+
+	It("should need every sample to parse", func() {
+		_, err := feeds.DetectDateParser([]string{"2014-01-31", "31 January 2014"})
+		Expect(err).To(Equal(feeds.ErrDateFormatNotDetected))
+	})
+
+	It("should not take eight digit year, month and day dates as epoch seconds", func() {
+		_, err := feeds.DetectDateParser([]string{"20140131", "20140203"})
+		Expect(err).To(Equal(feeds.ErrDateFormatNotDetected))
+
+		Expect(detected("86400000")).To(Equal(time.Date(1972, 9, 27, 0, 0, 0, 0, time.UTC)))
+	})
+
+	It("should take an ambiguous date as month, day and year", func() {
+		Expect(detected("01/02/2014", "01/03/2014")).To(Equal(time.Date(2014, 1, 2, 0, 0, 0, 0, time.UTC)))
+	})
+
+	It("should detect in the location given", func() {
+		newYork, _ := time.LoadLocation("America/New_York")
+		parser, err := feeds.DetectDateParserForLocation([]string{"2014-01-31 09:30"}, newYork)
+		Expect(err).To(BeNil())
+		Expect(parser("2014-01-31 09:30")).To(Equal(time.Date(2014, 1, 31, 9, 30, 0, 0, newYork)))
+	})
+
+	It("should return an error without samples", func() {
+		_, err := feeds.DetectDateParser(nil)
+		Expect(err).To(Equal(feeds.ErrNoDateSamples))
+	})
+})
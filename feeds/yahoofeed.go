@@ -1 +1,304 @@
 package feeds
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/jaybutera/gotrade"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// the public Yahoo Finance query api
+	YahooDefaultBaseURL = "https://query1.finance.yahoo.com"
+)
+
+// The format in which Yahoo Finance historical data is downloaded
+type YahooFormat int
+
+const (
+	// the v8 chart json format
+	YahooChartJSONFormat YahooFormat = iota
+	// the v7 "Date,Open,High,Low,Close,Adj Close,Volume" csv export
+	YahooCSVFormat
+)
+
+var (
+	ErrYahooNoChartResult = errors.New("Yahoo chart response contains no result")
+	ErrYahooCSVHeader     = errors.New("Yahoo csv must start with the header Date,Open,High,Low,Close,Adj Close,Volume")
+)
+
+var yahooCSVHeader = []string{"Date", "Open", "High", "Low", "Close", "Adj Close", "Volume"}
+
+type yahooChartResponse struct {
+	Chart struct {
+		Result []struct {
+			Meta struct {
+				Symbol               string
+				ExchangeTimezoneName string
+				GmtOffset            int
+				DataGranularity      string
+			}
+			Timestamp  []int64
+			Indicators struct {
+				Quote []struct {
+					Open   []*float64
+					High   []*float64
+					Low    []*float64
+					Close  []*float64
+					Volume []*float64
+				}
+				AdjClose []struct {
+					AdjClose []*float64
+				}
+			}
+		}
+		Error *struct {
+			Code        string
+			Description string
+		}
+	}
+}
+
+// ParseYahooChartJSON parses a Yahoo Finance v8 chart response into price bars, skipping bars with
+// missing prices. Daily, weekly and monthly bars are dated at midnight in the exchange timezone.
+// If adjustPrices is set the open, high and low are rebased onto the adjusted close.
+func ParseYahooChartJSON(reader io.Reader, adjustPrices bool) (bars []gotrade.DOHLCV, err error) {
+	return ParseYahooChartJSONForLocation(reader, nil, adjustPrices)
+}
+
+// ParseYahooChartJSONForLocation parses a Yahoo Finance v8 chart response like ParseYahooChartJSON, with the
+// bars dated in location. Daily, weekly and monthly bars keep their date in the exchange timezone at midnight
+// in location, a nil location is the exchange timezone.
+func ParseYahooChartJSONForLocation(reader io.Reader, location *time.Location, adjustPrices bool) (bars []gotrade.DOHLCV, err error) {
+	var response yahooChartResponse
+	if err = json.NewDecoder(reader).Decode(&response); err != nil {
+		return nil, err
+	}
+
+	if response.Chart.Error != nil {
+		return nil, fmt.Errorf("%s: %s", response.Chart.Error.Code, response.Chart.Error.Description)
+	}
+
+	if len(response.Chart.Result) == 0 || len(response.Chart.Result[0].Indicators.Quote) == 0 {
+		return nil, ErrYahooNoChartResult
+	}
+
+	result := response.Chart.Result[0]
+	exchangeLocation, err := time.LoadLocation(result.Meta.ExchangeTimezoneName)
+	if err != nil || result.Meta.ExchangeTimezoneName == "" {
+		exchangeLocation = time.FixedZone(result.Meta.ExchangeTimezoneName, result.Meta.GmtOffset)
+	}
+	if location == nil {
+		location = exchangeLocation
+	}
+	granularity := result.Meta.DataGranularity
+	truncateToDate := strings.HasSuffix(granularity, "d") || strings.HasSuffix(granularity, "wk") || strings.HasSuffix(granularity, "mo")
+
+	quote := result.Indicators.Quote[0]
+	var adjClose []*float64
+	if len(result.Indicators.AdjClose) > 0 {
+		adjClose = result.Indicators.AdjClose[0].AdjClose
+	}
+
+	for i, timestamp := range result.Timestamp {
+		values := []*float64{yahooValueAt(quote.Open, i), yahooValueAt(quote.High, i), yahooValueAt(quote.Low, i),
+			yahooValueAt(quote.Close, i), yahooValueAt(quote.Volume, i)}
+		if adjustPrices {
+			values = append(values, yahooValueAt(adjClose, i))
+		}
+
+		// yahoo reports non trading days within the range as nulls
+		if yahooHasNull(values) {
+			continue
+		}
+
+		date := time.Unix(timestamp, 0).In(location)
+		if truncateToDate {
+			exchangeDate := date.In(exchangeLocation)
+			date = time.Date(exchangeDate.Year(), exchangeDate.Month(), exchangeDate.Day(), 0, 0, 0, 0, location)
+		}
+
+		var adjustedClose float64
+		if adjustPrices {
+			adjustedClose = *values[5]
+		}
+		bars = append(bars, newYahooBar(date, *values[0], *values[1], *values[2], *values[3], adjustedClose, *values[4], adjustPrices))
+	}
+
+	return bars, nil
+}
+
+// ParseYahooCSV parses a Yahoo Finance "Date,Open,High,Low,Close,Adj Close,Volume" csv export into price bars,
+// skipping rows with missing prices. If adjustPrices is set the open, high and low are rebased onto the adjusted close.
+func ParseYahooCSV(reader io.Reader, dateParser TextDateParser, adjustPrices bool) (bars []gotrade.DOHLCV, err error) {
+	csvReader := csv.NewReader(reader)
+
+	header, err := csvReader.Read()
+	if err != nil {
+		return nil, err
+	}
+	if len(header) != len(yahooCSVHeader) {
+		return nil, ErrYahooCSVHeader
+	}
+	for i := range header {
+		if strings.TrimSpace(header[i]) != yahooCSVHeader[i] {
+			return nil, ErrYahooCSVHeader
+		}
+	}
+
+	var lineNumber int = 1
+	for {
+		lineNumber++
+
+		record, err := csvReader.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		// yahoo reports non trading days within the range as nulls
+		if strings.Contains(strings.Join(record, ","), "null") {
+			continue
+		}
+
+		date, err := dateParser(record[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s", lineNumber, err)
+		}
+
+		var values [6]float64
+		for i := range values {
+			values[i], err = strconv.ParseFloat(record[i+1], 64)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %s", lineNumber, err)
+			}
+		}
+
+		bars = append(bars, newYahooBar(date, values[0], values[1], values[2], values[3], values[4], values[5], adjustPrices))
+	}
+
+	return bars, nil
+}
+
+func newYahooBar(date time.Time, open float64, high float64, low float64, close float64, adjustedClose float64, volume float64, adjustPrices bool) gotrade.DOHLCV {
+	if adjustPrices && close != 0 {
+		factor := adjustedClose / close
+		return gotrade.NewDOHLCVDataItem(date, open*factor, high*factor, low*factor, adjustedClose, volume)
+	}
+	return gotrade.NewDOHLCVDataItem(date, open, high, low, close, volume)
+}
+
+func yahooValueAt(values []*float64, index int) *float64 {
+	if index >= len(values) {
+		return nil
+	}
+	return values[index]
+}
+
+func yahooHasNull(values []*float64) bool {
+	for _, value := range values {
+		if value == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// A client for the Yahoo Finance historical data api
+type YahooClient struct {
+	httpClient *http.Client
+	baseURL    string
+}
+
+// NewYahooClient creates a Yahoo Finance client that makes requests with httpClient against baseURL,
+// a nil httpClient uses http.DefaultClient
+func NewYahooClient(httpClient *http.Client, baseURL string) *YahooClient {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &YahooClient{httpClient: httpClient, baseURL: baseURL}
+}
+
+// NewDefaultYahooClient creates a Yahoo Finance client for the public Yahoo Finance api
+func NewDefaultYahooClient() *YahooClient {
+	return NewYahooClient(nil, YahooDefaultBaseURL)
+}
+
+// Download requests the daily price history of symbol between startDate and endDate in the given format,
+// the caller must close the returned body
+func (c *YahooClient) Download(symbol string, startDate time.Time, endDate time.Time, format YahooFormat) (body io.ReadCloser, err error) {
+	query := url.Values{}
+	query.Set("period1", strconv.FormatInt(startDate.Unix(), 10))
+	query.Set("period2", strconv.FormatInt(endDate.Unix(), 10))
+	query.Set("interval", "1d")
+	query.Set("events", "history")
+
+	path := "/v8/finance/chart/"
+	if format == YahooCSVFormat {
+		path = "/v7/finance/download/"
+	}
+
+	resp, err := c.httpClient.Get(c.baseURL + path + url.PathEscape(symbol) + "?" + query.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("yahoo request for symbol %s failed with status %s", symbol, resp.Status)
+	}
+
+	return resp.Body, nil
+}
+
+// A historical Yahoo Finance feed of daily prices
+type YahooFeed struct {
+	client       *YahooClient
+	symbol       string
+	startDate    time.Time
+	endDate      time.Time
+	format       YahooFormat
+	adjustPrices bool
+	location     *time.Location
+}
+
+// NewYahooFeed creates a historical Yahoo Finance feed for symbol between startDate and endDate, with daily
+// bars dated at midnight in location whichever the format, a nil location is UTC. If adjustPrices is set
+// the prices are rebased onto the adjusted close.
+func NewYahooFeed(symbol string, startDate time.Time, endDate time.Time, format YahooFormat, adjustPrices bool, location *time.Location, client *YahooClient) *YahooFeed {
+	if location == nil {
+		location = time.UTC
+	}
+	return &YahooFeed{client, symbol, startDate, endDate, format, adjustPrices, location}
+}
+
+func (yahooF *YahooFeed) FillDOHLCVStream(priceStream gotrade.DOHLCVStreamTickReceiver) (err error) {
+	body, err := yahooF.client.Download(yahooF.symbol, yahooF.startDate, yahooF.endDate, yahooF.format)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	var bars []gotrade.DOHLCV
+	if yahooF.format == YahooCSVFormat {
+		bars, err = ParseYahooCSV(body, DashedYearMonthDayDateParserForLocation(yahooF.location), yahooF.adjustPrices)
+	} else {
+		bars, err = ParseYahooChartJSONForLocation(body, yahooF.location, yahooF.adjustPrices)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, bar := range bars {
+		priceStream.ReceiveTick(bar)
+	}
+	return nil
+}
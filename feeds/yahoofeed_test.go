@@ -0,0 +1,186 @@
+package feeds_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/jaybutera/gotrade"
+	"github.com/jaybutera/gotrade/feeds"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"time"
+)
+
+// newRecordedYahooServer serves the recorded yahoo responses from the testdata folder
+func newRecordedYahooServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("interval") != "1d" || r.URL.Query().Get("period1") == "" || r.URL.Query().Get("period2") == "" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v8/finance/chart/AAPL":
+			http.ServeFile(w, r, "../testdata/yahoo_chart_aapl.json")
+		case "/v7/finance/download/AAPL":
+			http.ServeFile(w, r, "../testdata/yahoo_download_aapl.csv")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+var _ = Describe("when parsing a yahoo chart json response", func() {
+	var (
+		bars     []gotrade.DOHLCV
+		parseErr error
+		newYork  *time.Location
+	)
+
+	BeforeEach(func() {
+		newYork, _ = time.LoadLocation("America/New_York")
+	})
+
+	Context("without adjusting prices", func() {
+		BeforeEach(func() {
+			file, _ := os.Open("../testdata/yahoo_chart_aapl.json")
+			defer file.Close()
+			bars, parseErr = feeds.ParseYahooChartJSON(file, false)
+		})
+
+		It("should skip the days with missing prices", func() {
+			Expect(parseErr).To(BeNil())
+			Expect(len(bars)).To(Equal(5))
+		})
+
+		It("should date the daily bars at midnight in the exchange timezone", func() {
+			Expect(bars[0].D()).To(Equal(time.Date(2019, 1, 2, 0, 0, 0, 0, newYork)))
+			Expect(bars[3].D()).To(Equal(time.Date(2019, 1, 7, 0, 0, 0, 0, newYork)))
+		})
+
+		It("should parse the unadjusted prices and volume", func() {
+			Expect(bars[1].O()).To(Equal(143.98))
+			Expect(bars[1].H()).To(Equal(145.72))
+			Expect(bars[1].L()).To(Equal(142.0))
+			Expect(bars[1].C()).To(Equal(142.19))
+			Expect(bars[1].V()).To(Equal(91312200.0))
+		})
+	})
+
+	Context("adjusting prices", func() {
+		BeforeEach(func() {
+			file, _ := os.Open("../testdata/yahoo_chart_aapl.json")
+			defer file.Close()
+			bars, parseErr = feeds.ParseYahooChartJSON(file, true)
+		})
+
+		It("should rebase the prices onto the adjusted close", func() {
+			factor := 139.38 / 142.19
+			Expect(bars[1].O()).To(BeNumerically("~", 143.98*factor, 0.000001))
+			Expect(bars[1].H()).To(BeNumerically("~", 145.72*factor, 0.000001))
+			Expect(bars[1].L()).To(BeNumerically("~", 142.0*factor, 0.000001))
+			Expect(bars[1].C()).To(Equal(139.38))
+			Expect(bars[1].V()).To(Equal(91312200.0))
+		})
+	})
+
+	Context("for a location", func() {
+		BeforeEach(func() {
+			file, _ := os.Open("../testdata/yahoo_chart_aapl.json")
+			defer file.Close()
+			bars, parseErr = feeds.ParseYahooChartJSONForLocation(file, time.UTC, false)
+		})
+
+		It("should date the daily bars at midnight in the location on their exchange date", func() {
+			Expect(parseErr).To(BeNil())
+			Expect(bars[0].D()).To(Equal(time.Date(2019, 1, 2, 0, 0, 0, 0, time.UTC)))
+			Expect(bars[3].D()).To(Equal(time.Date(2019, 1, 7, 0, 0, 0, 0, time.UTC)))
+		})
+	})
+})
+
+var _ = Describe("when parsing a yahoo csv export", func() {
+	var (
+		bars     []gotrade.DOHLCV
+		parseErr error
+	)
+
+	BeforeEach(func() {
+		file, _ := os.Open("../testdata/yahoo_download_aapl.csv")
+		defer file.Close()
+		bars, parseErr = feeds.ParseYahooCSV(file, feeds.DashedYearMonthDayDateParser(), true)
+	})
+
+	It("should skip the header and the rows with missing prices", func() {
+		Expect(parseErr).To(BeNil())
+		Expect(len(bars)).To(Equal(5))
+		Expect(bars[4].D()).To(Equal(time.Date(2019, 1, 8, 0, 0, 0, 0, time.UTC)))
+	})
+
+	It("should rebase the prices onto the adjusted close", func() {
+		factor := 147.77 / 150.75
+		Expect(bars[4].O()).To(BeNumerically("~", 149.56*factor, 0.000001))
+		Expect(bars[4].C()).To(Equal(147.77))
+		Expect(bars[4].V()).To(Equal(41025300.0))
+	})
+})
+
+var _ = Describe("when filling a price stream from a yahoo feed", func() {
+	var (
+		server      *httptest.Server
+		priceStream *gotrade.InterDayDOHLCVStream
+		client      *feeds.YahooClient
+		startDate   time.Time
+		endDate     time.Time
+		newYork     *time.Location
+	)
+
+	BeforeEach(func() {
+		newYork, _ = time.LoadLocation("America/New_York")
+		server = newRecordedYahooServer()
+		client = feeds.NewYahooClient(server.Client(), server.URL)
+		priceStream = gotrade.NewDailyDOHLCVStream()
+		startDate = time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+		endDate = time.Date(2019, 1, 9, 0, 0, 0, 0, time.UTC)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should fill the stream from the chart json format", func() {
+		feed := feeds.NewYahooFeed("AAPL", startDate, endDate, feeds.YahooChartJSONFormat, false, newYork, client)
+		Expect(feed.FillDOHLCVStream(priceStream)).To(BeNil())
+		Expect(len(priceStream.Data)).To(Equal(5))
+		Expect(priceStream.Data[4].C()).To(Equal(150.75))
+	})
+
+	It("should fill the stream from the csv format", func() {
+		feed := feeds.NewYahooFeed("AAPL", startDate, endDate, feeds.YahooCSVFormat, true, newYork, client)
+		Expect(feed.FillDOHLCVStream(priceStream)).To(BeNil())
+		Expect(len(priceStream.Data)).To(Equal(5))
+		Expect(priceStream.Data[4].C()).To(Equal(147.77))
+	})
+
+	It("should date the bars of both formats at midnight in the location of the feed", func() {
+		for _, format := range []feeds.YahooFormat{feeds.YahooChartJSONFormat, feeds.YahooCSVFormat} {
+			priceStream = gotrade.NewDailyDOHLCVStream()
+			feed := feeds.NewYahooFeed("AAPL", startDate, endDate, format, false, newYork, client)
+			Expect(feed.FillDOHLCVStream(priceStream)).To(BeNil())
+			Expect(priceStream.Data[0].D()).To(Equal(time.Date(2019, 1, 2, 0, 0, 0, 0, newYork)))
+			Expect(priceStream.Data[4].D()).To(Equal(time.Date(2019, 1, 8, 0, 0, 0, 0, newYork)))
+		}
+	})
+
+	It("should date the bars in UTC without a location", func() {
+		feed := feeds.NewYahooFeed("AAPL", startDate, endDate, feeds.YahooChartJSONFormat, false, nil, client)
+		Expect(feed.FillDOHLCVStream(priceStream)).To(BeNil())
+		Expect(priceStream.Data[0].D()).To(Equal(time.Date(2019, 1, 2, 0, 0, 0, 0, time.UTC)))
+	})
+
+	It("should return an error for an unknown symbol", func() {
+		feed := feeds.NewYahooFeed("NOPE", startDate, endDate, feeds.YahooCSVFormat, false, newYork, client)
+		Expect(feed.FillDOHLCVStream(priceStream)).NotTo(BeNil())
+		Expect(len(priceStream.Data)).To(Equal(0))
+	})
+})
@@ -0,0 +1,72 @@
+package gotrade
+
+import (
+	"io"
+	"sync"
+)
+
+// A source of float ticks, such as an indicator's results, that consumers can subscribe to
+type FloatStreamSubscriber interface {
+	AddTickSubscription(subscriber TickReceiver) io.Closer
+}
+
+// The subscribers to a series of float ticks, for embedding in whatever produces the ticks.
+// Each tick carries the stream bar index of the source data bar it was produced from,
+// so that consumers further down a chain report the same bars as the source data stream.
+type FloatStream struct {
+	subscriberMutex sync.RWMutex
+	subscribers     []TickReceiver
+}
+
+// NewFloatStream creates a float stream without any subscribers
+func NewFloatStream() *FloatStream {
+	return &FloatStream{}
+}
+
+// AddTickSubscription attaches a subscriber to the stream, it is safe to call whilst ticks are being published.
+// Closing the returned subscription detaches the subscriber.
+func (p *FloatStream) AddTickSubscription(subscriber TickReceiver) io.Closer {
+	p.subscriberMutex.Lock()
+	defer p.subscriberMutex.Unlock()
+
+	// copy on write, so that ticks being published keep the subscribers they started with
+	subscribers := make([]TickReceiver, len(p.subscribers), len(p.subscribers)+1)
+	copy(subscribers, p.subscribers)
+	p.subscribers = append(subscribers, subscriber)
+
+	return newSubscription(func() { p.RemoveTickSubscription(subscriber) })
+}
+
+// RemoveTickSubscription detaches a subscriber from the stream, it is safe to call whilst ticks are being published
+func (p *FloatStream) RemoveTickSubscription(subscriber TickReceiver) {
+	p.subscriberMutex.Lock()
+	defer p.subscriberMutex.Unlock()
+
+	for i := range p.subscribers {
+		if p.subscribers[i] == subscriber {
+			subscribers := make([]TickReceiver, 0, len(p.subscribers)-1)
+			subscribers = append(subscribers, p.subscribers[:i]...)
+			p.subscribers = append(subscribers, p.subscribers[i+1:]...)
+			return
+		}
+	}
+}
+
+// TickSubscriberCount returns the number of subscribers attached to the stream
+func (p *FloatStream) TickSubscriberCount() int {
+	p.subscriberMutex.RLock()
+	defer p.subscriberMutex.RUnlock()
+	return len(p.subscribers)
+}
+
+// PublishTick notifies the subscribers of a tick, one after another in the order they subscribed,
+// so that subscribers combining several streams receive each bar from every stream in turn
+func (p *FloatStream) PublishTick(tickData float64, streamBarIndex int) {
+	p.subscriberMutex.RLock()
+	subscribers := p.subscribers
+	p.subscriberMutex.RUnlock()
+
+	for _, subscriber := range subscribers {
+		subscriber.ReceiveTick(tickData, streamBarIndex)
+	}
+}
@@ -0,0 +1,69 @@
+package gotrade_test
+
+import (
+	. "github.com/jaybutera/gotrade"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type fakeFloatTickReceiver struct {
+	ticks           []float64
+	streamBarIndexs []int
+}
+
+func (f *fakeFloatTickReceiver) ReceiveTick(tickData float64, streamBarIndex int) {
+	f.ticks = append(f.ticks, tickData)
+	f.streamBarIndexs = append(f.streamBarIndexs, streamBarIndex)
+}
+
+var _ = Describe("when publishing ticks to a float stream", func() {
+	var (
+		floatStream *FloatStream
+		first       *fakeFloatTickReceiver
+		second      *fakeFloatTickReceiver
+	)
+
+	BeforeEach(func() {
+		floatStream = NewFloatStream()
+		first = &fakeFloatTickReceiver{}
+		second = &fakeFloatTickReceiver{}
+	})
+
+	It("should not have any subscribers", func() {
+		Expect(floatStream.TickSubscriberCount()).To(Equal(0))
+	})
+
+	Context("and subscribers are attached", func() {
+		BeforeEach(func() {
+			floatStream.AddTickSubscription(first)
+			floatStream.AddTickSubscription(second)
+			floatStream.PublishTick(1.5, 10)
+			floatStream.PublishTick(2.5, 11)
+		})
+
+		It("should notify every subscriber of each tick with its stream bar index", func() {
+			Expect(floatStream.TickSubscriberCount()).To(Equal(2))
+			Expect(first.ticks).To(Equal([]float64{1.5, 2.5}))
+			Expect(first.streamBarIndexs).To(Equal([]int{10, 11}))
+			Expect(second.ticks).To(Equal([]float64{1.5, 2.5}))
+			Expect(second.streamBarIndexs).To(Equal([]int{10, 11}))
+		})
+	})
+
+	Context("and a subscription is closed", func() {
+		BeforeEach(func() {
+			subscription := floatStream.AddTickSubscription(first)
+			floatStream.AddTickSubscription(second)
+			floatStream.PublishTick(1.5, 1)
+			subscription.Close()
+			subscription.Close()
+			floatStream.PublishTick(2.5, 2)
+		})
+
+		It("should stop notifying the detached subscriber", func() {
+			Expect(floatStream.TickSubscriberCount()).To(Equal(1))
+			Expect(first.ticks).To(Equal([]float64{1.5}))
+			Expect(second.ticks).To(Equal([]float64{1.5, 2.5}))
+		})
+	})
+})
@@ -0,0 +1,122 @@
+package frames
+
+import (
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/ipc"
+	"github.com/apache/arrow/go/arrow/memory"
+	"io"
+	"os"
+)
+
+const (
+	// the most rows in an arrow record batch
+	arrowBatchSize = 64 * 1024
+)
+
+// an arrowWriter writes record batches in either of the arrow ipc formats
+type arrowWriter interface {
+	Write(record array.Record) error
+	Close() error
+}
+
+// WriteArrowStream writes the frame to the writer in the Arrow IPC stream format. The dates are stored as UTC
+// timestamps in nanoseconds, the columns of the bars as doubles and the added columns as nullable doubles,
+// null for the bars without a value.
+func (f *Frame) WriteArrowStream(w io.Writer) (err error) {
+	allocator := memory.NewGoAllocator()
+	schema := f.arrowSchema()
+	return f.writeArrow(ipc.NewWriter(w, ipc.WithSchema(schema), ipc.WithAllocator(allocator)), schema, allocator)
+}
+
+// WriteArrowFile writes the frame to the writer in the Arrow IPC file format, which is also Feather version 2
+func (f *Frame) WriteArrowFile(w io.WriteSeeker) (err error) {
+	allocator := memory.NewGoAllocator()
+	schema := f.arrowSchema()
+	arrowFileWriter, err := ipc.NewFileWriter(w, ipc.WithSchema(schema), ipc.WithAllocator(allocator))
+	if err != nil {
+		return err
+	}
+	return f.writeArrow(arrowFileWriter, schema, allocator)
+}
+
+// WriteFeatherFile writes the frame to a file in the Arrow IPC file format, read in pandas by read_feather
+func (f *Frame) WriteFeatherFile(fileName string) (err error) {
+	file, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+
+	if err = f.WriteArrowFile(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func (f *Frame) arrowSchema() *arrow.Schema {
+	fields := []arrow.Field{{Name: barColumnNames[0], Type: arrow.FixedWidthTypes.Timestamp_ns}}
+	for _, name := range barColumnNames[1:] {
+		fields = append(fields, arrow.Field{Name: name, Type: arrow.PrimitiveTypes.Float64})
+	}
+
+	for _, column := range f.columns {
+		fields = append(fields, arrow.Field{Name: column.Name, Type: arrow.PrimitiveTypes.Float64, Nullable: true})
+	}
+	return arrow.NewSchema(fields, nil)
+}
+
+func (f *Frame) writeArrow(w arrowWriter, schema *arrow.Schema, allocator memory.Allocator) (err error) {
+	builder := array.NewRecordBuilder(allocator, schema)
+	defer builder.Release()
+
+	// a frame without bars is written as a single empty record batch
+	for start := 0; ; start += arrowBatchSize {
+		end := start + arrowBatchSize
+		if end > f.Len() {
+			end = f.Len()
+		}
+
+		f.appendArrowRows(builder, start, end)
+		record := builder.NewRecord()
+		err = w.Write(record)
+		record.Release()
+		if err != nil {
+			w.Close()
+			return err
+		}
+
+		if end == f.Len() {
+			return w.Close()
+		}
+	}
+}
+
+// appendArrowRows appends the rows of the bars from the start index up to, but excluding, the end index
+func (f *Frame) appendArrowRows(builder *array.RecordBuilder, start int, end int) {
+	dates := builder.Field(0).(*array.TimestampBuilder)
+	var prices [5]*array.Float64Builder
+	for i := range prices {
+		prices[i] = builder.Field(i + 1).(*array.Float64Builder)
+	}
+
+	for _, tick := range f.priceStream.Data[start:end] {
+		dates.Append(arrow.Timestamp(tick.D().UnixNano()))
+		prices[0].Append(tick.O())
+		prices[1].Append(tick.H())
+		prices[2].Append(tick.L())
+		prices[3].Append(tick.C())
+		prices[4].Append(tick.V())
+	}
+
+	for i, column := range f.columns {
+		values := builder.Field(len(barColumnNames) + i).(*array.Float64Builder)
+		for bar := start; bar < end; bar++ {
+			if value, ok := column.Value(bar); ok {
+				values.Append(value)
+			} else {
+				values.AppendNull()
+			}
+		}
+	}
+}
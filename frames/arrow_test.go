@@ -0,0 +1,109 @@
+package frames_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"bytes"
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/ipc"
+	"github.com/jaybutera/gotrade"
+	"github.com/jaybutera/gotrade/frames"
+	"github.com/jaybutera/gotrade/indicators"
+	"os"
+)
+
+var _ = Describe("when writing a frame to arrow", func() {
+	var (
+		frame *frames.Frame
+		macd  *indicators.Macd
+	)
+
+	// schemaNames returns the names of the fields of a schema
+	schemaNames := func(schema *arrow.Schema) []string {
+		names := make([]string, len(schema.Fields()))
+		for i, field := range schema.Fields() {
+			names[i] = field.Name
+		}
+		return names
+	}
+
+	// expectRecord expects the record to hold the rows of the frame
+	expectRecord := func(record array.Record) {
+		Expect(record.NumRows()).To(Equal(int64(frame.Len())))
+		Expect(schemaNames(record.Schema())).To(Equal(frame.Names()))
+
+		dates := record.Column(0).(*array.Timestamp)
+		closes := record.Column(4).(*array.Float64)
+		for bar, tick := range frame.Bars() {
+			Expect(int64(dates.Value(bar))).To(Equal(tick.D().UnixNano()))
+			Expect(closes.Value(bar)).To(Equal(tick.C()))
+		}
+
+		signal := record.Column(8).(*array.Float64)
+		Expect(signal.NullN()).To(Equal(macd.ValidFromBar() - 1))
+		Expect(signal.IsNull(macd.ValidFromBar() - 2)).To(BeTrue())
+		Expect(signal.Value(macd.ValidFromBar() - 1)).To(Equal(macd.Signal[0]))
+		Expect(signal.Value(frame.Len() - 1)).To(Equal(macd.Signal[len(macd.Signal)-1]))
+	}
+
+	BeforeEach(func() {
+		frame, _, macd, _ = indicatorFrame()
+	})
+
+	It("should write the rows in the stream format", func() {
+		var output bytes.Buffer
+		Expect(frame.WriteArrowStream(&output)).To(BeNil())
+
+		arrowReader, err := ipc.NewReader(&output)
+		Expect(err).To(BeNil())
+		defer arrowReader.Release()
+
+		Expect(arrowReader.Next()).To(BeTrue())
+		expectRecord(arrowReader.Record())
+		Expect(arrowReader.Next()).To(BeFalse())
+	})
+
+	It("should write the rows in the file format", func() {
+		file, err := os.CreateTemp("", "frame*.feather")
+		Expect(err).To(BeNil())
+		file.Close()
+		defer os.Remove(file.Name())
+
+		Expect(frame.WriteFeatherFile(file.Name())).To(BeNil())
+
+		file, _ = os.Open(file.Name())
+		defer file.Close()
+		arrowReader, err := ipc.NewFileReader(file)
+		Expect(err).To(BeNil())
+		defer arrowReader.Close()
+
+		Expect(arrowReader.NumRecords()).To(Equal(1))
+		record, err := arrowReader.Record(0)
+		Expect(err).To(BeNil())
+		expectRecord(record)
+	})
+
+	It("should mark the added columns as nullable and the dates as UTC timestamps", func() {
+		var output bytes.Buffer
+		frame.WriteArrowStream(&output)
+		arrowReader, _ := ipc.NewReader(&output)
+		defer arrowReader.Release()
+
+		schema := arrowReader.Schema()
+		Expect(schema.Field(4).Nullable).To(BeFalse())
+		Expect(schema.Field(6).Nullable).To(BeTrue())
+		Expect(schema.Field(0).Type).To(Equal(arrow.FixedWidthTypes.Timestamp_ns))
+	})
+
+	It("should write a frame without bars", func() {
+		var output bytes.Buffer
+		Expect(frames.NewFrame(gotrade.NewDOHLCVStream()).WriteArrowStream(&output)).To(BeNil())
+
+		arrowReader, err := ipc.NewReader(&output)
+		Expect(err).To(BeNil())
+		defer arrowReader.Release()
+		Expect(arrowReader.Next()).To(BeTrue())
+		Expect(arrowReader.Record().NumRows()).To(Equal(int64(0)))
+	})
+})
@@ -0,0 +1,158 @@
+/*
+	import "github.com/jaybutera/gotrade/frames"
+
+	Package frames exports the bars of a price stream, and the output of the indicators attached to it, as
+	columns aligned on the date of each bar, e.g. for research in pandas.
+	A frame:
+		- has the Date, Open, High, Low, Close and Volume columns of the bars.
+		- aligns the values of each indicator column from the bar the indicator is valid from, the bars
+		- before it having no value.
+		- writes the columns to Parquet, or to the Arrow IPC file (Feather) or stream formats.
+
+	The columns are added once the stream has been filled, e.g.
+
+		priceStream := gotrade.NewDailyDOHLCVStream()
+		sma, err := indicators.NewSmaForStream(priceStream, 20, gotrade.UseClosePrice)
+		macd, err := indicators.NewMacdForStream(priceStream, 12, 26, 9, gotrade.UseClosePrice)
+		err = csvFeed.FillDOHLCVStream(priceStream)
+
+		frame := frames.NewFrame(priceStream.DOHLCVStream)
+		err = frame.AddIndicator("Sma 20", sma, sma.Data)
+		err = frame.AddIndicator("Macd", macd, macd.Macd)
+		err = frame.AddIndicator("Macd Signal", macd, macd.Signal)
+		err = frame.WriteParquet(file)
+
+	A Parquet file of bars, such as one written by a frame or by pandas, is read back as a feed by a ParquetFileFeed.
+*/
+package frames
+
+import (
+	"errors"
+	"github.com/jaybutera/gotrade"
+	"github.com/jaybutera/gotrade/indicators"
+	"math"
+	"strings"
+)
+
+var (
+	ErrColumnNameIsEmpty        = errors.New("column name must not be empty")
+	ErrColumnNameIsInvalid      = errors.New("column name must not contain ',' or '=', or start or end with a space")
+	ErrColumnNameRepeated       = errors.New("column name has already been added")
+	ErrValidFromBarTooSmall     = errors.New("validFromBar is less than the minimum (1)")
+	ErrColumnIsLongerThanStream = errors.New("column has more values than the stream has bars from its valid from bar")
+)
+
+// the names of the columns of the bars
+var barColumnNames = [6]string{"Date", "Open", "High", "Low", "Close", "Volume"}
+
+// A Column holds the values of an indicator output from the bar the indicator is valid from
+type Column struct {
+	Name string
+	// the stream bar number of the first value, starting at bar 1
+	ValidFromBar int
+	Values       []float64
+}
+
+// Value returns the value of the column for a bar of the stream, indexed from 0, and whether it has one
+func (column *Column) Value(bar int) (value float64, ok bool) {
+	index := bar - (column.ValidFromBar - 1)
+	if index < 0 || index >= len(column.Values) {
+		return math.NaN(), false
+	}
+	return column.Values[index], true
+}
+
+// A Frame holds the bars of a price stream and the columns aligned to them
+type Frame struct {
+	priceStream *gotrade.DOHLCVStream
+	columns     []*Column
+}
+
+// NewFrame creates a frame of the bars of the price stream
+func NewFrame(priceStream *gotrade.DOHLCVStream) *Frame {
+	return &Frame{priceStream: priceStream}
+}
+
+// Len returns the number of bars, and so rows, of the frame
+func (f *Frame) Len() int {
+	return len(f.priceStream.Data)
+}
+
+// Bars returns the bars of the frame
+func (f *Frame) Bars() []gotrade.DOHLCV {
+	return f.priceStream.Data
+}
+
+// Columns returns the columns added to the frame, in the order they were added
+func (f *Frame) Columns() []*Column {
+	return f.columns
+}
+
+// Names returns the names of every column of the frame, starting with the columns of the bars
+func (f *Frame) Names() []string {
+	names := append([]string{}, barColumnNames[:]...)
+	for _, column := range f.columns {
+		names = append(names, column.Name)
+	}
+	return names
+}
+
+// AddColumn adds the values of a column starting at the stream bar number validFromBar, the values are
+// shared, not copied. A column without values may have a validFromBar of -1, as an indicator has before
+// it is valid.
+func (f *Frame) AddColumn(name string, validFromBar int, values []float64) (err error) {
+	if err = f.validateName(name); err != nil {
+		return err
+	}
+
+	if len(values) > 0 && validFromBar < 1 {
+		return ErrValidFromBarTooSmall
+	}
+
+	if len(values) > 0 && validFromBar-1+len(values) > f.Len() {
+		return ErrColumnIsLongerThanStream
+	}
+
+	f.columns = append(f.columns, &Column{Name: name, ValidFromBar: validFromBar, Values: values})
+	return nil
+}
+
+// AddIndicator adds the values of an output of an indicator, aligned from the bar the indicator is valid from
+func (f *Frame) AddIndicator(name string, indicator indicators.Indicator, values []float64) (err error) {
+	return f.AddColumn(name, indicator.ValidFromBar(), values)
+}
+
+// Values returns the values of a column added to the frame for every bar, NaN for a bar without a value
+func (f *Frame) Values(name string) (values []float64, ok bool) {
+	for _, column := range f.columns {
+		if column.Name != name {
+			continue
+		}
+
+		values = make([]float64, f.Len())
+		for bar := range values {
+			values[bar], _ = column.Value(bar)
+		}
+		return values, true
+	}
+	return nil, false
+}
+
+func (f *Frame) validateName(name string) error {
+	if name == "" {
+		return ErrColumnNameIsEmpty
+	}
+
+	// the parquet schema is described by comma separated key=value pairs, with surrounding spaces trimmed
+	if strings.ContainsAny(name, ",=") || strings.TrimSpace(name) != name {
+		return ErrColumnNameIsInvalid
+	}
+
+	// names are compared ignoring case, as most readers of the files do
+	for _, existing := range f.Names() {
+		if strings.EqualFold(existing, name) {
+			return ErrColumnNameRepeated
+		}
+	}
+	return nil
+}
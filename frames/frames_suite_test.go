@@ -0,0 +1,39 @@
+package frames_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/jaybutera/gotrade"
+	"github.com/jaybutera/gotrade/feeds"
+	"github.com/jaybutera/gotrade/frames"
+	"github.com/jaybutera/gotrade/indicators"
+	"testing"
+	"time"
+)
+
+func TestFrames(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Frames Suite")
+}
+
+// indicatorFrame fills a daily stream with a years data and returns a frame of it with the outputs of a
+// simple moving average, a macd and an aroon
+func indicatorFrame() (frame *frames.Frame, sma *indicators.Sma, macd *indicators.Macd, aroon *indicators.Aroon) {
+	priceStream := gotrade.NewDailyDOHLCVStream()
+	sma, _ = indicators.NewSmaForStream(priceStream, 10, gotrade.UseClosePrice)
+	macd, _ = indicators.NewMacdForStream(priceStream, 12, 26, 9, gotrade.UseClosePrice)
+	aroon, _ = indicators.NewAroonForStream(priceStream, 14)
+
+	csvFeed := feeds.NewCSVFileFeedWithDOHLCVFormat("../testdata/JSETOPI.2013.data",
+		feeds.DashedYearMonthDayDateParserForLocation(time.UTC))
+	Expect(csvFeed.FillDOHLCVStream(priceStream)).To(BeNil())
+
+	frame = frames.NewFrame(priceStream.DOHLCVStream)
+	Expect(frame.AddIndicator("Sma 10", sma, sma.Data)).To(BeNil())
+	Expect(frame.AddIndicator("Macd", macd, macd.Macd)).To(BeNil())
+	Expect(frame.AddIndicator("Macd Signal", macd, macd.Signal)).To(BeNil())
+	Expect(frame.AddIndicator("Macd Histogram", macd, macd.Histogram)).To(BeNil())
+	Expect(frame.AddIndicator("Aroon Up", aroon, aroon.Up)).To(BeNil())
+	Expect(frame.AddIndicator("Aroon Down", aroon, aroon.Down)).To(BeNil())
+	return frame, sma, macd, aroon
+}
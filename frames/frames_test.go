@@ -0,0 +1,116 @@
+package frames_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/jaybutera/gotrade"
+	"github.com/jaybutera/gotrade/frames"
+	"github.com/jaybutera/gotrade/indicators"
+	"math"
+	"time"
+)
+
+var _ = Describe("when aligning indicator output with the bars of a stream", func() {
+	var (
+		frame *frames.Frame
+		sma   *indicators.Sma
+		macd  *indicators.Macd
+		aroon *indicators.Aroon
+	)
+
+	BeforeEach(func() {
+		frame, sma, macd, aroon = indicatorFrame()
+	})
+
+	It("should name the columns of the bars then the added columns", func() {
+		Expect(frame.Names()).To(Equal([]string{"Date", "Open", "High", "Low", "Close", "Volume",
+			"Sma 10", "Macd", "Macd Signal", "Macd Histogram", "Aroon Up", "Aroon Down"}))
+		Expect(frame.Columns()).To(HaveLen(6))
+	})
+
+	It("should have no value for the bars before an indicator is valid", func() {
+		values, ok := frame.Values("Sma 10")
+		Expect(ok).To(BeTrue())
+		Expect(values).To(HaveLen(frame.Len()))
+
+		for bar := 0; bar < sma.ValidFromBar()-1; bar++ {
+			Expect(math.IsNaN(values[bar])).To(BeTrue())
+		}
+	})
+
+	It("should align the first value of an indicator with the bar it is valid from", func() {
+		values, _ := frame.Values("Sma 10")
+		Expect(values[sma.ValidFromBar()-1]).To(Equal(sma.Data[0]))
+		Expect(values[frame.Len()-1]).To(Equal(sma.Data[len(sma.Data)-1]))
+
+		total := 0.0
+		for _, bar := range frame.Bars()[:10] {
+			total += bar.C()
+		}
+		Expect(values[9]).To(BeNumerically("~", total/10.0, 0.0001))
+	})
+
+	It("should align every output of an indicator with many outputs", func() {
+		signal, _ := frame.Values("Macd Signal")
+		histogram, _ := frame.Values("Macd Histogram")
+		Expect(signal[macd.ValidFromBar()-1:]).To(Equal(macd.Signal))
+		Expect(histogram[macd.ValidFromBar()-1:]).To(Equal(macd.Histogram))
+
+		up, _ := frame.Values("Aroon Up")
+		Expect(up[aroon.ValidFromBar()-1:]).To(Equal(aroon.Up))
+	})
+
+	It("should return the value of a column for a bar", func() {
+		column := frame.Columns()[0]
+		_, ok := column.Value(sma.ValidFromBar() - 2)
+		Expect(ok).To(BeFalse())
+
+		value, ok := column.Value(sma.ValidFromBar() - 1)
+		Expect(ok).To(BeTrue())
+		Expect(value).To(Equal(sma.Data[0]))
+
+		_, ok = column.Value(frame.Len())
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should not find a column that was not added", func() {
+		_, ok := frame.Values("Ema 10")
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should add a column of an indicator that is not yet valid", func() {
+		priceStream := gotrade.NewDOHLCVStream()
+		ema, _ := indicators.NewEmaForStream(priceStream, 10, gotrade.UseClosePrice)
+		priceStream.ReceiveTick(gotrade.NewDOHLCVDataItem(time.Now(), 1, 1, 1, 1, 1))
+
+		emptyFrame := frames.NewFrame(priceStream)
+		Expect(emptyFrame.AddIndicator("Ema 10", ema, ema.Data)).To(BeNil())
+		values, _ := emptyFrame.Values("Ema 10")
+		Expect(math.IsNaN(values[0])).To(BeTrue())
+	})
+
+	Context("and the column is invalid", func() {
+		It("should return an error for an empty name", func() {
+			Expect(frame.AddColumn("", 1, nil)).To(Equal(frames.ErrColumnNameIsEmpty))
+		})
+
+		It("should return an error for a name that cannot be stored", func() {
+			Expect(frame.AddColumn("Sma,20", 1, nil)).To(Equal(frames.ErrColumnNameIsInvalid))
+			Expect(frame.AddColumn("Sma=20", 1, nil)).To(Equal(frames.ErrColumnNameIsInvalid))
+			Expect(frame.AddColumn(" Sma 20", 1, nil)).To(Equal(frames.ErrColumnNameIsInvalid))
+		})
+
+		It("should return an error for a repeated name ignoring case", func() {
+			Expect(frame.AddColumn("sma 10", 1, nil)).To(Equal(frames.ErrColumnNameRepeated))
+			Expect(frame.AddColumn("close", 1, nil)).To(Equal(frames.ErrColumnNameRepeated))
+		})
+
+		It("should return an error for values before the first bar", func() {
+			Expect(frame.AddColumn("Sma 20", 0, []float64{1.0})).To(Equal(frames.ErrValidFromBarTooSmall))
+		})
+
+		It("should return an error for values after the last bar", func() {
+			Expect(frame.AddColumn("Sma 20", frame.Len(), []float64{1.0, 2.0})).To(Equal(frames.ErrColumnIsLongerThanStream))
+		})
+	})
+})
@@ -0,0 +1,67 @@
+package frames
+
+import (
+	"fmt"
+	"github.com/xitongsys/parquet-go/writer"
+	"io"
+	"os"
+)
+
+// WriteParquet writes the frame to the writer as a Parquet file. The dates are stored as UTC timestamps
+// in microseconds, the columns of the bars as required doubles and the added columns as optional doubles,
+// null for the bars without a value.
+func (f *Frame) WriteParquet(w io.Writer) (err error) {
+	parquetWriter, err := writer.NewCSVWriterFromWriter(f.parquetSchema(), w, 1)
+	if err != nil {
+		return err
+	}
+
+	for bar, tick := range f.priceStream.Data {
+		// the writer keeps each row until it is flushed, so a row cannot be reused
+		row := make([]interface{}, len(barColumnNames)+len(f.columns))
+		row[0] = tick.D().UnixNano() / 1000
+		row[1], row[2], row[3], row[4], row[5] = tick.O(), tick.H(), tick.L(), tick.C(), tick.V()
+
+		for i, column := range f.columns {
+			if value, ok := column.Value(bar); ok {
+				row[len(barColumnNames)+i] = value
+			}
+		}
+
+		if err = parquetWriter.Write(row); err != nil {
+			return err
+		}
+	}
+	return parquetWriter.WriteStop()
+}
+
+// WriteParquetFile writes the frame to a Parquet file
+func (f *Frame) WriteParquetFile(fileName string) (err error) {
+	file, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+
+	if err = f.WriteParquet(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// parquetSchema returns the metadata of each column, naming the columns in the writer by their index so
+// that names that are not identifiers cannot collide
+func (f *Frame) parquetSchema() []string {
+	schema := []string{fmt.Sprintf("name=%s, inname=Column0, type=INT64, convertedtype=TIMESTAMP_MICROS, "+
+		"logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=MICROS", barColumnNames[0])}
+
+	for i, name := range barColumnNames[1:] {
+		schema = append(schema, fmt.Sprintf("name=%s, inname=Column%d, type=DOUBLE", name, i+1))
+	}
+
+	for i, column := range f.columns {
+		schema = append(schema, fmt.Sprintf("name=%s, inname=Column%d, type=DOUBLE, repetitiontype=OPTIONAL",
+			column.Name, len(barColumnNames)+i))
+	}
+	return schema
+}
@@ -0,0 +1,121 @@
+package frames_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"bytes"
+	"github.com/jaybutera/gotrade"
+	"github.com/jaybutera/gotrade/feeds"
+	"github.com/jaybutera/gotrade/frames"
+	"github.com/jaybutera/gotrade/indicators"
+	"github.com/xitongsys/parquet-go-source/buffer"
+	"github.com/xitongsys/parquet-go/reader"
+	"os"
+	"time"
+)
+
+var _ = Describe("when writing a frame to parquet", func() {
+	var (
+		frame *frames.Frame
+		sma   *indicators.Sma
+		data  []byte
+	)
+
+	// readColumn reads a column of the parquet data by its index
+	readColumn := func(index int) []interface{} {
+		file, err := buffer.NewBufferFile(data)
+		Expect(err).To(BeNil())
+		parquetReader, err := reader.NewParquetColumnReader(file, 1)
+		Expect(err).To(BeNil())
+		defer parquetReader.ReadStop()
+
+		values, _, _, err := parquetReader.ReadColumnByIndex(int64(index), parquetReader.GetNumRows())
+		Expect(err).To(BeNil())
+		return values
+	}
+
+	BeforeEach(func() {
+		frame, sma, _, _ = indicatorFrame()
+
+		var output bytes.Buffer
+		Expect(frame.WriteParquet(&output)).To(BeNil())
+		data = output.Bytes()
+	})
+
+	It("should keep the names of the columns", func() {
+		file, _ := buffer.NewBufferFile(data)
+		parquetReader, err := reader.NewParquetColumnReader(file, 1)
+		Expect(err).To(BeNil())
+		Expect(parquetReader.GetNumRows()).To(Equal(int64(frame.Len())))
+
+		names := make([]string, len(parquetReader.SchemaHandler.ValueColumns))
+		for i := range names {
+			names[i] = parquetReader.SchemaHandler.GetExName(i + 1)
+		}
+		Expect(names).To(Equal(frame.Names()))
+	})
+
+	It("should write null for the bars before an indicator is valid", func() {
+		values := readColumn(6)
+		Expect(values).To(HaveLen(frame.Len()))
+		for bar := 0; bar < sma.ValidFromBar()-1; bar++ {
+			Expect(values[bar]).To(BeNil())
+		}
+
+		for i, value := range sma.Data {
+			Expect(values[sma.ValidFromBar()-1+i]).To(Equal(value))
+		}
+	})
+
+	It("should write the dates as microseconds since 1970", func() {
+		values := readColumn(0)
+		Expect(values[0]).To(Equal(frame.Bars()[0].D().UnixNano() / 1000))
+	})
+
+	It("should read the bars back as a feed", func() {
+		file, err := os.CreateTemp("", "frame*.parquet")
+		Expect(err).To(BeNil())
+		file.Write(data)
+		file.Close()
+		defer os.Remove(file.Name())
+
+		feed := frames.NewParquetFileFeed(file.Name(), feeds.DefaultCSVColumnNames())
+		priceStream := gotrade.NewDOHLCVStream()
+		Expect(feed.FillDOHLCVStream(priceStream)).To(BeNil())
+		Expect(priceStream.Data).To(Equal(frame.Bars()))
+	})
+
+	It("should write a parquet file", func() {
+		file, _ := os.CreateTemp("", "frame*.parquet")
+		file.Close()
+		defer os.Remove(file.Name())
+
+		Expect(frame.WriteParquetFile(file.Name())).To(BeNil())
+		written, _ := os.ReadFile(file.Name())
+		Expect(written).To(Equal(data))
+	})
+
+	It("should write a frame without bars", func() {
+		emptyFrame := frames.NewFrame(gotrade.NewDOHLCVStream())
+		Expect(emptyFrame.AddColumn("Sma 10", -1, nil)).To(BeNil())
+
+		var output bytes.Buffer
+		Expect(emptyFrame.WriteParquet(&output)).To(BeNil())
+
+		file, _ := buffer.NewBufferFile(output.Bytes())
+		parquetReader, err := reader.NewParquetColumnReader(file, 1)
+		Expect(err).To(BeNil())
+		Expect(parquetReader.GetNumRows()).To(Equal(int64(0)))
+	})
+
+	It("should write dates in any location as the same instant", func() {
+		johannesburg, _ := time.LoadLocation("Africa/Johannesburg")
+		priceStream := gotrade.NewDOHLCVStream()
+		priceStream.ReceiveTick(gotrade.NewDOHLCVDataItem(time.Date(2014, 1, 2, 9, 0, 0, 0, johannesburg), 1, 2, 0.5, 1.5, 100))
+
+		var output bytes.Buffer
+		Expect(frames.NewFrame(priceStream).WriteParquet(&output)).To(BeNil())
+		data = output.Bytes()
+		Expect(readColumn(0)[0]).To(Equal(time.Date(2014, 1, 2, 7, 0, 0, 0, time.UTC).UnixNano() / 1000))
+	})
+})
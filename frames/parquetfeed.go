@@ -0,0 +1,251 @@
+package frames
+
+import (
+	"errors"
+	"fmt"
+	"github.com/jaybutera/gotrade"
+	"github.com/jaybutera/gotrade/feeds"
+	"github.com/xitongsys/parquet-go-source/local"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/reader"
+	"github.com/xitongsys/parquet-go/schema"
+	"github.com/xitongsys/parquet-go/types"
+	"time"
+)
+
+const (
+	// the rows read from each column at a time
+	parquetBatchSize = 64 * 1024
+)
+
+var (
+	ErrParquetNestedColumns  = errors.New("the parquet file has nested columns, only flat files are supported")
+	ErrParquetColumnType     = errors.New("the parquet column type is not supported")
+	ErrParquetValueIsMissing = errors.New("the value is missing")
+	ErrParquetColumnTooShort = errors.New("the column has fewer values than the file has rows")
+)
+
+// A ParquetFileFeed reads bars from a flat parquet file, e.g. one written by pandas. The dates may be
+// timestamps, legacy INT96 timestamps or dates, and the prices and volume may be floating point or
+// integer columns.
+type ParquetFileFeed struct {
+	fileName    string
+	columnNames feeds.CSVColumnNames
+	location    *time.Location
+}
+
+// NewParquetFileFeed creates a feed of the parquet file, finding the columns by name as for the header of a csv
+// file. An empty name marks a field as absent from the file, leaving it zero in the bars.
+func NewParquetFileFeed(fileName string, columnNames feeds.CSVColumnNames) *ParquetFileFeed {
+	return &ParquetFileFeed{fileName: fileName, columnNames: columnNames, location: time.UTC}
+}
+
+// FileName returns the name of the parquet file
+func (parquetFF *ParquetFileFeed) FileName() string {
+	return parquetFF.fileName
+}
+
+// SetLocation sets the location of the bar dates, UTC by default. Timestamps stored as instants are converted
+// to the location and timestamps without a zone, as pandas writes naive datetimes, are read as times in it.
+func (parquetFF *ParquetFileFeed) SetLocation(location *time.Location) {
+	parquetFF.location = location
+}
+
+func (parquetFF *ParquetFileFeed) FillDOHLCVStream(priceStream gotrade.DOHLCVStreamTickReceiver) (err error) {
+	file, err := local.NewLocalFileReader(parquetFF.fileName)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	parquetReader, err := reader.NewParquetColumnReader(file, 1)
+	if err != nil {
+		return err
+	}
+	defer parquetReader.ReadStop()
+
+	columns, err := parquetFF.columns(parquetReader.SchemaHandler)
+	if err != nil {
+		return err
+	}
+
+	rows := parquetReader.GetNumRows()
+	var values [6][]interface{}
+	for row := int64(0); row < rows; row += parquetBatchSize {
+		batch := rows - row
+		if batch > parquetBatchSize {
+			batch = parquetBatchSize
+		}
+
+		for i, column := range columns {
+			if column == nil {
+				continue
+			}
+
+			if values[i], _, _, err = parquetReader.ReadColumnByIndex(int64(column.index), batch); err != nil {
+				return err
+			}
+
+			if int64(len(values[i])) != batch {
+				return fmt.Errorf("%s column %q: %w", column.field, column.name, ErrParquetColumnTooShort)
+			}
+		}
+
+		for j := int64(0); j < batch; j++ {
+			bar, err := parquetFF.bar(columns, values, int(j))
+			if err != nil {
+				return fmt.Errorf("row %d: %w", row+j+1, err)
+			}
+			priceStream.ReceiveTick(bar)
+		}
+	}
+	return nil
+}
+
+// a parquetColumn is a column of a field of the bars
+type parquetColumn struct {
+	field   string
+	name    string
+	index   int
+	element *parquet.SchemaElement
+}
+
+// columns finds the column of each field of the bars, nil for an absent field
+func (parquetFF *ParquetFileFeed) columns(schemaHandler *schema.SchemaHandler) (columns [6]*parquetColumn, err error) {
+	elements := schemaHandler.SchemaElements[1:]
+	if len(elements) != len(schemaHandler.ValueColumns) {
+		return columns, ErrParquetNestedColumns
+	}
+
+	header := make([]string, len(elements))
+	for i := range elements {
+		header[i] = schemaHandler.GetExName(i + 1)
+	}
+
+	columnIndexes, err := parquetFF.columnNames.Indexes(header)
+	if err != nil {
+		return columns, err
+	}
+
+	fields := [6]string{"date", "open", "high", "low", "close", "volume"}
+	for i, index := range columnIndexes {
+		if index == -1 {
+			continue
+		}
+
+		column := &parquetColumn{field: fields[i], name: header[index], index: index, element: elements[index]}
+		if !column.supported() {
+			return columns, fmt.Errorf("%s column %q: %w", column.field, column.name, ErrParquetColumnType)
+		}
+		columns[i] = column
+	}
+	return columns, nil
+}
+
+func (column *parquetColumn) supported() bool {
+	switch column.element.GetType() {
+	case parquet.Type_INT32:
+		if column.field == "date" {
+			return column.element.IsSetConvertedType() && column.element.GetConvertedType() == parquet.ConvertedType_DATE
+		}
+		return !column.element.IsSetConvertedType() || column.element.GetConvertedType() != parquet.ConvertedType_DECIMAL
+	case parquet.Type_INT64:
+		if column.field == "date" {
+			_, ok := column.timestampUnit()
+			return ok
+		}
+		return !column.element.IsSetConvertedType() || column.element.GetConvertedType() != parquet.ConvertedType_DECIMAL
+	case parquet.Type_INT96:
+		return column.field == "date"
+	case parquet.Type_FLOAT, parquet.Type_DOUBLE:
+		return column.field != "date"
+	}
+	return false
+}
+
+// timestampUnit returns the duration of a unit of a timestamp column
+func (column *parquetColumn) timestampUnit() (unit time.Duration, ok bool) {
+	if logicalType := column.element.GetLogicalType(); logicalType != nil && logicalType.IsSetTIMESTAMP() {
+		switch timeUnit := logicalType.GetTIMESTAMP().GetUnit(); {
+		case timeUnit.IsSetMILLIS():
+			return time.Millisecond, true
+		case timeUnit.IsSetMICROS():
+			return time.Microsecond, true
+		case timeUnit.IsSetNANOS():
+			return time.Nanosecond, true
+		}
+	}
+
+	if column.element.IsSetConvertedType() {
+		switch column.element.GetConvertedType() {
+		case parquet.ConvertedType_TIMESTAMP_MILLIS:
+			return time.Millisecond, true
+		case parquet.ConvertedType_TIMESTAMP_MICROS:
+			return time.Microsecond, true
+		}
+	}
+	return 0, false
+}
+
+// adjustedToUTC returns whether a timestamp column holds instants rather than times without a zone
+func (column *parquetColumn) adjustedToUTC() bool {
+	if logicalType := column.element.GetLogicalType(); logicalType != nil && logicalType.IsSetTIMESTAMP() {
+		return logicalType.GetTIMESTAMP().GetIsAdjustedToUTC()
+	}
+	// converted types and INT96 timestamps are instants
+	return true
+}
+
+func (parquetFF *ParquetFileFeed) bar(columns [6]*parquetColumn, values [6][]interface{}, row int) (bar gotrade.DOHLCV, err error) {
+	var date time.Time
+	var prices [5]float64
+	for i, column := range columns {
+		if column == nil {
+			continue
+		}
+
+		value := values[i][row]
+		if value == nil {
+			return nil, fmt.Errorf("%s column %q: %w", column.field, column.name, ErrParquetValueIsMissing)
+		}
+
+		if i == 0 {
+			date = parquetFF.date(column, value)
+			continue
+		}
+
+		switch number := value.(type) {
+		case float64:
+			prices[i-1] = number
+		case float32:
+			prices[i-1] = float64(number)
+		case int64:
+			prices[i-1] = float64(number)
+		case int32:
+			prices[i-1] = float64(number)
+		}
+	}
+	return gotrade.NewDOHLCVDataItem(date, prices[0], prices[1], prices[2], prices[3], prices[4]), nil
+}
+
+func (parquetFF *ParquetFileFeed) date(column *parquetColumn, value interface{}) time.Time {
+	var date time.Time
+	switch stored := value.(type) {
+	case int32:
+		// the days since 1970-01-01
+		days := time.Unix(int64(stored)*24*60*60, 0).UTC()
+		return time.Date(days.Year(), days.Month(), days.Day(), 0, 0, 0, 0, parquetFF.location)
+	case string:
+		date = types.INT96ToTime(stored)
+	case int64:
+		unit, _ := column.timestampUnit()
+		date = time.Unix(0, 0).Add(time.Duration(stored) * unit)
+	}
+
+	if column.adjustedToUTC() {
+		return date.In(parquetFF.location)
+	}
+
+	date = date.UTC()
+	return time.Date(date.Year(), date.Month(), date.Day(), date.Hour(), date.Minute(), date.Second(), date.Nanosecond(), parquetFF.location)
+}
@@ -0,0 +1,179 @@
+package frames_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"errors"
+	"github.com/jaybutera/gotrade"
+	"github.com/jaybutera/gotrade/feeds"
+	"github.com/jaybutera/gotrade/frames"
+	"github.com/xitongsys/parquet-go/types"
+	"github.com/xitongsys/parquet-go/writer"
+	"os"
+	"time"
+)
+
+var _ = Describe("when reading a parquet file", func() {
+	var (
+		fileName    string
+		priceStream *gotrade.DOHLCVStream
+		newYork     *time.Location
+	)
+
+	// writeParquet writes the rows to the file read by the feed, with the schema of each column
+	writeParquet := func(schema []string, rows ...[]interface{}) {
+		file, err := os.CreateTemp("", "parquetfeed*.parquet")
+		Expect(err).To(BeNil())
+		defer file.Close()
+
+		parquetWriter, err := writer.NewCSVWriterFromWriter(schema, file, 1)
+		Expect(err).To(BeNil())
+		for _, row := range rows {
+			Expect(parquetWriter.Write(row)).To(BeNil())
+		}
+		Expect(parquetWriter.WriteStop()).To(BeNil())
+		fileName = file.Name()
+	}
+
+	// the schema pandas writes for a frame of bars with a naive datetime index and integer volumes
+	pandasSchema := []string{
+		"name=date, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=false, logicaltype.unit=NANOS",
+		"name=open, type=DOUBLE, repetitiontype=OPTIONAL",
+		"name=high, type=DOUBLE, repetitiontype=OPTIONAL",
+		"name=low, type=DOUBLE, repetitiontype=OPTIONAL",
+		"name=close, type=DOUBLE, repetitiontype=OPTIONAL",
+		"name=volume, type=INT64, repetitiontype=OPTIONAL",
+	}
+
+	BeforeEach(func() {
+		priceStream = gotrade.NewDOHLCVStream()
+		newYork, _ = time.LoadLocation("America/New_York")
+	})
+
+	AfterEach(func() {
+		os.Remove(fileName)
+	})
+
+	It("should read the bars of a file written by pandas", func() {
+		writeParquet(pandasSchema,
+			[]interface{}{time.Date(2014, 1, 31, 9, 30, 0, 0, time.UTC).UnixNano(), 10.0, 11.0, 9.0, 10.5, int64(1000)},
+			[]interface{}{time.Date(2014, 1, 31, 9, 31, 0, 0, time.UTC).UnixNano(), 10.5, 12.0, 10.0, 11.5, int64(2000)})
+
+		feed := frames.NewParquetFileFeed(fileName, feeds.DefaultCSVColumnNames())
+		Expect(feed.FileName()).To(Equal(fileName))
+		Expect(feed.FillDOHLCVStream(priceStream)).To(BeNil())
+
+		Expect(priceStream.Data).To(HaveLen(2))
+		bar := priceStream.Data[1]
+		Expect(bar.D()).To(Equal(time.Date(2014, 1, 31, 9, 31, 0, 0, time.UTC)))
+		Expect([]float64{bar.O(), bar.H(), bar.L(), bar.C(), bar.V()}).To(Equal([]float64{10.5, 12.0, 10.0, 11.5, 2000.0}))
+	})
+
+	It("should read timestamps without a zone as times in the location of the feed", func() {
+		writeParquet(pandasSchema,
+			[]interface{}{time.Date(2014, 1, 31, 9, 30, 0, 0, time.UTC).UnixNano(), 10.0, 11.0, 9.0, 10.5, int64(1000)})
+
+		feed := frames.NewParquetFileFeed(fileName, feeds.DefaultCSVColumnNames())
+		feed.SetLocation(newYork)
+		Expect(feed.FillDOHLCVStream(priceStream)).To(BeNil())
+		Expect(priceStream.Data[0].D()).To(Equal(time.Date(2014, 1, 31, 9, 30, 0, 0, newYork)))
+	})
+
+	It("should convert timestamps of instants to the location of the feed", func() {
+		writeParquet([]string{
+			"name=Date, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=MILLIS",
+			"name=Close, type=FLOAT"},
+			[]interface{}{time.Date(2014, 1, 31, 14, 30, 0, 0, time.UTC).UnixNano() / 1e6, float32(10.5)})
+
+		feed := frames.NewParquetFileFeed(fileName, feeds.CSVColumnNames{Date: "Date", Close: "Close"})
+		feed.SetLocation(newYork)
+		Expect(feed.FillDOHLCVStream(priceStream)).To(BeNil())
+
+		bar := priceStream.Data[0]
+		Expect(bar.D()).To(Equal(time.Date(2014, 1, 31, 9, 30, 0, 0, newYork)))
+		Expect(bar.C()).To(Equal(10.5))
+		Expect(bar.V()).To(Equal(0.0))
+	})
+
+	It("should read legacy INT96 timestamps", func() {
+		writeParquet([]string{
+			"name=Date, type=INT96",
+			"name=Close, type=INT32"},
+			[]interface{}{types.TimeToINT96(time.Date(2014, 1, 31, 14, 30, 0, 0, time.UTC)), int32(10)})
+
+		feed := frames.NewParquetFileFeed(fileName, feeds.CSVColumnNames{Date: "Date", Close: "Close"})
+		Expect(feed.FillDOHLCVStream(priceStream)).To(BeNil())
+		Expect(priceStream.Data[0].D()).To(Equal(time.Date(2014, 1, 31, 14, 30, 0, 0, time.UTC)))
+		Expect(priceStream.Data[0].C()).To(Equal(10.0))
+	})
+
+	It("should read dates as midnight in the location of the feed", func() {
+		days := int32(time.Date(2014, 1, 31, 0, 0, 0, 0, time.UTC).Unix() / (24 * 60 * 60))
+		writeParquet([]string{
+			"name=Date, type=INT32, convertedtype=DATE",
+			"name=Close, type=DOUBLE"},
+			[]interface{}{days, 10.5})
+
+		feed := frames.NewParquetFileFeed(fileName, feeds.CSVColumnNames{Date: "Date", Close: "Close"})
+		feed.SetLocation(newYork)
+		Expect(feed.FillDOHLCVStream(priceStream)).To(BeNil())
+		Expect(priceStream.Data[0].D()).To(Equal(time.Date(2014, 1, 31, 0, 0, 0, 0, newYork)))
+	})
+
+	It("should read more rows than are read from a column at a time", func() {
+		rows := make([][]interface{}, 70000)
+		for i := range rows {
+			rows[i] = []interface{}{int64(i) * int64(time.Minute), 10.0, 11.0, 9.0, float64(i), int64(i)}
+		}
+		writeParquet(pandasSchema, rows...)
+
+		feed := frames.NewParquetFileFeed(fileName, feeds.DefaultCSVColumnNames())
+		Expect(feed.FillDOHLCVStream(priceStream)).To(BeNil())
+		Expect(priceStream.Data).To(HaveLen(len(rows)))
+		Expect(priceStream.Data[69999].C()).To(Equal(69999.0))
+		Expect(priceStream.Data[69999].D()).To(Equal(time.Unix(0, 69999*int64(time.Minute)).UTC()))
+	})
+
+	Context("that cannot be read", func() {
+		It("should return an error for a missing column", func() {
+			writeParquet(pandasSchema[:5],
+				[]interface{}{int64(0), 10.0, 11.0, 9.0, 10.5})
+
+			err := frames.NewParquetFileFeed(fileName, feeds.DefaultCSVColumnNames()).FillDOHLCVStream(priceStream)
+			Expect(errors.Is(err, feeds.ErrCSVHeaderColumnNotFound)).To(BeTrue())
+			Expect(err).To(MatchError(`volume column "Volume": the header has no such column`))
+		})
+
+		It("should return an error for a column of an unsupported type", func() {
+			writeParquet([]string{
+				"name=Date, type=DOUBLE",
+				"name=Close, type=DOUBLE"},
+				[]interface{}{1.0, 10.5})
+
+			err := frames.NewParquetFileFeed(fileName, feeds.CSVColumnNames{Date: "Date", Close: "Close"}).FillDOHLCVStream(priceStream)
+			Expect(errors.Is(err, frames.ErrParquetColumnType)).To(BeTrue())
+			Expect(err).To(MatchError(`date column "Date": the parquet column type is not supported`))
+		})
+
+		It("should return an error for a missing value with its row", func() {
+			writeParquet(pandasSchema,
+				[]interface{}{int64(0), 10.0, 11.0, 9.0, 10.5, int64(1000)},
+				[]interface{}{int64(60000000000), 10.0, 11.0, 9.0, nil, int64(1000)})
+
+			err := frames.NewParquetFileFeed(fileName, feeds.DefaultCSVColumnNames()).FillDOHLCVStream(priceStream)
+			Expect(errors.Is(err, frames.ErrParquetValueIsMissing)).To(BeTrue())
+			Expect(err).To(MatchError(`row 2: close column "close": the value is missing`))
+			Expect(priceStream.Data).To(HaveLen(1))
+		})
+
+		It("should return an error for a file that is not a parquet file", func() {
+			err := frames.NewParquetFileFeed("../testdata/JSETOPI.2013.data", feeds.DefaultCSVColumnNames()).FillDOHLCVStream(priceStream)
+			Expect(err).NotTo(BeNil())
+		})
+
+		It("should return an error for a file that does not exist", func() {
+			err := frames.NewParquetFileFeed("../testdata/missing.parquet", feeds.DefaultCSVColumnNames()).FillDOHLCVStream(priceStream)
+			Expect(os.IsNotExist(err)).To(BeTrue())
+		})
+	})
+})
@@ -65,6 +65,50 @@ func (di *DOHLCVDataItem) V() float64 {
 	return di.volumePrice
 }
 
+// The aggressor side of a trade
+type TradeSide int
+
+const (
+	UnknownSide TradeSide = iota
+	BuySide
+	SellSide
+)
+
+// A single trade print
+type Trade interface {
+	D() time.Time
+	P() float64
+	S() float64
+	Side() TradeSide
+}
+
+type TradeDataItem struct {
+	date  time.Time
+	price float64
+	size  float64
+	side  TradeSide
+}
+
+func NewTradeDataItem(date time.Time, price float64, size float64, side TradeSide) *TradeDataItem {
+	return &TradeDataItem{date, price, size, side}
+}
+
+func (ti *TradeDataItem) D() time.Time {
+	return ti.date
+}
+
+func (ti *TradeDataItem) P() float64 {
+	return ti.price
+}
+
+func (ti *TradeDataItem) S() float64 {
+	return ti.size
+}
+
+func (ti *TradeDataItem) Side() TradeSide {
+	return ti.side
+}
+
 // A function that selects which data property to use from a DOHLCV data structure
 type DOHLCVDataSelectionFunc func(dataItem DOHLCV) float64
 
@@ -98,6 +142,11 @@ type DOHLCVTickReceiver interface {
 	ReceiveDOHLCVTick(tickData DOHLCV, streamBarIndex int)
 }
 
+// Consumer of trade prints
+type TradeReceiver interface {
+	ReceiveTrade(trade Trade)
+}
+
 // Consumer of a float tick
 type TickReceiver interface {
 	ReceiveTick(tickData float64, streamBarIndex int)
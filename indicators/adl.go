@@ -56,15 +56,21 @@ func NewAdlWithSrcLen(sourceLength uint) (indicator *Adl, err error) {
 // NewAdlForStream creates an Accumulation Distribution Line Indicator (Adl) for online usage with a source data stream
 func NewAdlForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Adl, err error) {
 	ind, err := NewAdl()
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // NewAdlForStreamWithSrcLen creates an Accumulation Distribution Line Indicator (Adl) for offline usage with a source data stream
 func NewAdlForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Adl, err error) {
 	ind, err := NewAdlWithSrcLen(sourceLength)
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // ReceiveDOHLCVTick consumes a source data DOHLCV price tick
@@ -129,29 +129,41 @@ func NewDefaultAdxWithSrcLen(sourceLength uint) (indicator *Adx, err error) {
 // NewAdxForStream creates an Average Directional Index (Adx) for online usage with a source data stream
 func NewAdxForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int) (indicator *Adx, err error) {
 	ind, err := NewAdx(timePeriod)
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // NewDefaultAdxForStream creates an Average Directional Index (Adx) for online usage with a source data stream
 func NewDefaultAdxForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Adx, err error) {
 	ind, err := NewDefaultAdx()
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // NewAdxForStreamWithSrcLen creates an Average Directional Index (Adx) for offline usage with a source data stream
 func NewAdxForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int) (indicator *Adx, err error) {
 	ind, err := NewAdxWithSrcLen(sourceLength, timePeriod)
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // NewDefaultAdxForStreamWithSrcLen creates an Average Directional Index (Adx) for offline usage with a source data stream
 func NewDefaultAdxForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Adx, err error) {
 	ind, err := NewDefaultAdxWithSrcLen(sourceLength)
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // ReceiveDOHLCVTick consumes a source data DOHLCV price tick
@@ -119,29 +119,41 @@ func NewDefaultAdxrWithSrcLen(sourceLength uint) (indicator *Adxr, err error) {
 // NewAdxrForStream creates an Average Directional Rating Index (Adxr) for online usage with a source data stream
 func NewAdxrForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int) (indicator *Adxr, err error) {
 	ind, err := NewAdxr(timePeriod)
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // NewDefaultAdxrForStream creates an Average Directional Index Rating (Adxr) for online usage with a source data stream
 func NewDefaultAdxrForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Adxr, err error) {
 	ind, err := NewDefaultAdxr()
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // NewAdxrForStreamWithSrcLen creates an Average Directional Index Rating (Adxr) for offline usage with a source data stream
 func NewAdxrForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int) (indicator *Adxr, err error) {
 	ind, err := NewAdxrWithSrcLen(sourceLength, timePeriod)
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // NewDefaultAdxrForStreamWithSrcLen creates an Average Directional Index Rating (Adxr) for offline usage with a source data stream
 func NewDefaultAdxrForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Adxr, err error) {
 	ind, err := NewDefaultAdxrWithSrcLen(sourceLength)
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // ReceiveDOHLCVTick consumes a source data DOHLCV price tick
@@ -105,29 +105,41 @@ func NewDefaultAroonWithSrcLen(sourceLength uint) (indicator *Aroon, err error)
 // NewAroonForStream creates an Aroon (Aroon) for online usage with a source data stream
 func NewAroonForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int) (indicator *Aroon, err error) {
 	ind, err := NewAroon(timePeriod)
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // NewDefaultAroonForStream creates an Aroon (Aroon) for online usage with a source data stream
 func NewDefaultAroonForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Aroon, err error) {
 	ind, err := NewDefaultAroon()
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // NewAroonForStreamWithSrcLen creates an Aroon (Aroon) for online usage with a source data stream
 func NewAroonForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int) (indicator *Aroon, err error) {
 	ind, err := NewAroonWithSrcLen(sourceLength, timePeriod)
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // NewDefaultAroonForStreamWithSrcLen creates an Aroon (Aroon) for online usage with a source data stream
 func NewDefaultAroonForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Aroon, err error) {
 	ind, err := NewDefaultAroonWithSrcLen(sourceLength)
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // ReceiveDOHLCVTick consumes a source data DOHLCV price tick
@@ -98,29 +98,41 @@ func NewDefaultAroonOscWithSrcLen(sourceLength uint) (indicator *AroonOsc, err e
 // NewAroonOscForStream creates an Aroon Oscillator (AroonOsc) for online usage with a source data stream
 func NewAroonOscForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int) (indicator *AroonOsc, err error) {
 	ind, err := NewAroonOsc(timePeriod)
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // NewDefaultAroonOscForStream creates an Aroon Oscillator (AroonOsc) for online usage with a source data stream
 func NewDefaultAroonOscForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *AroonOsc, err error) {
 	ind, err := NewDefaultAroonOsc()
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // NewAroonOscForStreamWithSrcLen creates an Aroon Oscillator (AroonOsc) for offline usage with a source data stream
 func NewAroonOscForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int) (indicator *AroonOsc, err error) {
 	ind, err := NewAroonOscWithSrcLen(sourceLength, timePeriod)
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // NewDefaultAroonOscForStreamWithSrcLen creates an Aroon Oscillator (AroonOsc) for offline usage with a source data stream
 func NewDefaultAroonOscForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *AroonOsc, err error) {
 	ind, err := NewDefaultAroonOscWithSrcLen(sourceLength)
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // ReceiveDOHLCVTick consumes a source data DOHLCV price tick
@@ -129,39 +129,51 @@ func NewDefaultBollingerBandsWithSrcLen(sourceLength uint) (indicator *Bollinger
 // NewBollingerBandsForStream creates a Bollinger Bands Indicator (BollingerBand) for online usage with a source data stream
 func NewBollingerBandsForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *BollingerBands, err error) {
 	ind, err := NewBollingerBands(timePeriod, selectData)
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // NewDefaultBollingerBandsForStream creates a Bollinger Bands Indicator (BollingerBand) for online usage with a source data stream
 func NewDefaultBollingerBandsForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *BollingerBands, err error) {
 	ind, err := NewDefaultBollingerBands()
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // NewBollingerBandsForStreamWithSrcLen creates a Bollinger Bands Indicator (BollingerBand) for online usage with a source data stream
 func NewBollingerBandsForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *BollingerBands, err error) {
 	ind, err := NewBollingerBandsWithSrcLen(sourceLength, timePeriod, selectData)
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // NewDefaultBollingerBandsForStreamWithSrcLen creates a Bollinger Bands Indicator (BollingerBand) for online usage with a source data stream
 func NewDefaultBollingerBandsForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *BollingerBands, err error) {
 	ind, err := NewDefaultBollingerBandsWithSrcLen(sourceLength)
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // ReceiveDOHLCVTick consumes a source data DOHLCV price tick
 func (ind *BollingerBands) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
 	var selectedData float64 = ind.selectData(tickData)
-	ind.RecieveTick(selectedData, streamBarIndex)
+	ind.ReceiveTick(selectedData, streamBarIndex)
 }
 
 // ReceiveTick consumes a source data float price tick
-func (ind *BollingerBandsWithoutStorage) RecieveTick(tickData float64, streamBarIndex int) {
+func (ind *BollingerBandsWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
 	ind.sma.ReceiveTick(tickData, streamBarIndex)
 	ind.stdDev.ReceiveTick(tickData, streamBarIndex)
 }
@@ -0,0 +1,198 @@
+package indicators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/jaybutera/gotrade"
+	"github.com/jaybutera/gotrade/indicators"
+	"time"
+)
+
+var _ = Describe("when attaching an indicator to the results of another indicator", func() {
+	var (
+		priceStream *gotrade.InterDayDOHLCVStream
+	)
+
+	BeforeEach(func() {
+		priceStream = gotrade.NewDailyDOHLCVStream()
+	})
+
+	Context("given an sma is attached to an atr", func() {
+		var (
+			atr *indicators.Atr
+			sma *indicators.Sma
+		)
+
+		BeforeEach(func() {
+			atr, _ = indicators.NewAtrForStream(priceStream, 14)
+			sma, _ = indicators.NewSma(5, gotrade.UseClosePrice)
+			atr.AddTickSubscription(sma)
+			csvFeed.FillDOHLCVStream(priceStream)
+		})
+
+		It("the sma should be valid from the bar its lookback period after the atr is valid", func() {
+			Expect(sma.ValidFromBar()).To(Equal(atr.ValidFromBar() + sma.GetLookbackPeriod()))
+		})
+
+		It("the sma should have a result for each atr result after its lookback period", func() {
+			Expect(len(sma.Data)).To(Equal(len(atr.Data) - sma.GetLookbackPeriod()))
+		})
+
+		It("the sma should average the atr results", func() {
+			for k := range sma.Data {
+				total := 0.0
+				for _, atrValue := range atr.Data[k : k+5] {
+					total += atrValue
+				}
+				Expect(sma.Data[k]).To(BeNumerically("~", total/5.0, 0.0001))
+			}
+		})
+	})
+
+	Context("given an rsi is attached to an obv attached to a price stream", func() {
+		var (
+			obv *indicators.Obv
+			rsi *indicators.Rsi
+		)
+
+		BeforeEach(func() {
+			obv, _ = indicators.NewObvForStream(priceStream)
+			rsi, _ = indicators.NewRsi(14, gotrade.UseClosePrice)
+			obv.AddTickSubscription(rsi)
+			csvFeed.FillDOHLCVStream(priceStream)
+		})
+
+		It("the rsi should be valid from the bar of the combined lookback periods", func() {
+			Expect(rsi.ValidFromBar()).To(Equal(obv.GetLookbackPeriod() + rsi.GetLookbackPeriod() + 1))
+			Expect(len(rsi.Data)).To(Equal(len(priceStream.Data) - obv.GetLookbackPeriod() - rsi.GetLookbackPeriod()))
+		})
+	})
+
+	Context("given an sma is attached to the signal line of a macd", func() {
+		var (
+			macd *indicators.Macd
+			sma  *indicators.Sma
+		)
+
+		BeforeEach(func() {
+			macd, _ = indicators.NewDefaultMacdForStream(priceStream)
+			sma, _ = indicators.NewSma(3, gotrade.UseClosePrice)
+			macd.SignalStream().AddTickSubscription(sma)
+			csvFeed.FillDOHLCVStream(priceStream)
+		})
+
+		It("the sma should average the signal line results", func() {
+			Expect(sma.ValidFromBar()).To(Equal(macd.ValidFromBar() + 2))
+			Expect(sma.Data[0]).To(BeNumerically("~", (macd.Signal[0]+macd.Signal[1]+macd.Signal[2])/3.0, 0.0001))
+		})
+	})
+
+	Context("given an sma is attached to the upper band of bollinger bands", func() {
+		var (
+			bollinger *indicators.BollingerBands
+			sma       *indicators.Sma
+		)
+
+		BeforeEach(func() {
+			bollinger, _ = indicators.NewDefaultBollingerBandsForStream(priceStream)
+			sma, _ = indicators.NewSma(2, gotrade.UseClosePrice)
+			bollinger.UpperBandStream().AddTickSubscription(sma)
+			csvFeed.FillDOHLCVStream(priceStream)
+		})
+
+		It("the sma should average the upper band results", func() {
+			Expect(len(sma.Data)).To(Equal(len(bollinger.UpperBand) - 1))
+			Expect(sma.Data[0]).To(BeNumerically("~", (bollinger.UpperBand[0]+bollinger.UpperBand[1])/2.0, 0.0001))
+		})
+	})
+
+	Context("given bollinger bands are attached to an atr", func() {
+		var (
+			atr       *indicators.Atr
+			bollinger *indicators.BollingerBands
+		)
+
+		BeforeEach(func() {
+			atr, _ = indicators.NewAtrForStream(priceStream, 14)
+			bollinger, _ = indicators.NewBollingerBands(5, gotrade.UseClosePrice)
+			atr.AddTickSubscription(bollinger)
+			csvFeed.FillDOHLCVStream(priceStream)
+		})
+
+		It("the bollinger bands should be valid from the bar their lookback period after the atr is valid", func() {
+			Expect(bollinger.ValidFromBar()).To(Equal(atr.ValidFromBar() + bollinger.GetLookbackPeriod()))
+			Expect(len(bollinger.MiddleBand)).To(Equal(len(atr.Data) - bollinger.GetLookbackPeriod()))
+		})
+
+		It("the middle band should average the atr results", func() {
+			total := 0.0
+			for _, atrValue := range atr.Data[0:5] {
+				total += atrValue
+			}
+			Expect(bollinger.MiddleBand[0]).To(BeNumerically("~", total/5.0, 0.0001))
+		})
+	})
+
+	Context("given an sma is attached to a linear regression", func() {
+		var (
+			linReg *indicators.LinReg
+			sma    *indicators.Sma
+		)
+
+		BeforeEach(func() {
+			linReg, _ = indicators.NewDefaultLinRegForStream(priceStream)
+			sma, _ = indicators.NewSma(2, gotrade.UseClosePrice)
+			linReg.AddTickSubscription(sma)
+			csvFeed.FillDOHLCVStream(priceStream)
+		})
+
+		It("the sma should average the linear regression results", func() {
+			Expect(sma.ValidFromBar()).To(Equal(linReg.ValidFromBar() + 1))
+			Expect(len(sma.Data)).To(Equal(len(linReg.Data) - 1))
+			Expect(sma.Data[0]).To(BeNumerically("~", (linReg.Data[0]+linReg.Data[1])/2.0, 0.0001))
+		})
+	})
+
+	Context("given an sma is attached to a highest high value bars", func() {
+		var (
+			hhvBars *indicators.HhvBars
+			sma     *indicators.Sma
+		)
+
+		BeforeEach(func() {
+			hhvBars, _ = indicators.NewDefaultHhvBarsForStream(priceStream)
+			sma, _ = indicators.NewSma(2, gotrade.UseClosePrice)
+			hhvBars.AddTickSubscription(sma)
+			csvFeed.FillDOHLCVStream(priceStream)
+		})
+
+		It("the sma should average the bar counts as floats", func() {
+			Expect(len(sma.Data)).To(Equal(len(hhvBars.Data) - 1))
+			Expect(sma.Data[0]).To(BeNumerically("~", float64(hhvBars.Data[0]+hhvBars.Data[1])/2.0, 0.0001))
+		})
+	})
+
+	Context("given the subscription of an attached indicator is closed part way through the source data", func() {
+		var (
+			ema *indicators.Ema
+			sma *indicators.Sma
+		)
+
+		BeforeEach(func() {
+			ema, _ = indicators.NewEmaForStream(priceStream, 5, gotrade.UseClosePrice)
+			sma, _ = indicators.NewSma(2, gotrade.UseClosePrice)
+			subscription := ema.AddTickSubscription(sma)
+			for i := 0; i < 10; i++ {
+				priceStream.ReceiveTick(gotrade.NewDOHLCVDataItem(time.Date(2014, 1, i+1, 0, 0, 0, 0, time.UTC), 1.0, 2.0, 0.5, float64(i), 100.0))
+			}
+			subscription.Close()
+			priceStream.ReceiveTick(gotrade.NewDOHLCVDataItem(time.Date(2014, 1, 11, 0, 0, 0, 0, time.UTC), 1.0, 2.0, 0.5, 10.0, 100.0))
+		})
+
+		It("the attached indicator should only have results for the bars before the subscription was closed", func() {
+			Expect(ema.TickSubscriberCount()).To(Equal(0))
+			Expect(len(ema.Data)).To(Equal(7))
+			Expect(len(sma.Data)).To(Equal(5))
+		})
+	})
+})
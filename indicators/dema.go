@@ -120,29 +120,41 @@ func NewDefaultDemaWithSrcLen(sourceLength uint) (indicator *Dema, err error) {
 // NewDemaForStream creates a Double Exponential Moving Average (Dema) for online usage with a source data stream
 func NewDemaForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Dema, err error) {
 	newDema, err := NewDema(timePeriod, selectData)
-	priceStream.AddTickSubscription(newDema)
-	return newDema, err
+	if err != nil {
+		return nil, err
+	}
+	newDema.subscription = priceStream.AddTickSubscription(newDema)
+	return newDema, nil
 }
 
 // NewDefaultDemaForStream creates a Double Exponential Moving Average (Dema) for online usage with a source data stream
 func NewDefaultDemaForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Dema, err error) {
 	ind, err := NewDefaultDema()
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // NewDemaForStreamWithSrcLen creates a Double Exponential Moving Average (Dema) for offline usage with a source data stream
 func NewDemaForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Dema, err error) {
 	ind, err := NewDemaWithSrcLen(sourceLength, timePeriod, selectData)
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // NewDefaultDemaForStreamWithSrcLen creates a Double Exponential Moving Average (Dema) for offline usage with a source data stream
 func NewDefaultDemaForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Dema, err error) {
 	ind, err := NewDefaultDemaWithSrcLen(sourceLength)
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // ReceiveDOHLCVTick consumes a source data DOHLCV price tick
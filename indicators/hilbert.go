@@ -0,0 +1,310 @@
+package indicators
+
+import (
+	"container/list"
+	"math"
+)
+
+// the coefficients of the Hilbert transform filters used by the MESA indicators
+const (
+	hilbertA float64 = 0.0962
+	hilbertB float64 = 0.5769
+)
+
+var rad2Deg float64 = 180.0 / (4.0 * math.Atan(1))
+var deg2Rad float64 = 1.0 / rad2Deg
+
+// a Hilbert transform filter, odd and even bars are filtered separately
+type hilbertFilter struct {
+	odd           [3]float64
+	even          [3]float64
+	prevOdd       float64
+	prevEven      float64
+	prevInputOdd  float64
+	prevInputEven float64
+}
+
+func (f *hilbertFilter) apply(input float64, isEvenBar bool, hilbertIndex int, adjustedPrevPeriod float64) float64 {
+	var hilbertTempReal float64 = hilbertA * input
+	var result float64
+
+	if isEvenBar {
+		result = -f.even[hilbertIndex]
+		f.even[hilbertIndex] = hilbertTempReal
+		result += hilbertTempReal
+		result -= f.prevEven
+		f.prevEven = hilbertB * f.prevInputEven
+		result += f.prevEven
+		f.prevInputEven = input
+	} else {
+		result = -f.odd[hilbertIndex]
+		f.odd[hilbertIndex] = hilbertTempReal
+		result += hilbertTempReal
+		result -= f.prevOdd
+		f.prevOdd = hilbertB * f.prevInputOdd
+		result += f.prevOdd
+		f.prevInputOdd = input
+	}
+
+	return result * adjustedPrevPeriod
+}
+
+// the Hilbert transform of a 4 bar weighted moving average of the source data and the dominant cycle period
+// it measures, shared by the MESA indicators and calculated in the same order as TA-Lib
+type hilbertTransform struct {
+	barCounter int
+	warmUpBars int
+
+	// the 4 bar weighted moving average
+	periodHistory    *list.List
+	periodWMASub     float64
+	periodWMASum     float64
+	trailingWMAValue float64
+
+	// the hilbert transform filters
+	detrenderFilter hilbertFilter
+	q1Filter        hilbertFilter
+	jIFilter        hilbertFilter
+	jQFilter        hilbertFilter
+	hilbertIndex    int
+	i1ForOddPrev2   float64
+	i1ForOddPrev3   float64
+	i1ForEvenPrev2  float64
+	i1ForEvenPrev3  float64
+	prevI2          float64
+	prevQ2          float64
+	re              float64
+	im              float64
+
+	// the results for the latest bar
+	smoothedValue float64
+	detrender     float64
+	i1            float64
+	q1            float64
+	period        float64
+	smoothPeriod  float64
+}
+
+// newHilbertTransform creates a Hilbert transform which only smooths the source data for the first
+// 3 + warmUpBars bars before it starts to transform it
+func newHilbertTransform(warmUpBars int) *hilbertTransform {
+	return &hilbertTransform{
+		warmUpBars:    warmUpBars,
+		periodHistory: list.New(),
+	}
+}
+
+// receive consumes a source value and returns true once the value has been transformed
+func (h *hilbertTransform) receive(value float64) bool {
+	today := h.barCounter
+	h.barCounter += 1
+
+	h.periodHistory.PushBack(value)
+
+	// the first 3 bars seed the weighted moving average
+	if today < 3 {
+		h.periodWMASub += value
+		h.periodWMASum += value * float64(today+1)
+		return false
+	}
+
+	var adjustedPrevPeriod float64 = (0.075 * h.period) + 0.54
+
+	h.periodWMASub += value
+	h.periodWMASub -= h.trailingWMAValue
+	h.periodWMASum += value * 4.0
+	var first = h.periodHistory.Front()
+	h.trailingWMAValue = first.Value.(float64)
+	h.periodHistory.Remove(first)
+	h.smoothedValue = h.periodWMASum * 0.1
+	h.periodWMASum -= h.periodWMASub
+
+	if today < 3+h.warmUpBars {
+		return false
+	}
+
+	var isEvenBar bool = (today % 2) == 0
+	h.detrender = h.detrenderFilter.apply(h.smoothedValue, isEvenBar, h.hilbertIndex, adjustedPrevPeriod)
+	h.q1 = h.q1Filter.apply(h.detrender, isEvenBar, h.hilbertIndex, adjustedPrevPeriod)
+
+	var q2, i2 float64
+	if isEvenBar {
+		h.i1 = h.i1ForEvenPrev3
+		jI := h.jIFilter.apply(h.i1, isEvenBar, h.hilbertIndex, adjustedPrevPeriod)
+		jQ := h.jQFilter.apply(h.q1, isEvenBar, h.hilbertIndex, adjustedPrevPeriod)
+
+		h.hilbertIndex += 1
+		if h.hilbertIndex == 3 {
+			h.hilbertIndex = 0
+		}
+
+		q2 = (0.2 * (h.q1 + jI)) + (0.8 * h.prevQ2)
+		i2 = (0.2 * (h.i1 - jQ)) + (0.8 * h.prevI2)
+		h.i1ForOddPrev3 = h.i1ForOddPrev2
+		h.i1ForOddPrev2 = h.detrender
+	} else {
+		h.i1 = h.i1ForOddPrev3
+		jI := h.jIFilter.apply(h.i1, isEvenBar, h.hilbertIndex, adjustedPrevPeriod)
+		jQ := h.jQFilter.apply(h.q1, isEvenBar, h.hilbertIndex, adjustedPrevPeriod)
+
+		q2 = (0.2 * (h.q1 + jI)) + (0.8 * h.prevQ2)
+		i2 = (0.2 * (h.i1 - jQ)) + (0.8 * h.prevI2)
+		h.i1ForEvenPrev3 = h.i1ForEvenPrev2
+		h.i1ForEvenPrev2 = h.detrender
+	}
+
+	// measure the dominant cycle period with the homodyne discriminator
+	h.re = (0.2 * ((i2 * h.prevI2) + (q2 * h.prevQ2))) + (0.8 * h.re)
+	h.im = (0.2 * ((i2 * h.prevQ2) - (q2 * h.prevI2))) + (0.8 * h.im)
+	h.prevQ2 = q2
+	h.prevI2 = i2
+
+	var previousPeriod float64 = h.period
+	if h.im != 0.0 && h.re != 0.0 {
+		h.period = 360.0 / (math.Atan(h.im/h.re) * rad2Deg)
+	}
+
+	// limit the rate of change of the period and its range
+	if h.period > 1.5*previousPeriod {
+		h.period = 1.5 * previousPeriod
+	}
+	if h.period < 0.67*previousPeriod {
+		h.period = 0.67 * previousPeriod
+	}
+	if h.period < 6 {
+		h.period = 6
+	} else if h.period > 50 {
+		h.period = 50
+	}
+	h.period = (0.2 * h.period) + (0.8 * previousPeriod)
+	h.smoothPeriod = (0.33 * h.period) + (0.67 * h.smoothPeriod)
+
+	return true
+}
+
+// phase returns the phase of the latest bar in degrees
+func (h *hilbertTransform) phase() float64 {
+	if h.i1 != 0.0 {
+		return math.Atan(h.q1/h.i1) * rad2Deg
+	}
+	return 0.0
+}
+
+// the number of smoothed prices kept to measure the dominant cycle phase, the longest measurable period
+const dominantCycleMaxPeriod int = 50
+
+// the dominant cycle phase and instantaneous trendline of the source data, measured over the smoothed
+// dominant cycle period of a Hilbert transform
+type dominantCycle struct {
+	*hilbertTransform
+
+	// private variables
+	smoothPrice      [dominantCycleMaxPeriod]float64
+	smoothPriceIndex int
+	priceHistory     *list.List
+	dcPhase          float64
+	iTrend1          float64
+	iTrend2          float64
+	iTrend3          float64
+}
+
+// newDominantCycle creates a dominant cycle measurement which transforms the source data after 37 bars, as in TA-Lib
+func newDominantCycle() *dominantCycle {
+	return &dominantCycle{
+		hilbertTransform: newHilbertTransform(34),
+		smoothPriceIndex: -1,
+		priceHistory:     list.New(),
+	}
+}
+
+// receive consumes a source value and returns true once the value has been transformed
+func (d *dominantCycle) receive(value float64) bool {
+	d.priceHistory.PushFront(value)
+	if d.priceHistory.Len() > dominantCycleMaxPeriod+1 {
+		d.priceHistory.Remove(d.priceHistory.Back())
+	}
+
+	if !d.hilbertTransform.receive(value) {
+		return false
+	}
+
+	d.smoothPriceIndex += 1
+	if d.smoothPriceIndex == dominantCycleMaxPeriod {
+		d.smoothPriceIndex = 0
+	}
+	d.smoothPrice[d.smoothPriceIndex] = d.smoothedValue
+
+	return true
+}
+
+// dcPeriodInt returns the dominant cycle period rounded to a whole number of bars
+func (d *dominantCycle) dcPeriodInt() int {
+	return int(math.Floor(d.smoothPeriod + 0.5))
+}
+
+// updatePhase measures the phase of the dominant cycle in degrees by correlating the smoothed prices
+// of the last dominant cycle period with a sine and cosine wave
+func (d *dominantCycle) updatePhase() float64 {
+	var dcPeriodInt int = d.dcPeriodInt()
+	var realPart, imagPart float64
+	var index int = d.smoothPriceIndex
+	for i := 0; i < dcPeriodInt; i++ {
+		var angle float64 = (float64(i) * 2.0 * math.Pi) / float64(dcPeriodInt)
+		realPart += math.Sin(angle) * d.smoothPrice[index]
+		imagPart += math.Cos(angle) * d.smoothPrice[index]
+		if index == 0 {
+			index = dominantCycleMaxPeriod - 1
+		} else {
+			index -= 1
+		}
+	}
+
+	if math.Abs(imagPart) > 0.0 {
+		d.dcPhase = math.Atan(realPart/imagPart) * rad2Deg
+	} else if math.Abs(imagPart) <= 0.01 {
+		if realPart < 0.0 {
+			d.dcPhase -= 90.0
+		} else if realPart > 0.0 {
+			d.dcPhase += 90.0
+		}
+	}
+	d.dcPhase += 90.0
+
+	// compensate for the one bar lag of the weighted moving average
+	d.dcPhase += 360.0 / d.smoothPeriod
+	if imagPart < 0.0 {
+		d.dcPhase += 180.0
+	}
+	if d.dcPhase > 315.0 {
+		d.dcPhase -= 360.0
+	}
+
+	return d.dcPhase
+}
+
+// updateTrendline averages the source data over the dominant cycle period and returns the instantaneous
+// trendline, a 4 bar weighted moving average of the averages
+func (d *dominantCycle) updateTrendline() float64 {
+	var dcPeriodInt int = d.dcPeriodInt()
+	var average float64
+	var count int
+	for e := d.priceHistory.Front(); e != nil && count < dcPeriodInt; e = e.Next() {
+		average += e.Value.(float64)
+		count += 1
+	}
+	if dcPeriodInt > 0 {
+		average = average / float64(dcPeriodInt)
+	}
+
+	var trendline float64 = (4.0*average + 3.0*d.iTrend1 + 2.0*d.iTrend2 + d.iTrend3) / 10.0
+	d.iTrend3 = d.iTrend2
+	d.iTrend2 = d.iTrend1
+	d.iTrend1 = average
+
+	return trendline
+}
+
+// currentSmoothPrice returns the smoothed price of the latest bar
+func (d *dominantCycle) currentSmoothPrice() float64 {
+	return d.smoothPrice[d.smoothPriceIndex]
+}
@@ -0,0 +1,144 @@
+// Hilbert Transform - Dominant Cycle Period (HtDcPeriod)
+package indicators
+
+import (
+	"github.com/jaybutera/gotrade"
+)
+
+// A Hilbert Transform - Dominant Cycle Period Indicator (HtDcPeriod), no storage, for use in other indicators
+type HtDcPeriodWithoutStorage struct {
+	*baseIndicatorWithFloatBounds
+
+	// private variables
+	hilbertTransform *hilbertTransform
+}
+
+// NewHtDcPeriodWithoutStorage creates a Hilbert Transform - Dominant Cycle Period Indicator (HtDcPeriod) without storage
+func NewHtDcPeriodWithoutStorage(valueAvailableAction ValueAvailableActionFloat) (indicator *HtDcPeriodWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the hilbert transform starts after smoothing 12 bars and the result after 32 bars, as in TA-Lib
+	lookback := 32
+	ind := HtDcPeriodWithoutStorage{
+		baseIndicatorWithFloatBounds: newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction),
+		hilbertTransform:             newHilbertTransform(9),
+	}
+
+	return &ind, nil
+}
+
+// A Hilbert Transform - Dominant Cycle Period Indicator (HtDcPeriod)
+type HtDcPeriod struct {
+	*HtDcPeriodWithoutStorage
+	selectData gotrade.DOHLCVDataSelectionFunc
+
+	// public variables
+	Data []float64
+}
+
+// NewHtDcPeriod creates a Hilbert Transform - Dominant Cycle Period Indicator (HtDcPeriod) for online usage
+func NewHtDcPeriod(selectData gotrade.DOHLCVDataSelectionFunc) (indicator *HtDcPeriod, err error) {
+	if selectData == nil {
+		return nil, ErrDOHLCVDataSelectFuncIsNil
+	}
+
+	ind := HtDcPeriod{
+		selectData: selectData,
+	}
+
+	ind.HtDcPeriodWithoutStorage, err = NewHtDcPeriodWithoutStorage(func(dataItem float64, streamBarIndex int) {
+		ind.Data = append(ind.Data, dataItem)
+	})
+
+	return &ind, err
+}
+
+// NewDefaultHtDcPeriod creates a Hilbert Transform - Dominant Cycle Period Indicator (HtDcPeriod) for online usage with default parameters
+//	- selectData: the close price
+func NewDefaultHtDcPeriod() (indicator *HtDcPeriod, err error) {
+	return NewHtDcPeriod(gotrade.UseClosePrice)
+}
+
+// NewHtDcPeriodWithSrcLen creates a Hilbert Transform - Dominant Cycle Period Indicator (HtDcPeriod) for offline usage
+func NewHtDcPeriodWithSrcLen(sourceLength uint, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *HtDcPeriod, err error) {
+	ind, err := NewHtDcPeriod(selectData)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultHtDcPeriodWithSrcLen creates a Hilbert Transform - Dominant Cycle Period Indicator (HtDcPeriod) for offline usage with default parameters
+func NewDefaultHtDcPeriodWithSrcLen(sourceLength uint) (indicator *HtDcPeriod, err error) {
+	ind, err := NewDefaultHtDcPeriod()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewHtDcPeriodForStream creates a Hilbert Transform - Dominant Cycle Period Indicator (HtDcPeriod) for online usage with a source data stream
+func NewHtDcPeriodForStream(priceStream gotrade.DOHLCVStreamSubscriber, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *HtDcPeriod, err error) {
+	ind, err := NewHtDcPeriod(selectData)
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
+}
+
+// NewDefaultHtDcPeriodForStream creates a Hilbert Transform - Dominant Cycle Period Indicator (HtDcPeriod) for online usage with a source data stream
+func NewDefaultHtDcPeriodForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *HtDcPeriod, err error) {
+	ind, err := NewDefaultHtDcPeriod()
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
+}
+
+// NewHtDcPeriodForStreamWithSrcLen creates a Hilbert Transform - Dominant Cycle Period Indicator (HtDcPeriod) for offline usage with a source data stream
+func NewHtDcPeriodForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *HtDcPeriod, err error) {
+	ind, err := NewHtDcPeriodWithSrcLen(sourceLength, selectData)
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
+}
+
+// NewDefaultHtDcPeriodForStreamWithSrcLen creates a Hilbert Transform - Dominant Cycle Period Indicator (HtDcPeriod) for offline usage with a source data stream
+func NewDefaultHtDcPeriodForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *HtDcPeriod, err error) {
+	ind, err := NewDefaultHtDcPeriodWithSrcLen(sourceLength)
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *HtDcPeriod) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	var selectedData = ind.selectData(tickData)
+	ind.ReceiveTick(selectedData, streamBarIndex)
+}
+
+func (ind *HtDcPeriodWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	if !ind.hilbertTransform.receive(tickData) {
+		return
+	}
+
+	if ind.hilbertTransform.barCounter > ind.GetLookbackPeriod() {
+		ind.UpdateIndicatorWithNewValue(ind.hilbertTransform.smoothPeriod, streamBarIndex)
+	}
+}
@@ -0,0 +1,146 @@
+// Hilbert Transform - Dominant Cycle Phase (HtDcPhase)
+package indicators
+
+import (
+	"github.com/jaybutera/gotrade"
+)
+
+// A Hilbert Transform - Dominant Cycle Phase Indicator (HtDcPhase), no storage, for use in other indicators
+type HtDcPhaseWithoutStorage struct {
+	*baseIndicatorWithFloatBounds
+
+	// private variables
+	dominantCycle *dominantCycle
+}
+
+// NewHtDcPhaseWithoutStorage creates a Hilbert Transform - Dominant Cycle Phase Indicator (HtDcPhase) without storage
+func NewHtDcPhaseWithoutStorage(valueAvailableAction ValueAvailableActionFloat) (indicator *HtDcPhaseWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the dominant cycle is measured after smoothing 37 bars and the result after 63 bars, as in TA-Lib
+	lookback := 63
+	ind := HtDcPhaseWithoutStorage{
+		baseIndicatorWithFloatBounds: newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction),
+		dominantCycle:                newDominantCycle(),
+	}
+
+	return &ind, nil
+}
+
+// A Hilbert Transform - Dominant Cycle Phase Indicator (HtDcPhase)
+type HtDcPhase struct {
+	*HtDcPhaseWithoutStorage
+	selectData gotrade.DOHLCVDataSelectionFunc
+
+	// public variables
+	Data []float64
+}
+
+// NewHtDcPhase creates a Hilbert Transform - Dominant Cycle Phase Indicator (HtDcPhase) for online usage
+func NewHtDcPhase(selectData gotrade.DOHLCVDataSelectionFunc) (indicator *HtDcPhase, err error) {
+	if selectData == nil {
+		return nil, ErrDOHLCVDataSelectFuncIsNil
+	}
+
+	ind := HtDcPhase{
+		selectData: selectData,
+	}
+
+	ind.HtDcPhaseWithoutStorage, err = NewHtDcPhaseWithoutStorage(func(dataItem float64, streamBarIndex int) {
+		ind.Data = append(ind.Data, dataItem)
+	})
+
+	return &ind, err
+}
+
+// NewDefaultHtDcPhase creates a Hilbert Transform - Dominant Cycle Phase Indicator (HtDcPhase) for online usage with default parameters
+//	- selectData: the close price
+func NewDefaultHtDcPhase() (indicator *HtDcPhase, err error) {
+	return NewHtDcPhase(gotrade.UseClosePrice)
+}
+
+// NewHtDcPhaseWithSrcLen creates a Hilbert Transform - Dominant Cycle Phase Indicator (HtDcPhase) for offline usage
+func NewHtDcPhaseWithSrcLen(sourceLength uint, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *HtDcPhase, err error) {
+	ind, err := NewHtDcPhase(selectData)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultHtDcPhaseWithSrcLen creates a Hilbert Transform - Dominant Cycle Phase Indicator (HtDcPhase) for offline usage with default parameters
+func NewDefaultHtDcPhaseWithSrcLen(sourceLength uint) (indicator *HtDcPhase, err error) {
+	ind, err := NewDefaultHtDcPhase()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewHtDcPhaseForStream creates a Hilbert Transform - Dominant Cycle Phase Indicator (HtDcPhase) for online usage with a source data stream
+func NewHtDcPhaseForStream(priceStream gotrade.DOHLCVStreamSubscriber, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *HtDcPhase, err error) {
+	ind, err := NewHtDcPhase(selectData)
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
+}
+
+// NewDefaultHtDcPhaseForStream creates a Hilbert Transform - Dominant Cycle Phase Indicator (HtDcPhase) for online usage with a source data stream
+func NewDefaultHtDcPhaseForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *HtDcPhase, err error) {
+	ind, err := NewDefaultHtDcPhase()
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
+}
+
+// NewHtDcPhaseForStreamWithSrcLen creates a Hilbert Transform - Dominant Cycle Phase Indicator (HtDcPhase) for offline usage with a source data stream
+func NewHtDcPhaseForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *HtDcPhase, err error) {
+	ind, err := NewHtDcPhaseWithSrcLen(sourceLength, selectData)
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
+}
+
+// NewDefaultHtDcPhaseForStreamWithSrcLen creates a Hilbert Transform - Dominant Cycle Phase Indicator (HtDcPhase) for offline usage with a source data stream
+func NewDefaultHtDcPhaseForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *HtDcPhase, err error) {
+	ind, err := NewDefaultHtDcPhaseWithSrcLen(sourceLength)
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *HtDcPhase) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	var selectedData = ind.selectData(tickData)
+	ind.ReceiveTick(selectedData, streamBarIndex)
+}
+
+func (ind *HtDcPhaseWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	if !ind.dominantCycle.receive(tickData) {
+		return
+	}
+
+	var dcPhase float64 = ind.dominantCycle.updatePhase()
+
+	if ind.dominantCycle.barCounter > ind.GetLookbackPeriod() {
+		ind.UpdateIndicatorWithNewValue(dcPhase, streamBarIndex)
+	}
+}
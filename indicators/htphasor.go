@@ -0,0 +1,149 @@
+// Hilbert Transform - Phasor Components (HtPhasor)
+package indicators
+
+import (
+	"github.com/jaybutera/gotrade"
+)
+
+// A Hilbert Transform - Phasor Components Indicator (HtPhasor), no storage, for use in other indicators
+type HtPhasorWithoutStorage struct {
+	*baseIndicatorWithFloatBoundsHtPhasor
+
+	// private variables
+	hilbertTransform *hilbertTransform
+}
+
+// NewHtPhasorWithoutStorage creates a Hilbert Transform - Phasor Components Indicator (HtPhasor) without storage
+func NewHtPhasorWithoutStorage(valueAvailableAction ValueAvailableActionHtPhasor) (indicator *HtPhasorWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the hilbert transform starts after smoothing 12 bars and the result after 32 bars, as in TA-Lib
+	lookback := 32
+	ind := HtPhasorWithoutStorage{
+		baseIndicatorWithFloatBoundsHtPhasor: newBaseIndicatorWithFloatBoundsHtPhasor(lookback, valueAvailableAction),
+		hilbertTransform:                     newHilbertTransform(9),
+	}
+
+	return &ind, nil
+}
+
+// A Hilbert Transform - Phasor Components Indicator (HtPhasor)
+type HtPhasor struct {
+	*HtPhasorWithoutStorage
+	selectData gotrade.DOHLCVDataSelectionFunc
+
+	// public variables
+	InPhase    []float64
+	Quadrature []float64
+}
+
+// NewHtPhasor creates a Hilbert Transform - Phasor Components Indicator (HtPhasor) for online usage
+func NewHtPhasor(selectData gotrade.DOHLCVDataSelectionFunc) (indicator *HtPhasor, err error) {
+	if selectData == nil {
+		return nil, ErrDOHLCVDataSelectFuncIsNil
+	}
+
+	ind := HtPhasor{
+		selectData: selectData,
+	}
+
+	ind.HtPhasorWithoutStorage, err = NewHtPhasorWithoutStorage(
+		func(dataItemInPhase float64, dataItemQuadrature float64, streamBarIndex int) {
+			ind.InPhase = append(ind.InPhase, dataItemInPhase)
+			ind.Quadrature = append(ind.Quadrature, dataItemQuadrature)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultHtPhasor creates a Hilbert Transform - Phasor Components Indicator (HtPhasor) for online usage with default parameters
+//	- selectData: the close price
+func NewDefaultHtPhasor() (indicator *HtPhasor, err error) {
+	return NewHtPhasor(gotrade.UseClosePrice)
+}
+
+// NewHtPhasorWithSrcLen creates a Hilbert Transform - Phasor Components Indicator (HtPhasor) for offline usage
+func NewHtPhasorWithSrcLen(sourceLength uint, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *HtPhasor, err error) {
+	ind, err := NewHtPhasor(selectData)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.InPhase = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+		ind.Quadrature = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultHtPhasorWithSrcLen creates a Hilbert Transform - Phasor Components Indicator (HtPhasor) for offline usage with default parameters
+func NewDefaultHtPhasorWithSrcLen(sourceLength uint) (indicator *HtPhasor, err error) {
+	ind, err := NewDefaultHtPhasor()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.InPhase = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+		ind.Quadrature = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewHtPhasorForStream creates a Hilbert Transform - Phasor Components Indicator (HtPhasor) for online usage with a source data stream
+func NewHtPhasorForStream(priceStream gotrade.DOHLCVStreamSubscriber, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *HtPhasor, err error) {
+	ind, err := NewHtPhasor(selectData)
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
+}
+
+// NewDefaultHtPhasorForStream creates a Hilbert Transform - Phasor Components Indicator (HtPhasor) for online usage with a source data stream
+func NewDefaultHtPhasorForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *HtPhasor, err error) {
+	ind, err := NewDefaultHtPhasor()
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
+}
+
+// NewHtPhasorForStreamWithSrcLen creates a Hilbert Transform - Phasor Components Indicator (HtPhasor) for offline usage with a source data stream
+func NewHtPhasorForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *HtPhasor, err error) {
+	ind, err := NewHtPhasorWithSrcLen(sourceLength, selectData)
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
+}
+
+// NewDefaultHtPhasorForStreamWithSrcLen creates a Hilbert Transform - Phasor Components Indicator (HtPhasor) for offline usage with a source data stream
+func NewDefaultHtPhasorForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *HtPhasor, err error) {
+	ind, err := NewDefaultHtPhasorWithSrcLen(sourceLength)
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *HtPhasor) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	var selectedData = ind.selectData(tickData)
+	ind.ReceiveTick(selectedData, streamBarIndex)
+}
+
+func (ind *HtPhasorWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	if !ind.hilbertTransform.receive(tickData) {
+		return
+	}
+
+	if ind.hilbertTransform.barCounter > ind.GetLookbackPeriod() {
+		ind.UpdateIndicatorWithNewValue(ind.hilbertTransform.i1, ind.hilbertTransform.q1, streamBarIndex)
+	}
+}
@@ -0,0 +1,152 @@
+// Hilbert Transform - SineWave (HtSine)
+package indicators
+
+import (
+	"github.com/jaybutera/gotrade"
+	"math"
+)
+
+// A Hilbert Transform - SineWave Indicator (HtSine), no storage, for use in other indicators
+type HtSineWithoutStorage struct {
+	*baseIndicatorWithFloatBoundsHtSine
+
+	// private variables
+	dominantCycle *dominantCycle
+}
+
+// NewHtSineWithoutStorage creates a Hilbert Transform - SineWave Indicator (HtSine) without storage
+func NewHtSineWithoutStorage(valueAvailableAction ValueAvailableActionHtSine) (indicator *HtSineWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the dominant cycle is measured after smoothing 37 bars and the result after 63 bars, as in TA-Lib
+	lookback := 63
+	ind := HtSineWithoutStorage{
+		baseIndicatorWithFloatBoundsHtSine: newBaseIndicatorWithFloatBoundsHtSine(lookback, valueAvailableAction),
+		dominantCycle:                      newDominantCycle(),
+	}
+
+	return &ind, nil
+}
+
+// A Hilbert Transform - SineWave Indicator (HtSine)
+type HtSine struct {
+	*HtSineWithoutStorage
+	selectData gotrade.DOHLCVDataSelectionFunc
+
+	// public variables
+	Sine     []float64
+	LeadSine []float64
+}
+
+// NewHtSine creates a Hilbert Transform - SineWave Indicator (HtSine) for online usage
+func NewHtSine(selectData gotrade.DOHLCVDataSelectionFunc) (indicator *HtSine, err error) {
+	if selectData == nil {
+		return nil, ErrDOHLCVDataSelectFuncIsNil
+	}
+
+	ind := HtSine{
+		selectData: selectData,
+	}
+
+	ind.HtSineWithoutStorage, err = NewHtSineWithoutStorage(
+		func(dataItemSine float64, dataItemLeadSine float64, streamBarIndex int) {
+			ind.Sine = append(ind.Sine, dataItemSine)
+			ind.LeadSine = append(ind.LeadSine, dataItemLeadSine)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultHtSine creates a Hilbert Transform - SineWave Indicator (HtSine) for online usage with default parameters
+//	- selectData: the close price
+func NewDefaultHtSine() (indicator *HtSine, err error) {
+	return NewHtSine(gotrade.UseClosePrice)
+}
+
+// NewHtSineWithSrcLen creates a Hilbert Transform - SineWave Indicator (HtSine) for offline usage
+func NewHtSineWithSrcLen(sourceLength uint, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *HtSine, err error) {
+	ind, err := NewHtSine(selectData)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Sine = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+		ind.LeadSine = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultHtSineWithSrcLen creates a Hilbert Transform - SineWave Indicator (HtSine) for offline usage with default parameters
+func NewDefaultHtSineWithSrcLen(sourceLength uint) (indicator *HtSine, err error) {
+	ind, err := NewDefaultHtSine()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Sine = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+		ind.LeadSine = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewHtSineForStream creates a Hilbert Transform - SineWave Indicator (HtSine) for online usage with a source data stream
+func NewHtSineForStream(priceStream gotrade.DOHLCVStreamSubscriber, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *HtSine, err error) {
+	ind, err := NewHtSine(selectData)
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
+}
+
+// NewDefaultHtSineForStream creates a Hilbert Transform - SineWave Indicator (HtSine) for online usage with a source data stream
+func NewDefaultHtSineForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *HtSine, err error) {
+	ind, err := NewDefaultHtSine()
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
+}
+
+// NewHtSineForStreamWithSrcLen creates a Hilbert Transform - SineWave Indicator (HtSine) for offline usage with a source data stream
+func NewHtSineForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *HtSine, err error) {
+	ind, err := NewHtSineWithSrcLen(sourceLength, selectData)
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
+}
+
+// NewDefaultHtSineForStreamWithSrcLen creates a Hilbert Transform - SineWave Indicator (HtSine) for offline usage with a source data stream
+func NewDefaultHtSineForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *HtSine, err error) {
+	ind, err := NewDefaultHtSineWithSrcLen(sourceLength)
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *HtSine) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	var selectedData = ind.selectData(tickData)
+	ind.ReceiveTick(selectedData, streamBarIndex)
+}
+
+func (ind *HtSineWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	if !ind.dominantCycle.receive(tickData) {
+		return
+	}
+
+	var dcPhase float64 = ind.dominantCycle.updatePhase()
+
+	if ind.dominantCycle.barCounter > ind.GetLookbackPeriod() {
+		ind.UpdateIndicatorWithNewValue(math.Sin(dcPhase*deg2Rad), math.Sin((dcPhase+45.0)*deg2Rad), streamBarIndex)
+	}
+}
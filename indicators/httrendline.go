@@ -0,0 +1,146 @@
+// Hilbert Transform - Instantaneous Trendline (HtTrendline)
+package indicators
+
+import (
+	"github.com/jaybutera/gotrade"
+)
+
+// A Hilbert Transform - Instantaneous Trendline Indicator (HtTrendline), no storage, for use in other indicators
+type HtTrendlineWithoutStorage struct {
+	*baseIndicatorWithFloatBounds
+
+	// private variables
+	dominantCycle *dominantCycle
+}
+
+// NewHtTrendlineWithoutStorage creates a Hilbert Transform - Instantaneous Trendline Indicator (HtTrendline) without storage
+func NewHtTrendlineWithoutStorage(valueAvailableAction ValueAvailableActionFloat) (indicator *HtTrendlineWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the dominant cycle is measured after smoothing 37 bars and the result after 63 bars, as in TA-Lib
+	lookback := 63
+	ind := HtTrendlineWithoutStorage{
+		baseIndicatorWithFloatBounds: newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction),
+		dominantCycle:                newDominantCycle(),
+	}
+
+	return &ind, nil
+}
+
+// A Hilbert Transform - Instantaneous Trendline Indicator (HtTrendline)
+type HtTrendline struct {
+	*HtTrendlineWithoutStorage
+	selectData gotrade.DOHLCVDataSelectionFunc
+
+	// public variables
+	Data []float64
+}
+
+// NewHtTrendline creates a Hilbert Transform - Instantaneous Trendline Indicator (HtTrendline) for online usage
+func NewHtTrendline(selectData gotrade.DOHLCVDataSelectionFunc) (indicator *HtTrendline, err error) {
+	if selectData == nil {
+		return nil, ErrDOHLCVDataSelectFuncIsNil
+	}
+
+	ind := HtTrendline{
+		selectData: selectData,
+	}
+
+	ind.HtTrendlineWithoutStorage, err = NewHtTrendlineWithoutStorage(func(dataItem float64, streamBarIndex int) {
+		ind.Data = append(ind.Data, dataItem)
+	})
+
+	return &ind, err
+}
+
+// NewDefaultHtTrendline creates a Hilbert Transform - Instantaneous Trendline Indicator (HtTrendline) for online usage with default parameters
+//	- selectData: the close price
+func NewDefaultHtTrendline() (indicator *HtTrendline, err error) {
+	return NewHtTrendline(gotrade.UseClosePrice)
+}
+
+// NewHtTrendlineWithSrcLen creates a Hilbert Transform - Instantaneous Trendline Indicator (HtTrendline) for offline usage
+func NewHtTrendlineWithSrcLen(sourceLength uint, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *HtTrendline, err error) {
+	ind, err := NewHtTrendline(selectData)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultHtTrendlineWithSrcLen creates a Hilbert Transform - Instantaneous Trendline Indicator (HtTrendline) for offline usage with default parameters
+func NewDefaultHtTrendlineWithSrcLen(sourceLength uint) (indicator *HtTrendline, err error) {
+	ind, err := NewDefaultHtTrendline()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewHtTrendlineForStream creates a Hilbert Transform - Instantaneous Trendline Indicator (HtTrendline) for online usage with a source data stream
+func NewHtTrendlineForStream(priceStream gotrade.DOHLCVStreamSubscriber, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *HtTrendline, err error) {
+	ind, err := NewHtTrendline(selectData)
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
+}
+
+// NewDefaultHtTrendlineForStream creates a Hilbert Transform - Instantaneous Trendline Indicator (HtTrendline) for online usage with a source data stream
+func NewDefaultHtTrendlineForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *HtTrendline, err error) {
+	ind, err := NewDefaultHtTrendline()
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
+}
+
+// NewHtTrendlineForStreamWithSrcLen creates a Hilbert Transform - Instantaneous Trendline Indicator (HtTrendline) for offline usage with a source data stream
+func NewHtTrendlineForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *HtTrendline, err error) {
+	ind, err := NewHtTrendlineWithSrcLen(sourceLength, selectData)
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
+}
+
+// NewDefaultHtTrendlineForStreamWithSrcLen creates a Hilbert Transform - Instantaneous Trendline Indicator (HtTrendline) for offline usage with a source data stream
+func NewDefaultHtTrendlineForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *HtTrendline, err error) {
+	ind, err := NewDefaultHtTrendlineWithSrcLen(sourceLength)
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *HtTrendline) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	var selectedData = ind.selectData(tickData)
+	ind.ReceiveTick(selectedData, streamBarIndex)
+}
+
+func (ind *HtTrendlineWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	if !ind.dominantCycle.receive(tickData) {
+		return
+	}
+
+	var trendline float64 = ind.dominantCycle.updateTrendline()
+
+	if ind.dominantCycle.barCounter > ind.GetLookbackPeriod() {
+		ind.UpdateIndicatorWithNewValue(trendline, streamBarIndex)
+	}
+}
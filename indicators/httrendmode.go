@@ -0,0 +1,187 @@
+// Hilbert Transform - Trend vs Cycle Mode (HtTrendMode)
+package indicators
+
+import (
+	"github.com/jaybutera/gotrade"
+	"math"
+)
+
+// A Hilbert Transform - Trend vs Cycle Mode Indicator (HtTrendMode), no storage, for use in other indicators
+type HtTrendModeWithoutStorage struct {
+	*baseIndicatorWithIntBounds
+
+	// private variables
+	dominantCycle    *dominantCycle
+	daysInTrend      int
+	sine             float64
+	leadSine         float64
+	previousSine     float64
+	previousLeadSine float64
+}
+
+// NewHtTrendModeWithoutStorage creates a Hilbert Transform - Trend vs Cycle Mode Indicator (HtTrendMode) without storage
+func NewHtTrendModeWithoutStorage(valueAvailableAction ValueAvailableActionInt) (indicator *HtTrendModeWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the dominant cycle is measured after smoothing 37 bars and the result after 63 bars, as in TA-Lib
+	lookback := 63
+	ind := HtTrendModeWithoutStorage{
+		baseIndicatorWithIntBounds: newBaseIndicatorWithIntBounds(lookback, valueAvailableAction),
+		dominantCycle:              newDominantCycle(),
+	}
+
+	return &ind, nil
+}
+
+// A Hilbert Transform - Trend vs Cycle Mode Indicator (HtTrendMode)
+type HtTrendMode struct {
+	*HtTrendModeWithoutStorage
+	selectData gotrade.DOHLCVDataSelectionFunc
+
+	// public variables
+	Data []int64
+}
+
+// NewHtTrendMode creates a Hilbert Transform - Trend vs Cycle Mode Indicator (HtTrendMode) for online usage
+func NewHtTrendMode(selectData gotrade.DOHLCVDataSelectionFunc) (indicator *HtTrendMode, err error) {
+	if selectData == nil {
+		return nil, ErrDOHLCVDataSelectFuncIsNil
+	}
+
+	ind := HtTrendMode{
+		selectData: selectData,
+	}
+
+	ind.HtTrendModeWithoutStorage, err = NewHtTrendModeWithoutStorage(func(dataItem int64, streamBarIndex int) {
+		ind.Data = append(ind.Data, dataItem)
+	})
+
+	return &ind, err
+}
+
+// NewDefaultHtTrendMode creates a Hilbert Transform - Trend vs Cycle Mode Indicator (HtTrendMode) for online usage with default parameters
+//	- selectData: the close price
+func NewDefaultHtTrendMode() (indicator *HtTrendMode, err error) {
+	return NewHtTrendMode(gotrade.UseClosePrice)
+}
+
+// NewHtTrendModeWithSrcLen creates a Hilbert Transform - Trend vs Cycle Mode Indicator (HtTrendMode) for offline usage
+func NewHtTrendModeWithSrcLen(sourceLength uint, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *HtTrendMode, err error) {
+	ind, err := NewHtTrendMode(selectData)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]int64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultHtTrendModeWithSrcLen creates a Hilbert Transform - Trend vs Cycle Mode Indicator (HtTrendMode) for offline usage with default parameters
+func NewDefaultHtTrendModeWithSrcLen(sourceLength uint) (indicator *HtTrendMode, err error) {
+	ind, err := NewDefaultHtTrendMode()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]int64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewHtTrendModeForStream creates a Hilbert Transform - Trend vs Cycle Mode Indicator (HtTrendMode) for online usage with a source data stream
+func NewHtTrendModeForStream(priceStream gotrade.DOHLCVStreamSubscriber, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *HtTrendMode, err error) {
+	ind, err := NewHtTrendMode(selectData)
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
+}
+
+// NewDefaultHtTrendModeForStream creates a Hilbert Transform - Trend vs Cycle Mode Indicator (HtTrendMode) for online usage with a source data stream
+func NewDefaultHtTrendModeForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *HtTrendMode, err error) {
+	ind, err := NewDefaultHtTrendMode()
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
+}
+
+// NewHtTrendModeForStreamWithSrcLen creates a Hilbert Transform - Trend vs Cycle Mode Indicator (HtTrendMode) for offline usage with a source data stream
+func NewHtTrendModeForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *HtTrendMode, err error) {
+	ind, err := NewHtTrendModeWithSrcLen(sourceLength, selectData)
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
+}
+
+// NewDefaultHtTrendModeForStreamWithSrcLen creates a Hilbert Transform - Trend vs Cycle Mode Indicator (HtTrendMode) for offline usage with a source data stream
+func NewDefaultHtTrendModeForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *HtTrendMode, err error) {
+	ind, err := NewDefaultHtTrendModeWithSrcLen(sourceLength)
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *HtTrendMode) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	var selectedData = ind.selectData(tickData)
+	ind.ReceiveTick(selectedData, streamBarIndex)
+}
+
+func (ind *HtTrendModeWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	if !ind.dominantCycle.receive(tickData) {
+		return
+	}
+
+	var previousDcPhase float64 = ind.dominantCycle.dcPhase
+	var dcPhase float64 = ind.dominantCycle.updatePhase()
+	var smoothPeriod float64 = ind.dominantCycle.smoothPeriod
+
+	ind.previousSine = ind.sine
+	ind.previousLeadSine = ind.leadSine
+	ind.sine = math.Sin(dcPhase * deg2Rad)
+	ind.leadSine = math.Sin((dcPhase + 45.0) * deg2Rad)
+
+	var trendline float64 = ind.dominantCycle.updateTrendline()
+
+	// a crossing of the sine and lead sine starts a cycle
+	var trend int64 = 1
+	if (ind.sine > ind.leadSine && ind.previousSine <= ind.previousLeadSine) ||
+		(ind.sine < ind.leadSine && ind.previousSine >= ind.previousLeadSine) {
+		ind.daysInTrend = 0
+		trend = 0
+	}
+
+	// a trend must last for at least half a cycle
+	ind.daysInTrend += 1
+	if float64(ind.daysInTrend) < 0.5*smoothPeriod {
+		trend = 0
+	}
+
+	// the phase advancing at the rate of the dominant cycle is a cycle
+	var deltaPhase float64 = dcPhase - previousDcPhase
+	if smoothPeriod != 0.0 && deltaPhase > 0.67*360.0/smoothPeriod && deltaPhase < 1.5*360.0/smoothPeriod {
+		trend = 0
+	}
+
+	// a price far enough from the trendline is a trend
+	var smoothPrice float64 = ind.dominantCycle.currentSmoothPrice()
+	if trendline != 0.0 && math.Abs((smoothPrice-trendline)/trendline) >= 0.015 {
+		trend = 1
+	}
+
+	if ind.dominantCycle.barCounter > ind.GetLookbackPeriod() {
+		ind.UpdateIndicatorWithNewValue(trend, streamBarIndex)
+	}
+}
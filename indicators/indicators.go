@@ -7,6 +7,24 @@
 		- maximum and minimum bounds of the transformed results are calculated automatically.
 		- a lookback period indicating the lag between source data and the transformed result.
 		- the source data bar from which the indicator is valid
+		- publishing the transformed result to the indicators attached to it.
+
+	Indicators with a single float result are float streams that other indicators can be attached to,
+	e.g. an sma of an atr:
+
+		atr, _ := indicators.NewAtrForStream(priceStream, 14)
+		sma, _ := indicators.NewSma(5, gotrade.UseClosePrice)
+		atr.AddTickSubscription(sma)
+
+	Indicators with several results provide a float stream for each result, e.g. UpperBandStream.
+	Results keep the bar number of the source data bar they were calculated from, so the valid from bar
+	of an attached indicator is relative to the source data stream.
+
+	An indicator created for a data stream keeps its subscription to the stream, Close detaches it:
+
+		sma, _ := indicators.NewSmaForStream(priceStream, 20, gotrade.UseClosePrice)
+		...
+		sma.Close()
 
  	Functions are provided for each indicator that provide indicator creation
  	for the following scenarios:
@@ -30,6 +48,7 @@ package indicators
 import (
 	"errors"
 	"github.com/jaybutera/gotrade"
+	"io"
 	"math"
 )
 
@@ -139,6 +158,8 @@ type baseIndicator struct {
 	validFromBar   int
 	dataLength     int
 	lookbackPeriod int
+	// the subscription to the source data stream of an indicator created for a stream
+	subscription io.Closer
 }
 
 func newBaseIndicator(lookbackPeriod int) *baseIndicator {
@@ -158,6 +179,18 @@ func (ind *baseIndicator) Length() int {
 	return ind.dataLength
 }
 
+// Close detaches an indicator created for a source data stream from the stream, it receives no further ticks.
+// Closing an indicator that was not created for a stream, or closing it again, does nothing.
+func (ind *baseIndicator) Close() error {
+	if ind.subscription == nil {
+		return nil
+	}
+
+	subscription := ind.subscription
+	ind.subscription = nil
+	return subscription.Close()
+}
+
 func (ind *baseIndicator) IncDataLength() {
 	ind.dataLength += 1
 }
@@ -177,6 +210,7 @@ type baseIndicatorWithTimePeriod struct {
 type baseIndicatorWithFloatBounds struct {
 	*baseIndicator
 	*baseFloatBounds
+	*gotrade.FloatStream
 	valueAvailableAction ValueAvailableActionFloat
 }
 
@@ -184,6 +218,7 @@ func newBaseIndicatorWithFloatBounds(lookbackPeriod int, valueAvailableAction Va
 	ind := baseIndicatorWithFloatBounds{
 		baseIndicator:        newBaseIndicator(lookbackPeriod),
 		baseFloatBounds:      newBaseFloatBounds(),
+		FloatStream:          gotrade.NewFloatStream(),
 		valueAvailableAction: valueAvailableAction,
 	}
 	return &ind
@@ -201,12 +236,17 @@ func (ind *baseIndicatorWithFloatBounds) UpdateIndicatorWithNewValue(newValue fl
 
 	// notify of a new result value though the value available action
 	ind.valueAvailableAction(newValue, streamBarIndex)
+
+	// notify the indicators attached to this indicator
+	ind.PublishTick(newValue, streamBarIndex)
 }
 
 type baseIndicatorWithFloatBoundsAroon struct {
 	*baseIndicator
 	*baseFloatBounds
 	valueAvailableAction ValueAvailableActionAroon
+	aroonUpStream        *gotrade.FloatStream
+	aroonDownStream      *gotrade.FloatStream
 }
 
 func newBaseIndicatorWithFloatBoundsAroon(lookbackPeriod int, valueAvailableAction ValueAvailableActionAroon) *baseIndicatorWithFloatBoundsAroon {
@@ -214,6 +254,8 @@ func newBaseIndicatorWithFloatBoundsAroon(lookbackPeriod int, valueAvailableActi
 		baseIndicator:        newBaseIndicator(lookbackPeriod),
 		baseFloatBounds:      newBaseFloatBounds(),
 		valueAvailableAction: valueAvailableAction,
+		aroonUpStream:        gotrade.NewFloatStream(),
+		aroonDownStream:      gotrade.NewFloatStream(),
 	}
 	return &ind
 }
@@ -230,12 +272,29 @@ func (ind *baseIndicatorWithFloatBoundsAroon) UpdateIndicatorWithNewValue(newAro
 
 	// notify of a new result value though the value available action
 	ind.valueAvailableAction(newAroonUpValue, newAroonDwnValue, streamBarIndex)
+
+	// notify the indicators attached to this indicator
+	ind.aroonUpStream.PublishTick(newAroonUpValue, streamBarIndex)
+	ind.aroonDownStream.PublishTick(newAroonDwnValue, streamBarIndex)
+}
+
+// AroonUpStream returns the stream of the aroon up results, for attaching other indicators
+func (ind *baseIndicatorWithFloatBoundsAroon) AroonUpStream() *gotrade.FloatStream {
+	return ind.aroonUpStream
+}
+
+// AroonDownStream returns the stream of the aroon down results, for attaching other indicators
+func (ind *baseIndicatorWithFloatBoundsAroon) AroonDownStream() *gotrade.FloatStream {
+	return ind.aroonDownStream
 }
 
 type baseIndicatorWithFloatBoundsBollinger struct {
 	*baseIndicator
 	*baseFloatBounds
 	valueAvailableAction ValueAvailableActionBollinger
+	upperBandStream      *gotrade.FloatStream
+	middleBandStream     *gotrade.FloatStream
+	lowerBandStream      *gotrade.FloatStream
 }
 
 func newBaseIndicatorWithFloatBoundsBollinger(lookbackPeriod int, valueAvailableAction ValueAvailableActionBollinger) *baseIndicatorWithFloatBoundsBollinger {
@@ -243,6 +302,9 @@ func newBaseIndicatorWithFloatBoundsBollinger(lookbackPeriod int, valueAvailable
 		baseIndicator:        newBaseIndicator(lookbackPeriod),
 		baseFloatBounds:      newBaseFloatBounds(),
 		valueAvailableAction: valueAvailableAction,
+		upperBandStream:      gotrade.NewFloatStream(),
+		middleBandStream:     gotrade.NewFloatStream(),
+		lowerBandStream:      gotrade.NewFloatStream(),
 	}
 	return &ind
 }
@@ -259,12 +321,34 @@ func (ind *baseIndicatorWithFloatBoundsBollinger) UpdateIndicatorWithNewValue(ne
 
 	// notify of a new result value though the value available action
 	ind.valueAvailableAction(newUpperBandValue, newMiddleBandValue, newLowerBandValue, streamBarIndex)
+
+	// notify the indicators attached to this indicator
+	ind.upperBandStream.PublishTick(newUpperBandValue, streamBarIndex)
+	ind.middleBandStream.PublishTick(newMiddleBandValue, streamBarIndex)
+	ind.lowerBandStream.PublishTick(newLowerBandValue, streamBarIndex)
+}
+
+// UpperBandStream returns the stream of the upper band results, for attaching other indicators
+func (ind *baseIndicatorWithFloatBoundsBollinger) UpperBandStream() *gotrade.FloatStream {
+	return ind.upperBandStream
+}
+
+// MiddleBandStream returns the stream of the middle band results, for attaching other indicators
+func (ind *baseIndicatorWithFloatBoundsBollinger) MiddleBandStream() *gotrade.FloatStream {
+	return ind.middleBandStream
+}
+
+// LowerBandStream returns the stream of the lower band results, for attaching other indicators
+func (ind *baseIndicatorWithFloatBoundsBollinger) LowerBandStream() *gotrade.FloatStream {
+	return ind.lowerBandStream
 }
 
 type baseIndicatorWithFloatBoundsStoch struct {
 	*baseIndicator
 	*baseFloatBounds
 	valueAvailableAction ValueAvailableActionStoch
+	kStream              *gotrade.FloatStream
+	dStream              *gotrade.FloatStream
 }
 
 func newBaseIndicatorWithFloatBoundsStoch(lookbackPeriod int, valueAvailableAction ValueAvailableActionStoch) *baseIndicatorWithFloatBoundsStoch {
@@ -272,6 +356,8 @@ func newBaseIndicatorWithFloatBoundsStoch(lookbackPeriod int, valueAvailableActi
 		baseIndicator:        newBaseIndicator(lookbackPeriod),
 		baseFloatBounds:      newBaseFloatBounds(),
 		valueAvailableAction: valueAvailableAction,
+		kStream:              gotrade.NewFloatStream(),
+		dStream:              gotrade.NewFloatStream(),
 	}
 	return &ind
 }
@@ -291,11 +377,176 @@ func (ind *baseIndicatorWithFloatBoundsStoch) UpdateIndicatorWithNewValue(newSlo
 
 	// notify of a new result value though the value available action
 	ind.valueAvailableAction(newSlowKValue, newSlowDValue, streamBarIndex)
+
+	// notify the indicators attached to this indicator
+	ind.kStream.PublishTick(newSlowKValue, streamBarIndex)
+	ind.dStream.PublishTick(newSlowDValue, streamBarIndex)
+}
+
+// KStream returns the stream of the %K results, for attaching other indicators
+func (ind *baseIndicatorWithFloatBoundsStoch) KStream() *gotrade.FloatStream {
+	return ind.kStream
+}
+
+// DStream returns the stream of the %D results, for attaching other indicators
+func (ind *baseIndicatorWithFloatBoundsStoch) DStream() *gotrade.FloatStream {
+	return ind.dStream
+}
+
+type baseIndicatorWithFloatBoundsMama struct {
+	*baseIndicator
+	*baseFloatBounds
+	valueAvailableAction ValueAvailableActionMama
+	mamaStream           *gotrade.FloatStream
+	famaStream           *gotrade.FloatStream
+}
+
+func newBaseIndicatorWithFloatBoundsMama(lookbackPeriod int, valueAvailableAction ValueAvailableActionMama) *baseIndicatorWithFloatBoundsMama {
+	ind := baseIndicatorWithFloatBoundsMama{
+		baseIndicator:        newBaseIndicator(lookbackPeriod),
+		baseFloatBounds:      newBaseFloatBounds(),
+		valueAvailableAction: valueAvailableAction,
+		mamaStream:           gotrade.NewFloatStream(),
+		famaStream:           gotrade.NewFloatStream(),
+	}
+	return &ind
+}
+
+func (ind *baseIndicatorWithFloatBoundsMama) UpdateIndicatorWithNewValue(newMamaValue float64, newFamaValue float64, streamBarIndex int) {
+	// increment the number of results this indicator can be expected to return
+	ind.IncDataLength()
+
+	// set the streamBarIndex from which this indicator returns valid results
+	ind.SetValidFromBar(streamBarIndex)
+
+	var max = math.Max(newMamaValue, newFamaValue)
+	var min = math.Min(newMamaValue, newFamaValue)
+
+	// update the min max data bounds
+	ind.UpdateMinMax(min, max)
+
+	// notify of a new result value though the value available action
+	ind.valueAvailableAction(newMamaValue, newFamaValue, streamBarIndex)
+
+	// notify the indicators attached to this indicator
+	ind.mamaStream.PublishTick(newMamaValue, streamBarIndex)
+	ind.famaStream.PublishTick(newFamaValue, streamBarIndex)
+}
+
+// MamaStream returns the stream of the mama results, for attaching other indicators
+func (ind *baseIndicatorWithFloatBoundsMama) MamaStream() *gotrade.FloatStream {
+	return ind.mamaStream
+}
+
+// FamaStream returns the stream of the fama results, for attaching other indicators
+func (ind *baseIndicatorWithFloatBoundsMama) FamaStream() *gotrade.FloatStream {
+	return ind.famaStream
+}
+
+type baseIndicatorWithFloatBoundsHtPhasor struct {
+	*baseIndicator
+	*baseFloatBounds
+	valueAvailableAction ValueAvailableActionHtPhasor
+	inPhaseStream        *gotrade.FloatStream
+	quadratureStream     *gotrade.FloatStream
+}
+
+func newBaseIndicatorWithFloatBoundsHtPhasor(lookbackPeriod int, valueAvailableAction ValueAvailableActionHtPhasor) *baseIndicatorWithFloatBoundsHtPhasor {
+	ind := baseIndicatorWithFloatBoundsHtPhasor{
+		baseIndicator:        newBaseIndicator(lookbackPeriod),
+		baseFloatBounds:      newBaseFloatBounds(),
+		valueAvailableAction: valueAvailableAction,
+		inPhaseStream:        gotrade.NewFloatStream(),
+		quadratureStream:     gotrade.NewFloatStream(),
+	}
+	return &ind
+}
+
+func (ind *baseIndicatorWithFloatBoundsHtPhasor) UpdateIndicatorWithNewValue(newInPhaseValue float64, newQuadratureValue float64, streamBarIndex int) {
+	// increment the number of results this indicator can be expected to return
+	ind.IncDataLength()
+
+	// set the streamBarIndex from which this indicator returns valid results
+	ind.SetValidFromBar(streamBarIndex)
+
+	var max = math.Max(newInPhaseValue, newQuadratureValue)
+	var min = math.Min(newInPhaseValue, newQuadratureValue)
+
+	// update the min max data bounds
+	ind.UpdateMinMax(min, max)
+
+	// notify of a new result value though the value available action
+	ind.valueAvailableAction(newInPhaseValue, newQuadratureValue, streamBarIndex)
+
+	// notify the indicators attached to this indicator
+	ind.inPhaseStream.PublishTick(newInPhaseValue, streamBarIndex)
+	ind.quadratureStream.PublishTick(newQuadratureValue, streamBarIndex)
+}
+
+// InPhaseStream returns the stream of the in phase results, for attaching other indicators
+func (ind *baseIndicatorWithFloatBoundsHtPhasor) InPhaseStream() *gotrade.FloatStream {
+	return ind.inPhaseStream
+}
+
+// QuadratureStream returns the stream of the quadrature results, for attaching other indicators
+func (ind *baseIndicatorWithFloatBoundsHtPhasor) QuadratureStream() *gotrade.FloatStream {
+	return ind.quadratureStream
+}
+
+type baseIndicatorWithFloatBoundsHtSine struct {
+	*baseIndicator
+	*baseFloatBounds
+	valueAvailableAction ValueAvailableActionHtSine
+	sineStream           *gotrade.FloatStream
+	leadSineStream       *gotrade.FloatStream
+}
+
+func newBaseIndicatorWithFloatBoundsHtSine(lookbackPeriod int, valueAvailableAction ValueAvailableActionHtSine) *baseIndicatorWithFloatBoundsHtSine {
+	ind := baseIndicatorWithFloatBoundsHtSine{
+		baseIndicator:        newBaseIndicator(lookbackPeriod),
+		baseFloatBounds:      newBaseFloatBounds(),
+		valueAvailableAction: valueAvailableAction,
+		sineStream:           gotrade.NewFloatStream(),
+		leadSineStream:       gotrade.NewFloatStream(),
+	}
+	return &ind
+}
+
+func (ind *baseIndicatorWithFloatBoundsHtSine) UpdateIndicatorWithNewValue(newSineValue float64, newLeadSineValue float64, streamBarIndex int) {
+	// increment the number of results this indicator can be expected to return
+	ind.IncDataLength()
+
+	// set the streamBarIndex from which this indicator returns valid results
+	ind.SetValidFromBar(streamBarIndex)
+
+	var max = math.Max(newSineValue, newLeadSineValue)
+	var min = math.Min(newSineValue, newLeadSineValue)
+
+	// update the min max data bounds
+	ind.UpdateMinMax(min, max)
+
+	// notify of a new result value though the value available action
+	ind.valueAvailableAction(newSineValue, newLeadSineValue, streamBarIndex)
+
+	// notify the indicators attached to this indicator
+	ind.sineStream.PublishTick(newSineValue, streamBarIndex)
+	ind.leadSineStream.PublishTick(newLeadSineValue, streamBarIndex)
+}
+
+// SineStream returns the stream of the sine results, for attaching other indicators
+func (ind *baseIndicatorWithFloatBoundsHtSine) SineStream() *gotrade.FloatStream {
+	return ind.sineStream
+}
+
+// LeadSineStream returns the stream of the lead sine results, for attaching other indicators
+func (ind *baseIndicatorWithFloatBoundsHtSine) LeadSineStream() *gotrade.FloatStream {
+	return ind.leadSineStream
 }
 
 type baseIndicatorWithIntBounds struct {
 	*baseIndicator
 	*baseIntBounds
+	*gotrade.FloatStream
 	valueAvailableAction ValueAvailableActionInt
 }
 
@@ -303,6 +554,7 @@ func newBaseIndicatorWithIntBounds(lookbackPeriod int, valueAvailableAction Valu
 	ind := baseIndicatorWithIntBounds{
 		baseIndicator:        newBaseIndicator(lookbackPeriod),
 		baseIntBounds:        newBaseIntBounds(),
+		FloatStream:          gotrade.NewFloatStream(),
 		valueAvailableAction: valueAvailableAction,
 	}
 	return &ind
@@ -320,6 +572,9 @@ func (ind *baseIndicatorWithIntBounds) UpdateIndicatorWithNewValue(newValue int6
 
 	// notify of a new result value though the value available action
 	ind.valueAvailableAction(newValue, streamBarIndex)
+
+	// notify the indicators attached to this indicator, which receive the result as a float
+	ind.PublishTick(float64(newValue), streamBarIndex)
 }
 
 type ValueAvailableActionFloat func(dataItem float64, streamBarIndex int)
@@ -330,3 +585,6 @@ type ValueAvailableActionMacd func(dataItemMacd float64, dataItemSignal float64,
 type ValueAvailableActionAroon func(dataItemAroonUp float64, dataItemAroonDown float64, streamBarIndex int)
 type ValueAvailableActionStoch func(dataItemK float64, dataItemD float64, streamBarIndex int)
 type ValueAvailableActionLinearReg func(dataItem float64, slope float64, intercept float64, streamBarIndex int)
+type ValueAvailableActionMama func(dataItemMama float64, dataItemFama float64, streamBarIndex int)
+type ValueAvailableActionHtPhasor func(dataItemInPhase float64, dataItemQuadrature float64, streamBarIndex int)
+type ValueAvailableActionHtSine func(dataItemSine float64, dataItemLeadSine float64, streamBarIndex int)
@@ -27,6 +27,7 @@ var _ = Describe("when executing the gotrade simple moving average with a years
 		BeforeEach(func() {
 			period = 10
 			sma, err = indicators.NewSma(period, gotrade.UseClosePrice)
+			Expect(err).To(BeNil())
 			priceStream.AddTickSubscription(sma)
 			csvFeed.FillDOHLCVStream(priceStream)
 		})
@@ -63,6 +64,7 @@ var _ = Describe("when executing the gotrade exponential moving average with a y
 		BeforeEach(func() {
 			period = 10
 			ema, err = indicators.NewEma(period, gotrade.UseClosePrice)
+			Expect(err).To(BeNil())
 			priceStream.AddTickSubscription(ema)
 			csvFeed.FillDOHLCVStream(priceStream)
 		})
@@ -99,6 +101,7 @@ var _ = Describe("when executing the gotrade weighted moving average with a year
 		BeforeEach(func() {
 			period = 10
 			wma, err = indicators.NewWma(period, gotrade.UseClosePrice)
+			Expect(err).To(BeNil())
 			priceStream.AddTickSubscription(wma)
 			csvFeed.FillDOHLCVStream(priceStream)
 		})
@@ -135,6 +138,7 @@ var _ = Describe("when executing the gotrade double exponential moving average w
 		BeforeEach(func() {
 			period = 10
 			dema, err = indicators.NewDema(period, gotrade.UseClosePrice)
+			Expect(err).To(BeNil())
 			priceStream.AddTickSubscription(dema)
 			csvFeed.FillDOHLCVStream(priceStream)
 		})
@@ -171,6 +175,7 @@ var _ = Describe("when executing the gotrade triple exponential moving average w
 		BeforeEach(func() {
 			period = 10
 			tema, err = indicators.NewTema(period, gotrade.UseClosePrice)
+			Expect(err).To(BeNil())
 			priceStream.AddTickSubscription(tema)
 			csvFeed.FillDOHLCVStream(priceStream)
 		})
@@ -207,6 +212,7 @@ var _ = Describe("when executing the gotrade variance with a years data and know
 		BeforeEach(func() {
 			period = 10
 			variance, err = indicators.NewVar(period, gotrade.UseClosePrice)
+			Expect(err).To(BeNil())
 			priceStream.AddTickSubscription(variance)
 			csvFeed.FillDOHLCVStream(priceStream)
 		})
@@ -243,6 +249,7 @@ var _ = Describe("when executing the gotrade standard deviation with a years dat
 		BeforeEach(func() {
 			period = 10
 			stdDev, err = indicators.NewStdDev(period, gotrade.UseClosePrice)
+			Expect(err).To(BeNil())
 			priceStream.AddTickSubscription(stdDev)
 			csvFeed.FillDOHLCVStream(priceStream)
 		})
@@ -279,6 +286,7 @@ var _ = Describe("when executing the gotrade bollinger bands with a years data a
 		BeforeEach(func() {
 			period = 10
 			bb, err = indicators.NewBollingerBands(period, gotrade.UseClosePrice)
+			Expect(err).To(BeNil())
 			priceStream.AddTickSubscription(bb)
 			csvFeed.FillDOHLCVStream(priceStream)
 		})
@@ -315,6 +323,7 @@ var _ = Describe("when executing the gotrade macd with a years data and known ou
 
 		BeforeEach(func() {
 			macd, err = indicators.NewMacd(12, 26, 9, gotrade.UseClosePrice)
+			Expect(err).To(BeNil())
 			priceStream.AddTickSubscription(macd)
 			csvFeed.FillDOHLCVStream(priceStream)
 		})
@@ -351,6 +360,7 @@ var _ = Describe("when executing the gotrade aroon with a years data and known o
 
 		BeforeEach(func() {
 			aroon, err = indicators.NewAroon(25)
+			Expect(err).To(BeNil())
 			priceStream.AddTickSubscription(aroon)
 			csvFeed.FillDOHLCVStream(priceStream)
 		})
@@ -391,6 +401,7 @@ var _ = Describe("when executing the gotrade aroonosc with a years data and know
 
 		BeforeEach(func() {
 			aroon, err = indicators.NewAroonOsc(25)
+			Expect(err).To(BeNil())
 			priceStream.AddTickSubscription(aroon)
 			csvFeed.FillDOHLCVStream(priceStream)
 		})
@@ -425,6 +436,7 @@ var _ = Describe("when executing the gotrade truerange with a years data and kno
 
 		BeforeEach(func() {
 			trueRange, err = indicators.NewTrueRange()
+			Expect(err).To(BeNil())
 			priceStream.AddTickSubscription(trueRange)
 			csvFeed.FillDOHLCVStream(priceStream)
 		})
@@ -459,6 +471,7 @@ var _ = Describe("when executing the gotrade average truerange with a years data
 
 		BeforeEach(func() {
 			avgTrueRange, err = indicators.NewAtr(14)
+			Expect(err).To(BeNil())
 			priceStream.AddTickSubscription(avgTrueRange)
 			csvFeed.FillDOHLCVStream(priceStream)
 		})
@@ -493,6 +506,7 @@ var _ = Describe("when executing the gotrade accumulation distribution line with
 
 		BeforeEach(func() {
 			adl, err = indicators.NewAdl()
+			Expect(err).To(BeNil())
 			priceStream.AddTickSubscription(adl)
 			csvFeed.FillDOHLCVStream(priceStream)
 		})
@@ -530,7 +544,8 @@ var _ = Describe("when executing the gotrade chaikin oscilator with a years data
 	Describe("using no a fast Time Period of 3 and a slow Time Period of 10", func() {
 
 		BeforeEach(func() {
-			chaikinOsc, err = indicators.NewChaikinOsc(3, 10)
+			chaikinOsc, err = indicators.NewChaikinOsc(fastPeriod, slowPeriod)
+			Expect(err).To(BeNil())
 			priceStream.AddTickSubscription(chaikinOsc)
 			csvFeed.FillDOHLCVStream(priceStream)
 		})
@@ -565,6 +580,7 @@ var _ = Describe("when executing the gotrade on balance volume indicator with a
 
 		BeforeEach(func() {
 			obv, err = indicators.NewObv()
+			Expect(err).To(BeNil())
 			priceStream.AddTickSubscription(obv)
 			csvFeed.FillDOHLCVStream(priceStream)
 		})
@@ -599,6 +615,7 @@ var _ = Describe("when executing the gotrade average price indicator with a year
 
 		BeforeEach(func() {
 			avgPrice, err = indicators.NewAvgPrice()
+			Expect(err).To(BeNil())
 			priceStream.AddTickSubscription(avgPrice)
 			csvFeed.FillDOHLCVStream(priceStream)
 		})
@@ -633,6 +650,7 @@ var _ = Describe("when executing the gotrade median price indicator with a years
 
 		BeforeEach(func() {
 			medPrice, err = indicators.NewMedPrice()
+			Expect(err).To(BeNil())
 			priceStream.AddTickSubscription(medPrice)
 			csvFeed.FillDOHLCVStream(priceStream)
 		})
@@ -667,6 +685,7 @@ var _ = Describe("when executing the gotrade typical price indicator with a year
 
 		BeforeEach(func() {
 			typPrice, err = indicators.NewTypPrice()
+			Expect(err).To(BeNil())
 			priceStream.AddTickSubscription(typPrice)
 			csvFeed.FillDOHLCVStream(priceStream)
 		})
@@ -701,6 +720,7 @@ var _ = Describe("when executing the gotrade plus directional movement indicator
 
 		BeforeEach(func() {
 			plusDM, err = indicators.NewPlusDm(1)
+			Expect(err).To(BeNil())
 			priceStream.AddTickSubscription(plusDM)
 			csvFeed.FillDOHLCVStream(priceStream)
 		})
@@ -735,6 +755,7 @@ var _ = Describe("when executing the gotrade plus directional movement indicator
 
 		BeforeEach(func() {
 			plusDM, err = indicators.NewPlusDm(14)
+			Expect(err).To(BeNil())
 			priceStream.AddTickSubscription(plusDM)
 			csvFeed.FillDOHLCVStream(priceStream)
 		})
@@ -769,6 +790,7 @@ var _ = Describe("when executing the gotrade minus directional movement indicato
 
 		BeforeEach(func() {
 			minusDM, err = indicators.NewMinusDm(1)
+			Expect(err).To(BeNil())
 			priceStream.AddTickSubscription(minusDM)
 			csvFeed.FillDOHLCVStream(priceStream)
 		})
@@ -803,6 +825,7 @@ var _ = Describe("when executing the gotrade minus directional movement indicato
 
 		BeforeEach(func() {
 			minusDM, err = indicators.NewMinusDm(14)
+			Expect(err).To(BeNil())
 			priceStream.AddTickSubscription(minusDM)
 			csvFeed.FillDOHLCVStream(priceStream)
 		})
@@ -837,6 +860,7 @@ var _ = Describe("when executing the gotrade plus directional indicator (1) with
 
 		BeforeEach(func() {
 			plusDI, err = indicators.NewPlusDi(1)
+			Expect(err).To(BeNil())
 			priceStream.AddTickSubscription(plusDI)
 			csvFeed.FillDOHLCVStream(priceStream)
 		})
@@ -871,6 +895,7 @@ var _ = Describe("when executing the gotrade plus directional indicator (14) wit
 
 		BeforeEach(func() {
 			plusDI, err = indicators.NewPlusDi(14)
+			Expect(err).To(BeNil())
 			priceStream.AddTickSubscription(plusDI)
 			csvFeed.FillDOHLCVStream(priceStream)
 		})
@@ -905,6 +930,7 @@ var _ = Describe("when executing the gotrade minus directional indicator (1) wit
 
 		BeforeEach(func() {
 			minusDI, err = indicators.NewMinusDi(1)
+			Expect(err).To(BeNil())
 			priceStream.AddTickSubscription(minusDI)
 			csvFeed.FillDOHLCVStream(priceStream)
 		})
@@ -939,6 +965,7 @@ var _ = Describe("when executing the gotrade minus directional indicator (14) wi
 
 		BeforeEach(func() {
 			minusDI, err = indicators.NewMinusDi(14)
+			Expect(err).To(BeNil())
 			priceStream.AddTickSubscription(minusDI)
 			csvFeed.FillDOHLCVStream(priceStream)
 		})
@@ -973,6 +1000,7 @@ var _ = Describe("when executing the gotrade directional movement indicator (14)
 
 		BeforeEach(func() {
 			dx, err = indicators.NewDx(14)
+			Expect(err).To(BeNil())
 			priceStream.AddTickSubscription(dx)
 			csvFeed.FillDOHLCVStream(priceStream)
 		})
@@ -1007,6 +1035,7 @@ var _ = Describe("when executing the gotrade average directional movement indica
 
 		BeforeEach(func() {
 			adx, err = indicators.NewAdx(14)
+			Expect(err).To(BeNil())
 			priceStream.AddTickSubscription(adx)
 			csvFeed.FillDOHLCVStream(priceStream)
 		})
@@ -1041,6 +1070,7 @@ var _ = Describe("when executing the gotrade average directional movement rating
 
 		BeforeEach(func() {
 			adxr, err = indicators.NewAdxr(14)
+			Expect(err).To(BeNil())
 			priceStream.AddTickSubscription(adxr)
 			csvFeed.FillDOHLCVStream(priceStream)
 		})
@@ -1077,6 +1107,7 @@ var _ = Describe("when executing the gotrade relative strength index with a year
 		BeforeEach(func() {
 			period = 14
 			rsi, err = indicators.NewRsi(period, gotrade.UseClosePrice)
+			Expect(err).To(BeNil())
 			priceStream.AddTickSubscription(rsi)
 			csvFeed.FillDOHLCVStream(priceStream)
 		})
@@ -1113,6 +1144,7 @@ var _ = Describe("when executing the gotrade momentum with a years data and know
 		BeforeEach(func() {
 			period = 10
 			ind, err = indicators.NewMom(period, gotrade.UseClosePrice)
+			Expect(err).To(BeNil())
 			priceStream.AddTickSubscription(ind)
 			csvFeed.FillDOHLCVStream(priceStream)
 		})
@@ -1149,6 +1181,7 @@ var _ = Describe("when executing the gotrade rate of change with a years data an
 		BeforeEach(func() {
 			period = 10
 			ind, err = indicators.NewRoc(period, gotrade.UseClosePrice)
+			Expect(err).To(BeNil())
 			priceStream.AddTickSubscription(ind)
 			csvFeed.FillDOHLCVStream(priceStream)
 		})
@@ -1185,6 +1218,7 @@ var _ = Describe("when executing the gotrade rate of change percentage with a ye
 		BeforeEach(func() {
 			period = 10
 			ind, err = indicators.NewRocP(period, gotrade.UseClosePrice)
+			Expect(err).To(BeNil())
 			priceStream.AddTickSubscription(ind)
 			csvFeed.FillDOHLCVStream(priceStream)
 		})
@@ -1221,6 +1255,7 @@ var _ = Describe("when executing the gotrade rate of change ratio with a years d
 		BeforeEach(func() {
 			period = 10
 			ind, err = indicators.NewRocR(period, gotrade.UseClosePrice)
+			Expect(err).To(BeNil())
 			priceStream.AddTickSubscription(ind)
 			csvFeed.FillDOHLCVStream(priceStream)
 		})
@@ -1257,6 +1292,7 @@ var _ = Describe("when executing the gotrade rate of change ratio 100 scale with
 		BeforeEach(func() {
 			period = 10
 			ind, err = indicators.NewRocR100(period, gotrade.UseClosePrice)
+			Expect(err).To(BeNil())
 			priceStream.AddTickSubscription(ind)
 			csvFeed.FillDOHLCVStream(priceStream)
 		})
@@ -1291,6 +1327,7 @@ var _ = Describe("when executing the gotrade money flow index (14) with a years
 
 		BeforeEach(func() {
 			ind, err = indicators.NewMfi(14)
+			Expect(err).To(BeNil())
 			priceStream.AddTickSubscription(ind)
 			csvFeed.FillDOHLCVStream(priceStream)
 		})
@@ -1325,6 +1362,7 @@ var _ = Describe("when executing the gotrade parabolic stop and reverse (Sar) wi
 
 		BeforeEach(func() {
 			ind, err = indicators.NewSar(0.02, 0.20)
+			Expect(err).To(BeNil())
 			priceStream.AddTickSubscription(ind)
 			csvFeed.FillDOHLCVStream(priceStream)
 		})
@@ -1359,6 +1397,7 @@ var _ = Describe("when executing the gotrade linearregression (LinReg) with a ye
 
 		BeforeEach(func() {
 			ind, err = indicators.NewLinReg(14, gotrade.UseClosePrice)
+			Expect(err).To(BeNil())
 			priceStream.AddTickSubscription(ind)
 			csvFeed.FillDOHLCVStream(priceStream)
 		})
@@ -1393,6 +1432,7 @@ var _ = Describe("when executing the gotrade linearregression slope (LinRegSlp)
 
 		BeforeEach(func() {
 			ind, err = indicators.NewLinRegSlp(14, gotrade.UseClosePrice)
+			Expect(err).To(BeNil())
 			priceStream.AddTickSubscription(ind)
 			csvFeed.FillDOHLCVStream(priceStream)
 		})
@@ -1427,6 +1467,7 @@ var _ = Describe("when executing the gotrade linearregression intercept (LinRegI
 
 		BeforeEach(func() {
 			ind, err = indicators.NewLinRegInt(14, gotrade.UseClosePrice)
+			Expect(err).To(BeNil())
 			priceStream.AddTickSubscription(ind)
 			csvFeed.FillDOHLCVStream(priceStream)
 		})
@@ -1461,6 +1502,7 @@ var _ = Describe("when executing the gotrade linearregression angle (LinRegAng)
 
 		BeforeEach(func() {
 			ind, err = indicators.NewLinRegAng(14, gotrade.UseClosePrice)
+			Expect(err).To(BeNil())
 			priceStream.AddTickSubscription(ind)
 			csvFeed.FillDOHLCVStream(priceStream)
 		})
@@ -1495,6 +1537,7 @@ var _ = Describe("when executing the gotrade time series forecast (Tsf) with a y
 
 		BeforeEach(func() {
 			ind, err = indicators.NewTsf(14, gotrade.UseClosePrice)
+			Expect(err).To(BeNil())
 			priceStream.AddTickSubscription(ind)
 			csvFeed.FillDOHLCVStream(priceStream)
 		})
@@ -1529,6 +1572,7 @@ var _ = Describe("when executing the gotrade kaufman adaptive moving average (Ka
 
 		BeforeEach(func() {
 			ind, err = indicators.NewKama(30, gotrade.UseClosePrice)
+			Expect(err).To(BeNil())
 			priceStream.AddTickSubscription(ind)
 			csvFeed.FillDOHLCVStream(priceStream)
 		})
@@ -1563,6 +1607,7 @@ var _ = Describe("when executing the gotrade triangular moving average (Trima) w
 
 		BeforeEach(func() {
 			ind, err = indicators.NewTrima(30, gotrade.UseClosePrice)
+			Expect(err).To(BeNil())
 			priceStream.AddTickSubscription(ind)
 			csvFeed.FillDOHLCVStream(priceStream)
 		})
@@ -1597,6 +1642,7 @@ var _ = Describe("when executing the gotrade williams percent r (WillR) with a y
 
 		BeforeEach(func() {
 			ind, err = indicators.NewWillR(14)
+			Expect(err).To(BeNil())
 			priceStream.AddTickSubscription(ind)
 			csvFeed.FillDOHLCVStream(priceStream)
 		})
@@ -1631,6 +1677,7 @@ var _ = Describe("when executing the gotrade highest high value (Hhv) with a yea
 
 		BeforeEach(func() {
 			ind, err = indicators.NewHhv(14, gotrade.UseClosePrice)
+			Expect(err).To(BeNil())
 			priceStream.AddTickSubscription(ind)
 			csvFeed.FillDOHLCVStream(priceStream)
 		})
@@ -1665,6 +1712,7 @@ var _ = Describe("when executing the gotrade lowest low value (Llv) with a years
 
 		BeforeEach(func() {
 			ind, err = indicators.NewLlv(14, gotrade.UseClosePrice)
+			Expect(err).To(BeNil())
 			priceStream.AddTickSubscription(ind)
 			csvFeed.FillDOHLCVStream(priceStream)
 		})
@@ -1699,6 +1747,7 @@ var _ = Describe("when executing the gotrade highest high bars (HhvBars) with a
 
 		BeforeEach(func() {
 			ind, err = indicators.NewHhvBars(14, gotrade.UseClosePrice)
+			Expect(err).To(BeNil())
 			priceStream.AddTickSubscription(ind)
 			csvFeed.FillDOHLCVStream(priceStream)
 		})
@@ -1733,6 +1782,7 @@ var _ = Describe("when executing the gotrade lowest low bars (LlvBars) with a ye
 
 		BeforeEach(func() {
 			ind, err = indicators.NewLlvBars(14, gotrade.UseClosePrice)
+			Expect(err).To(BeNil())
 			priceStream.AddTickSubscription(ind)
 			csvFeed.FillDOHLCVStream(priceStream)
 		})
@@ -1767,6 +1817,7 @@ var _ = Describe("when executing the gotrade stochastic oscillator with a years
 
 		BeforeEach(func() {
 			stoch, err = indicators.NewStochOsc(5, 3, 3)
+			Expect(err).To(BeNil())
 			priceStream.AddTickSubscription(stoch)
 			csvFeed.FillDOHLCVStream(priceStream)
 		})
@@ -1807,6 +1858,7 @@ var _ = Describe("when executing the gotrade stochastic rsi oscillator with a ye
 
 		BeforeEach(func() {
 			stoch, err = indicators.NewStochRsi(14, 5, 3)
+			Expect(err).To(BeNil())
 			priceStream.AddTickSubscription(stoch)
 			csvFeed.FillDOHLCVStream(priceStream)
 		})
@@ -1847,6 +1899,7 @@ var _ = Describe("when executing the gotrade commodity channel index (Cci) with
 
 		BeforeEach(func() {
 			ind, err = indicators.NewCci(14)
+			Expect(err).To(BeNil())
 			priceStream.AddTickSubscription(ind)
 			csvFeed.FillDOHLCVStream(priceStream)
 		})
@@ -1862,3 +1915,273 @@ var _ = Describe("when executing the gotrade commodity channel index (Cci) with
 		})
 	})
 })
+
+var _ = Describe("when executing the gotrade mesa adaptive moving average with a years data and known output", func() {
+	var (
+		mama            *indicators.Mama
+		expectedResults []MamaData
+		err             error
+		priceStream     *gotrade.InterDayDOHLCVStream
+	)
+
+	BeforeEach(func() {
+		// load the expected results data
+		expectedResults, _ = LoadCSVMamaPriceDataFromFile("mama_3_5_expectedresult.data")
+		priceStream = gotrade.NewDailyDOHLCVStream()
+	})
+
+	Describe("using a fast limit of 0.5 and a slow limit of 0.05", func() {
+
+		BeforeEach(func() {
+			mama, err = indicators.NewMama(0.5, 0.05, gotrade.UseClosePrice)
+			Expect(err).To(BeNil())
+			priceStream.AddTickSubscription(mama)
+			csvFeed.FillDOHLCVStream(priceStream)
+		})
+
+		It("the result set should have a length equal to the source data length less the lookbackperiod", func() {
+			Expect(mama.Length()).To(Equal(len(priceStream.Data) - mama.GetLookbackPeriod()))
+		})
+
+		It("it should have correctly calculated the mama for each item in the result set accurate to two decimal places", func() {
+			Expect(len(expectedResults)).To(Equal(mama.Length()))
+			for k := range expectedResults {
+				Expect(expectedResults[k].Mama()).To(BeNumerically("~", mama.Mama[k], 0.01))
+			}
+		})
+
+		It("it should have correctly calculated the fama for each item in the result set accurate to two decimal places", func() {
+			for k := range expectedResults {
+				Expect(expectedResults[k].Fama()).To(BeNumerically("~", mama.Fama[k], 0.01))
+			}
+		})
+	})
+})
+
+var _ = Describe("when executing the gotrade hilbert transform instantaneous trendline with a years data and known output", func() {
+	var (
+		ind             *indicators.HtTrendline
+		expectedResults []float64
+		err             error
+		priceStream     *gotrade.InterDayDOHLCVStream
+	)
+
+	BeforeEach(func() {
+		// load the expected results data
+		expectedResults, _ = LoadCSVPriceDataFromFile("ht_rendline_expectedresult.data")
+		priceStream = gotrade.NewDailyDOHLCVStream()
+	})
+
+	Describe("using the close price", func() {
+
+		BeforeEach(func() {
+			ind, err = indicators.NewHtTrendline(gotrade.UseClosePrice)
+			Expect(err).To(BeNil())
+			priceStream.AddTickSubscription(ind)
+			csvFeed.FillDOHLCVStream(priceStream)
+		})
+
+		It("the result set should have a length equal to the source data length less the lookbackperiod", func() {
+			Expect(ind.Length()).To(Equal(len(priceStream.Data) - ind.GetLookbackPeriod()))
+		})
+
+		It("it should have correctly calculated the trendline for each item in the result set accurate to two decimal places", func() {
+			Expect(len(expectedResults)).To(Equal(ind.Length()))
+			for k := range expectedResults {
+				Expect(expectedResults[k]).To(BeNumerically("~", ind.Data[k], 0.01))
+			}
+		})
+	})
+})
+
+var _ = Describe("when executing the gotrade hilbert transform dominant cycle period with a years data and known output", func() {
+	var (
+		ind             *indicators.HtDcPeriod
+		expectedResults []float64
+		err             error
+		priceStream     *gotrade.InterDayDOHLCVStream
+	)
+
+	BeforeEach(func() {
+		// load the expected results data
+		expectedResults, _ = LoadCSVPriceDataFromFile("ht_dcperiod_expectedresult.data")
+		priceStream = gotrade.NewDailyDOHLCVStream()
+	})
+
+	Describe("using the close price", func() {
+
+		BeforeEach(func() {
+			ind, err = indicators.NewHtDcPeriod(gotrade.UseClosePrice)
+			Expect(err).To(BeNil())
+			priceStream.AddTickSubscription(ind)
+			csvFeed.FillDOHLCVStream(priceStream)
+		})
+
+		It("the result set should have a length equal to the source data length less the lookbackperiod", func() {
+			Expect(ind.Length()).To(Equal(len(priceStream.Data) - ind.GetLookbackPeriod()))
+		})
+
+		It("it should have correctly calculated the dominant cycle period for each item in the result set accurate to two decimal places", func() {
+			Expect(len(expectedResults)).To(Equal(ind.Length()))
+			for k := range expectedResults {
+				Expect(expectedResults[k]).To(BeNumerically("~", ind.Data[k], 0.01))
+			}
+		})
+	})
+})
+
+var _ = Describe("when executing the gotrade hilbert transform dominant cycle phase with a years data and known output", func() {
+	var (
+		ind             *indicators.HtDcPhase
+		expectedResults []float64
+		err             error
+		priceStream     *gotrade.InterDayDOHLCVStream
+	)
+
+	BeforeEach(func() {
+		// load the expected results data
+		expectedResults, _ = LoadCSVPriceDataFromFile("ht_dcphase_expectedresult.data")
+		priceStream = gotrade.NewDailyDOHLCVStream()
+	})
+
+	Describe("using the close price", func() {
+
+		BeforeEach(func() {
+			ind, err = indicators.NewHtDcPhase(gotrade.UseClosePrice)
+			Expect(err).To(BeNil())
+			priceStream.AddTickSubscription(ind)
+			csvFeed.FillDOHLCVStream(priceStream)
+		})
+
+		It("the result set should have a length equal to the source data length less the lookbackperiod", func() {
+			Expect(ind.Length()).To(Equal(len(priceStream.Data) - ind.GetLookbackPeriod()))
+		})
+
+		It("it should have correctly calculated the dominant cycle phase for each item in the result set accurate to two decimal places", func() {
+			Expect(len(expectedResults)).To(Equal(ind.Length()))
+			for k := range expectedResults {
+				Expect(expectedResults[k]).To(BeNumerically("~", ind.Data[k], 0.01))
+			}
+		})
+	})
+})
+
+var _ = Describe("when executing the gotrade hilbert transform phasor components with a years data and known output", func() {
+	var (
+		ind             *indicators.HtPhasor
+		expectedResults []HtPhasorData
+		err             error
+		priceStream     *gotrade.InterDayDOHLCVStream
+	)
+
+	BeforeEach(func() {
+		// load the expected results data
+		expectedResults, _ = LoadCSVHtPhasorPriceDataFromFile("ht_phasor_expectedresult.data")
+		priceStream = gotrade.NewDailyDOHLCVStream()
+	})
+
+	Describe("using the close price", func() {
+
+		BeforeEach(func() {
+			ind, err = indicators.NewHtPhasor(gotrade.UseClosePrice)
+			Expect(err).To(BeNil())
+			priceStream.AddTickSubscription(ind)
+			csvFeed.FillDOHLCVStream(priceStream)
+		})
+
+		It("the result set should have a length equal to the source data length less the lookbackperiod", func() {
+			Expect(ind.Length()).To(Equal(len(priceStream.Data) - ind.GetLookbackPeriod()))
+		})
+
+		It("it should have correctly calculated the in phase component for each item in the result set accurate to two decimal places", func() {
+			Expect(len(expectedResults)).To(Equal(ind.Length()))
+			for k := range expectedResults {
+				Expect(expectedResults[k].InPhase()).To(BeNumerically("~", ind.InPhase[k], 0.01))
+			}
+		})
+
+		It("it should have correctly calculated the quadrature component for each item in the result set accurate to two decimal places", func() {
+			for k := range expectedResults {
+				Expect(expectedResults[k].Quadrature()).To(BeNumerically("~", ind.Quadrature[k], 0.01))
+			}
+		})
+	})
+})
+
+var _ = Describe("when executing the gotrade hilbert transform sine wave with a years data and known output", func() {
+	var (
+		ind             *indicators.HtSine
+		expectedResults []HtSineData
+		err             error
+		priceStream     *gotrade.InterDayDOHLCVStream
+	)
+
+	BeforeEach(func() {
+		// load the expected results data
+		expectedResults, _ = LoadCSVHtSinePriceDataFromFile("ht_sine_expectedresult.data")
+		priceStream = gotrade.NewDailyDOHLCVStream()
+	})
+
+	Describe("using the close price", func() {
+
+		BeforeEach(func() {
+			ind, err = indicators.NewHtSine(gotrade.UseClosePrice)
+			Expect(err).To(BeNil())
+			priceStream.AddTickSubscription(ind)
+			csvFeed.FillDOHLCVStream(priceStream)
+		})
+
+		It("the result set should have a length equal to the source data length less the lookbackperiod", func() {
+			Expect(ind.Length()).To(Equal(len(priceStream.Data) - ind.GetLookbackPeriod()))
+		})
+
+		It("it should have correctly calculated the sine for each item in the result set accurate to two decimal places", func() {
+			Expect(len(expectedResults)).To(Equal(ind.Length()))
+			for k := range expectedResults {
+				Expect(expectedResults[k].Sine()).To(BeNumerically("~", ind.Sine[k], 0.01))
+			}
+		})
+
+		It("it should have correctly calculated the lead sine for each item in the result set accurate to two decimal places", func() {
+			for k := range expectedResults {
+				Expect(expectedResults[k].LeadSine()).To(BeNumerically("~", ind.LeadSine[k], 0.01))
+			}
+		})
+	})
+})
+
+var _ = Describe("when executing the gotrade hilbert transform trend vs cycle mode with a years data and known output", func() {
+	var (
+		ind             *indicators.HtTrendMode
+		expectedResults []int64
+		err             error
+		priceStream     *gotrade.InterDayDOHLCVStream
+	)
+
+	BeforeEach(func() {
+		// load the expected results data
+		expectedResults, _ = LoadCSVIntPriceDataFromFile("ht_trendmode_expectedresult.data")
+		priceStream = gotrade.NewDailyDOHLCVStream()
+	})
+
+	Describe("using the close price", func() {
+
+		BeforeEach(func() {
+			ind, err = indicators.NewHtTrendMode(gotrade.UseClosePrice)
+			Expect(err).To(BeNil())
+			priceStream.AddTickSubscription(ind)
+			csvFeed.FillDOHLCVStream(priceStream)
+		})
+
+		It("the result set should have a length equal to the source data length less the lookbackperiod", func() {
+			Expect(ind.Length()).To(Equal(len(priceStream.Data) - ind.GetLookbackPeriod()))
+		})
+
+		It("it should have correctly calculated the trend mode for each item in the result set", func() {
+			Expect(len(expectedResults)).To(Equal(ind.Length()))
+			for k := range expectedResults {
+				Expect(expectedResults[k]).To(Equal(ind.Data[k]))
+			}
+		})
+	})
+})
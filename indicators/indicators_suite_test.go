@@ -520,6 +520,105 @@ func LoadCSVStochPriceDataFromFile(fileName string) (results []StochData, err er
 	return results, nil
 }
 
+func LoadCSVMamaPriceDataFromFile(fileName string) (results []MamaData, err error) {
+	file, err := os.Open("../testdata/" + fileName)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return nil, err
+	}
+	defer file.Close()
+	reader := csv.NewReader(file)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			fmt.Println("Error:", err)
+			return nil, err
+		}
+
+		mama, err := strconv.ParseFloat(strings.TrimSpace(record[0]), 64)
+		if err != nil {
+			fmt.Println("Error:", err)
+			return nil, err
+		}
+
+		fama, err := strconv.ParseFloat(strings.TrimSpace(record[1]), 64)
+		if err != nil {
+			fmt.Println("Error:", err)
+			return nil, err
+		}
+		results = append(results, NewMamaDataItem(mama, fama))
+	}
+	return results, nil
+}
+
+func LoadCSVHtPhasorPriceDataFromFile(fileName string) (results []HtPhasorData, err error) {
+	file, err := os.Open("../testdata/" + fileName)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return nil, err
+	}
+	defer file.Close()
+	reader := csv.NewReader(file)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			fmt.Println("Error:", err)
+			return nil, err
+		}
+
+		inPhase, err := strconv.ParseFloat(strings.TrimSpace(record[0]), 64)
+		if err != nil {
+			fmt.Println("Error:", err)
+			return nil, err
+		}
+
+		quadrature, err := strconv.ParseFloat(strings.TrimSpace(record[1]), 64)
+		if err != nil {
+			fmt.Println("Error:", err)
+			return nil, err
+		}
+		results = append(results, NewHtPhasorDataItem(inPhase, quadrature))
+	}
+	return results, nil
+}
+
+func LoadCSVHtSinePriceDataFromFile(fileName string) (results []HtSineData, err error) {
+	file, err := os.Open("../testdata/" + fileName)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return nil, err
+	}
+	defer file.Close()
+	reader := csv.NewReader(file)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			fmt.Println("Error:", err)
+			return nil, err
+		}
+
+		sine, err := strconv.ParseFloat(strings.TrimSpace(record[0]), 64)
+		if err != nil {
+			fmt.Println("Error:", err)
+			return nil, err
+		}
+
+		leadSine, err := strconv.ParseFloat(strings.TrimSpace(record[1]), 64)
+		if err != nil {
+			fmt.Println("Error:", err)
+			return nil, err
+		}
+		results = append(results, NewHtSineDataItem(sine, leadSine))
+	}
+	return results, nil
+}
+
 type GetMaximumFloatFunc func() float64
 
 type GetMinimumFloatFunc func() float64
@@ -646,6 +745,30 @@ func GetDataMinMacd(macd []float64, signal []float64, histogram []float64) float
 	return min
 }
 
+func GetDataMaxMama(mama []float64, fama []float64) float64 {
+	return GetDataMaxStoch(mama, fama)
+}
+
+func GetDataMinMama(mama []float64, fama []float64) float64 {
+	return GetDataMinStoch(mama, fama)
+}
+
+func GetDataMaxHtPhasor(inPhase []float64, quadrature []float64) float64 {
+	return GetDataMaxStoch(inPhase, quadrature)
+}
+
+func GetDataMinHtPhasor(inPhase []float64, quadrature []float64) float64 {
+	return GetDataMinStoch(inPhase, quadrature)
+}
+
+func GetDataMaxHtSine(sine []float64, leadSine []float64) float64 {
+	return GetDataMaxStoch(sine, leadSine)
+}
+
+func GetDataMinHtSine(sine []float64, leadSine []float64) float64 {
+	return GetDataMinStoch(sine, leadSine)
+}
+
 func GetDataMaxStoch(slowK []float64, slowD []float64) float64 {
 	max := math.SmallestNonzeroFloat64
 
@@ -788,6 +911,72 @@ func (sdi *StochDataItem) D() float64 {
 	return sdi.d
 }
 
+type MamaData interface {
+	Mama() float64
+	Fama() float64
+}
+
+type MamaDataItem struct {
+	mama float64
+	fama float64
+}
+
+func NewMamaDataItem(mama float64, fama float64) *MamaDataItem {
+	return &MamaDataItem{mama: mama, fama: fama}
+}
+
+func (mdi *MamaDataItem) Mama() float64 {
+	return mdi.mama
+}
+
+func (mdi *MamaDataItem) Fama() float64 {
+	return mdi.fama
+}
+
+type HtPhasorData interface {
+	InPhase() float64
+	Quadrature() float64
+}
+
+type HtPhasorDataItem struct {
+	inPhase    float64
+	quadrature float64
+}
+
+func NewHtPhasorDataItem(inPhase float64, quadrature float64) *HtPhasorDataItem {
+	return &HtPhasorDataItem{inPhase: inPhase, quadrature: quadrature}
+}
+
+func (hpdi *HtPhasorDataItem) InPhase() float64 {
+	return hpdi.inPhase
+}
+
+func (hpdi *HtPhasorDataItem) Quadrature() float64 {
+	return hpdi.quadrature
+}
+
+type HtSineData interface {
+	Sine() float64
+	LeadSine() float64
+}
+
+type HtSineDataItem struct {
+	sine     float64
+	leadSine float64
+}
+
+func NewHtSineDataItem(sine float64, leadSine float64) *HtSineDataItem {
+	return &HtSineDataItem{sine: sine, leadSine: leadSine}
+}
+
+func (hsdi *HtSineDataItem) Sine() float64 {
+	return hsdi.sine
+}
+
+func (hsdi *HtSineDataItem) LeadSine() float64 {
+	return hsdi.leadSine
+}
+
 type fakeDOHLCVStreamSubscriber struct {
 	numTimesAddTickSubscriptionCalled int
 	lastCallToAddTickSubscriptionArg  gotrade.DOHLCVTickReceiver
@@ -801,9 +990,14 @@ func newFakeDOHLCVStreamSubscriber() *fakeDOHLCVStreamSubscriber {
 
 	return &fss
 }
-func (f *fakeDOHLCVStreamSubscriber) AddTickSubscription(subscriber gotrade.DOHLCVTickReceiver) {
+func (f *fakeDOHLCVStreamSubscriber) AddTickSubscription(subscriber gotrade.DOHLCVTickReceiver) io.Closer {
 	f.lastCallToAddTickSubscriptionArg = subscriber
 	f.numTimesAddTickSubscriptionCalled += 1
+	return f
+}
+
+func (f *fakeDOHLCVStreamSubscriber) Close() error {
+	return nil
 }
 
 func fakeFloatValAvailable(dataItem float64, streamBarIndex int) {
@@ -833,3 +1027,7 @@ func fakeMacdValAvailable(dataItemMacd float64, dataItemSignal float64, dataItem
 func FakeStochValueAvailable(dataItemK float64, dataItemD float64, streamBarIndex int) {
 
 }
+
+func FakeMamaValueAvailable(dataItemMama float64, dataItemFama float64, streamBarIndex int) {
+
+}
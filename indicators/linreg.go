@@ -62,6 +62,7 @@ func NewLinRegWithoutStorage(timePeriod int, valueAvailableAction ValueAvailable
 // A Linear Regression Indicator (LinReg)
 type LinReg struct {
 	*LinRegWithoutStorage
+	*gotrade.FloatStream
 	selectData gotrade.DOHLCVDataSelectionFunc
 
 	// public variables
@@ -75,7 +76,8 @@ func NewLinReg(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indi
 	}
 
 	ind := LinReg{
-		selectData: selectData,
+		FloatStream: gotrade.NewFloatStream(),
+		selectData:  selectData,
 	}
 
 	ind.LinRegWithoutStorage, err = NewLinRegWithoutStorage(timePeriod,
@@ -83,6 +85,8 @@ func NewLinReg(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indi
 			ind.Data = append(ind.Data, dataItem)
 
 			ind.UpdateMinMax(dataItem, dataItem)
+
+			ind.PublishTick(dataItem, streamBarIndex)
 		})
 
 	return &ind, err
@@ -122,29 +126,41 @@ func NewDefaultLinRegWithSrcLen(sourceLength uint) (indicator *LinReg, err error
 // NewLinRegForStream creates a Linear Regression Indicator (LinReg) for online usage with a source data stream
 func NewLinRegForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *LinReg, err error) {
 	ind, err := NewLinReg(timePeriod, selectData)
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // NewDefaultLinRegForStream creates a Linear Regression Indicator (LinReg) for online usage with a source data stream
 func NewDefaultLinRegForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *LinReg, err error) {
 	ind, err := NewDefaultLinReg()
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // NewLinRegForStreamWithSrcLen creates a Linear Regression Indicator (LinReg) for offline usage with a source data stream
 func NewLinRegForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *LinReg, err error) {
 	ind, err := NewLinRegWithSrcLen(sourceLength, timePeriod, selectData)
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // NewDefaultLinRegForStreamWithSrcLen creates a Linear Regression Indicator (LinReg) for offline usage with a source data stream
 func NewDefaultLinRegForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *LinReg, err error) {
 	ind, err := NewDefaultLinRegWithSrcLen(sourceLength)
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // ReceiveDOHLCVTick consumes a source data DOHLCV price tick
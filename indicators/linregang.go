@@ -8,6 +8,7 @@ import (
 // A Linear Regression Angle Indicator (LinRegAng)
 type LinRegAng struct {
 	*LinRegWithoutStorage
+	*gotrade.FloatStream
 	selectData gotrade.DOHLCVDataSelectionFunc
 
 	// public variables
@@ -21,7 +22,8 @@ func NewLinRegAng(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (i
 	}
 
 	ind := LinRegAng{
-		selectData: selectData,
+		FloatStream: gotrade.NewFloatStream(),
+		selectData:  selectData,
 	}
 
 	ind.LinRegWithoutStorage, err = NewLinRegWithoutStorage(timePeriod,
@@ -31,6 +33,8 @@ func NewLinRegAng(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (i
 			ind.UpdateMinMax(result, result)
 
 			ind.Data = append(ind.Data, result)
+
+			ind.PublishTick(result, streamBarIndex)
 		})
 
 	return &ind, err
@@ -70,29 +74,41 @@ func NewDefaultLinRegAngWithSrcLen(sourceLength uint) (indicator *LinRegAng, err
 // NewLinRegAngForStream creates a Linear Regression Angle Indicator (LinRegAng) for online usage with a source data stream
 func NewLinRegAngForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *LinRegAng, err error) {
 	ind, err := NewLinRegAng(timePeriod, selectData)
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // NewDefaultLinRegAngForStream creates a Linear Regression Angle Indicator (LinRegAng) for online usage with a source data stream
 func NewDefaultLinRegAngForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *LinRegAng, err error) {
 	ind, err := NewDefaultLinRegAng()
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // NewLinRegAngForStreamWithSrcLen creates a Linear Regression Angle Indicator (LinRegAng) for offline usage with a source data stream
 func NewLinRegAngForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *LinRegAng, err error) {
 	ind, err := NewLinRegAngWithSrcLen(sourceLength, timePeriod, selectData)
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // NewDefaultLinRegAngForStreamWithSrcLen creates a Linear Regression Angle Indicator (LinRegAng) for offline usage with a source data stream
 func NewDefaultLinRegAngForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *LinRegAng, err error) {
 	ind, err := NewDefaultLinRegAngWithSrcLen(sourceLength)
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // ReceiveDOHLCVTick consumes a source data DOHLCV price tick
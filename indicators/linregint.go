@@ -7,6 +7,7 @@ import (
 // A Linear Regression Intercept Indicator (LinRegInt)
 type LinRegInt struct {
 	*LinRegWithoutStorage
+	*gotrade.FloatStream
 	selectData gotrade.DOHLCVDataSelectionFunc
 
 	// public variables
@@ -20,7 +21,8 @@ func NewLinRegInt(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (i
 	}
 
 	ind := LinRegInt{
-		selectData: selectData,
+		FloatStream: gotrade.NewFloatStream(),
+		selectData:  selectData,
 	}
 
 	ind.LinRegWithoutStorage, err = NewLinRegWithoutStorage(timePeriod,
@@ -30,6 +32,8 @@ func NewLinRegInt(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (i
 			ind.UpdateMinMax(result, result)
 
 			ind.Data = append(ind.Data, result)
+
+			ind.PublishTick(result, streamBarIndex)
 		})
 
 	return &ind, err
@@ -69,29 +73,41 @@ func NewDefaultLinRegIntWithSrcLen(sourceLength uint) (indicator *LinRegInt, err
 // NewLinRegIntForStream creates a Linear Regression Intercept Indicator (LinRegInt) for online usage with a source data stream
 func NewLinRegIntForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *LinRegInt, err error) {
 	ind, err := NewLinRegInt(timePeriod, selectData)
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // NewDefaultLinRegIntForStream creates a Linear Regression Intercept Indicator (LinRegInt) for online usage with a source data stream
 func NewDefaultLinRegIntForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *LinRegInt, err error) {
 	ind, err := NewDefaultLinRegInt()
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // NewLinRegIntForStreamWithSrcLen creates a Linear Regression Intercept Indicator (LinRegInt) for offline usage with a source data stream
 func NewLinRegIntForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *LinRegInt, err error) {
 	ind, err := NewLinRegIntWithSrcLen(sourceLength, timePeriod, selectData)
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // NewDefaultLinRegIntForStreamWithSrcLen creates a Linear Regression Intercept Indicator (LinRegInt) for offline usage with a source data stream
 func NewDefaultLinRegIntForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *LinRegInt, err error) {
 	ind, err := NewDefaultLinRegIntWithSrcLen(sourceLength)
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // ReceiveDOHLCVTick consumes a source data DOHLCV price tick
@@ -7,6 +7,7 @@ import (
 // A Linear Regression Intercept Indicator (LinRegInt)
 type LinRegSlp struct {
 	*LinRegWithoutStorage
+	*gotrade.FloatStream
 	selectData gotrade.DOHLCVDataSelectionFunc
 
 	// public variables
@@ -20,7 +21,8 @@ func NewLinRegSlp(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (i
 	}
 
 	ind := LinRegSlp{
-		selectData: selectData,
+		FloatStream: gotrade.NewFloatStream(),
+		selectData:  selectData,
 	}
 
 	ind.LinRegWithoutStorage, err = NewLinRegWithoutStorage(timePeriod,
@@ -30,6 +32,8 @@ func NewLinRegSlp(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (i
 			ind.UpdateMinMax(result, result)
 
 			ind.Data = append(ind.Data, result)
+
+			ind.PublishTick(result, streamBarIndex)
 		})
 
 	return &ind, err
@@ -69,29 +73,41 @@ func NewDefaultLinRegSlpWithSrcLen(sourceLength uint) (indicator *LinRegSlp, err
 // NewLinRegSlpForStream creates a Linear Regression Slope Indicator (LinRegSlp) for online usage with a source data stream
 func NewLinRegSlpForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *LinRegSlp, err error) {
 	ind, err := NewLinRegSlp(timePeriod, selectData)
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // NewDefaultLinRegSlpForStream creates a Linear Regression Slope Indicator (LinRegSlp) for online usage with a source data stream
 func NewDefaultLinRegSlpForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *LinRegSlp, err error) {
 	ind, err := NewDefaultLinRegSlp()
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // NewLinRegSlpForStreamWithSrcLen creates a Linear Regression Slope Indicator (LinRegSlp) for offline usage with a source data stream
 func NewLinRegSlpForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *LinRegSlp, err error) {
 	ind, err := NewLinRegSlpWithSrcLen(sourceLength, timePeriod, selectData)
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // NewDefaultLinRegSlpForStreamWithSrcLen creates a Linear Regression Slope Indicator (LinRegSlp) for offline usage with a source data stream
 func NewDefaultLinRegSlpForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *LinRegSlp, err error) {
 	ind, err := NewDefaultLinRegSlpWithSrcLen(sourceLength)
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // ReceiveDOHLCVTick consumes a source data DOHLCV price tick
@@ -24,6 +24,9 @@ type Macd struct {
 	currentMacd          float64
 	emaSlowSkip          int
 	selectData           gotrade.DOHLCVDataSelectionFunc
+	macdStream           *gotrade.FloatStream
+	signalStream         *gotrade.FloatStream
+	histogramStream      *gotrade.FloatStream
 
 	// public variables
 	Macd      []float64
@@ -75,6 +78,9 @@ func NewMacd(fastTimePeriod int, slowTimePeriod int, signalTimePeriod int, selec
 		fastTimePeriod:   fastTimePeriod,
 		slowTimePeriod:   slowTimePeriod,
 		signalTimePeriod: signalTimePeriod,
+		macdStream:       gotrade.NewFloatStream(),
+		signalStream:     gotrade.NewFloatStream(),
+		histogramStream:  gotrade.NewFloatStream(),
 	}
 
 	// shift the fast ema up so that it has valid data at the same time as the slow emas
@@ -113,6 +119,11 @@ func NewMacd(fastTimePeriod int, slowTimePeriod int, signalTimePeriod int, selec
 
 		// notify of a new result value though the value available action
 		ind.valueAvailableAction(macd, signal, histogram, streamBarIndex)
+
+		// notify the indicators attached to this indicator
+		ind.macdStream.PublishTick(macd, streamBarIndex)
+		ind.signalStream.PublishTick(signal, streamBarIndex)
+		ind.histogramStream.PublishTick(histogram, streamBarIndex)
 	})
 
 	ind.selectData = selectData
@@ -168,29 +179,41 @@ func NewDefaultMacdWithSrcLen(sourceLength uint) (indicator *Macd, err error) {
 // NewMacdForStream creates a Moving Average Convergence Divergence Indicator (Macd) for online usage with a source data stream
 func NewMacdForStream(priceStream gotrade.DOHLCVStreamSubscriber, fastTimePeriod int, slowTimePeriod int, signalTimePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Macd, err error) {
 	ind, err := NewMacd(fastTimePeriod, slowTimePeriod, signalTimePeriod, selectData)
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // NewDefaultMacdForStream creates a Moving Average Convergence Divergence Indicator (Macd) for online usage with a source data stream
 func NewDefaultMacdForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Macd, err error) {
 	ind, err := NewDefaultMacd()
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // NewMacdForStreamWithSrcLen creates a Moving Average Convergence Divergence Indicator (Macd) for offline usage with a source data stream
 func NewMacdForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, fastTimePeriod int, slowTimePeriod int, signalTimePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Macd, err error) {
 	ind, err := NewMacdWithSrcLen(sourceLength, fastTimePeriod, slowTimePeriod, signalTimePeriod, selectData)
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // NewDefaultMacdForStreamWithSrcLen creates a Moving Average Convergence Divergence Indicator (Macd) for offline usage with a source data stream
 func NewDefaultMacdForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Macd, err error) {
 	ind, err := NewDefaultMacdWithSrcLen(sourceLength)
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // ReceiveDOHLCVTick consumes a source data DOHLCV price tick
@@ -205,3 +228,18 @@ func (ind *Macd) ReceiveTick(tickData float64, streamBarIndex int) {
 	}
 	ind.emaSlow.ReceiveTick(tickData, streamBarIndex)
 }
+
+// MacdStream returns the stream of the macd line results, for attaching other indicators
+func (ind *Macd) MacdStream() *gotrade.FloatStream {
+	return ind.macdStream
+}
+
+// SignalStream returns the stream of the signal line results, for attaching other indicators
+func (ind *Macd) SignalStream() *gotrade.FloatStream {
+	return ind.signalStream
+}
+
+// HistogramStream returns the stream of the histogram results, for attaching other indicators
+func (ind *Macd) HistogramStream() *gotrade.FloatStream {
+	return ind.histogramStream
+}
@@ -0,0 +1,200 @@
+// MESA Adaptive Moving Average (Mama)
+package indicators
+
+import (
+	"errors"
+	"github.com/jaybutera/gotrade"
+)
+
+// A MESA Adaptive Moving Average Indicator (Mama), no storage, for use in other indicators
+type MamaWithoutStorage struct {
+	*baseIndicatorWithFloatBoundsMama
+
+	// private variables
+	hilbertTransform *hilbertTransform
+	fastLimit        float64
+	slowLimit        float64
+	previousPhase    float64
+	mama             float64
+	fama             float64
+}
+
+// NewMamaWithoutStorage creates a MESA Adaptive Moving Average Indicator (Mama) without storage
+func NewMamaWithoutStorage(fastLimit float64, slowLimit float64, valueAvailableAction ValueAvailableActionMama) (indicator *MamaWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum fastLimit for this indicator is 0.01
+	if fastLimit < 0.01 {
+		return nil, errors.New("fastLimit is less than the minimum (0.01)")
+	}
+
+	// the maximum fastLimit for this indicator is 0.99
+	if fastLimit > 0.99 {
+		return nil, errors.New("fastLimit is greater than the maximum (0.99)")
+	}
+
+	// the minimum slowLimit for this indicator is 0.01
+	if slowLimit < 0.01 {
+		return nil, errors.New("slowLimit is less than the minimum (0.01)")
+	}
+
+	// the maximum slowLimit for this indicator is 0.99
+	if slowLimit > 0.99 {
+		return nil, errors.New("slowLimit is greater than the maximum (0.99)")
+	}
+
+	// the hilbert transform starts after smoothing 12 bars and the result after 32 bars, as in TA-Lib
+	lookback := 32
+	ind := MamaWithoutStorage{
+		baseIndicatorWithFloatBoundsMama: newBaseIndicatorWithFloatBoundsMama(lookback, valueAvailableAction),
+		hilbertTransform:                 newHilbertTransform(9),
+		fastLimit:                        fastLimit,
+		slowLimit:                        slowLimit,
+	}
+
+	return &ind, nil
+}
+
+// A MESA Adaptive Moving Average Indicator (Mama)
+type Mama struct {
+	*MamaWithoutStorage
+	selectData gotrade.DOHLCVDataSelectionFunc
+
+	// public variables
+	Mama []float64
+	Fama []float64
+}
+
+// NewMama creates a MESA Adaptive Moving Average Indicator (Mama) for online usage
+func NewMama(fastLimit float64, slowLimit float64, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Mama, err error) {
+	if selectData == nil {
+		return nil, ErrDOHLCVDataSelectFuncIsNil
+	}
+
+	ind := Mama{
+		selectData: selectData,
+	}
+
+	ind.MamaWithoutStorage, err = NewMamaWithoutStorage(fastLimit, slowLimit,
+		func(dataItemMama float64, dataItemFama float64, streamBarIndex int) {
+			ind.Mama = append(ind.Mama, dataItemMama)
+			ind.Fama = append(ind.Fama, dataItemFama)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultMama creates a MESA Adaptive Moving Average Indicator (Mama) for online usage with default parameters
+//	- fastLimit: 0.5
+//	- slowLimit: 0.05
+func NewDefaultMama() (indicator *Mama, err error) {
+	fastLimit := 0.5
+	slowLimit := 0.05
+	return NewMama(fastLimit, slowLimit, gotrade.UseClosePrice)
+}
+
+// NewMamaWithSrcLen creates a MESA Adaptive Moving Average Indicator (Mama) for offline usage
+func NewMamaWithSrcLen(sourceLength uint, fastLimit float64, slowLimit float64, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Mama, err error) {
+	ind, err := NewMama(fastLimit, slowLimit, selectData)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Mama = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+		ind.Fama = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultMamaWithSrcLen creates a MESA Adaptive Moving Average Indicator (Mama) for offline usage with default parameters
+func NewDefaultMamaWithSrcLen(sourceLength uint) (indicator *Mama, err error) {
+	ind, err := NewDefaultMama()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Mama = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+		ind.Fama = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewMamaForStream creates a MESA Adaptive Moving Average Indicator (Mama) for online usage with a source data stream
+func NewMamaForStream(priceStream gotrade.DOHLCVStreamSubscriber, fastLimit float64, slowLimit float64, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Mama, err error) {
+	ind, err := NewMama(fastLimit, slowLimit, selectData)
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
+}
+
+// NewDefaultMamaForStream creates a MESA Adaptive Moving Average Indicator (Mama) for online usage with a source data stream
+func NewDefaultMamaForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Mama, err error) {
+	ind, err := NewDefaultMama()
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
+}
+
+// NewMamaForStreamWithSrcLen creates a MESA Adaptive Moving Average Indicator (Mama) for offline usage with a source data stream
+func NewMamaForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, fastLimit float64, slowLimit float64, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Mama, err error) {
+	ind, err := NewMamaWithSrcLen(sourceLength, fastLimit, slowLimit, selectData)
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
+}
+
+// NewDefaultMamaForStreamWithSrcLen creates a MESA Adaptive Moving Average Indicator (Mama) for offline usage with a source data stream
+func NewDefaultMamaForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Mama, err error) {
+	ind, err := NewDefaultMamaWithSrcLen(sourceLength)
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *Mama) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	var selectedData = ind.selectData(tickData)
+	ind.ReceiveTick(selectedData, streamBarIndex)
+}
+
+func (ind *MamaWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	if !ind.hilbertTransform.receive(tickData) {
+		return
+	}
+
+	// the rate of change of the phase sets how quickly the average adapts
+	var phase float64 = ind.hilbertTransform.phase()
+	var deltaPhase float64 = ind.previousPhase - phase
+	ind.previousPhase = phase
+	if deltaPhase < 1.0 {
+		deltaPhase = 1.0
+	}
+
+	var alpha float64 = ind.fastLimit
+	if deltaPhase > 1.0 {
+		alpha = ind.fastLimit / deltaPhase
+		if alpha < ind.slowLimit {
+			alpha = ind.slowLimit
+		}
+	}
+
+	ind.mama = (alpha * tickData) + ((1 - alpha) * ind.mama)
+	alpha *= 0.5
+	ind.fama = (alpha * ind.mama) + ((1 - alpha) * ind.fama)
+
+	if ind.hilbertTransform.barCounter > ind.GetLookbackPeriod() {
+		ind.UpdateIndicatorWithNewValue(ind.mama, ind.fama, streamBarIndex)
+	}
+}
@@ -108,29 +108,41 @@ func NewDefaultMomWithSrcLen(sourceLength uint) (indicator *Mom, err error) {
 // NewMomForStream creates a Momentum (Mom) for online usage with a source data stream
 func NewMomForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Mom, err error) {
 	newMom, err := NewMom(timePeriod, selectData)
-	priceStream.AddTickSubscription(newMom)
-	return newMom, err
+	if err != nil {
+		return nil, err
+	}
+	newMom.subscription = priceStream.AddTickSubscription(newMom)
+	return newMom, nil
 }
 
 // NewDefaultMomForStream creates a Momentum (Mom) for online usage with a source data stream
 func NewDefaultMomForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Mom, err error) {
 	ind, err := NewDefaultMom()
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // NewMomForStreamWithSrcLen creates a Momentum (Mom) for offline usage with a source data stream
 func NewMomForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Mom, err error) {
 	ind, err := NewMomWithSrcLen(sourceLength, timePeriod, selectData)
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // NewDefaultMomForStreamWithSrcLen creates a Momentum (Mom) for offline usage with a source data stream
 func NewDefaultMomForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Mom, err error) {
 	ind, err := NewDefaultMomWithSrcLen(sourceLength)
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // ReceiveDOHLCVTick consumes a source data DOHLCV price tick
@@ -66,15 +66,21 @@ func NewObvWithSrcLen(sourceLength uint) (indicator *Obv, err error) {
 // NewObvForStream creates an On Balance Volume (Obv) for online usage with a source data stream
 func NewObvForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Obv, err error) {
 	ind, err := NewObv()
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // NewObvForStreamWithSrcLen creates an On Balance Volume (Obv) for offline usage with a source data stream
 func NewObvForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Obv, err error) {
 	ind, err := NewObvWithSrcLen(sourceLength)
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // ReceiveDOHLCVTick consumes a source data DOHLCV price tick
@@ -405,3 +405,42 @@ var _ = Describe("when calculating a simple moving average (sma) with DOHLCV sou
 		})
 	})
 })
+
+var _ = Describe("when closing a simple moving average (sma) created for a price stream", func() {
+	var (
+		priceStream *gotrade.DOHLCVStream
+		indicator   *indicators.Sma
+	)
+
+	BeforeEach(func() {
+		priceStream = gotrade.NewDOHLCVStream()
+		indicator, _ = indicators.NewSmaForStream(priceStream, 4, gotrade.UseClosePrice)
+
+		for i := 0; i < 6; i++ {
+			priceStream.ReceiveTick(sourceDOHLCVData[i])
+		}
+		Expect(indicator.Close()).To(Succeed())
+
+		for i := 6; i < len(sourceDOHLCVData); i++ {
+			priceStream.ReceiveTick(sourceDOHLCVData[i])
+		}
+	})
+
+	It("should not receive the ticks after it was closed", func() {
+		Expect(indicator.Length()).To(Equal(3))
+	})
+
+	It("should do nothing when closed again", func() {
+		Expect(indicator.Close()).To(Succeed())
+	})
+})
+
+var _ = Describe("when creating a simple moving average (sma) for a price stream with invalid parameters", func() {
+	It("should not attach the indicator to the stream", func() {
+		stream := newFakeDOHLCVStreamSubscriber()
+		indicator, err := indicators.NewSmaForStream(stream, 1, gotrade.UseClosePrice)
+		Expect(indicator).To(BeNil())
+		Expect(err).To(HaveOccurred())
+		Expect(stream.numTimesAddTickSubscriptionCalled).To(Equal(0))
+	})
+})
@@ -165,29 +165,41 @@ func NewDefaultStochRsiWithSrcLen(sourceLength uint) (indicator *StochRsi, err e
 // NewStochRsiForStream creates a Stochastic Relative Strength Indicator (StochRsi) for online usage with a source data stream
 func NewStochRsiForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, fastKTimePeriod int, fastDTimePeriod int) (indicator *StochRsi, err error) {
 	ind, err := NewStochRsi(timePeriod, fastKTimePeriod, fastDTimePeriod)
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // NewDefaultStochRsiForStream creates a Stochastic Relative Strength Indicator (StochRsi) for online usage with a source data stream
 func NewDefaultStochRsiForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *StochRsi, err error) {
 	ind, err := NewDefaultStochRsi()
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // NewStochRsiForStreamWithSrcLen creates a Stochastic Relative Strength Indicator (StochRsi) for offline usage with a source data stream
 func NewStochRsiForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, fastKTimePeriod int, fastDTimePeriod int) (indicator *StochRsi, err error) {
 	ind, err := NewStochRsiWithSrcLen(sourceLength, timePeriod, fastKTimePeriod, fastDTimePeriod)
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // NewDefaultStochRsiForStreamWithSrcLen creates a Stochastic Relative Strength Indicator (StochRsi) for offline usage with a source data stream
 func NewDefaultStochRsiForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *StochRsi, err error) {
 	ind, err := NewDefaultStochRsiWithSrcLen(sourceLength)
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // ReceiveDOHLCVTick consumes a source data DOHLCV price tick
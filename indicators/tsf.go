@@ -7,6 +7,7 @@ import (
 // A Time Series Forecast Indicator (Tsf)
 type Tsf struct {
 	*LinRegWithoutStorage
+	*gotrade.FloatStream
 	selectData gotrade.DOHLCVDataSelectionFunc
 
 	// public variables
@@ -20,7 +21,8 @@ func NewTsf(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicat
 	}
 
 	ind := Tsf{
-		selectData: selectData,
+		FloatStream: gotrade.NewFloatStream(),
+		selectData:  selectData,
 	}
 
 	ind.LinRegWithoutStorage, err = NewLinRegWithoutStorage(timePeriod,
@@ -30,6 +32,8 @@ func NewTsf(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicat
 			ind.UpdateMinMax(result, result)
 
 			ind.Data = append(ind.Data, result)
+
+			ind.PublishTick(result, streamBarIndex)
 		})
 
 	return &ind, err
@@ -69,29 +73,41 @@ func NewDefaultTsfWithSrcLen(sourceLength uint) (indicator *Tsf, err error) {
 // NewTsfForStream creates a Time Series Forecast Indicator (Tsf) for online usage with a source data stream
 func NewTsfForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Tsf, err error) {
 	ind, err := NewTsf(timePeriod, selectData)
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // NewDefaultTsfForStream creates a Time Series Forecast Indicator (Tsf) for online usage with a source data stream
 func NewDefaultTsfForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Tsf, err error) {
 	ind, err := NewDefaultTsf()
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // NewTsfForStreamWithSrcLen creates a Time Series Forecast Indicator (Tsf) for offline usage with a source data stream
 func NewTsfForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Tsf, err error) {
 	ind, err := NewTsfWithSrcLen(sourceLength, timePeriod, selectData)
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // NewDefaultTsfForStreamWithSrcLen creates a Time Series Forecast Indicator (Tsf) for offline usage with a source data stream
 func NewDefaultTsfForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Tsf, err error) {
 	ind, err := NewDefaultTsfWithSrcLen(sourceLength)
-	priceStream.AddTickSubscription(ind)
-	return ind, err
+	if err != nil {
+		return nil, err
+	}
+	ind.subscription = priceStream.AddTickSubscription(ind)
+	return ind, nil
 }
 
 // ReceiveDOHLCVTick consumes a source data DOHLCV price tick
@@ -0,0 +1,62 @@
+// Bars Since (BarsSince)
+package operators
+
+import (
+	"github.com/jaybutera/gotrade/indicators"
+)
+
+// A Bars Since Operator (BarsSince), no storage, for use in other operators
+type BarsSinceWithoutStorage struct {
+	*baseOperatorWithInt
+
+	// private variables
+	lastTrueBarIndex int
+}
+
+// NewBarsSinceWithoutStorage creates a Bars Since Operator (BarsSince) without storage
+func NewBarsSinceWithoutStorage(valueAvailableAction indicators.ValueAvailableActionInt) (operator *BarsSinceWithoutStorage, err error) {
+
+	// an operator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, indicators.ErrValueAvailableActionIsNil
+	}
+
+	lookback := 0
+	op := BarsSinceWithoutStorage{
+		baseOperatorWithInt: newBaseOperatorWithInt(lookback, valueAvailableAction),
+		lastTrueBarIndex:    -1,
+	}
+
+	return &op, nil
+}
+
+// ReceiveTick consumes a value of the condition, the result is the number of bars since the condition was
+// last true, 0 on a bar it is true, there is no result until the condition has been true
+func (op *BarsSinceWithoutStorage) ReceiveTick(dataItem bool, streamBarIndex int) {
+	if dataItem {
+		op.lastTrueBarIndex = streamBarIndex
+	}
+
+	if op.lastTrueBarIndex != -1 {
+		op.updateOperatorWithNewValue(int64(streamBarIndex-op.lastTrueBarIndex), streamBarIndex)
+	}
+}
+
+// A Bars Since Operator (BarsSince)
+type BarsSince struct {
+	*BarsSinceWithoutStorage
+
+	// public variables
+	Data []int64
+}
+
+// NewBarsSince creates a Bars Since Operator (BarsSince) for online usage
+func NewBarsSince() (operator *BarsSince, err error) {
+	op := BarsSince{}
+	op.BarsSinceWithoutStorage, err = NewBarsSinceWithoutStorage(
+		func(dataItem int64, streamBarIndex int) {
+			op.Data = append(op.Data, dataItem)
+		})
+
+	return &op, err
+}
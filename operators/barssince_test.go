@@ -0,0 +1,67 @@
+package operators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/jaybutera/gotrade/indicators"
+	"github.com/jaybutera/gotrade/operators"
+)
+
+var _ = Describe("when creating a barssincewithoutstorage", func() {
+	var (
+		operator      *operators.BarsSinceWithoutStorage
+		operatorError error
+	)
+
+	Context("and the operator was not given a value available action", func() {
+		BeforeEach(func() {
+			operator, operatorError = operators.NewBarsSinceWithoutStorage(nil)
+		})
+
+		It("the operator should not be created and return the appropriate error message", func() {
+			Expect(operator).To(BeNil())
+			Expect(operatorError).To(Equal(indicators.ErrValueAvailableActionIsNil))
+		})
+	})
+})
+
+var _ = Describe("when calculating bars since", func() {
+	var (
+		operator *operators.BarsSince
+	)
+
+	Context("given the operator is created via the standard constructor", func() {
+		BeforeEach(func() {
+			operator, _ = operators.NewBarsSince()
+			for i, condition := range []bool{false, false, true, false, false, true, false} {
+				operator.ReceiveTick(condition, i+1)
+			}
+		})
+
+		It("should have results from the first bar the condition is true", func() {
+			Expect(operator.ValidFromBar()).To(Equal(3))
+			Expect(operator.Length()).To(Equal(5))
+		})
+
+		It("should count the bars since the condition was last true", func() {
+			Expect(operator.Data).To(Equal([]int64{0, 1, 2, 0, 1}))
+		})
+	})
+
+	Context("given the operator is given the output of another operator", func() {
+		var (
+			crossesAbove *operators.CrossesAboveWithoutStorage
+		)
+
+		BeforeEach(func() {
+			operator, _ = operators.NewBarsSince()
+			crossesAbove, _ = operators.NewCrossesAboveConstantWithoutStorage(10.0, operator.ReceiveTick)
+			receiveSeries(crossesAbove.ReceiveA, 1, 9.0, 11.0, 12.0, 13.0)
+		})
+
+		It("should count the bars since the other operator was last true", func() {
+			Expect(operator.ValidFromBar()).To(Equal(2))
+			Expect(operator.Data).To(Equal([]int64{0, 1, 2}))
+		})
+	})
+})
@@ -0,0 +1,121 @@
+// Crosses Above (CrossesAbove)
+package operators
+
+import (
+	"github.com/jaybutera/gotrade"
+	"github.com/jaybutera/gotrade/indicators"
+)
+
+// A Crosses Above Operator (CrossesAbove), no storage, for use in other operators
+type CrossesAboveWithoutStorage struct {
+	*baseOperatorWithBool
+
+	// private variables
+	operands    *operandPair
+	previousA   float64
+	previousB   float64
+	hasPrevious bool
+}
+
+// NewCrossesAboveWithoutStorage creates a Crosses Above Operator (CrossesAbove) without storage
+func NewCrossesAboveWithoutStorage(valueAvailableAction ValueAvailableActionBool) (operator *CrossesAboveWithoutStorage, err error) {
+	return newCrossesAboveWithoutStorage(newSeriesOperand(), valueAvailableAction)
+}
+
+// NewCrossesAboveConstantWithoutStorage creates a Crosses Above Operator (CrossesAbove) without storage
+// comparing operand A to the constant b
+func NewCrossesAboveConstantWithoutStorage(b float64, valueAvailableAction ValueAvailableActionBool) (operator *CrossesAboveWithoutStorage, err error) {
+	return newCrossesAboveWithoutStorage(newConstantOperand(b), valueAvailableAction)
+}
+
+func newCrossesAboveWithoutStorage(b operand, valueAvailableAction ValueAvailableActionBool) (operator *CrossesAboveWithoutStorage, err error) {
+
+	// an operator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, indicators.ErrValueAvailableActionIsNil
+	}
+
+	// the previous values are required to detect the cross
+	lookback := 1
+	op := CrossesAboveWithoutStorage{
+		baseOperatorWithBool: newBaseOperatorWithBool(lookback, valueAvailableAction),
+	}
+	op.operands = newOperandPair(b, op.evaluate)
+
+	return &op, nil
+}
+
+// ReceiveA consumes a value of operand A, the operand crossing
+func (op *CrossesAboveWithoutStorage) ReceiveA(dataItem float64, streamBarIndex int) {
+	op.operands.receiveA(dataItem, streamBarIndex)
+}
+
+// ReceiveB consumes a value of operand B, the operand crossed, it is ignored if B is a constant
+func (op *CrossesAboveWithoutStorage) ReceiveB(dataItem float64, streamBarIndex int) {
+	op.operands.receiveB(dataItem, streamBarIndex)
+}
+
+func (op *CrossesAboveWithoutStorage) evaluate(a float64, b float64, streamBarIndex int) {
+	if op.hasPrevious {
+		// A crosses above B when it was at or below B on the previous bar and is above B now
+		var result bool = op.previousA <= op.previousB && a > b
+		op.updateOperatorWithNewValue(result, streamBarIndex)
+	}
+
+	op.previousA = a
+	op.previousB = b
+	op.hasPrevious = true
+}
+
+// A Crosses Above Operator (CrossesAbove)
+type CrossesAbove struct {
+	*CrossesAboveWithoutStorage
+
+	// public variables
+	Data []bool
+}
+
+// NewCrossesAbove creates a Crosses Above Operator (CrossesAbove) for online usage
+func NewCrossesAbove() (operator *CrossesAbove, err error) {
+	op := CrossesAbove{}
+	op.CrossesAboveWithoutStorage, err = NewCrossesAboveWithoutStorage(
+		func(dataItem bool, streamBarIndex int) {
+			op.Data = append(op.Data, dataItem)
+		})
+
+	return &op, err
+}
+
+// NewCrossesAboveConstant creates a Crosses Above Operator (CrossesAbove) for online usage comparing
+// operand A to the constant b
+func NewCrossesAboveConstant(b float64) (operator *CrossesAbove, err error) {
+	op := CrossesAbove{}
+	op.CrossesAboveWithoutStorage, err = NewCrossesAboveConstantWithoutStorage(b,
+		func(dataItem bool, streamBarIndex int) {
+			op.Data = append(op.Data, dataItem)
+		})
+
+	return &op, err
+}
+
+// NewCrossesAboveForStream creates a Crosses Above Operator (CrossesAbove) for online usage with the float streams of its operands
+func NewCrossesAboveForStream(streamA gotrade.FloatStreamSubscriber, streamB gotrade.FloatStreamSubscriber) (operator *CrossesAbove, err error) {
+	op, err := NewCrossesAbove()
+	if err != nil {
+		return nil, err
+	}
+	subscribeOperand(streamA, op.ReceiveA)
+	subscribeOperand(streamB, op.ReceiveB)
+	return op, nil
+}
+
+// NewCrossesAboveConstantForStream creates a Crosses Above Operator (CrossesAbove) for online usage with the float stream of
+// operand A compared to the constant b
+func NewCrossesAboveConstantForStream(streamA gotrade.FloatStreamSubscriber, b float64) (operator *CrossesAbove, err error) {
+	op, err := NewCrossesAboveConstant(b)
+	if err != nil {
+		return nil, err
+	}
+	subscribeOperand(streamA, op.ReceiveA)
+	return op, nil
+}
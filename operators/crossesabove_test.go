@@ -0,0 +1,89 @@
+package operators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/jaybutera/gotrade/indicators"
+	"github.com/jaybutera/gotrade/operators"
+)
+
+var _ = Describe("when creating a crossesabovewithoutstorage", func() {
+	var (
+		operator      *operators.CrossesAboveWithoutStorage
+		operatorError error
+	)
+
+	Context("and the operator was not given a value available action", func() {
+		BeforeEach(func() {
+			operator, operatorError = operators.NewCrossesAboveWithoutStorage(nil)
+		})
+
+		It("the operator should not be created and return the appropriate error message", func() {
+			Expect(operator).To(BeNil())
+			Expect(operatorError).To(Equal(indicators.ErrValueAvailableActionIsNil))
+		})
+	})
+})
+
+var _ = Describe("when calculating crosses above", func() {
+	var (
+		operator *operators.CrossesAbove
+	)
+
+	Context("given the operator is created via the standard constructor", func() {
+		BeforeEach(func() {
+			operator, _ = operators.NewCrossesAbove()
+		})
+
+		It("should have a lookback period of 1", func() {
+			Expect(operator.GetLookbackPeriod()).To(Equal(1))
+		})
+
+		Context("and the operands start at different bars", func() {
+			BeforeEach(func() {
+				receiveSeries(operator.ReceiveA, 1, 5.0, 6.0, 7.0, 8.0, 9.0, 10.0, 11.0)
+				receiveSeries(operator.ReceiveB, 3, 10.0, 9.0, 8.0, 10.0, 10.0)
+			})
+
+			It("should only have results from the bar after both operands have values", func() {
+				Expect(operator.ValidFromBar()).To(Equal(4))
+				Expect(operator.Length()).To(Equal(4))
+			})
+
+			It("should be true only on the bar operand A moves from at or below to above operand B", func() {
+				Expect(operator.Data).To(Equal([]bool{false, true, false, true}))
+			})
+		})
+
+		Context("and the operands are received interleaved bar by bar", func() {
+			BeforeEach(func() {
+				for i, values := range [][2]float64{{1.0, 2.0}, {3.0, 2.0}, {1.0, 2.0}, {2.0, 2.0}, {2.5, 2.0}} {
+					operator.ReceiveB(values[1], i+1)
+					operator.ReceiveA(values[0], i+1)
+				}
+			})
+
+			It("should be true only on the bars operand A crosses above operand B", func() {
+				Expect(operator.Data).To(Equal([]bool{true, false, false, true}))
+			})
+		})
+	})
+
+	Context("given the operator is created with a constant operand", func() {
+		BeforeEach(func() {
+			operator, _ = operators.NewCrossesAboveConstant(30.0)
+			receiveSeries(operator.ReceiveA, 1, 25.0, 31.0, 35.0, 29.0, 30.0, 30.5)
+		})
+
+		It("should compare operand A to the constant from its first bar", func() {
+			Expect(operator.ValidFromBar()).To(Equal(2))
+			Expect(operator.Data).To(Equal([]bool{true, false, false, false, true}))
+		})
+
+		It("should ignore values received for the constant operand", func() {
+			operator.ReceiveB(100.0, 7)
+			operator.ReceiveA(31.0, 7)
+			Expect(operator.Data[len(operator.Data)-1]).To(BeFalse())
+		})
+	})
+})
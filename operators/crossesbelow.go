@@ -0,0 +1,121 @@
+// Crosses Below (CrossesBelow)
+package operators
+
+import (
+	"github.com/jaybutera/gotrade"
+	"github.com/jaybutera/gotrade/indicators"
+)
+
+// A Crosses Below Operator (CrossesBelow), no storage, for use in other operators
+type CrossesBelowWithoutStorage struct {
+	*baseOperatorWithBool
+
+	// private variables
+	operands    *operandPair
+	previousA   float64
+	previousB   float64
+	hasPrevious bool
+}
+
+// NewCrossesBelowWithoutStorage creates a Crosses Below Operator (CrossesBelow) without storage
+func NewCrossesBelowWithoutStorage(valueAvailableAction ValueAvailableActionBool) (operator *CrossesBelowWithoutStorage, err error) {
+	return newCrossesBelowWithoutStorage(newSeriesOperand(), valueAvailableAction)
+}
+
+// NewCrossesBelowConstantWithoutStorage creates a Crosses Below Operator (CrossesBelow) without storage
+// comparing operand A to the constant b
+func NewCrossesBelowConstantWithoutStorage(b float64, valueAvailableAction ValueAvailableActionBool) (operator *CrossesBelowWithoutStorage, err error) {
+	return newCrossesBelowWithoutStorage(newConstantOperand(b), valueAvailableAction)
+}
+
+func newCrossesBelowWithoutStorage(b operand, valueAvailableAction ValueAvailableActionBool) (operator *CrossesBelowWithoutStorage, err error) {
+
+	// an operator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, indicators.ErrValueAvailableActionIsNil
+	}
+
+	// the previous values are required to detect the cross
+	lookback := 1
+	op := CrossesBelowWithoutStorage{
+		baseOperatorWithBool: newBaseOperatorWithBool(lookback, valueAvailableAction),
+	}
+	op.operands = newOperandPair(b, op.evaluate)
+
+	return &op, nil
+}
+
+// ReceiveA consumes a value of operand A, the operand crossing
+func (op *CrossesBelowWithoutStorage) ReceiveA(dataItem float64, streamBarIndex int) {
+	op.operands.receiveA(dataItem, streamBarIndex)
+}
+
+// ReceiveB consumes a value of operand B, the operand crossed, it is ignored if B is a constant
+func (op *CrossesBelowWithoutStorage) ReceiveB(dataItem float64, streamBarIndex int) {
+	op.operands.receiveB(dataItem, streamBarIndex)
+}
+
+func (op *CrossesBelowWithoutStorage) evaluate(a float64, b float64, streamBarIndex int) {
+	if op.hasPrevious {
+		// A crosses below B when it was at or above B on the previous bar and is below B now
+		var result bool = op.previousA >= op.previousB && a < b
+		op.updateOperatorWithNewValue(result, streamBarIndex)
+	}
+
+	op.previousA = a
+	op.previousB = b
+	op.hasPrevious = true
+}
+
+// A Crosses Below Operator (CrossesBelow)
+type CrossesBelow struct {
+	*CrossesBelowWithoutStorage
+
+	// public variables
+	Data []bool
+}
+
+// NewCrossesBelow creates a Crosses Below Operator (CrossesBelow) for online usage
+func NewCrossesBelow() (operator *CrossesBelow, err error) {
+	op := CrossesBelow{}
+	op.CrossesBelowWithoutStorage, err = NewCrossesBelowWithoutStorage(
+		func(dataItem bool, streamBarIndex int) {
+			op.Data = append(op.Data, dataItem)
+		})
+
+	return &op, err
+}
+
+// NewCrossesBelowConstant creates a Crosses Below Operator (CrossesBelow) for online usage comparing
+// operand A to the constant b
+func NewCrossesBelowConstant(b float64) (operator *CrossesBelow, err error) {
+	op := CrossesBelow{}
+	op.CrossesBelowWithoutStorage, err = NewCrossesBelowConstantWithoutStorage(b,
+		func(dataItem bool, streamBarIndex int) {
+			op.Data = append(op.Data, dataItem)
+		})
+
+	return &op, err
+}
+
+// NewCrossesBelowForStream creates a Crosses Below Operator (CrossesBelow) for online usage with the float streams of its operands
+func NewCrossesBelowForStream(streamA gotrade.FloatStreamSubscriber, streamB gotrade.FloatStreamSubscriber) (operator *CrossesBelow, err error) {
+	op, err := NewCrossesBelow()
+	if err != nil {
+		return nil, err
+	}
+	subscribeOperand(streamA, op.ReceiveA)
+	subscribeOperand(streamB, op.ReceiveB)
+	return op, nil
+}
+
+// NewCrossesBelowConstantForStream creates a Crosses Below Operator (CrossesBelow) for online usage with the float stream of
+// operand A compared to the constant b
+func NewCrossesBelowConstantForStream(streamA gotrade.FloatStreamSubscriber, b float64) (operator *CrossesBelow, err error) {
+	op, err := NewCrossesBelowConstant(b)
+	if err != nil {
+		return nil, err
+	}
+	subscribeOperand(streamA, op.ReceiveA)
+	return op, nil
+}
@@ -0,0 +1,59 @@
+package operators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/jaybutera/gotrade/indicators"
+	"github.com/jaybutera/gotrade/operators"
+)
+
+var _ = Describe("when creating a crossesbelowwithoutstorage", func() {
+	var (
+		operator      *operators.CrossesBelowWithoutStorage
+		operatorError error
+	)
+
+	Context("and the operator was not given a value available action", func() {
+		BeforeEach(func() {
+			operator, operatorError = operators.NewCrossesBelowConstantWithoutStorage(70.0, nil)
+		})
+
+		It("the operator should not be created and return the appropriate error message", func() {
+			Expect(operator).To(BeNil())
+			Expect(operatorError).To(Equal(indicators.ErrValueAvailableActionIsNil))
+		})
+	})
+})
+
+var _ = Describe("when calculating crosses below", func() {
+	var (
+		operator *operators.CrossesBelow
+	)
+
+	Context("given the operator is created via the standard constructor", func() {
+		BeforeEach(func() {
+			operator, _ = operators.NewCrossesBelow()
+			receiveSeries(operator.ReceiveB, 1, 10.0, 10.0, 10.0, 10.0, 10.0, 10.0)
+			receiveSeries(operator.ReceiveA, 2, 12.0, 10.0, 9.0, 11.0, 8.0)
+		})
+
+		It("should only have results from the bar after both operands have values", func() {
+			Expect(operator.ValidFromBar()).To(Equal(3))
+		})
+
+		It("should be true only on the bars operand A moves from at or above to below operand B", func() {
+			Expect(operator.Data).To(Equal([]bool{false, true, false, true}))
+		})
+	})
+
+	Context("given the operator is created with a constant operand", func() {
+		BeforeEach(func() {
+			operator, _ = operators.NewCrossesBelowConstant(70.0)
+			receiveSeries(operator.ReceiveA, 1, 75.0, 69.0, 71.0, 65.0)
+		})
+
+		It("should be true only on the bars operand A crosses below the constant", func() {
+			Expect(operator.Data).To(Equal([]bool{true, false, true}))
+		})
+	})
+})
@@ -0,0 +1,96 @@
+// Falling (Falling)
+package operators
+
+import (
+	"errors"
+	"github.com/jaybutera/gotrade"
+	"github.com/jaybutera/gotrade/indicators"
+)
+
+// A Falling Operator (Falling), no storage, for use in other operators
+type FallingWithoutStorage struct {
+	*baseOperatorWithBool
+
+	// private variables
+	previousValue  float64
+	valueCounter   int
+	fallingCounter int
+	timePeriod     int
+}
+
+// NewFallingWithoutStorage creates a Falling Operator (Falling) without storage
+func NewFallingWithoutStorage(timePeriod int, valueAvailableAction ValueAvailableActionBool) (operator *FallingWithoutStorage, err error) {
+
+	// an operator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, indicators.ErrValueAvailableActionIsNil
+	}
+
+	// the minimum timeperiod for this operator is 1
+	if timePeriod < 1 {
+		return nil, errors.New("timePeriod is less than the minimum (1)")
+	}
+
+	// check the maximum timeperiod
+	if timePeriod > indicators.MaximumLookbackPeriod {
+		return nil, errors.New("timePeriod is greater than the maximum (100000)")
+	}
+
+	lookback := timePeriod
+	op := FallingWithoutStorage{
+		baseOperatorWithBool: newBaseOperatorWithBool(lookback, valueAvailableAction),
+		timePeriod:           timePeriod,
+	}
+
+	return &op, nil
+}
+
+// GetTimePeriod returns the number of bars the value must have fallen on
+func (op *FallingWithoutStorage) GetTimePeriod() int {
+	return op.timePeriod
+}
+
+// ReceiveTick consumes a value of the operand, the result is true if the value fell on each of the last timePeriod bars
+func (op *FallingWithoutStorage) ReceiveTick(dataItem float64, streamBarIndex int) {
+	op.valueCounter += 1
+
+	if op.valueCounter > 1 && dataItem < op.previousValue {
+		op.fallingCounter += 1
+	} else {
+		op.fallingCounter = 0
+	}
+	op.previousValue = dataItem
+
+	if op.valueCounter > op.GetLookbackPeriod() {
+		op.updateOperatorWithNewValue(op.fallingCounter >= op.timePeriod, streamBarIndex)
+	}
+}
+
+// A Falling Operator (Falling)
+type Falling struct {
+	*FallingWithoutStorage
+
+	// public variables
+	Data []bool
+}
+
+// NewFalling creates a Falling Operator (Falling) for online usage
+func NewFalling(timePeriod int) (operator *Falling, err error) {
+	op := Falling{}
+	op.FallingWithoutStorage, err = NewFallingWithoutStorage(timePeriod,
+		func(dataItem bool, streamBarIndex int) {
+			op.Data = append(op.Data, dataItem)
+		})
+
+	return &op, err
+}
+
+// NewFallingForStream creates a Falling Operator (Falling) for online usage with the float stream of its operand
+func NewFallingForStream(stream gotrade.FloatStreamSubscriber, timePeriod int) (operator *Falling, err error) {
+	op, err := NewFalling(timePeriod)
+	if err != nil {
+		return nil, err
+	}
+	stream.AddTickSubscription(op)
+	return op, nil
+}
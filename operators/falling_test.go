@@ -0,0 +1,55 @@
+package operators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/jaybutera/gotrade/indicators"
+	"github.com/jaybutera/gotrade/operators"
+)
+
+var _ = Describe("when creating a fallingwithoutstorage", func() {
+	var (
+		operator      *operators.FallingWithoutStorage
+		operatorError error
+	)
+
+	Context("and the operator was not given a value available action", func() {
+		BeforeEach(func() {
+			operator, operatorError = operators.NewFallingWithoutStorage(2, nil)
+		})
+
+		It("the operator should not be created and return the appropriate error message", func() {
+			Expect(operator).To(BeNil())
+			Expect(operatorError).To(Equal(indicators.ErrValueAvailableActionIsNil))
+		})
+	})
+
+	Context("and the operator was given a time period below the minimum", func() {
+		BeforeEach(func() {
+			operator, operatorError = operators.NewFallingWithoutStorage(0, func(dataItem bool, streamBarIndex int) {})
+		})
+
+		It("the operator should not be created and return the appropriate error message", func() {
+			Expect(operator).To(BeNil())
+			Expect(operatorError).To(MatchError("timePeriod is less than the minimum (1)"))
+		})
+	})
+})
+
+var _ = Describe("when calculating falling", func() {
+	var (
+		operator *operators.Falling
+	)
+
+	Context("given the operator is created via the standard constructor", func() {
+		BeforeEach(func() {
+			operator, _ = operators.NewFalling(1)
+			receiveSeries(operator.ReceiveTick, 1, 5.0, 4.0, 4.0, 3.0, 3.5)
+		})
+
+		It("should be true on the bars the value fell on each of the last time period bars", func() {
+			Expect(operator.ValidFromBar()).To(Equal(2))
+			Expect(operator.Data).To(Equal([]bool{true, false, true, false}))
+		})
+	})
+})
@@ -0,0 +1,109 @@
+// Greater Than (GreaterThan)
+package operators
+
+import (
+	"github.com/jaybutera/gotrade"
+	"github.com/jaybutera/gotrade/indicators"
+)
+
+// A Greater Than Operator (GreaterThan), no storage, for use in other operators
+type GreaterThanWithoutStorage struct {
+	*baseOperatorWithBool
+
+	// private variables
+	operands *operandPair
+}
+
+// NewGreaterThanWithoutStorage creates a Greater Than Operator (GreaterThan) without storage
+func NewGreaterThanWithoutStorage(valueAvailableAction ValueAvailableActionBool) (operator *GreaterThanWithoutStorage, err error) {
+	return newGreaterThanWithoutStorage(newSeriesOperand(), valueAvailableAction)
+}
+
+// NewGreaterThanConstantWithoutStorage creates a Greater Than Operator (GreaterThan) without storage
+// comparing operand A to the constant b
+func NewGreaterThanConstantWithoutStorage(b float64, valueAvailableAction ValueAvailableActionBool) (operator *GreaterThanWithoutStorage, err error) {
+	return newGreaterThanWithoutStorage(newConstantOperand(b), valueAvailableAction)
+}
+
+func newGreaterThanWithoutStorage(b operand, valueAvailableAction ValueAvailableActionBool) (operator *GreaterThanWithoutStorage, err error) {
+
+	// an operator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, indicators.ErrValueAvailableActionIsNil
+	}
+
+	lookback := 0
+	op := GreaterThanWithoutStorage{
+		baseOperatorWithBool: newBaseOperatorWithBool(lookback, valueAvailableAction),
+	}
+	op.operands = newOperandPair(b, op.evaluate)
+
+	return &op, nil
+}
+
+// ReceiveA consumes a value of operand A, the left hand side of the comparison
+func (op *GreaterThanWithoutStorage) ReceiveA(dataItem float64, streamBarIndex int) {
+	op.operands.receiveA(dataItem, streamBarIndex)
+}
+
+// ReceiveB consumes a value of operand B, the right hand side of the comparison, it is ignored if B is a constant
+func (op *GreaterThanWithoutStorage) ReceiveB(dataItem float64, streamBarIndex int) {
+	op.operands.receiveB(dataItem, streamBarIndex)
+}
+
+func (op *GreaterThanWithoutStorage) evaluate(a float64, b float64, streamBarIndex int) {
+	op.updateOperatorWithNewValue(a > b, streamBarIndex)
+}
+
+// A Greater Than Operator (GreaterThan)
+type GreaterThan struct {
+	*GreaterThanWithoutStorage
+
+	// public variables
+	Data []bool
+}
+
+// NewGreaterThan creates a Greater Than Operator (GreaterThan) for online usage
+func NewGreaterThan() (operator *GreaterThan, err error) {
+	op := GreaterThan{}
+	op.GreaterThanWithoutStorage, err = NewGreaterThanWithoutStorage(
+		func(dataItem bool, streamBarIndex int) {
+			op.Data = append(op.Data, dataItem)
+		})
+
+	return &op, err
+}
+
+// NewGreaterThanConstant creates a Greater Than Operator (GreaterThan) for online usage comparing
+// operand A to the constant b
+func NewGreaterThanConstant(b float64) (operator *GreaterThan, err error) {
+	op := GreaterThan{}
+	op.GreaterThanWithoutStorage, err = NewGreaterThanConstantWithoutStorage(b,
+		func(dataItem bool, streamBarIndex int) {
+			op.Data = append(op.Data, dataItem)
+		})
+
+	return &op, err
+}
+
+// NewGreaterThanForStream creates a Greater Than Operator (GreaterThan) for online usage with the float streams of its operands
+func NewGreaterThanForStream(streamA gotrade.FloatStreamSubscriber, streamB gotrade.FloatStreamSubscriber) (operator *GreaterThan, err error) {
+	op, err := NewGreaterThan()
+	if err != nil {
+		return nil, err
+	}
+	subscribeOperand(streamA, op.ReceiveA)
+	subscribeOperand(streamB, op.ReceiveB)
+	return op, nil
+}
+
+// NewGreaterThanConstantForStream creates a Greater Than Operator (GreaterThan) for online usage with the float stream of
+// operand A compared to the constant b
+func NewGreaterThanConstantForStream(streamA gotrade.FloatStreamSubscriber, b float64) (operator *GreaterThan, err error) {
+	op, err := NewGreaterThanConstant(b)
+	if err != nil {
+		return nil, err
+	}
+	subscribeOperand(streamA, op.ReceiveA)
+	return op, nil
+}
@@ -0,0 +1,62 @@
+package operators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/jaybutera/gotrade/indicators"
+	"github.com/jaybutera/gotrade/operators"
+)
+
+var _ = Describe("when creating a greaterthanwithoutstorage", func() {
+	var (
+		operator      *operators.GreaterThanWithoutStorage
+		operatorError error
+	)
+
+	Context("and the operator was not given a value available action", func() {
+		BeforeEach(func() {
+			operator, operatorError = operators.NewGreaterThanWithoutStorage(nil)
+		})
+
+		It("the operator should not be created and return the appropriate error message", func() {
+			Expect(operator).To(BeNil())
+			Expect(operatorError).To(Equal(indicators.ErrValueAvailableActionIsNil))
+		})
+	})
+})
+
+var _ = Describe("when calculating greater than", func() {
+	var (
+		operator *operators.GreaterThan
+	)
+
+	Context("given the operator is created via the standard constructor", func() {
+		BeforeEach(func() {
+			operator, _ = operators.NewGreaterThan()
+			receiveSeries(operator.ReceiveA, 1, 1.0, 2.0, 3.0, 4.0)
+			receiveSeries(operator.ReceiveB, 2, 1.0, 3.0, 5.0)
+		})
+
+		It("should have a result for each bar both operands have values", func() {
+			Expect(operator.GetLookbackPeriod()).To(Equal(0))
+			Expect(operator.ValidFromBar()).To(Equal(2))
+			Expect(operator.Length()).To(Equal(3))
+		})
+
+		It("should be true on the bars operand A is greater than operand B", func() {
+			Expect(operator.Data).To(Equal([]bool{true, false, false}))
+		})
+	})
+
+	Context("given the operator is created with a constant operand", func() {
+		BeforeEach(func() {
+			operator, _ = operators.NewGreaterThanConstant(70.0)
+			receiveSeries(operator.ReceiveA, 1, 65.0, 70.0, 75.0)
+		})
+
+		It("should be true on the bars operand A is greater than the constant", func() {
+			Expect(operator.ValidFromBar()).To(Equal(1))
+			Expect(operator.Data).To(Equal([]bool{false, false, true}))
+		})
+	})
+})
@@ -0,0 +1,109 @@
+// Less Than (LessThan)
+package operators
+
+import (
+	"github.com/jaybutera/gotrade"
+	"github.com/jaybutera/gotrade/indicators"
+)
+
+// A Less Than Operator (LessThan), no storage, for use in other operators
+type LessThanWithoutStorage struct {
+	*baseOperatorWithBool
+
+	// private variables
+	operands *operandPair
+}
+
+// NewLessThanWithoutStorage creates a Less Than Operator (LessThan) without storage
+func NewLessThanWithoutStorage(valueAvailableAction ValueAvailableActionBool) (operator *LessThanWithoutStorage, err error) {
+	return newLessThanWithoutStorage(newSeriesOperand(), valueAvailableAction)
+}
+
+// NewLessThanConstantWithoutStorage creates a Less Than Operator (LessThan) without storage
+// comparing operand A to the constant b
+func NewLessThanConstantWithoutStorage(b float64, valueAvailableAction ValueAvailableActionBool) (operator *LessThanWithoutStorage, err error) {
+	return newLessThanWithoutStorage(newConstantOperand(b), valueAvailableAction)
+}
+
+func newLessThanWithoutStorage(b operand, valueAvailableAction ValueAvailableActionBool) (operator *LessThanWithoutStorage, err error) {
+
+	// an operator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, indicators.ErrValueAvailableActionIsNil
+	}
+
+	lookback := 0
+	op := LessThanWithoutStorage{
+		baseOperatorWithBool: newBaseOperatorWithBool(lookback, valueAvailableAction),
+	}
+	op.operands = newOperandPair(b, op.evaluate)
+
+	return &op, nil
+}
+
+// ReceiveA consumes a value of operand A, the left hand side of the comparison
+func (op *LessThanWithoutStorage) ReceiveA(dataItem float64, streamBarIndex int) {
+	op.operands.receiveA(dataItem, streamBarIndex)
+}
+
+// ReceiveB consumes a value of operand B, the right hand side of the comparison, it is ignored if B is a constant
+func (op *LessThanWithoutStorage) ReceiveB(dataItem float64, streamBarIndex int) {
+	op.operands.receiveB(dataItem, streamBarIndex)
+}
+
+func (op *LessThanWithoutStorage) evaluate(a float64, b float64, streamBarIndex int) {
+	op.updateOperatorWithNewValue(a < b, streamBarIndex)
+}
+
+// A Less Than Operator (LessThan)
+type LessThan struct {
+	*LessThanWithoutStorage
+
+	// public variables
+	Data []bool
+}
+
+// NewLessThan creates a Less Than Operator (LessThan) for online usage
+func NewLessThan() (operator *LessThan, err error) {
+	op := LessThan{}
+	op.LessThanWithoutStorage, err = NewLessThanWithoutStorage(
+		func(dataItem bool, streamBarIndex int) {
+			op.Data = append(op.Data, dataItem)
+		})
+
+	return &op, err
+}
+
+// NewLessThanConstant creates a Less Than Operator (LessThan) for online usage comparing
+// operand A to the constant b
+func NewLessThanConstant(b float64) (operator *LessThan, err error) {
+	op := LessThan{}
+	op.LessThanWithoutStorage, err = NewLessThanConstantWithoutStorage(b,
+		func(dataItem bool, streamBarIndex int) {
+			op.Data = append(op.Data, dataItem)
+		})
+
+	return &op, err
+}
+
+// NewLessThanForStream creates a Less Than Operator (LessThan) for online usage with the float streams of its operands
+func NewLessThanForStream(streamA gotrade.FloatStreamSubscriber, streamB gotrade.FloatStreamSubscriber) (operator *LessThan, err error) {
+	op, err := NewLessThan()
+	if err != nil {
+		return nil, err
+	}
+	subscribeOperand(streamA, op.ReceiveA)
+	subscribeOperand(streamB, op.ReceiveB)
+	return op, nil
+}
+
+// NewLessThanConstantForStream creates a Less Than Operator (LessThan) for online usage with the float stream of
+// operand A compared to the constant b
+func NewLessThanConstantForStream(streamA gotrade.FloatStreamSubscriber, b float64) (operator *LessThan, err error) {
+	op, err := NewLessThanConstant(b)
+	if err != nil {
+		return nil, err
+	}
+	subscribeOperand(streamA, op.ReceiveA)
+	return op, nil
+}
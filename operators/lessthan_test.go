@@ -0,0 +1,56 @@
+package operators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/jaybutera/gotrade/indicators"
+	"github.com/jaybutera/gotrade/operators"
+)
+
+var _ = Describe("when creating a lessthanwithoutstorage", func() {
+	var (
+		operator      *operators.LessThanWithoutStorage
+		operatorError error
+	)
+
+	Context("and the operator was not given a value available action", func() {
+		BeforeEach(func() {
+			operator, operatorError = operators.NewLessThanWithoutStorage(nil)
+		})
+
+		It("the operator should not be created and return the appropriate error message", func() {
+			Expect(operator).To(BeNil())
+			Expect(operatorError).To(Equal(indicators.ErrValueAvailableActionIsNil))
+		})
+	})
+})
+
+var _ = Describe("when calculating less than", func() {
+	var (
+		operator *operators.LessThan
+	)
+
+	Context("given the operator is created via the standard constructor", func() {
+		BeforeEach(func() {
+			operator, _ = operators.NewLessThan()
+			receiveSeries(operator.ReceiveB, 1, 2.0, 2.0, 2.0)
+			receiveSeries(operator.ReceiveA, 1, 1.0, 2.0, 3.0)
+		})
+
+		It("should be true on the bars operand A is less than operand B", func() {
+			Expect(operator.Data).To(Equal([]bool{true, false, false}))
+		})
+	})
+
+	Context("given the operator is created with a constant operand", func() {
+		BeforeEach(func() {
+			operator, _ = operators.NewLessThanConstant(30.0)
+			receiveSeries(operator.ReceiveA, 5, 25.0, 30.0, 35.0)
+		})
+
+		It("should be true on the bars operand A is less than the constant", func() {
+			Expect(operator.ValidFromBar()).To(Equal(5))
+			Expect(operator.Data).To(Equal([]bool{true, false, false}))
+		})
+	})
+})
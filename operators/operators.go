@@ -0,0 +1,236 @@
+/*
+	import "github.com/jaybutera/gotrade/operators"
+
+	Package operators provides operators over the outputs of indicators, such as crosses and comparisons.
+	All operators follow the basic structure of:
+		- receiving the values of their operands, the outputs of indicators or constants.
+		- aligning the operands by stream bar index, operands starting at different bars are
+		- only evaluated from the first bar all of them have a value for.
+		- a lookback period indicating the number of aligned values consumed before the first result.
+		- the source data bar from which the operator is valid
+
+	Operands are received from the float streams of indicators, e.g.
+
+		fast, _ := indicators.NewSmaForStream(priceStream, 10, gotrade.UseClosePrice)
+		slow, _ := indicators.NewSmaForStream(priceStream, 20, gotrade.UseClosePrice)
+		crossesAbove, _ := operators.NewCrossesAboveForStream(fast, slow)
+
+	or through the operator's receive functions, which match ValueAvailableActionFloat so they can be
+	given as the value available action of an indicator without storage, e.g.
+
+		crossesAbove, _ := operators.NewCrossesAbove()
+		fast, _ := indicators.NewSmaWithoutStorage(10, crossesAbove.ReceiveA)
+		slow, _ := indicators.NewSmaWithoutStorage(20, crossesAbove.ReceiveB)
+
+	Each operator provides the following creation functions
+		* Operator with storage
+		* Operator with a constant operand, for the binary operators
+		* Operator for attachment to the float streams of its operands
+		* Operator without storage
+			- for use inside other operators, has no storage of results which is instead
+			- provided via a callback when it becomes available for use in the parent operator.
+*/
+package operators
+
+import (
+	"github.com/jaybutera/gotrade"
+	"github.com/jaybutera/gotrade/indicators"
+	"sync"
+)
+
+type ValueAvailableActionBool func(dataItem bool, streamBarIndex int)
+
+type baseOperator struct {
+	validFromBar   int
+	dataLength     int
+	lookbackPeriod int
+}
+
+func newBaseOperator(lookbackPeriod int) *baseOperator {
+	op := baseOperator{lookbackPeriod: lookbackPeriod, validFromBar: -1}
+	return &op
+}
+
+// ValidFromBar returns the source data bar number from which this operator is valid, starts at bar 1
+func (op *baseOperator) ValidFromBar() int {
+	return op.validFromBar
+}
+
+// GetLookbackPeriod returns the number of aligned operand values consumed before the first result
+func (op *baseOperator) GetLookbackPeriod() int {
+	return op.lookbackPeriod
+}
+
+// Length returns the number of results generated by the operator
+func (op *baseOperator) Length() int {
+	return op.dataLength
+}
+
+func (op *baseOperator) updateWithNewValue(streamBarIndex int) {
+	// increment the number of results this operator can be expected to return
+	op.dataLength += 1
+
+	// set the streamBarIndex from which this operator returns valid results
+	if op.validFromBar == -1 {
+		op.validFromBar = streamBarIndex
+	}
+}
+
+type baseOperatorWithBool struct {
+	*baseOperator
+	valueAvailableAction ValueAvailableActionBool
+}
+
+func newBaseOperatorWithBool(lookbackPeriod int, valueAvailableAction ValueAvailableActionBool) *baseOperatorWithBool {
+	op := baseOperatorWithBool{
+		baseOperator:         newBaseOperator(lookbackPeriod),
+		valueAvailableAction: valueAvailableAction,
+	}
+	return &op
+}
+
+func (op *baseOperatorWithBool) updateOperatorWithNewValue(newValue bool, streamBarIndex int) {
+	op.updateWithNewValue(streamBarIndex)
+
+	// notify of a new result value though the value available action
+	op.valueAvailableAction(newValue, streamBarIndex)
+}
+
+type baseOperatorWithInt struct {
+	*baseOperator
+	valueAvailableAction indicators.ValueAvailableActionInt
+}
+
+func newBaseOperatorWithInt(lookbackPeriod int, valueAvailableAction indicators.ValueAvailableActionInt) *baseOperatorWithInt {
+	op := baseOperatorWithInt{
+		baseOperator:         newBaseOperator(lookbackPeriod),
+		valueAvailableAction: valueAvailableAction,
+	}
+	return &op
+}
+
+func (op *baseOperatorWithInt) updateOperatorWithNewValue(newValue int64, streamBarIndex int) {
+	op.updateWithNewValue(streamBarIndex)
+
+	// notify of a new result value though the value available action
+	op.valueAvailableAction(newValue, streamBarIndex)
+}
+
+type baseOperatorWithFloat struct {
+	*baseOperator
+	valueAvailableAction indicators.ValueAvailableActionFloat
+}
+
+func newBaseOperatorWithFloat(lookbackPeriod int, valueAvailableAction indicators.ValueAvailableActionFloat) *baseOperatorWithFloat {
+	op := baseOperatorWithFloat{
+		baseOperator:         newBaseOperator(lookbackPeriod),
+		valueAvailableAction: valueAvailableAction,
+	}
+	return &op
+}
+
+func (op *baseOperatorWithFloat) updateOperatorWithNewValue(newValue float64, streamBarIndex int) {
+	op.updateWithNewValue(streamBarIndex)
+
+	// notify of a new result value though the value available action
+	op.valueAvailableAction(newValue, streamBarIndex)
+}
+
+// a value of an operand received for a stream bar
+type operandValue struct {
+	value          float64
+	streamBarIndex int
+}
+
+// an operand is the output of an indicator, or a constant
+type operand struct {
+	// the values received that the other operand does not yet have a value for, oldest first
+	pending  []operandValue
+	constant bool
+	value    float64
+}
+
+func newSeriesOperand() operand {
+	return operand{}
+}
+
+func newConstantOperand(value float64) operand {
+	return operand{value: value, constant: true}
+}
+
+// take returns the value of the operand for the stream bar if it has one, values for earlier bars
+// are discarded as the other operand has moved past them
+func (o *operand) take(streamBarIndex int) (value float64, ok bool) {
+	if o.constant {
+		return o.value, true
+	}
+
+	for len(o.pending) > 0 && o.pending[0].streamBarIndex < streamBarIndex {
+		o.pending = o.pending[1:]
+	}
+
+	if len(o.pending) > 0 && o.pending[0].streamBarIndex == streamBarIndex {
+		value = o.pending[0].value
+		o.pending = o.pending[1:]
+		return value, true
+	}
+
+	return 0.0, false
+}
+
+func (o *operand) hold(value float64, streamBarIndex int) {
+	o.pending = append(o.pending, operandValue{value: value, streamBarIndex: streamBarIndex})
+}
+
+// The two operands of a binary operator, evaluated once both have a value for the same stream bar
+type operandPair struct {
+	// the operands may be received concurrently when a stream dispatches to its subscribers concurrently
+	mutex    sync.Mutex
+	a        operand
+	b        operand
+	evaluate func(a float64, b float64, streamBarIndex int)
+}
+
+func newOperandPair(b operand, evaluate func(a float64, b float64, streamBarIndex int)) *operandPair {
+	return &operandPair{a: newSeriesOperand(), b: b, evaluate: evaluate}
+}
+
+func (p *operandPair) receiveA(dataItem float64, streamBarIndex int) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if b, ok := p.b.take(streamBarIndex); ok {
+		p.evaluate(dataItem, b, streamBarIndex)
+	} else {
+		p.a.hold(dataItem, streamBarIndex)
+	}
+}
+
+func (p *operandPair) receiveB(dataItem float64, streamBarIndex int) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	// a constant operand does not change
+	if p.b.constant {
+		return
+	}
+
+	if a, ok := p.a.take(streamBarIndex); ok {
+		p.evaluate(a, dataItem, streamBarIndex)
+	} else {
+		p.b.hold(dataItem, streamBarIndex)
+	}
+}
+
+// operandReceiver receives one operand of an operator from a float stream
+type operandReceiver struct {
+	receive func(tickData float64, streamBarIndex int)
+}
+
+func subscribeOperand(stream gotrade.FloatStreamSubscriber, receive func(tickData float64, streamBarIndex int)) {
+	stream.AddTickSubscription(&operandReceiver{receive: receive})
+}
+
+func (r *operandReceiver) ReceiveTick(tickData float64, streamBarIndex int) {
+	r.receive(tickData, streamBarIndex)
+}
@@ -0,0 +1,99 @@
+package operators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/jaybutera/gotrade"
+	"github.com/jaybutera/gotrade/indicators"
+	"github.com/jaybutera/gotrade/operators"
+)
+
+var _ = Describe("when calculating a fast sma crossing above a slow sma with a years data", func() {
+	var (
+		crossesAbove *operators.CrossesAbove
+		fast         *indicators.SmaWithoutStorage
+		slow         *indicators.SmaWithoutStorage
+		fastSma      *indicators.Sma
+		slowSma      *indicators.Sma
+		priceStream  *gotrade.InterDayDOHLCVStream
+	)
+
+	BeforeEach(func() {
+		priceStream = gotrade.NewDailyDOHLCVStream()
+		fastSma, _ = indicators.NewSmaForStream(priceStream, 10, gotrade.UseClosePrice)
+		slowSma, _ = indicators.NewSmaForStream(priceStream, 20, gotrade.UseClosePrice)
+		csvFeed.FillDOHLCVStream(priceStream)
+
+		crossesAbove, _ = operators.NewCrossesAbove()
+		fast, _ = indicators.NewSmaWithoutStorage(10, crossesAbove.ReceiveA)
+		slow, _ = indicators.NewSmaWithoutStorage(20, crossesAbove.ReceiveB)
+		for i := range priceStream.Data {
+			fast.ReceiveTick(priceStream.Data[i].C(), i+1)
+			slow.ReceiveTick(priceStream.Data[i].C(), i+1)
+		}
+	})
+
+	It("should be valid from the bar after the slow sma is valid", func() {
+		Expect(crossesAbove.ValidFromBar()).To(Equal(slowSma.ValidFromBar() + 1))
+		Expect(len(crossesAbove.Data)).To(Equal(len(slowSma.Data) - 1))
+	})
+
+	It("should be true only on the bars the fast sma crosses above the slow sma", func() {
+		offset := len(fastSma.Data) - len(slowSma.Data)
+		crosses := 0
+		for k := range crossesAbove.Data {
+			expected := fastSma.Data[offset+k] <= slowSma.Data[k] && fastSma.Data[offset+k+1] > slowSma.Data[k+1]
+			Expect(crossesAbove.Data[k]).To(Equal(expected))
+			if expected {
+				crosses += 1
+			}
+		}
+		Expect(crosses).To(BeNumerically(">", 0))
+	})
+})
+
+var _ = Describe("when attaching operators to the float streams of indicators with a years data", func() {
+	var (
+		crossesAbove *operators.CrossesAbove
+		greaterThan  *operators.GreaterThan
+		rising       *operators.Rising
+		fastSma      *indicators.Sma
+		slowSma      *indicators.Sma
+		rsi          *indicators.Rsi
+		priceStream  *gotrade.InterDayDOHLCVStream
+	)
+
+	BeforeEach(func() {
+		priceStream = gotrade.NewDailyDOHLCVStream()
+		priceStream.SetDispatchStrategy(gotrade.FanOutDispatch)
+		fastSma, _ = indicators.NewSmaForStream(priceStream, 10, gotrade.UseClosePrice)
+		slowSma, _ = indicators.NewSmaForStream(priceStream, 20, gotrade.UseClosePrice)
+		rsi, _ = indicators.NewRsiForStream(priceStream, 14, gotrade.UseClosePrice)
+		crossesAbove, _ = operators.NewCrossesAboveForStream(fastSma, slowSma)
+		greaterThan, _ = operators.NewGreaterThanConstantForStream(rsi, 50.0)
+		rising, _ = operators.NewRisingForStream(slowSma, 3)
+		csvFeed.FillDOHLCVStream(priceStream)
+	})
+
+	It("the crosses should be true only on the bars the fast sma crosses above the slow sma", func() {
+		Expect(crossesAbove.ValidFromBar()).To(Equal(slowSma.ValidFromBar() + 1))
+		offset := len(fastSma.Data) - len(slowSma.Data)
+		for k := range crossesAbove.Data {
+			expected := fastSma.Data[offset+k] <= slowSma.Data[k] && fastSma.Data[offset+k+1] > slowSma.Data[k+1]
+			Expect(crossesAbove.Data[k]).To(Equal(expected))
+		}
+	})
+
+	It("the comparison should have a result for each rsi result", func() {
+		Expect(greaterThan.ValidFromBar()).To(Equal(rsi.ValidFromBar()))
+		Expect(len(greaterThan.Data)).To(Equal(len(rsi.Data)))
+		for k := range greaterThan.Data {
+			Expect(greaterThan.Data[k]).To(Equal(rsi.Data[k] > 50.0))
+		}
+	})
+
+	It("rising should be valid from its time period after the slow sma", func() {
+		Expect(rising.ValidFromBar()).To(Equal(slowSma.ValidFromBar() + 3))
+		Expect(len(rising.Data)).To(Equal(len(slowSma.Data) - 3))
+	})
+})
@@ -0,0 +1,34 @@
+package operators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/jaybutera/gotrade/feeds"
+	"testing"
+	"time"
+)
+
+var (
+	csvFeed *feeds.CSVFileFeed
+)
+
+func TestOperators(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Operators Suite")
+}
+
+var _ = BeforeSuite(func() {
+	csvFeed = feeds.NewCSVFileFeedWithDOHLCVFormat("../testdata/JSETOPI.2013.data",
+		feeds.DashedYearDayMonthDateParserForLocation(time.Local))
+})
+
+var _ = AfterSuite(func() {
+	csvFeed = nil
+})
+
+// receiveSeries sends the values to the receive function as a series starting at the given stream bar
+func receiveSeries(receive func(dataItem float64, streamBarIndex int), firstStreamBarIndex int, values ...float64) {
+	for i, value := range values {
+		receive(value, firstStreamBarIndex+i)
+	}
+}
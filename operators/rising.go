@@ -0,0 +1,96 @@
+// Rising (Rising)
+package operators
+
+import (
+	"errors"
+	"github.com/jaybutera/gotrade"
+	"github.com/jaybutera/gotrade/indicators"
+)
+
+// A Rising Operator (Rising), no storage, for use in other operators
+type RisingWithoutStorage struct {
+	*baseOperatorWithBool
+
+	// private variables
+	previousValue float64
+	valueCounter  int
+	risingCounter int
+	timePeriod    int
+}
+
+// NewRisingWithoutStorage creates a Rising Operator (Rising) without storage
+func NewRisingWithoutStorage(timePeriod int, valueAvailableAction ValueAvailableActionBool) (operator *RisingWithoutStorage, err error) {
+
+	// an operator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, indicators.ErrValueAvailableActionIsNil
+	}
+
+	// the minimum timeperiod for this operator is 1
+	if timePeriod < 1 {
+		return nil, errors.New("timePeriod is less than the minimum (1)")
+	}
+
+	// check the maximum timeperiod
+	if timePeriod > indicators.MaximumLookbackPeriod {
+		return nil, errors.New("timePeriod is greater than the maximum (100000)")
+	}
+
+	lookback := timePeriod
+	op := RisingWithoutStorage{
+		baseOperatorWithBool: newBaseOperatorWithBool(lookback, valueAvailableAction),
+		timePeriod:           timePeriod,
+	}
+
+	return &op, nil
+}
+
+// GetTimePeriod returns the number of bars the value must have risen on
+func (op *RisingWithoutStorage) GetTimePeriod() int {
+	return op.timePeriod
+}
+
+// ReceiveTick consumes a value of the operand, the result is true if the value rose on each of the last timePeriod bars
+func (op *RisingWithoutStorage) ReceiveTick(dataItem float64, streamBarIndex int) {
+	op.valueCounter += 1
+
+	if op.valueCounter > 1 && dataItem > op.previousValue {
+		op.risingCounter += 1
+	} else {
+		op.risingCounter = 0
+	}
+	op.previousValue = dataItem
+
+	if op.valueCounter > op.GetLookbackPeriod() {
+		op.updateOperatorWithNewValue(op.risingCounter >= op.timePeriod, streamBarIndex)
+	}
+}
+
+// A Rising Operator (Rising)
+type Rising struct {
+	*RisingWithoutStorage
+
+	// public variables
+	Data []bool
+}
+
+// NewRising creates a Rising Operator (Rising) for online usage
+func NewRising(timePeriod int) (operator *Rising, err error) {
+	op := Rising{}
+	op.RisingWithoutStorage, err = NewRisingWithoutStorage(timePeriod,
+		func(dataItem bool, streamBarIndex int) {
+			op.Data = append(op.Data, dataItem)
+		})
+
+	return &op, err
+}
+
+// NewRisingForStream creates a Rising Operator (Rising) for online usage with the float stream of its operand
+func NewRisingForStream(stream gotrade.FloatStreamSubscriber, timePeriod int) (operator *Rising, err error) {
+	op, err := NewRising(timePeriod)
+	if err != nil {
+		return nil, err
+	}
+	stream.AddTickSubscription(op)
+	return op, nil
+}
@@ -0,0 +1,75 @@
+package operators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/jaybutera/gotrade/indicators"
+	"github.com/jaybutera/gotrade/operators"
+)
+
+var _ = Describe("when creating a risingwithoutstorage", func() {
+	var (
+		operator      *operators.RisingWithoutStorage
+		operatorError error
+	)
+
+	Context("and the operator was not given a value available action", func() {
+		BeforeEach(func() {
+			operator, operatorError = operators.NewRisingWithoutStorage(2, nil)
+		})
+
+		It("the operator should not be created and return the appropriate error message", func() {
+			Expect(operator).To(BeNil())
+			Expect(operatorError).To(Equal(indicators.ErrValueAvailableActionIsNil))
+		})
+	})
+
+	Context("and the operator was given a time period below the minimum", func() {
+		BeforeEach(func() {
+			operator, operatorError = operators.NewRisingWithoutStorage(0, func(dataItem bool, streamBarIndex int) {})
+		})
+
+		It("the operator should not be created and return the appropriate error message", func() {
+			Expect(operator).To(BeNil())
+			Expect(operatorError).To(MatchError("timePeriod is less than the minimum (1)"))
+		})
+	})
+
+	Context("and the operator was given a time period above the maximum", func() {
+		BeforeEach(func() {
+			operator, operatorError = operators.NewRisingWithoutStorage(indicators.MaximumLookbackPeriod+1, func(dataItem bool, streamBarIndex int) {})
+		})
+
+		It("the operator should not be created and return the appropriate error message", func() {
+			Expect(operator).To(BeNil())
+			Expect(operatorError).To(MatchError("timePeriod is greater than the maximum (100000)"))
+		})
+	})
+})
+
+var _ = Describe("when calculating rising", func() {
+	var (
+		operator *operators.Rising
+	)
+
+	Context("given the operator is created via the standard constructor", func() {
+		BeforeEach(func() {
+			operator, _ = operators.NewRising(2)
+			receiveSeries(operator.ReceiveTick, 3, 1.0, 2.0, 3.0, 3.0, 4.0, 5.0, 6.0, 5.0)
+		})
+
+		It("should have a lookback period equal to the time period", func() {
+			Expect(operator.GetTimePeriod()).To(Equal(2))
+			Expect(operator.GetLookbackPeriod()).To(Equal(2))
+		})
+
+		It("should have results from the bar after the lookback period", func() {
+			Expect(operator.ValidFromBar()).To(Equal(5))
+			Expect(operator.Length()).To(Equal(6))
+		})
+
+		It("should be true on the bars the value rose on each of the last time period bars", func() {
+			Expect(operator.Data).To(Equal([]bool{true, false, false, true, true, false}))
+		})
+	})
+})
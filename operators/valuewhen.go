@@ -0,0 +1,118 @@
+// Value When (ValueWhen)
+package operators
+
+import (
+	"github.com/jaybutera/gotrade"
+	"github.com/jaybutera/gotrade/indicators"
+	"sync"
+)
+
+// A Value When Operator (ValueWhen), no storage, for use in other operators
+type ValueWhenWithoutStorage struct {
+	*baseOperatorWithFloat
+
+	// private variables
+	// the operands may be received concurrently when a stream dispatches to its subscribers concurrently
+	mutex      sync.Mutex
+	conditions []conditionValue
+	values     operand
+	hasValue   bool
+	valueWhen  float64
+}
+
+// a value of the condition received for a stream bar
+type conditionValue struct {
+	condition      bool
+	streamBarIndex int
+}
+
+// NewValueWhenWithoutStorage creates a Value When Operator (ValueWhen) without storage
+func NewValueWhenWithoutStorage(valueAvailableAction indicators.ValueAvailableActionFloat) (operator *ValueWhenWithoutStorage, err error) {
+
+	// an operator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, indicators.ErrValueAvailableActionIsNil
+	}
+
+	lookback := 0
+	op := ValueWhenWithoutStorage{
+		baseOperatorWithFloat: newBaseOperatorWithFloat(lookback, valueAvailableAction),
+		values:                newSeriesOperand(),
+	}
+
+	return &op, nil
+}
+
+// ReceiveCondition consumes a value of the condition
+func (op *ValueWhenWithoutStorage) ReceiveCondition(dataItem bool, streamBarIndex int) {
+	op.mutex.Lock()
+	defer op.mutex.Unlock()
+
+	if value, ok := op.values.take(streamBarIndex); ok {
+		op.evaluate(dataItem, value, streamBarIndex)
+	} else {
+		op.conditions = append(op.conditions, conditionValue{condition: dataItem, streamBarIndex: streamBarIndex})
+	}
+}
+
+// ReceiveValue consumes a value of the series
+func (op *ValueWhenWithoutStorage) ReceiveValue(dataItem float64, streamBarIndex int) {
+	op.mutex.Lock()
+	defer op.mutex.Unlock()
+
+	// conditions for earlier bars are discarded as the series has moved past them
+	for len(op.conditions) > 0 && op.conditions[0].streamBarIndex < streamBarIndex {
+		op.conditions = op.conditions[1:]
+	}
+
+	if len(op.conditions) > 0 && op.conditions[0].streamBarIndex == streamBarIndex {
+		condition := op.conditions[0].condition
+		op.conditions = op.conditions[1:]
+		op.evaluate(condition, dataItem, streamBarIndex)
+	} else {
+		op.values.hold(dataItem, streamBarIndex)
+	}
+}
+
+// evaluate produces the value of the series on the last bar the condition was true, there is no result
+// until the condition has been true
+func (op *ValueWhenWithoutStorage) evaluate(condition bool, value float64, streamBarIndex int) {
+	if condition {
+		op.valueWhen = value
+		op.hasValue = true
+	}
+
+	if op.hasValue {
+		op.updateOperatorWithNewValue(op.valueWhen, streamBarIndex)
+	}
+}
+
+// A Value When Operator (ValueWhen)
+type ValueWhen struct {
+	*ValueWhenWithoutStorage
+
+	// public variables
+	Data []float64
+}
+
+// NewValueWhen creates a Value When Operator (ValueWhen) for online usage
+func NewValueWhen() (operator *ValueWhen, err error) {
+	op := ValueWhen{}
+	op.ValueWhenWithoutStorage, err = NewValueWhenWithoutStorage(
+		func(dataItem float64, streamBarIndex int) {
+			op.Data = append(op.Data, dataItem)
+		})
+
+	return &op, err
+}
+
+// NewValueWhenForStream creates a Value When Operator (ValueWhen) for online usage with the float stream of the series,
+// the condition is received through ReceiveCondition
+func NewValueWhenForStream(valueStream gotrade.FloatStreamSubscriber) (operator *ValueWhen, err error) {
+	op, err := NewValueWhen()
+	if err != nil {
+		return nil, err
+	}
+	subscribeOperand(valueStream, op.ReceiveValue)
+	return op, nil
+}
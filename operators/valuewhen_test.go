@@ -0,0 +1,51 @@
+package operators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/jaybutera/gotrade/indicators"
+	"github.com/jaybutera/gotrade/operators"
+)
+
+var _ = Describe("when creating a valuewhenwithoutstorage", func() {
+	var (
+		operator      *operators.ValueWhenWithoutStorage
+		operatorError error
+	)
+
+	Context("and the operator was not given a value available action", func() {
+		BeforeEach(func() {
+			operator, operatorError = operators.NewValueWhenWithoutStorage(nil)
+		})
+
+		It("the operator should not be created and return the appropriate error message", func() {
+			Expect(operator).To(BeNil())
+			Expect(operatorError).To(Equal(indicators.ErrValueAvailableActionIsNil))
+		})
+	})
+})
+
+var _ = Describe("when calculating value when", func() {
+	var (
+		operator *operators.ValueWhen
+	)
+
+	Context("given the operator is created via the standard constructor", func() {
+		BeforeEach(func() {
+			operator, _ = operators.NewValueWhen()
+			receiveSeries(operator.ReceiveValue, 1, 10.0, 11.0, 12.0, 13.0, 14.0, 15.0)
+			for i, condition := range []bool{false, true, false, false, true} {
+				operator.ReceiveCondition(condition, i+2)
+			}
+		})
+
+		It("should have results from the first bar the condition is true", func() {
+			Expect(operator.ValidFromBar()).To(Equal(3))
+			Expect(operator.Length()).To(Equal(4))
+		})
+
+		It("should hold the value of the series on the bar the condition was last true", func() {
+			Expect(operator.Data).To(Equal([]float64{12.0, 12.0, 12.0, 15.0}))
+		})
+	})
+})
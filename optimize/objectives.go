@@ -0,0 +1,61 @@
+package optimize
+
+import (
+	"github.com/jaybutera/gotrade/backtest"
+	"github.com/jaybutera/gotrade/stats"
+	"math"
+)
+
+// TotalReturn scores a result by the return of its final equity over its initial cash
+func TotalReturn(result *backtest.Result) float64 {
+	return result.FinalEquity/result.InitialCash - 1.0
+}
+
+// NetProfit scores a result by the profit or loss of its closed trades
+func NetProfit(result *backtest.Result) float64 {
+	total := 0.0
+	for _, trade := range result.Trades {
+		total += trade.ProfitLoss
+	}
+	return total
+}
+
+// SharpeRatio scores a result by the Sharpe ratio of its equity curve, results without the equity
+// to calculate it score negative infinity
+func SharpeRatio(periodsPerYear float64, riskFreeRate float64) Objective {
+	return func(result *backtest.Result) float64 {
+		return equityStatistic(result, periodsPerYear, riskFreeRate, func(s *stats.EquityStatistics) float64 {
+			return s.Sharpe
+		})
+	}
+}
+
+// CalmarRatio scores a result by the annualized return over the max drawdown of its equity curve,
+// results without the equity to calculate it score negative infinity
+func CalmarRatio(periodsPerYear float64) Objective {
+	return func(result *backtest.Result) float64 {
+		return equityStatistic(result, periodsPerYear, 0.0, func(s *stats.EquityStatistics) float64 {
+			return s.Calmar
+		})
+	}
+}
+
+func equityStatistic(result *backtest.Result, periodsPerYear float64, riskFreeRate float64,
+	statistic func(s *stats.EquityStatistics) float64) float64 {
+
+	equity := make([]float64, len(result.EquityCurve))
+	for i, point := range result.EquityCurve {
+		equity[i] = point.Equity
+	}
+
+	s, err := stats.NewEquityStatistics(equity, periodsPerYear, riskFreeRate)
+	if err != nil {
+		return math.Inf(-1)
+	}
+
+	// a flat equity curve has no volatility to measure against
+	if value := statistic(s); !math.IsNaN(value) {
+		return value
+	}
+	return math.Inf(-1)
+}
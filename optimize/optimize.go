@@ -0,0 +1,321 @@
+/*
+	import "github.com/jaybutera/gotrade/optimize"
+
+	Package optimize searches the parameters of a strategy for those that score best over historical bars.
+	The optimizer supports:
+		- a grid search of every combination of the values of the parameter ranges.
+		- a random search of a sample of the combinations.
+		- an adaptive search, sampling at random and then around the best combination found so far.
+		- a walk forward analysis, optimizing over in sample windows and testing over the out of sample windows following them.
+
+	Runs are backtested in parallel across the CPU cores, with the results ranked by score and then by the
+	order of the combination, so that the ranking does not depend on the order the runs complete in.
+	The bars should be read once into a memory feed and shared by the runs, e.g.
+
+		memoryFeed, err := feeds.LoadMemoryFeed(csvFeed.FillDOHLCVStream)
+		...
+		newStrategy := func(parameters optimize.Parameters) (backtest.Strategy, error) {
+			return newSmaCross(parameters.Int("fast"), parameters.Int("slow"))
+		}
+
+		optimizer := optimize.NewOptimizer(newStrategy, optimize.TotalReturn, backtest.DefaultConfig(),
+			optimize.IntRange("fast", 5, 20, 5), optimize.IntRange("slow", 20, 100, 10))
+		runs, err := optimizer.GridSearch(memoryFeed)
+		best := optimize.Best(runs)
+*/
+package optimize
+
+import (
+	"errors"
+	"github.com/jaybutera/gotrade/backtest"
+	"math"
+	"math/rand"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+var (
+	ErrSamplesTooSmall = errors.New("samples is less than the minimum (1)")
+	ErrRoundsTooSmall  = errors.New("rounds is less than the minimum (1)")
+)
+
+// A StrategyFactory creates a strategy for the parameters of a run, a new strategy is created
+// for every run as runs execute concurrently. Returning an error, e.g. for a fast period that is
+// not less than the slow period, fails the run without failing the search.
+type StrategyFactory func(parameters Parameters) (backtest.Strategy, error)
+
+// An Objective scores the result of a run, the higher the better
+type Objective func(result *backtest.Result) float64
+
+// A Run is the backtest of a strategy with a combination of parameters
+type Run struct {
+	Parameters Parameters
+	// the result of the backtest, nil when the run failed
+	Result *backtest.Result
+	// the objective's score of the result, NaN when the run failed
+	Score float64
+	// the error creating or backtesting the strategy
+	Err error
+
+	// the index of the combination the parameters were taken from
+	index int
+}
+
+// An Optimizer searches the values of the parameter ranges for the strategy scoring best
+type Optimizer struct {
+	Ranges      []Range
+	NewStrategy StrategyFactory
+	Objective   Objective
+	Config      backtest.Config
+	// the number of runs to execute concurrently, the number of CPU cores when 0
+	Workers int
+}
+
+// NewOptimizer creates an optimizer of the strategy over the ranges of its parameters
+func NewOptimizer(newStrategy StrategyFactory, objective Objective, config backtest.Config, ranges ...Range) *Optimizer {
+	o := Optimizer{Ranges: ranges, NewStrategy: newStrategy, Objective: objective, Config: config}
+	return &o
+}
+
+// Combinations returns the parameters of every combination of the values of the ranges,
+// with the first range changing slowest
+func (o *Optimizer) Combinations() ([]Parameters, error) {
+	if err := validateRanges(o.Ranges); err != nil {
+		return nil, err
+	}
+
+	g := newGrid(o.Ranges)
+	combinations := make([]Parameters, g.size)
+	for i := range combinations {
+		combinations[i] = g.parameters(i)
+	}
+	return combinations, nil
+}
+
+// GridSearch backtests every combination of the values of the ranges over the feed, returning the runs ranked best first
+func (o *Optimizer) GridSearch(feed backtest.Feed) (runs []*Run, err error) {
+	if err = validateRanges(o.Ranges); err != nil {
+		return nil, err
+	}
+
+	g := newGrid(o.Ranges)
+	indices := make([]int, g.size)
+	for i := range indices {
+		indices[i] = i
+	}
+
+	runs = o.evaluate(feed, g, indices)
+	rank(runs)
+	return runs, nil
+}
+
+// RandomSearch backtests a sample of distinct combinations of the values of the ranges over the feed, returning the runs
+// ranked best first. The sample is drawn from the seed, so the same seed gives the same runs.
+func (o *Optimizer) RandomSearch(feed backtest.Feed, samples int, seed int64) (runs []*Run, err error) {
+	if err = validateRanges(o.Ranges); err != nil {
+		return nil, err
+	}
+
+	if samples < 1 {
+		return nil, ErrSamplesTooSmall
+	}
+
+	g := newGrid(o.Ranges)
+	random := rand.New(rand.NewSource(seed))
+	runs = o.evaluate(feed, g, sample(g, random, samples, make(map[int]bool)))
+	rank(runs)
+	return runs, nil
+}
+
+// AdaptiveSearch backtests a random sample of combinations and then, for each further round, a sample of the
+// combinations neighbouring the best run so far, the neighbourhood narrowing each round. It returns every run
+// ranked best first. The samples are drawn from the seed, so the same seed gives the same runs.
+func (o *Optimizer) AdaptiveSearch(feed backtest.Feed, samplesPerRound int, rounds int, seed int64) (runs []*Run, err error) {
+	if err = validateRanges(o.Ranges); err != nil {
+		return nil, err
+	}
+
+	if samplesPerRound < 1 {
+		return nil, ErrSamplesTooSmall
+	}
+
+	if rounds < 1 {
+		return nil, ErrRoundsTooSmall
+	}
+
+	g := newGrid(o.Ranges)
+	random := rand.New(rand.NewSource(seed))
+	evaluated := make(map[int]bool)
+
+	runs = o.evaluate(feed, g, sample(g, random, samplesPerRound, evaluated))
+	rank(runs)
+
+	// the neighbourhood spans a quarter of each range's values, halving each round
+	spans := make([]int, len(g.values))
+	for i := range spans {
+		spans[i] = len(g.values[i])/4 + 1
+	}
+
+	for round := 1; round < rounds && len(evaluated) < g.size; round++ {
+		best := Best(runs)
+		if best == nil {
+			return runs, nil
+		}
+
+		indices := neighbours(g, random, best.index, spans, samplesPerRound, evaluated)
+		if len(indices) == 0 {
+			// the neighbourhood is exhausted, continue the search elsewhere
+			indices = sample(g, random, samplesPerRound, evaluated)
+		}
+
+		runs = append(runs, o.evaluate(feed, g, indices)...)
+		rank(runs)
+
+		for i := range spans {
+			if spans[i] > 1 {
+				spans[i] /= 2
+			}
+		}
+	}
+	return runs, nil
+}
+
+// Best returns the best ranked run that did not fail, or nil
+func Best(runs []*Run) *Run {
+	for _, run := range runs {
+		if run.Err == nil && !math.IsNaN(run.Score) {
+			return run
+		}
+	}
+	return nil
+}
+
+// evaluate backtests the combinations concurrently, each run writing only its own result
+func (o *Optimizer) evaluate(feed backtest.Feed, g *grid, indices []int) []*Run {
+	runs := make([]*Run, len(indices))
+
+	workers := o.Workers
+	if workers < 1 {
+		workers = runtime.NumCPU()
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				runs[i] = o.run(feed, g.parameters(indices[i]))
+				runs[i].index = indices[i]
+			}
+		}()
+	}
+
+	for i := range indices {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return runs
+}
+
+func (o *Optimizer) run(feed backtest.Feed, parameters Parameters) *Run {
+	return o.runWithConfig(feed, o.Config, parameters)
+}
+
+// runWithConfig backtests the strategy for the parameters over the feed with the config in place of the optimizer's
+func (o *Optimizer) runWithConfig(feed backtest.Feed, config backtest.Config, parameters Parameters) *Run {
+	r := Run{Parameters: parameters, Score: math.NaN()}
+
+	strategy, err := o.NewStrategy(parameters)
+	if err != nil {
+		r.Err = err
+		return &r
+	}
+
+	r.Result, r.Err = backtest.NewBacktest(feed, strategy, config).Run()
+	if r.Err == nil {
+		r.Score = o.Objective(r.Result)
+	}
+	return &r
+}
+
+// rank orders the runs by score, best first, then by the order of their combination, with the failed runs last
+func rank(runs []*Run) {
+	sort.SliceStable(runs, func(i, j int) bool {
+		iFailed, jFailed := math.IsNaN(runs[i].Score), math.IsNaN(runs[j].Score)
+		if iFailed != jFailed {
+			return jFailed
+		}
+
+		if !iFailed && runs[i].Score != runs[j].Score {
+			return runs[i].Score > runs[j].Score
+		}
+		return runs[i].index < runs[j].index
+	})
+}
+
+// sample draws distinct combinations not evaluated yet, marking them as evaluated
+func sample(g *grid, random *rand.Rand, samples int, evaluated map[int]bool) []int {
+	remaining := g.size - len(evaluated)
+	if samples > remaining {
+		samples = remaining
+	}
+
+	indices := make([]int, 0, samples)
+	for len(indices) < samples {
+		index := random.Intn(g.size)
+		if evaluated[index] {
+			continue
+		}
+
+		evaluated[index] = true
+		indices = append(indices, index)
+	}
+	return indices
+}
+
+// neighbours draws distinct combinations not evaluated yet, within the span of each range's value
+// around the centre combination, marking them as evaluated
+func neighbours(g *grid, random *rand.Rand, centre int, spans []int, samples int, evaluated map[int]bool) []int {
+	centrePosition := g.position(centre)
+
+	// the combinations within the spans, in order
+	var candidates []int
+	position := make([]int, len(centrePosition))
+	var visit func(rangeIndex int)
+	visit = func(rangeIndex int) {
+		if rangeIndex == len(position) {
+			if index := g.index(position); !evaluated[index] {
+				candidates = append(candidates, index)
+			}
+			return
+		}
+
+		low := centrePosition[rangeIndex] - spans[rangeIndex]
+		high := centrePosition[rangeIndex] + spans[rangeIndex]
+		for p := low; p <= high; p++ {
+			if p >= 0 && p < len(g.values[rangeIndex]) {
+				position[rangeIndex] = p
+				visit(rangeIndex + 1)
+			}
+		}
+	}
+	visit(0)
+
+	random.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+
+	if len(candidates) > samples {
+		candidates = candidates[:samples]
+	}
+
+	for _, index := range candidates {
+		evaluated[index] = true
+	}
+	return candidates
+}
@@ -0,0 +1,81 @@
+package optimize_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"errors"
+	"github.com/jaybutera/gotrade"
+	"github.com/jaybutera/gotrade/backtest"
+	"github.com/jaybutera/gotrade/feeds"
+	"github.com/jaybutera/gotrade/indicators"
+	"github.com/jaybutera/gotrade/operators"
+	"github.com/jaybutera/gotrade/optimize"
+	"testing"
+	"time"
+)
+
+var (
+	memoryFeed *feeds.MemoryFeed
+)
+
+func TestOptimize(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Optimize Suite")
+}
+
+var _ = BeforeSuite(func() {
+	csvFeed := feeds.NewCSVFileFeedWithDOHLCVFormat("../testdata/JSETOPI.ALL.data",
+		feeds.DashedYearDayMonthDateParserForLocation(time.Local))
+
+	var err error
+	memoryFeed, err = feeds.LoadMemoryFeed(csvFeed.FillDOHLCVStream)
+	Expect(err).To(BeNil())
+})
+
+var _ = AfterSuite(func() {
+	memoryFeed = nil
+})
+
+// smaCross holds a long position whilst the fast sma is above the slow sma
+type smaCross struct {
+	fastPeriod   int
+	slowPeriod   int
+	crossesAbove *operators.CrossesAbove
+	crossesBelow *operators.CrossesBelow
+}
+
+func newSmaCross(parameters optimize.Parameters) (backtest.Strategy, error) {
+	s := smaCross{fastPeriod: parameters.Int("fast"), slowPeriod: parameters.Int("slow")}
+	if s.fastPeriod >= s.slowPeriod {
+		return nil, errors.New("fast must be less than slow")
+	}
+	return &s, nil
+}
+
+func (s *smaCross) Init(priceStream gotrade.DOHLCVStreamSubscriber, broker *backtest.Broker) (err error) {
+	fast, err := indicators.NewSmaForStream(priceStream, s.fastPeriod, gotrade.UseClosePrice)
+	if err != nil {
+		return err
+	}
+	slow, err := indicators.NewSmaForStream(priceStream, s.slowPeriod, gotrade.UseClosePrice)
+	if err != nil {
+		return err
+	}
+	if s.crossesAbove, err = operators.NewCrossesAboveForStream(fast, slow); err != nil {
+		return err
+	}
+	s.crossesBelow, err = operators.NewCrossesBelowForStream(fast, slow)
+	return err
+}
+
+func (s *smaCross) OnBar(bar gotrade.DOHLCV, streamBarIndex int, broker *backtest.Broker) {
+	if !backtest.HasValueForBar(s.crossesAbove, streamBarIndex) {
+		return
+	}
+
+	if s.crossesAbove.Data[len(s.crossesAbove.Data)-1] && broker.Position() == 0.0 {
+		broker.Buy(1)
+	} else if s.crossesBelow.Data[len(s.crossesBelow.Data)-1] && broker.Position() > 0.0 {
+		broker.ClosePosition()
+	}
+}
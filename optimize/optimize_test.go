@@ -0,0 +1,264 @@
+package optimize_test
+
+import (
+	. "github.com/jaybutera/gotrade/optimize"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"fmt"
+	"github.com/jaybutera/gotrade"
+	"github.com/jaybutera/gotrade/backtest"
+	"github.com/jaybutera/gotrade/indicators"
+	"math"
+)
+
+// scores returns the parameters and score of each run in order
+func scores(runs []*Run) []string {
+	ranked := make([]string, len(runs))
+	for i, run := range runs {
+		ranked[i] = run.Parameters.String()
+		if run.Err == nil {
+			ranked[i] += fmt.Sprintf(" %v", run.Score)
+		}
+	}
+	return ranked
+}
+
+var _ = Describe("when optimizing an sma cross over its periods", func() {
+	var (
+		optimizer *Optimizer
+	)
+
+	BeforeEach(func() {
+		optimizer = NewOptimizer(newSmaCross, TotalReturn, backtest.DefaultConfig(),
+			IntRange("fast", 5, 25, 5), IntRange("slow", 20, 60, 20))
+	})
+
+	Context("with a grid search", func() {
+		var (
+			runs []*Run
+			err  error
+		)
+
+		BeforeEach(func() {
+			runs, err = optimizer.GridSearch(memoryFeed)
+		})
+
+		It("should run every combination", func() {
+			Expect(err).To(BeNil())
+			Expect(len(runs)).To(Equal(15))
+		})
+
+		It("should rank the runs by score with the failed runs last", func() {
+			for i := 1; i < len(runs); i++ {
+				if runs[i].Err == nil {
+					Expect(runs[i-1].Score).To(BeNumerically(">=", runs[i].Score))
+				}
+			}
+
+			// the fast periods not less than the slow period
+			failed := runs[len(runs)-2:]
+			Expect(failed[0].Parameters).To(Equal(Parameters{"fast": 20, "slow": 20}))
+			Expect(failed[1].Parameters).To(Equal(Parameters{"fast": 25, "slow": 20}))
+			Expect(failed[0].Err).To(MatchError("fast must be less than slow"))
+			Expect(math.IsNaN(failed[0].Score)).To(BeTrue())
+			Expect(failed[0].Result).To(BeNil())
+		})
+
+		It("should score the same as a backtest of the best parameters", func() {
+			best := Best(runs)
+			strategy, _ := newSmaCross(best.Parameters)
+			result, _ := backtest.NewBacktest(memoryFeed, strategy, backtest.DefaultConfig()).Run()
+
+			Expect(best.Score).To(Equal(TotalReturn(result)))
+			Expect(len(best.Result.Trades)).To(Equal(len(result.Trades)))
+		})
+
+		It("should rank the same whatever the number of workers", func() {
+			optimizer.Workers = 1
+			sequential, _ := optimizer.GridSearch(memoryFeed)
+			optimizer.Workers = 8
+			parallel, _ := optimizer.GridSearch(memoryFeed)
+
+			Expect(scores(sequential)).To(Equal(scores(runs)))
+			Expect(scores(parallel)).To(Equal(scores(runs)))
+		})
+	})
+
+	Context("with a random search", func() {
+		It("should run distinct combinations", func() {
+			runs, err := optimizer.RandomSearch(memoryFeed, 6, 42)
+			Expect(err).To(BeNil())
+			Expect(len(runs)).To(Equal(6))
+
+			seen := make(map[string]bool)
+			for _, run := range runs {
+				Expect(seen[run.Parameters.String()]).To(BeFalse())
+				seen[run.Parameters.String()] = true
+			}
+		})
+
+		It("should run the same combinations for the same seed", func() {
+			first, _ := optimizer.RandomSearch(memoryFeed, 6, 42)
+			second, _ := optimizer.RandomSearch(memoryFeed, 6, 42)
+			Expect(scores(second)).To(Equal(scores(first)))
+		})
+
+		It("should run every combination at most once", func() {
+			runs, _ := optimizer.RandomSearch(memoryFeed, 100, 42)
+			Expect(len(runs)).To(Equal(15))
+		})
+
+		It("should return an error for too few samples", func() {
+			runs, err := optimizer.RandomSearch(memoryFeed, 0, 42)
+			Expect(runs).To(BeNil())
+			Expect(err).To(Equal(ErrSamplesTooSmall))
+		})
+	})
+
+	Context("with an adaptive search", func() {
+		BeforeEach(func() {
+			optimizer.Ranges = []Range{IntRange("fast", 2, 20, 2), IntRange("slow", 20, 100, 10)}
+		})
+
+		It("should run distinct combinations for each round", func() {
+			runs, err := optimizer.AdaptiveSearch(memoryFeed, 5, 3, 7)
+			Expect(err).To(BeNil())
+			Expect(len(runs)).To(Equal(15))
+
+			seen := make(map[string]bool)
+			for _, run := range runs {
+				Expect(seen[run.Parameters.String()]).To(BeFalse())
+				seen[run.Parameters.String()] = true
+			}
+		})
+
+		It("should score at least as well as its first round", func() {
+			firstRound, _ := optimizer.RandomSearch(memoryFeed, 5, 7)
+			runs, _ := optimizer.AdaptiveSearch(memoryFeed, 5, 3, 7)
+			Expect(Best(runs).Score).To(BeNumerically(">=", Best(firstRound).Score))
+		})
+
+		It("should run the same combinations for the same seed", func() {
+			first, _ := optimizer.AdaptiveSearch(memoryFeed, 5, 3, 7)
+			second, _ := optimizer.AdaptiveSearch(memoryFeed, 5, 3, 7)
+			Expect(scores(second)).To(Equal(scores(first)))
+		})
+
+		It("should return an error for too few rounds", func() {
+			runs, err := optimizer.AdaptiveSearch(memoryFeed, 5, 0, 7)
+			Expect(runs).To(BeNil())
+			Expect(err).To(Equal(ErrRoundsTooSmall))
+		})
+	})
+
+	Context("with a sharpe ratio objective", func() {
+		It("should rank the runs by the sharpe ratio", func() {
+			optimizer.Objective = SharpeRatio(252.0, 0.0)
+			runs, err := optimizer.GridSearch(memoryFeed)
+			Expect(err).To(BeNil())
+			Expect(Best(runs).Score).To(BeNumerically(">", 0.0))
+			Expect(Best(runs).Score).To(BeNumerically("<", 10.0))
+		})
+	})
+})
+
+var _ = Describe("when walking forward an sma cross", func() {
+	var (
+		optimizer *Optimizer
+	)
+
+	BeforeEach(func() {
+		optimizer = NewOptimizer(newSmaCross, TotalReturn, backtest.DefaultConfig(),
+			IntRange("fast", 5, 15, 5), IntRange("slow", 30, 60, 30))
+	})
+
+	It("should step the out of sample windows on from each other", func() {
+		windows, err := optimizer.WalkForward(memoryFeed, 1000, 500, false, optimizer.GridSearch)
+		Expect(err).To(BeNil())
+		Expect(len(windows)).To(Equal((memoryFeed.Len() - 1000) / 500))
+
+		for i, window := range windows {
+			Expect(window.InSampleStart).To(Equal(i * 500))
+			Expect(window.InSampleEnd - window.InSampleStart).To(Equal(1000))
+			Expect(window.OutOfSampleStart).To(Equal(window.InSampleEnd))
+			Expect(window.OutOfSampleEnd - window.OutOfSampleStart).To(Equal(500))
+		}
+	})
+
+	It("should test the best in sample parameters out of sample", func() {
+		windows, _ := optimizer.WalkForward(memoryFeed, 1000, 500, false, optimizer.GridSearch)
+
+		for _, window := range windows {
+			Expect(window.Best).To(Equal(Best(window.InSample)))
+			Expect(window.OutOfSample.Parameters).To(Equal(window.Best.Parameters))
+			Expect(window.OutOfSample.Err).To(BeNil())
+			Expect(len(window.OutOfSample.Result.EquityCurve)).To(Equal(500))
+		}
+	})
+
+	It("should grow the in sample bars of anchored windows", func() {
+		windows, err := optimizer.WalkForward(memoryFeed, 1000, 500, true, optimizer.GridSearch)
+		Expect(err).To(BeNil())
+
+		for i, window := range windows {
+			Expect(window.InSampleStart).To(Equal(0))
+			Expect(window.InSampleEnd).To(Equal(1000 + i*500))
+			Expect(len(window.InSample[0].Result.EquityCurve)).To(Equal(window.InSampleEnd))
+		}
+	})
+
+	It("should warm up the out of sample run on the in sample bars", func() {
+		var probes []*warmUpProbe
+		probeOptimizer := NewOptimizer(func(parameters Parameters) (backtest.Strategy, error) {
+			probe := &warmUpProbe{}
+			probes = append(probes, probe)
+			return probe, nil
+		}, TotalReturn, backtest.DefaultConfig())
+
+		windows, err := probeOptimizer.WalkForward(memoryFeed, 1000, 500, false, func(feed backtest.Feed) ([]*Run, error) {
+			return []*Run{{Parameters: Parameters{}, Score: 0.0}}, nil
+		})
+		Expect(err).To(BeNil())
+		Expect(probes).To(HaveLen(len(windows)))
+
+		for i, window := range windows {
+			Expect(probes[i].firstBar).To(Equal(1001))
+			Expect(probes[i].smaLength).To(Equal(1001 - 59))
+			Expect(window.OutOfSample.Result.EquityCurve).To(HaveLen(500))
+			Expect(window.OutOfSample.Result.EquityCurve[0].StreamBarIndex).To(Equal(1001))
+		}
+	})
+
+	It("should use the search given", func() {
+		windows, err := optimizer.WalkForward(memoryFeed, 1000, 500, false, func(feed backtest.Feed) ([]*Run, error) {
+			return optimizer.RandomSearch(feed, 2, 1)
+		})
+		Expect(err).To(BeNil())
+		Expect(len(windows[0].InSample)).To(Equal(2))
+	})
+
+	It("should return an error for too few bars", func() {
+		windows, err := optimizer.WalkForward(memoryFeed, memoryFeed.Len(), 1, false, optimizer.GridSearch)
+		Expect(windows).To(BeNil())
+		Expect(err).To(Equal(ErrTooFewBars))
+	})
+})
+
+// warmUpProbe records the first bar the strategy is called for and the results its sma has by then
+type warmUpProbe struct {
+	sma       *indicators.Sma
+	firstBar  int
+	smaLength int
+}
+
+func (p *warmUpProbe) Init(priceStream gotrade.DOHLCVStreamSubscriber, broker *backtest.Broker) (err error) {
+	p.sma, err = indicators.NewSmaForStream(priceStream, 60, gotrade.UseClosePrice)
+	return err
+}
+
+func (p *warmUpProbe) OnBar(bar gotrade.DOHLCV, streamBarIndex int, broker *backtest.Broker) {
+	if p.firstBar == 0 {
+		p.firstBar = streamBarIndex
+		p.smaLength = p.sma.Length()
+	}
+}
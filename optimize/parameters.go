@@ -0,0 +1,138 @@
+package optimize
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+var (
+	ErrNoRanges = errors.New("ranges must contain at least 1 range")
+)
+
+// A Range of values for a parameter, from Min to Max inclusive in increments of Step
+type Range struct {
+	Name string
+	Min  float64
+	Max  float64
+	Step float64
+}
+
+// IntRange creates a range of whole numbers, e.g. for a time period
+func IntRange(name string, min int, max int, step int) Range {
+	return Range{Name: name, Min: float64(min), Max: float64(max), Step: float64(step)}
+}
+
+// Values returns the values of the range, calculated from the minimum so that steps do not accumulate rounding errors
+func (r Range) Values() []float64 {
+	count := int(math.Floor((r.Max-r.Min)/r.Step+1e-9)) + 1
+	values := make([]float64, count)
+	for i := range values {
+		values[i] = r.Min + float64(i)*r.Step
+	}
+	return values
+}
+
+func (r Range) validate() error {
+	switch {
+	case r.Name == "":
+		return errors.New("name must not be empty")
+	case r.Step <= 0.0 || math.IsNaN(r.Step):
+		return fmt.Errorf("%s: step must be greater than 0", r.Name)
+	case r.Max < r.Min || math.IsNaN(r.Min) || math.IsNaN(r.Max):
+		return fmt.Errorf("%s: max is less than min", r.Name)
+	}
+	return nil
+}
+
+func validateRanges(ranges []Range) error {
+	if len(ranges) == 0 {
+		return ErrNoRanges
+	}
+
+	names := make(map[string]bool, len(ranges))
+	for _, r := range ranges {
+		if err := r.validate(); err != nil {
+			return err
+		}
+
+		if names[r.Name] {
+			return fmt.Errorf("%s: the range is repeated", r.Name)
+		}
+		names[r.Name] = true
+	}
+	return nil
+}
+
+// The Parameters of a run, by the name of their range
+type Parameters map[string]float64
+
+// Float returns the value of a parameter
+func (p Parameters) Float(name string) float64 {
+	return p[name]
+}
+
+// Int returns the value of a parameter rounded to a whole number
+func (p Parameters) Int(name string) int {
+	return int(math.Round(p[name]))
+}
+
+// String returns the parameters ordered by name, e.g. "fast=10 slow=30"
+func (p Parameters) String() string {
+	names := make([]string, 0, len(p))
+	for name := range p {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	values := make([]string, len(names))
+	for i, name := range names {
+		values[i] = fmt.Sprintf("%s=%g", name, p[name])
+	}
+	return strings.Join(values, " ")
+}
+
+// grid indexes the combinations of the values of the ranges, with the first range changing slowest
+type grid struct {
+	ranges []Range
+	values [][]float64
+	size   int
+}
+
+func newGrid(ranges []Range) *grid {
+	g := grid{ranges: ranges, values: make([][]float64, len(ranges)), size: 1}
+	for i, r := range ranges {
+		g.values[i] = r.Values()
+		g.size *= len(g.values[i])
+	}
+	return &g
+}
+
+// position returns the index of each range's value in the combination
+func (g *grid) position(index int) []int {
+	position := make([]int, len(g.ranges))
+	for i := len(g.ranges) - 1; i >= 0; i-- {
+		position[i] = index % len(g.values[i])
+		index /= len(g.values[i])
+	}
+	return position
+}
+
+// index returns the combination of the indices of each range's value
+func (g *grid) index(position []int) int {
+	index := 0
+	for i := range g.ranges {
+		index = index*len(g.values[i]) + position[i]
+	}
+	return index
+}
+
+func (g *grid) parameters(index int) Parameters {
+	parameters := make(Parameters, len(g.ranges))
+	for i, valueIndex := range g.position(index) {
+		parameters[g.ranges[i].Name] = g.values[i][valueIndex]
+	}
+	return parameters
+}
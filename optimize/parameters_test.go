@@ -0,0 +1,58 @@
+package optimize_test
+
+import (
+	. "github.com/jaybutera/gotrade/optimize"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/jaybutera/gotrade/backtest"
+)
+
+var _ = Describe("when creating the values of a range", func() {
+	It("should include the min and the max", func() {
+		Expect(IntRange("period", 5, 20, 5).Values()).To(Equal([]float64{5.0, 10.0, 15.0, 20.0}))
+	})
+
+	It("should stop at the last step within the max", func() {
+		Expect(IntRange("period", 5, 22, 5).Values()).To(Equal([]float64{5.0, 10.0, 15.0, 20.0}))
+	})
+
+	It("should not accumulate rounding errors over fractional steps", func() {
+		values := Range{Name: "multiplier", Min: 0.1, Max: 1.0, Step: 0.1}.Values()
+		Expect(len(values)).To(Equal(10))
+		Expect(values[9]).To(BeNumerically("~", 1.0, 1e-12))
+	})
+})
+
+var _ = Describe("when combining the values of ranges", func() {
+	It("should combine every value with the first range changing slowest", func() {
+		optimizer := NewOptimizer(nil, TotalReturn, backtest.DefaultConfig(),
+			IntRange("fast", 1, 2, 1), IntRange("slow", 10, 30, 10))
+
+		combinations, err := optimizer.Combinations()
+		Expect(err).To(BeNil())
+		Expect(combinations).To(Equal([]Parameters{
+			{"fast": 1, "slow": 10}, {"fast": 1, "slow": 20}, {"fast": 1, "slow": 30},
+			{"fast": 2, "slow": 10}, {"fast": 2, "slow": 20}, {"fast": 2, "slow": 30},
+		}))
+	})
+
+	It("should return an error without a range", func() {
+		_, err := NewOptimizer(nil, TotalReturn, backtest.DefaultConfig()).Combinations()
+		Expect(err).To(Equal(ErrNoRanges))
+	})
+
+	It("should return an error for an invalid range", func() {
+		_, err := NewOptimizer(nil, TotalReturn, backtest.DefaultConfig(), IntRange("fast", 1, 10, 0)).Combinations()
+		Expect(err).To(MatchError("fast: step must be greater than 0"))
+
+		_, err = NewOptimizer(nil, TotalReturn, backtest.DefaultConfig(), IntRange("fast", 10, 1, 1)).Combinations()
+		Expect(err).To(MatchError("fast: max is less than min"))
+
+		_, err = NewOptimizer(nil, TotalReturn, backtest.DefaultConfig(), IntRange("fast", 1, 10, 1), IntRange("fast", 1, 10, 1)).Combinations()
+		Expect(err).To(MatchError("fast: the range is repeated"))
+	})
+
+	It("should format the parameters ordered by name", func() {
+		Expect(Parameters{"slow": 30, "fast": 10, "multiplier": 2.5}.String()).To(Equal("fast=10 multiplier=2.5 slow=30"))
+	})
+})
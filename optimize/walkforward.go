@@ -0,0 +1,78 @@
+package optimize
+
+import (
+	"errors"
+	"github.com/jaybutera/gotrade/backtest"
+	"github.com/jaybutera/gotrade/feeds"
+)
+
+var (
+	ErrInSampleBarsTooSmall    = errors.New("inSampleBars is less than the minimum (1)")
+	ErrOutOfSampleBarsTooSmall = errors.New("outOfSampleBars is less than the minimum (1)")
+	ErrTooFewBars              = errors.New("feed has fewer bars than a single in and out of sample window")
+)
+
+// A Search optimizes over a feed, returning the runs ranked best first, e.g. an optimizer's GridSearch
+type Search func(feed backtest.Feed) ([]*Run, error)
+
+// A WalkForwardWindow is the optimization over the in sample bars and the test of the best parameters
+// over the out of sample bars that follow, the bars are given as indices into the feed with the end excluded
+type WalkForwardWindow struct {
+	InSampleStart    int
+	InSampleEnd      int
+	OutOfSampleStart int
+	OutOfSampleEnd   int
+	// the runs over the in sample bars, ranked best first
+	InSample []*Run
+	// the best run over the in sample bars, nil when every run failed
+	Best *Run
+	// the run of the best parameters over the out of sample bars, after warming up on the in sample bars,
+	// nil when every in sample run failed
+	OutOfSample *Run
+}
+
+// WalkForward splits the feed into windows of in sample bars followed by out of sample bars, stepping forward
+// by the out of sample bars so that the out of sample windows follow on from each other. The in sample
+// bars of each window are optimized with the search and the best parameters backtested over the out of sample
+// bars. Anchored windows keep the in sample bars starting from the first bar, growing with each step.
+// The out of sample backtest warms up the strategy's indicators on the in sample bars before them, so that the
+// strategy can trade from the first out of sample bar, and its result holds only the out of sample bars.
+func (o *Optimizer) WalkForward(feed *feeds.MemoryFeed, inSampleBars int, outOfSampleBars int, anchored bool, search Search) (windows []*WalkForwardWindow, err error) {
+	if inSampleBars < 1 {
+		return nil, ErrInSampleBarsTooSmall
+	}
+
+	if outOfSampleBars < 1 {
+		return nil, ErrOutOfSampleBarsTooSmall
+	}
+
+	if feed.Len() < inSampleBars+outOfSampleBars {
+		return nil, ErrTooFewBars
+	}
+
+	for start := 0; start+inSampleBars+outOfSampleBars <= feed.Len(); start += outOfSampleBars {
+		w := WalkForwardWindow{
+			InSampleStart:    start,
+			InSampleEnd:      start + inSampleBars,
+			OutOfSampleStart: start + inSampleBars,
+			OutOfSampleEnd:   start + inSampleBars + outOfSampleBars,
+		}
+		if anchored {
+			w.InSampleStart = 0
+		}
+
+		if w.InSample, err = search(feed.Slice(w.InSampleStart, w.InSampleEnd)); err != nil {
+			return nil, err
+		}
+
+		if w.Best = Best(w.InSample); w.Best != nil {
+			config := o.Config
+			config.WarmUpBars = w.OutOfSampleStart - w.InSampleStart
+			w.OutOfSample = o.runWithConfig(feed.Slice(w.InSampleStart, w.OutOfSampleEnd), config, w.Best.Parameters)
+			w.OutOfSample.index = w.Best.index
+		}
+
+		windows = append(windows, &w)
+	}
+	return windows, nil
+}
@@ -0,0 +1,155 @@
+package patterns
+
+import (
+	"github.com/jaybutera/gotrade"
+	"math"
+)
+
+// The candles a pattern is recognized from, candles are addressed by the number of bars back
+// from the latest candle, which is 0
+type candleWindow struct {
+	settings    *CandleSettings
+	penetration float64
+
+	// a ring of the latest candles
+	candles   []gotrade.DOHLCV
+	nextIndex int
+	count     int
+
+	// the number of candles received, the latest candle is barIndex - 1
+	barIndex int
+
+	// the hikkake patterns remember the pattern awaiting confirmation
+	patternBarIndex int
+	patternResult   int64
+	patternHigh     float64
+	patternLow      float64
+}
+
+func newCandleWindow(size int, settings *CandleSettings, penetration float64) *candleWindow {
+	return &candleWindow{
+		settings:    settings,
+		penetration: penetration,
+		candles:     make([]gotrade.DOHLCV, size),
+	}
+}
+
+func (w *candleWindow) add(candle gotrade.DOHLCV) {
+	w.candles[w.nextIndex] = candle
+	w.nextIndex += 1
+	if w.nextIndex == len(w.candles) {
+		w.nextIndex = 0
+	}
+	if w.count < len(w.candles) {
+		w.count += 1
+	}
+	w.barIndex += 1
+}
+
+// at returns the candle the given number of bars back
+func (w *candleWindow) at(barsBack int) gotrade.DOHLCV {
+	index := w.nextIndex - 1 - barsBack
+	if index < 0 {
+		index += len(w.candles)
+	}
+	return w.candles[index]
+}
+
+func (w *candleWindow) o(barsBack int) float64 {
+	return w.at(barsBack).O()
+}
+
+func (w *candleWindow) h(barsBack int) float64 {
+	return w.at(barsBack).H()
+}
+
+func (w *candleWindow) l(barsBack int) float64 {
+	return w.at(barsBack).L()
+}
+
+func (w *candleWindow) c(barsBack int) float64 {
+	return w.at(barsBack).C()
+}
+
+func (w *candleWindow) realBody(barsBack int) float64 {
+	return math.Abs(w.c(barsBack) - w.o(barsBack))
+}
+
+func (w *candleWindow) upperShadow(barsBack int) float64 {
+	return w.h(barsBack) - w.bodyTop(barsBack)
+}
+
+func (w *candleWindow) lowerShadow(barsBack int) float64 {
+	return w.bodyBottom(barsBack) - w.l(barsBack)
+}
+
+func (w *candleWindow) highLowRange(barsBack int) float64 {
+	return w.h(barsBack) - w.l(barsBack)
+}
+
+func (w *candleWindow) bodyTop(barsBack int) float64 {
+	return math.Max(w.o(barsBack), w.c(barsBack))
+}
+
+func (w *candleWindow) bodyBottom(barsBack int) float64 {
+	return math.Min(w.o(barsBack), w.c(barsBack))
+}
+
+// color returns 1 for a white (rising) candle and -1 for a black (falling) candle
+func (w *candleWindow) color(barsBack int) int64 {
+	if w.c(barsBack) >= w.o(barsBack) {
+		return 1
+	}
+	return -1
+}
+
+// realBodyGapUp returns true if the real body of the later candle is entirely above the earlier one's
+func (w *candleWindow) realBodyGapUp(later int, earlier int) bool {
+	return w.bodyBottom(later) > w.bodyTop(earlier)
+}
+
+// realBodyGapDown returns true if the real body of the later candle is entirely below the earlier one's
+func (w *candleWindow) realBodyGapDown(later int, earlier int) bool {
+	return w.bodyTop(later) < w.bodyBottom(earlier)
+}
+
+// candleGapUp returns true if the later candle's low is above the earlier candle's high
+func (w *candleWindow) candleGapUp(later int, earlier int) bool {
+	return w.l(later) > w.h(earlier)
+}
+
+// candleGapDown returns true if the later candle's high is below the earlier candle's low
+func (w *candleWindow) candleGapDown(later int, earlier int) bool {
+	return w.h(later) < w.l(earlier)
+}
+
+func (w *candleWindow) candleRange(setting CandleSetting, barsBack int) float64 {
+	switch setting.RangeType {
+	case HighLowRange:
+		return w.highLowRange(barsBack)
+	case ShadowsRange:
+		return w.upperShadow(barsBack) + w.lowerShadow(barsBack)
+	}
+	return w.realBody(barsBack)
+}
+
+// average returns the threshold of the candle setting for the candle the given number of bars back,
+// the factor times the average range of the candles preceding it
+func (w *candleWindow) average(setting CandleSetting, barsBack int) float64 {
+	var average float64
+	if setting.AvgPeriod == 0 {
+		average = w.candleRange(setting, barsBack)
+	} else {
+		var sum float64
+		for i := 1; i <= setting.AvgPeriod; i++ {
+			sum += w.candleRange(setting, barsBack+i)
+		}
+		average = sum / float64(setting.AvgPeriod)
+	}
+
+	// the shadows range is the sum of two shadows, so it is halved to the average shadow
+	if setting.RangeType == ShadowsRange {
+		return setting.Factor * average / 2.0
+	}
+	return setting.Factor * average
+}
@@ -0,0 +1,246 @@
+package patterns_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/jaybutera/gotrade"
+	"github.com/jaybutera/gotrade/patterns"
+)
+
+// A candle sequence following the neutral candles and the signal TA-Lib gives its last candle
+type knownAnswer struct {
+	pattern     patterns.Pattern
+	description string
+	candles     []gotrade.DOHLCV
+	signal      int64
+}
+
+// the candles of each answer are sized well clear of the thresholds of the default candle settings, against
+// the averages of the neutral candles and of the earlier candles of the pattern
+var knownAnswers = []knownAnswer{
+	{patterns.TwoCrows, "a long white candle, a black candle gapping up and a black candle closing into the first",
+		[]gotrade.DOHLCV{candle(100.0, 102.1, 99.9, 102.0), candle(103.0, 103.2, 102.3, 102.5), candle(102.8, 102.9, 100.9, 101.0)}, -100},
+	{patterns.ThreeBlackCrows, "a white candle followed by three falling black candles closing near their lows",
+		[]gotrade.DOHLCV{candle(100.0, 101.1, 99.9, 101.0), candle(100.9, 100.95, 99.9, 99.95), candle(100.0, 100.05, 98.95, 99.0), candle(99.1, 99.15, 98.0, 98.05)}, -100},
+	{patterns.ThreeInside, "a black harami and a white candle closing above the first open",
+		[]gotrade.DOHLCV{candle(102.0, 102.1, 99.9, 100.0), candle(100.5, 101.2, 100.3, 101.0), candle(101.0, 102.6, 100.9, 102.5)}, 100},
+	{patterns.ThreeInside, "a white harami and a black candle closing below the first open",
+		[]gotrade.DOHLCV{candle(100.0, 102.1, 99.9, 102.0), candle(101.5, 101.7, 100.8, 101.0), candle(101.0, 101.1, 99.4, 99.5)}, -100},
+	{patterns.ThreeLineStrike, "three rising white candles struck down below the first open",
+		[]gotrade.DOHLCV{candle(100.0, 101.1, 99.9, 101.0), candle(100.5, 102.1, 100.4, 102.0), candle(101.5, 103.1, 101.4, 103.0), candle(103.5, 103.6, 99.4, 99.5)}, 100},
+	{patterns.ThreeLineStrike, "three falling black candles struck up above the first open",
+		[]gotrade.DOHLCV{candle(101.0, 101.1, 99.9, 100.0), candle(100.5, 100.6, 98.9, 99.0), candle(99.5, 99.6, 97.9, 98.0), candle(97.5, 101.6, 97.4, 101.5)}, -100},
+	{patterns.ThreeOutside, "a bullish engulfing and a higher close",
+		[]gotrade.DOHLCV{candle(100.5, 100.7, 99.8, 100.0), candle(99.8, 101.2, 99.7, 100.9), candle(100.9, 101.6, 100.8, 101.5)}, 100},
+	{patterns.ThreeOutside, "a bearish engulfing and a lower close",
+		[]gotrade.DOHLCV{candle(100.0, 100.7, 99.8, 100.5), candle(100.7, 100.9, 99.6, 99.8), candle(99.8, 99.9, 99.1, 99.2)}, -100},
+	{patterns.ThreeStarsInSouth, "three shrinking black candles ending in a small marubozu within the second",
+		[]gotrade.DOHLCV{candle(103.0, 103.1, 98.5, 101.0), candle(102.0, 102.1, 99.5, 100.5), candle(100.4, 100.45, 99.95, 100.0)}, 100},
+	{patterns.ThreeWhiteSoldiers, "three rising white candles closing near their highs",
+		[]gotrade.DOHLCV{candle(100.0, 101.02, 99.9, 101.0), candle(100.9, 102.02, 100.8, 102.0), candle(101.9, 103.02, 101.8, 103.0)}, 100},
+	{patterns.AbandonedBaby, "a long black candle, a doji gapping below it and a white candle gapping above the doji",
+		[]gotrade.DOHLCV{candle(102.0, 102.1, 99.9, 100.0), candle(99.5, 99.6, 99.4, 99.5), candle(99.8, 101.3, 99.7, 101.2)}, 100},
+	{patterns.AbandonedBaby, "a long white candle, a doji gapping above it and a black candle gapping below the doji",
+		[]gotrade.DOHLCV{candle(100.0, 102.1, 99.9, 102.0), candle(102.5, 102.6, 102.4, 102.5), candle(102.2, 102.3, 100.7, 100.8)}, -100},
+	{patterns.AdvanceBlock, "three rising white candles, the last shrinking with a long upper shadow",
+		[]gotrade.DOHLCV{candle(100.0, 102.1, 99.9, 102.0), candle(101.5, 103.0, 101.4, 102.8), candle(102.5, 104.0, 102.4, 103.0)}, -100},
+	{patterns.BeltHold, "a long white candle opening on its low",
+		[]gotrade.DOHLCV{candle(100.0, 101.6, 99.98, 101.5)}, 100},
+	{patterns.BeltHold, "a long black candle opening on its high",
+		[]gotrade.DOHLCV{candle(101.5, 101.52, 99.9, 100.0)}, -100},
+	{patterns.Breakaway, "a long black candle, three falling candles gapping below it and a white candle closing in the gap",
+		[]gotrade.DOHLCV{candle(102.0, 102.1, 99.9, 100.0), candle(99.5, 99.6, 98.9, 99.0), candle(99.0, 99.3, 98.4, 98.5),
+			candle(98.5, 98.8, 97.9, 98.0), candle(98.2, 99.9, 98.1, 99.8)}, 100},
+	{patterns.Breakaway, "a long white candle, three rising candles gapping above it and a black candle closing in the gap",
+		[]gotrade.DOHLCV{candle(98.0, 100.1, 97.9, 100.0), candle(100.5, 101.1, 100.4, 101.0), candle(101.0, 101.6, 100.7, 101.5),
+			candle(101.5, 102.1, 101.2, 102.0), candle(101.8, 101.9, 100.1, 100.2)}, -100},
+	{patterns.ClosingMarubozu, "a long white candle closing on its high",
+		[]gotrade.DOHLCV{candle(100.0, 101.52, 99.7, 101.5)}, 100},
+	{patterns.ClosingMarubozu, "a long black candle closing on its low",
+		[]gotrade.DOHLCV{candle(101.5, 101.8, 99.98, 100.0)}, -100},
+	{patterns.ConcealBabySwallow, "two black marubozu, a black candle gapping down into them and a black candle engulfing it",
+		[]gotrade.DOHLCV{candle(102.0, 102.02, 100.98, 101.0), candle(101.0, 101.01, 99.99, 100.0), candle(99.5, 100.3, 99.0, 99.2),
+			candle(100.4, 100.5, 98.5, 98.6)}, 100},
+	{patterns.CounterAttack, "a long black candle and a long white candle closing at its close",
+		[]gotrade.DOHLCV{candle(102.0, 102.1, 99.9, 100.0), candle(98.5, 100.05, 98.4, 100.0)}, 100},
+	{patterns.CounterAttack, "a long white candle and a long black candle closing at its close",
+		[]gotrade.DOHLCV{candle(100.0, 102.1, 99.9, 102.0), candle(103.5, 103.6, 101.95, 102.0)}, -100},
+	{patterns.DarkCloudCover, "a long white candle and a black candle opening above its high and closing below its middle",
+		[]gotrade.DOHLCV{candle(100.0, 102.1, 99.9, 102.0), candle(102.5, 102.6, 100.7, 100.8)}, -100},
+	{patterns.Doji, "a candle with an open and close nearly equal",
+		[]gotrade.DOHLCV{candle(100.0, 101.0, 99.5, 100.05)}, 100},
+	{patterns.DojiStar, "a long black candle and a doji gapping below it",
+		[]gotrade.DOHLCV{candle(102.0, 102.1, 99.9, 100.0), candle(99.5, 99.9, 99.1, 99.48)}, 100},
+	{patterns.DojiStar, "a long white candle and a doji gapping above it",
+		[]gotrade.DOHLCV{candle(100.0, 102.1, 99.9, 102.0), candle(102.5, 102.9, 102.1, 102.52)}, -100},
+	{patterns.DragonflyDoji, "a doji with a long lower shadow and no upper shadow",
+		[]gotrade.DOHLCV{candle(100.0, 100.02, 99.0, 100.01)}, 100},
+	{patterns.Engulfing, "a white candle engulfing a black candle",
+		[]gotrade.DOHLCV{candle(100.5, 101.0, 99.5, 100.0), candle(99.8, 101.2, 99.7, 100.9)}, 100},
+	{patterns.Engulfing, "a black candle engulfing a white candle",
+		[]gotrade.DOHLCV{candle(100.0, 101.0, 99.5, 100.5), candle(100.7, 100.9, 99.6, 99.8)}, -100},
+	{patterns.EveningDojiStar, "a long white candle, a doji gapping above it and a black candle closing into the first",
+		[]gotrade.DOHLCV{candle(100.0, 102.1, 99.9, 102.0), candle(102.5, 102.7, 102.3, 102.51), candle(102.3, 102.4, 100.7, 100.8)}, -100},
+	{patterns.EveningStar, "a long white candle, a short candle gapping above it and a black candle closing into the first",
+		[]gotrade.DOHLCV{candle(100.0, 102.1, 99.9, 102.0), candle(102.5, 102.8, 102.3, 102.6), candle(102.4, 102.5, 100.7, 100.8)}, -100},
+	{patterns.GapSideSideWhite, "two similar white candles gapping above a white candle",
+		[]gotrade.DOHLCV{candle(100.0, 101.1, 99.9, 101.0), candle(101.5, 102.1, 101.4, 102.0), candle(101.52, 102.1, 101.4, 102.02)}, 100},
+	{patterns.GapSideSideWhite, "two similar white candles gapping below a black candle",
+		[]gotrade.DOHLCV{candle(101.0, 101.1, 99.9, 100.0), candle(99.0, 99.6, 98.9, 99.5), candle(99.02, 99.6, 98.9, 99.52)}, -100},
+	{patterns.GravestoneDoji, "a doji with a long upper shadow and no lower shadow",
+		[]gotrade.DOHLCV{candle(100.0, 101.0, 99.99, 100.01)}, 100},
+	{patterns.Hammer, "a small body with a long lower shadow near the prior low",
+		[]gotrade.DOHLCV{candle(99.6, 99.82, 98.0, 99.8)}, 100},
+	{patterns.HangingMan, "a small body with a long lower shadow near the prior high",
+		[]gotrade.DOHLCV{candle(100.8, 101.02, 99.5, 101.0)}, -100},
+	{patterns.Harami, "a long black candle and a short candle within its body",
+		[]gotrade.DOHLCV{candle(102.0, 102.1, 99.8, 100.0), candle(100.8, 101.3, 100.7, 101.2)}, 100},
+	{patterns.Harami, "a long white candle and a short candle within its body",
+		[]gotrade.DOHLCV{candle(100.0, 102.2, 99.9, 102.0), candle(101.2, 101.5, 100.9, 101.0)}, -100},
+	{patterns.HaramiCross, "a long black candle and a doji within its body",
+		[]gotrade.DOHLCV{candle(102.0, 102.1, 99.9, 100.0), candle(101.0, 101.4, 100.6, 100.98)}, 100},
+	{patterns.HaramiCross, "a long white candle and a doji within its body",
+		[]gotrade.DOHLCV{candle(100.0, 102.1, 99.9, 102.0), candle(101.0, 101.4, 100.6, 101.02)}, -100},
+	{patterns.HighWave, "a short white body with very long shadows",
+		[]gotrade.DOHLCV{candle(100.0, 101.5, 98.5, 100.3)}, 100},
+	{patterns.HighWave, "a short black body with very long shadows",
+		[]gotrade.DOHLCV{candle(100.3, 101.5, 98.5, 100.0)}, -100},
+	{patterns.Hikkake, "an inside bar and a false breakout below it",
+		[]gotrade.DOHLCV{candle(100.0, 102.0, 98.0, 101.0), candle(100.5, 101.5, 99.0, 100.0), candle(99.5, 101.0, 98.5, 99.0)}, 100},
+	{patterns.Hikkake, "an inside bar, a false breakout below it and a close above it",
+		[]gotrade.DOHLCV{candle(100.0, 102.0, 98.0, 101.0), candle(100.5, 101.5, 99.0, 100.0), candle(99.5, 101.0, 98.5, 99.0),
+			candle(99.5, 102.0, 99.4, 101.8)}, 200},
+	{patterns.Hikkake, "an inside bar and a false breakout above it",
+		[]gotrade.DOHLCV{candle(100.0, 102.0, 98.0, 101.0), candle(100.5, 101.5, 99.0, 100.0), candle(100.5, 102.0, 99.5, 101.5)}, -100},
+	{patterns.Hikkake, "an inside bar, a false breakout above it and a close below it",
+		[]gotrade.DOHLCV{candle(100.0, 102.0, 98.0, 101.0), candle(100.5, 101.5, 99.0, 100.0), candle(100.5, 102.0, 99.5, 101.5),
+			candle(100.0, 100.2, 98.5, 98.8)}, -200},
+	{patterns.HikkakeMod, "two inside bars, the first closing near its low, and a false breakout below them",
+		[]gotrade.DOHLCV{candle(100.0, 103.0, 97.0, 101.0), candle(100.5, 102.0, 98.0, 98.1), candle(99.0, 101.0, 98.5, 100.0),
+			candle(99.0, 100.5, 98.0, 98.2)}, 100},
+	{patterns.HikkakeMod, "two inside bars, a false breakout below them and a close above them",
+		[]gotrade.DOHLCV{candle(100.0, 103.0, 97.0, 101.0), candle(100.5, 102.0, 98.0, 98.1), candle(99.0, 101.0, 98.5, 100.0),
+			candle(99.0, 100.5, 98.0, 98.2), candle(99.0, 101.6, 98.9, 101.5)}, 200},
+	{patterns.HikkakeMod, "two inside bars, the first closing near its high, and a false breakout above them",
+		[]gotrade.DOHLCV{candle(100.0, 103.0, 97.0, 101.0), candle(99.5, 102.0, 98.0, 101.9), candle(101.0, 101.5, 99.0, 100.0),
+			candle(100.5, 101.8, 99.5, 101.6)}, -100},
+	{patterns.HikkakeMod, "two inside bars, a false breakout above them and a close below them",
+		[]gotrade.DOHLCV{candle(100.0, 103.0, 97.0, 101.0), candle(99.5, 102.0, 98.0, 101.9), candle(101.0, 101.5, 99.0, 100.0),
+			candle(100.5, 101.8, 99.5, 101.6), candle(100.0, 100.1, 98.5, 98.7)}, -200},
+	{patterns.HomingPigeon, "a long black candle and a short black candle within its body",
+		[]gotrade.DOHLCV{candle(102.0, 102.1, 99.9, 100.0), candle(101.3, 101.4, 100.7, 100.9)}, 100},
+	{patterns.IdenticalThreeCrows, "three black candles each opening at the prior close",
+		[]gotrade.DOHLCV{candle(102.0, 102.1, 100.98, 101.0), candle(101.01, 101.1, 99.99, 100.0), candle(100.0, 100.1, 98.99, 99.0)}, -100},
+	{patterns.InNeck, "a long black candle and a white candle opening below its low and closing just above its close",
+		[]gotrade.DOHLCV{candle(102.0, 102.1, 99.9, 100.0), candle(99.5, 100.1, 99.4, 100.05)}, -100},
+	{patterns.InvertedHammer, "a small body with a long upper shadow gapping below the prior body",
+		[]gotrade.DOHLCV{candle(99.5, 100.5, 99.48, 99.7)}, 100},
+	{patterns.Kicking, "a black marubozu and a white marubozu gapping above it",
+		[]gotrade.DOHLCV{candle(102.0, 102.01, 99.99, 100.0), candle(102.5, 104.51, 102.49, 104.5)}, 100},
+	{patterns.Kicking, "a white marubozu and a black marubozu gapping below it",
+		[]gotrade.DOHLCV{candle(100.0, 102.01, 99.99, 102.0), candle(99.5, 99.51, 97.49, 97.5)}, -100},
+	{patterns.KickingByLength, "a black marubozu and a longer white marubozu gapping above it",
+		[]gotrade.DOHLCV{candle(102.0, 102.01, 99.99, 100.0), candle(102.5, 105.01, 102.49, 105.0)}, 100},
+	{patterns.KickingByLength, "a black marubozu and a shorter white marubozu gapping above it",
+		[]gotrade.DOHLCV{candle(102.0, 102.01, 99.99, 100.0), candle(102.5, 104.01, 102.49, 104.0)}, -100},
+	{patterns.LadderBottom, "three falling black candles, a black candle with an upper shadow and a white candle closing above it",
+		[]gotrade.DOHLCV{candle(104.0, 104.1, 102.9, 103.0), candle(103.5, 103.6, 101.9, 102.0), candle(102.5, 102.6, 100.9, 101.0),
+			candle(101.0, 101.8, 99.9, 100.0), candle(101.2, 102.1, 101.1, 102.0)}, 100},
+	{patterns.LongLeggedDoji, "a doji with long shadows",
+		[]gotrade.DOHLCV{candle(100.0, 101.0, 99.0, 100.01)}, 100},
+	{patterns.LongLine, "a long white body with short shadows",
+		[]gotrade.DOHLCV{candle(100.0, 101.6, 99.8, 101.5)}, 100},
+	{patterns.LongLine, "a long black body with short shadows",
+		[]gotrade.DOHLCV{candle(101.5, 101.7, 99.9, 100.0)}, -100},
+	{patterns.Marubozu, "a long white body without shadows",
+		[]gotrade.DOHLCV{candle(100.0, 101.52, 99.98, 101.5)}, 100},
+	{patterns.Marubozu, "a long black body without shadows",
+		[]gotrade.DOHLCV{candle(101.5, 101.52, 99.98, 100.0)}, -100},
+	{patterns.MatchingLow, "two black candles closing at the same price",
+		[]gotrade.DOHLCV{candle(101.0, 101.1, 99.9, 100.0), candle(100.8, 100.9, 99.95, 100.02)}, 100},
+	{patterns.MatHold, "a long white candle, three small falling candles holding above its middle and a white candle closing above them",
+		[]gotrade.DOHLCV{candle(100.0, 102.1, 99.9, 102.0), candle(102.8, 103.0, 102.4, 102.5), candle(102.2, 102.4, 101.6, 101.8),
+			candle(102.0, 102.1, 101.3, 101.6), candle(101.8, 103.6, 101.7, 103.5)}, 100},
+	{patterns.MorningDojiStar, "a long black candle, a doji gapping below it and a white candle closing into the first",
+		[]gotrade.DOHLCV{candle(102.0, 102.1, 99.9, 100.0), candle(99.5, 99.7, 99.3, 99.49), candle(99.6, 101.3, 99.5, 101.2)}, 100},
+	{patterns.MorningStar, "a long black candle, a short candle gapping below it and a white candle closing into the first",
+		[]gotrade.DOHLCV{candle(102.0, 102.1, 99.9, 100.0), candle(99.5, 99.7, 99.2, 99.4), candle(99.6, 101.3, 99.5, 101.2)}, 100},
+	{patterns.OnNeck, "a long black candle and a white candle opening below its low and closing at its low",
+		[]gotrade.DOHLCV{candle(102.0, 102.1, 99.9, 100.0), candle(99.4, 99.95, 99.3, 99.92)}, -100},
+	{patterns.Piercing, "a long black candle and a long white candle opening below its low and closing above its middle",
+		[]gotrade.DOHLCV{candle(102.0, 102.1, 99.9, 100.0), candle(99.5, 101.6, 99.4, 101.5)}, 100},
+	{patterns.RickshawMan, "a doji with long shadows in the middle of its range",
+		[]gotrade.DOHLCV{candle(100.0, 101.0, 99.0, 100.01)}, 100},
+	{patterns.RiseFallThreeMethods, "a long white candle, three small falling black candles within it and a long white candle closing above it",
+		[]gotrade.DOHLCV{candle(100.0, 102.1, 99.9, 102.0), candle(101.8, 101.9, 101.3, 101.5), candle(101.5, 101.6, 101.0, 101.2),
+			candle(101.2, 101.3, 100.7, 100.9), candle(101.0, 102.6, 100.9, 102.5)}, 100},
+	{patterns.RiseFallThreeMethods, "a long black candle, three small rising white candles within it and a long black candle closing below it",
+		[]gotrade.DOHLCV{candle(102.0, 102.1, 99.9, 100.0), candle(100.2, 100.7, 100.1, 100.5), candle(100.5, 101.0, 100.4, 100.8),
+			candle(100.8, 101.3, 100.7, 101.1), candle(101.0, 101.1, 99.4, 99.5)}, -100},
+	{patterns.SeparatingLines, "a black candle and a white belt hold opening at its open",
+		[]gotrade.DOHLCV{candle(101.0, 101.1, 99.9, 100.0), candle(101.02, 102.6, 101.0, 102.5)}, 100},
+	{patterns.SeparatingLines, "a white candle and a black belt hold opening at its open",
+		[]gotrade.DOHLCV{candle(100.0, 101.1, 99.9, 101.0), candle(100.02, 100.03, 98.4, 98.5)}, -100},
+	{patterns.ShootingStar, "a small body with a long upper shadow gapping above the prior body",
+		[]gotrade.DOHLCV{candle(100.7, 101.8, 100.68, 100.9)}, -100},
+	{patterns.ShortLine, "a short white body with short shadows",
+		[]gotrade.DOHLCV{candle(100.0, 100.4, 99.8, 100.3)}, 100},
+	{patterns.ShortLine, "a short black body with short shadows",
+		[]gotrade.DOHLCV{candle(100.3, 100.4, 99.8, 100.0)}, -100},
+	{patterns.SpinningTop, "a short white body with shadows longer than it",
+		[]gotrade.DOHLCV{candle(100.0, 100.8, 99.4, 100.3)}, 100},
+	{patterns.SpinningTop, "a short black body with shadows longer than it",
+		[]gotrade.DOHLCV{candle(100.3, 100.8, 99.4, 100.0)}, -100},
+	{patterns.StalledPattern, "two long rising white candles and a small white candle opening near the second close",
+		[]gotrade.DOHLCV{candle(100.0, 102.1, 99.9, 102.0), candle(101.8, 103.82, 101.7, 103.8), candle(103.7, 104.1, 103.6, 104.0)}, -100},
+	{patterns.StickSandwich, "two black candles closing at the same price around a white candle trading above",
+		[]gotrade.DOHLCV{candle(101.0, 101.1, 99.9, 100.0), candle(100.5, 101.6, 100.2, 101.5), candle(101.8, 101.9, 99.9, 100.02)}, 100},
+	{patterns.Takuri, "a doji with a very long lower shadow and no upper shadow",
+		[]gotrade.DOHLCV{candle(100.0, 100.02, 98.5, 100.01)}, 100},
+	{patterns.TasukiGap, "two white candles with a gap between them and a black candle not closing the gap",
+		[]gotrade.DOHLCV{candle(100.0, 101.1, 99.9, 101.0), candle(101.5, 102.1, 101.4, 102.0), candle(101.8, 101.9, 101.2, 101.3)}, 100},
+	{patterns.TasukiGap, "two black candles with a gap between them and a white candle not closing the gap",
+		[]gotrade.DOHLCV{candle(101.0, 101.1, 99.9, 100.0), candle(99.5, 99.6, 98.9, 99.0), candle(99.2, 99.8, 99.1, 99.7)}, -100},
+	{patterns.Thrusting, "a long black candle and a white candle opening below its low and closing below its middle",
+		[]gotrade.DOHLCV{candle(102.0, 102.1, 99.9, 100.0), candle(99.5, 100.9, 99.4, 100.8)}, -100},
+	{patterns.Tristar, "three dojis with the middle one gapping below the others",
+		[]gotrade.DOHLCV{candle(100.0, 100.5, 99.5, 100.01), candle(99.4, 99.8, 99.0, 99.41), candle(99.7, 100.1, 99.3, 99.71)}, 100},
+	{patterns.Tristar, "three dojis with the middle one gapping above the others",
+		[]gotrade.DOHLCV{candle(100.0, 100.5, 99.5, 100.01), candle(100.6, 101.0, 100.2, 100.61), candle(100.3, 100.7, 99.9, 100.31)}, -100},
+	{patterns.UniqueThreeRiver, "a long black candle, a black harami with a lower low and a small white candle opening above that low",
+		[]gotrade.DOHLCV{candle(102.0, 102.1, 99.9, 100.0), candle(101.5, 101.6, 99.0, 100.5), candle(99.5, 99.9, 99.4, 99.8)}, 100},
+	{patterns.UpsideGapTwoCrows, "a long white candle, a small black candle gapping above it and a black candle engulfing that",
+		[]gotrade.DOHLCV{candle(100.0, 102.1, 99.9, 102.0), candle(102.8, 102.9, 102.4, 102.5), candle(103.0, 103.1, 102.1, 102.2)}, -100},
+	{patterns.XSideGapThreeMethods, "two white candles with a gap between them and a black candle closing the gap",
+		[]gotrade.DOHLCV{candle(100.0, 101.1, 99.9, 101.0), candle(101.5, 102.1, 101.4, 102.0), candle(101.8, 101.9, 100.4, 100.5)}, 100},
+	{patterns.XSideGapThreeMethods, "two black candles with a gap between them and a white candle closing the gap",
+		[]gotrade.DOHLCV{candle(101.0, 101.1, 99.9, 100.0), candle(99.5, 99.6, 98.9, 99.0), candle(99.2, 100.6, 99.1, 100.5)}, -100},
+}
+
+var _ = Describe("when recognizing every candlestick pattern from known candles and their TA-Lib signals", func() {
+	It("should have a known answer for every pattern", func() {
+		answered := map[patterns.Pattern]bool{}
+		for _, answer := range knownAnswers {
+			answered[answer.pattern] = true
+		}
+
+		for _, pattern := range patterns.Patterns() {
+			Expect(answered).To(HaveKey(pattern), pattern.String())
+		}
+	})
+
+	It("should not recognize any pattern in the neutral candles", func() {
+		for _, pattern := range patterns.Patterns() {
+			Expect(recognize(pattern, neutralCandles(20))).To(Equal(int64(0)), pattern.String())
+		}
+	})
+
+	for _, answer := range knownAnswers {
+		answer := answer
+
+		It("should signal "+answer.pattern.String()+" for "+answer.description, func() {
+			candles := append(neutralCandles(12), answer.candles...)
+			Expect(recognize(answer.pattern, candles)).To(Equal(answer.signal))
+		})
+	}
+})
@@ -0,0 +1,214 @@
+package patterns
+
+import (
+	"math"
+)
+
+// CDL3BLACKCROWS: a white candle, then three black candles with very short lower shadows, each opening
+// within the prior body and closing lower
+var threeBlackCrows = patternDefinition{
+	name: "CDL3BLACKCROWS",
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.ShadowVeryShort) + 3
+	},
+	recognize: func(w *candleWindow) int64 {
+		s := w.settings
+		if w.color(3) == 1 &&
+			w.color(2) == -1 && w.lowerShadow(2) < w.average(s.ShadowVeryShort, 2) &&
+			w.color(1) == -1 && w.lowerShadow(1) < w.average(s.ShadowVeryShort, 1) &&
+			w.color(0) == -1 && w.lowerShadow(0) < w.average(s.ShadowVeryShort, 0) &&
+			w.o(1) < w.o(2) && w.o(1) > w.c(2) &&
+			w.o(0) < w.o(1) && w.o(0) > w.c(1) &&
+			w.h(3) > w.c(2) &&
+			w.c(2) > w.c(1) && w.c(1) > w.c(0) {
+			return -100
+		}
+		return 0
+	},
+}
+
+// CDL3LINESTRIKE: three candles of the same color stepping in one direction, then a candle of the
+// opposite color opening beyond the third and closing beyond the first
+var threeLineStrike = patternDefinition{
+	name: "CDL3LINESTRIKE",
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.Near) + 3
+	},
+	recognize: func(w *candleWindow) int64 {
+		s := w.settings
+		if w.color(3) == w.color(2) && w.color(2) == w.color(1) &&
+			w.color(0) == -w.color(1) &&
+			// the 2nd and 3rd open within or near the prior body
+			w.o(2) >= w.bodyBottom(3)-w.average(s.Near, 3) &&
+			w.o(2) <= w.bodyTop(3)+w.average(s.Near, 3) &&
+			w.o(1) >= w.bodyBottom(2)-w.average(s.Near, 2) &&
+			w.o(1) <= w.bodyTop(2)+w.average(s.Near, 2) &&
+			((w.color(1) == 1 &&
+				w.c(1) > w.c(2) && w.c(2) > w.c(3) &&
+				w.o(0) > w.c(1) && w.c(0) < w.o(3)) ||
+				(w.color(1) == -1 &&
+					w.c(1) < w.c(2) && w.c(2) < w.c(3) &&
+					w.o(0) < w.c(1) && w.c(0) > w.o(3))) {
+			return w.color(1) * 100
+		}
+		return 0
+	},
+}
+
+// CDLBREAKAWAY: a long candle, then a candle gapping away and two more continuing in the same
+// direction, then a candle of the opposite color closing within the gap
+var breakaway = patternDefinition{
+	name: "CDLBREAKAWAY",
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.BodyLong) + 4
+	},
+	recognize: func(w *candleWindow) int64 {
+		if w.realBody(4) > w.average(w.settings.BodyLong, 4) &&
+			w.color(4) == w.color(3) && w.color(3) == w.color(1) &&
+			w.color(1) == -w.color(0) &&
+			((w.color(4) == -1 &&
+				w.realBodyGapDown(3, 4) &&
+				w.h(2) < w.h(3) && w.l(2) < w.l(3) &&
+				w.h(1) < w.h(2) && w.l(1) < w.l(2) &&
+				w.c(0) > w.o(3) && w.c(0) < w.c(4)) ||
+				(w.color(4) == 1 &&
+					w.realBodyGapUp(3, 4) &&
+					w.h(2) > w.h(3) && w.l(2) > w.l(3) &&
+					w.h(1) > w.h(2) && w.l(1) > w.l(2) &&
+					w.c(0) < w.o(3) && w.c(0) > w.c(4))) {
+			return w.color(0) * 100
+		}
+		return 0
+	},
+}
+
+// CDLCONCEALBABYSWALL: two black marubozu, a black candle gapping down with an upper shadow into the
+// prior body, then a black candle engulfing it including its shadows
+var concealBabySwallow = patternDefinition{
+	name: "CDLCONCEALBABYSWALL",
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.ShadowVeryShort) + 3
+	},
+	recognize: func(w *candleWindow) int64 {
+		s := w.settings
+		if w.color(3) == -1 && w.color(2) == -1 && w.color(1) == -1 && w.color(0) == -1 &&
+			w.lowerShadow(3) < w.average(s.ShadowVeryShort, 3) &&
+			w.upperShadow(3) < w.average(s.ShadowVeryShort, 3) &&
+			w.lowerShadow(2) < w.average(s.ShadowVeryShort, 2) &&
+			w.upperShadow(2) < w.average(s.ShadowVeryShort, 2) &&
+			w.realBodyGapDown(1, 2) &&
+			w.upperShadow(1) > w.average(s.ShadowVeryShort, 1) &&
+			w.h(1) > w.c(2) &&
+			w.h(0) > w.h(1) && w.l(0) < w.l(1) {
+			return 100
+		}
+		return 0
+	},
+}
+
+// CDLHIKKAKEMOD: a hikkake whose inside bar follows another inside bar and closes near its
+// extreme, confirmed when a close beyond the other side of the inside bar follows within 3 bars
+var hikkakeMod = patternDefinition{
+	name: "CDLHIKKAKEMOD",
+	lookback: func(s *CandleSettings) int {
+		return int(math.Max(1, float64(maxAvgPeriod(s.Near)))) + 5
+	},
+	primingBars: 3,
+	recognize: func(w *candleWindow) int64 {
+		s := w.settings
+		if w.h(2) < w.h(3) && w.l(2) > w.l(3) &&
+			w.h(1) < w.h(2) && w.l(1) > w.l(2) &&
+			((w.h(0) < w.h(1) && w.l(0) < w.l(1) &&
+				w.c(2) <= w.l(2)+w.average(s.Near, 2)) ||
+				(w.h(0) > w.h(1) && w.l(0) > w.l(1) &&
+					w.c(2) >= w.h(2)-w.average(s.Near, 2))) {
+			return w.rememberHikkake()
+		}
+		return w.confirmHikkake()
+	},
+}
+
+// CDLLADDERBOTTOM: three falling black candles, a black candle with an upper shadow, then a white
+// candle opening above it and closing above its high
+var ladderBottom = patternDefinition{
+	name: "CDLLADDERBOTTOM",
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.ShadowVeryShort) + 4
+	},
+	recognize: func(w *candleWindow) int64 {
+		if w.color(4) == -1 && w.color(3) == -1 && w.color(2) == -1 &&
+			w.o(4) > w.o(3) && w.o(3) > w.o(2) &&
+			w.c(4) > w.c(3) && w.c(3) > w.c(2) &&
+			w.color(1) == -1 &&
+			w.upperShadow(1) > w.average(w.settings.ShadowVeryShort, 1) &&
+			w.color(0) == 1 &&
+			w.o(0) > w.o(1) &&
+			w.c(0) > w.h(1) {
+			return 100
+		}
+		return 0
+	},
+}
+
+// CDLMATHOLD: a long white candle, three small candles gapping up and falling but holding within its
+// body, then a white candle closing above their highs
+var matHold = patternDefinition{
+	name:               "CDLMATHOLD",
+	defaultPenetration: 0.5,
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.BodyShort, s.BodyLong) + 4
+	},
+	recognize: func(w *candleWindow) int64 {
+		s := w.settings
+		var penetrationLimit float64 = w.c(4) - w.realBody(4)*w.penetration
+		if w.realBody(4) > w.average(s.BodyLong, 4) &&
+			w.realBody(3) < w.average(s.BodyShort, 3) &&
+			w.realBody(2) < w.average(s.BodyShort, 2) &&
+			w.realBody(1) < w.average(s.BodyShort, 1) &&
+			w.color(4) == 1 && w.color(3) == -1 && w.color(0) == 1 &&
+			w.realBodyGapUp(3, 4) &&
+			// the 3rd and 4th hold within the 1st body, penetrating it less than the penetration
+			w.bodyBottom(2) < w.c(4) && w.bodyBottom(1) < w.c(4) &&
+			w.bodyBottom(2) > penetrationLimit && w.bodyBottom(1) > penetrationLimit &&
+			// the 2nd to 4th are falling
+			w.bodyTop(2) < w.o(3) && w.bodyTop(1) < w.bodyTop(2) &&
+			w.o(0) > w.c(1) &&
+			w.c(0) > math.Max(math.Max(w.h(3), w.h(2)), w.h(1)) {
+			return 100
+		}
+		return 0
+	},
+}
+
+// CDLRISEFALL3METHODS: a long candle, three small candles of the opposite color moving against it
+// within its range, then a long candle of its color closing beyond its close
+var riseFallThreeMethods = patternDefinition{
+	name: "CDLRISEFALL3METHODS",
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.BodyShort, s.BodyLong) + 4
+	},
+	recognize: func(w *candleWindow) int64 {
+		s := w.settings
+		var direction float64 = float64(w.color(4))
+		if w.realBody(4) > w.average(s.BodyLong, 4) &&
+			w.realBody(3) < w.average(s.BodyShort, 3) &&
+			w.realBody(2) < w.average(s.BodyShort, 2) &&
+			w.realBody(1) < w.average(s.BodyShort, 1) &&
+			w.realBody(0) > w.average(s.BodyLong, 0) &&
+			w.color(4) == -w.color(3) && w.color(3) == w.color(2) &&
+			w.color(2) == w.color(1) && w.color(1) == -w.color(0) &&
+			// the 2nd to 4th hold within the 1st range
+			w.bodyBottom(3) < w.h(4) && w.bodyTop(3) > w.l(4) &&
+			w.bodyBottom(2) < w.h(4) && w.bodyTop(2) > w.l(4) &&
+			w.bodyBottom(1) < w.h(4) && w.bodyTop(1) > w.l(4) &&
+			// the 2nd to 4th move against the 1st
+			w.c(2)*direction < w.c(3)*direction &&
+			w.c(1)*direction < w.c(2)*direction &&
+			// the 5th opens beyond the prior close and closes beyond the 1st close
+			w.o(0)*direction > w.c(1)*direction &&
+			w.c(0)*direction > w.c(4)*direction {
+			return w.color(4) * 100
+		}
+		return 0
+	},
+}
@@ -0,0 +1,292 @@
+/*
+	import "github.com/jaybutera/gotrade/patterns"
+
+	Package patterns provides candlestick pattern recognition, following the TA-Lib CDL functions.
+	All recognizers follow the basic structure of:
+		- receiving price data and emitting a signal for each bar once the lookback period has passed.
+		- a signal of +100 for a bullish pattern, -100 for a bearish pattern and 0 for no pattern,
+		  the hikkake patterns signal +200 or -200 when the pattern is confirmed.
+		- body and shadow thresholds compared against rolling averages of the recent candle ranges,
+		  which are configurable through the candle settings.
+
+	Recognizers are created with the same functions as the indicators, for online usage, offline usage,
+	for attachment to a data stream and without storage for use inside other indicators.
+*/
+package patterns
+
+import (
+	"errors"
+)
+
+var (
+	ErrUnknownPattern               = errors.New("Unknown candlestick pattern")
+	ErrCandleSettingsIsNil          = errors.New("Candle settings are required")
+	ErrCandleSettingAvgPeriodBelow0 = errors.New("A candle setting average period must be 0 or greater")
+	ErrCandleSettingFactorBelow0    = errors.New("A candle setting factor must be 0 or greater")
+)
+
+// The part of a candle a candle setting measures
+type RangeType int
+
+const (
+	// the distance between the open and close
+	RealBodyRange RangeType = iota
+	// the distance between the high and low
+	HighLowRange
+	// the sum of the upper and lower shadows
+	ShadowsRange
+)
+
+// A threshold compared against the average range of the candles preceding the candle being judged
+type CandleSetting struct {
+	// the part of the candle averaged
+	RangeType RangeType
+	// the number of preceding candles averaged, 0 uses the range of the candle being judged
+	AvgPeriod int
+	// the multiple of the average range which makes the threshold
+	Factor float64
+}
+
+// The thresholds which decide what makes a long body, a short shadow etc.
+type CandleSettings struct {
+	// real body is long when it's longer than the average of the 10 previous candles' real body
+	BodyLong CandleSetting
+	// real body is very long when it's longer than 3 times the average of the 10 previous candles' real body
+	BodyVeryLong CandleSetting
+	// real body is short when it's shorter than the average of the 10 previous candles' real bodies
+	BodyShort CandleSetting
+	// real body is like doji's body when it's shorter than 10% the average of the 10 previous candles' high-low range
+	BodyDoji CandleSetting
+	// shadow is long when it's longer than the real body
+	ShadowLong CandleSetting
+	// shadow is very long when it's longer than 2 times the real body
+	ShadowVeryLong CandleSetting
+	// shadow is short when it's shorter than half the average of the 10 previous candles' sum of shadows
+	ShadowShort CandleSetting
+	// shadow is very short when it's shorter than 10% the average of the 10 previous candles' high-low range
+	ShadowVeryShort CandleSetting
+	// when measuring distance between parts of candles or width of gaps "near" means "<= 20% of the average of the 5 previous candles' high-low range"
+	Near CandleSetting
+	// when measuring distance between parts of candles or width of gaps "far" means ">= 60% of the average of the 5 previous candles' high-low range"
+	Far CandleSetting
+	// when measuring distance between parts of candles or width of gaps "equal" means "<= 5% of the average of the 5 previous candles' high-low range"
+	Equal CandleSetting
+}
+
+// DefaultCandleSettings returns the TA-Lib default candle settings
+func DefaultCandleSettings() *CandleSettings {
+	return &CandleSettings{
+		BodyLong:        CandleSetting{RangeType: RealBodyRange, AvgPeriod: 10, Factor: 1.0},
+		BodyVeryLong:    CandleSetting{RangeType: RealBodyRange, AvgPeriod: 10, Factor: 3.0},
+		BodyShort:       CandleSetting{RangeType: RealBodyRange, AvgPeriod: 10, Factor: 1.0},
+		BodyDoji:        CandleSetting{RangeType: HighLowRange, AvgPeriod: 10, Factor: 0.1},
+		ShadowLong:      CandleSetting{RangeType: RealBodyRange, AvgPeriod: 0, Factor: 1.0},
+		ShadowVeryLong:  CandleSetting{RangeType: RealBodyRange, AvgPeriod: 0, Factor: 2.0},
+		ShadowShort:     CandleSetting{RangeType: ShadowsRange, AvgPeriod: 10, Factor: 1.0},
+		ShadowVeryShort: CandleSetting{RangeType: HighLowRange, AvgPeriod: 10, Factor: 0.1},
+		Near:            CandleSetting{RangeType: HighLowRange, AvgPeriod: 5, Factor: 0.2},
+		Far:             CandleSetting{RangeType: HighLowRange, AvgPeriod: 5, Factor: 0.6},
+		Equal:           CandleSetting{RangeType: HighLowRange, AvgPeriod: 5, Factor: 0.05},
+	}
+}
+
+func (s *CandleSettings) validate() error {
+	for _, setting := range []CandleSetting{s.BodyLong, s.BodyVeryLong, s.BodyShort, s.BodyDoji,
+		s.ShadowLong, s.ShadowVeryLong, s.ShadowShort, s.ShadowVeryShort, s.Near, s.Far, s.Equal} {
+		if setting.AvgPeriod < 0 {
+			return ErrCandleSettingAvgPeriodBelow0
+		}
+		if setting.Factor < 0 {
+			return ErrCandleSettingFactorBelow0
+		}
+	}
+	return nil
+}
+
+// maxAvgPeriod returns the longest average period of the given candle settings
+func maxAvgPeriod(settings ...CandleSetting) int {
+	var max int
+	for _, setting := range settings {
+		if setting.AvgPeriod > max {
+			max = setting.AvgPeriod
+		}
+	}
+	return max
+}
+
+// A candlestick pattern, named after its TA-Lib function
+type Pattern int
+
+const (
+	TwoCrows Pattern = iota
+	ThreeBlackCrows
+	ThreeInside
+	ThreeLineStrike
+	ThreeOutside
+	ThreeStarsInSouth
+	ThreeWhiteSoldiers
+	AbandonedBaby
+	AdvanceBlock
+	BeltHold
+	Breakaway
+	ClosingMarubozu
+	ConcealBabySwallow
+	CounterAttack
+	DarkCloudCover
+	Doji
+	DojiStar
+	DragonflyDoji
+	Engulfing
+	EveningDojiStar
+	EveningStar
+	GapSideSideWhite
+	GravestoneDoji
+	Hammer
+	HangingMan
+	Harami
+	HaramiCross
+	HighWave
+	Hikkake
+	HikkakeMod
+	HomingPigeon
+	IdenticalThreeCrows
+	InNeck
+	InvertedHammer
+	Kicking
+	KickingByLength
+	LadderBottom
+	LongLeggedDoji
+	LongLine
+	Marubozu
+	MatchingLow
+	MatHold
+	MorningDojiStar
+	MorningStar
+	OnNeck
+	Piercing
+	RickshawMan
+	RiseFallThreeMethods
+	SeparatingLines
+	ShootingStar
+	ShortLine
+	SpinningTop
+	StalledPattern
+	StickSandwich
+	Takuri
+	TasukiGap
+	Thrusting
+	Tristar
+	UniqueThreeRiver
+	UpsideGapTwoCrows
+	XSideGapThreeMethods
+	patternCount
+)
+
+// Patterns returns every candlestick pattern
+func Patterns() []Pattern {
+	patterns := make([]Pattern, patternCount)
+	for i := range patterns {
+		patterns[i] = Pattern(i)
+	}
+	return patterns
+}
+
+// String returns the TA-Lib function name of the pattern, e.g. CDLDOJI
+func (p Pattern) String() string {
+	if p < 0 || p >= patternCount {
+		return "CDLUNKNOWN"
+	}
+	return patternDefinitions[p].name
+}
+
+// DefaultPenetration returns the TA-Lib default penetration of the pattern, 0 for patterns without one
+func (p Pattern) DefaultPenetration() float64 {
+	if p < 0 || p >= patternCount {
+		return 0.0
+	}
+	return patternDefinitions[p].defaultPenetration
+}
+
+// Lookback returns the number of bars the pattern needs before its first signal with the given candle settings
+func (p Pattern) Lookback(settings *CandleSettings) int {
+	if p < 0 || p >= patternCount {
+		return 0
+	}
+	return patternDefinitions[p].lookback(settings)
+}
+
+// the recognition of a pattern, as in the TA-Lib CDL function of the same name
+type patternDefinition struct {
+	name               string
+	defaultPenetration float64
+	// the bars before the first signal
+	lookback func(s *CandleSettings) int
+	// the bars before the first signal which are recognized to prime the pattern's state, without a signal
+	primingBars int
+	// recognize returns the signal for the latest candle in the window
+	recognize func(w *candleWindow) int64
+}
+
+var patternDefinitions = [patternCount]patternDefinition{
+	TwoCrows:             twoCrows,
+	ThreeBlackCrows:      threeBlackCrows,
+	ThreeInside:          threeInside,
+	ThreeLineStrike:      threeLineStrike,
+	ThreeOutside:         threeOutside,
+	ThreeStarsInSouth:    threeStarsInSouth,
+	ThreeWhiteSoldiers:   threeWhiteSoldiers,
+	AbandonedBaby:        abandonedBaby,
+	AdvanceBlock:         advanceBlock,
+	BeltHold:             beltHold,
+	Breakaway:            breakaway,
+	ClosingMarubozu:      closingMarubozu,
+	ConcealBabySwallow:   concealBabySwallow,
+	CounterAttack:        counterAttack,
+	DarkCloudCover:       darkCloudCover,
+	Doji:                 doji,
+	DojiStar:             dojiStar,
+	DragonflyDoji:        dragonflyDoji,
+	Engulfing:            engulfing,
+	EveningDojiStar:      eveningDojiStar,
+	EveningStar:          eveningStar,
+	GapSideSideWhite:     gapSideSideWhite,
+	GravestoneDoji:       gravestoneDoji,
+	Hammer:               hammer,
+	HangingMan:           hangingMan,
+	Harami:               harami,
+	HaramiCross:          haramiCross,
+	HighWave:             highWave,
+	Hikkake:              hikkake,
+	HikkakeMod:           hikkakeMod,
+	HomingPigeon:         homingPigeon,
+	IdenticalThreeCrows:  identicalThreeCrows,
+	InNeck:               inNeck,
+	InvertedHammer:       invertedHammer,
+	Kicking:              kicking,
+	KickingByLength:      kickingByLength,
+	LadderBottom:         ladderBottom,
+	LongLeggedDoji:       longLeggedDoji,
+	LongLine:             longLine,
+	Marubozu:             marubozu,
+	MatchingLow:          matchingLow,
+	MatHold:              matHold,
+	MorningDojiStar:      morningDojiStar,
+	MorningStar:          morningStar,
+	OnNeck:               onNeck,
+	Piercing:             piercing,
+	RickshawMan:          rickshawMan,
+	RiseFallThreeMethods: riseFallThreeMethods,
+	SeparatingLines:      separatingLines,
+	ShootingStar:         shootingStar,
+	ShortLine:            shortLine,
+	SpinningTop:          spinningTop,
+	StalledPattern:       stalledPattern,
+	StickSandwich:        stickSandwich,
+	Takuri:               takuri,
+	TasukiGap:            tasukiGap,
+	Thrusting:            thrusting,
+	Tristar:              tristar,
+	UniqueThreeRiver:     uniqueThreeRiver,
+	UpsideGapTwoCrows:    upsideGapTwoCrows,
+	XSideGapThreeMethods: xSideGapThreeMethods,
+}
@@ -0,0 +1,57 @@
+package patterns_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/jaybutera/gotrade"
+	"github.com/jaybutera/gotrade/feeds"
+	"github.com/jaybutera/gotrade/patterns"
+	"testing"
+	"time"
+)
+
+var (
+	csvFeed *feeds.CSVFileFeed
+)
+
+func TestPatterns(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Patterns Suite")
+}
+
+var _ = BeforeSuite(func() {
+	csvFeed = feeds.NewCSVFileFeedWithDOHLCVFormat("../testdata/JSETOPI.2013.data",
+		feeds.DashedYearDayMonthDateParserForLocation(time.Local))
+})
+
+var _ = AfterSuite(func() {
+	csvFeed = nil
+})
+
+// neutralCandles returns alternating candles with a body of 0.5 and shadows of 0.5 around a flat price, used to
+// prime the rolling averages before the candles of a pattern
+func neutralCandles(count int) (results []gotrade.DOHLCV) {
+	for i := 0; i < count; i++ {
+		if i%2 == 0 {
+			results = append(results, gotrade.NewDOHLCVDataItem(time.Time{}, 100.0, 101.0, 99.5, 100.5, 0.0))
+		} else {
+			results = append(results, gotrade.NewDOHLCVDataItem(time.Time{}, 100.5, 101.0, 99.5, 100.0, 0.0))
+		}
+	}
+	return results
+}
+
+func candle(o float64, h float64, l float64, c float64) gotrade.DOHLCV {
+	return gotrade.NewDOHLCVDataItem(time.Time{}, o, h, l, c, 0.0)
+}
+
+// recognize feeds the candles to a default recognizer for the pattern and returns the signal of the last candle
+func recognize(pattern patterns.Pattern, candles []gotrade.DOHLCV) int64 {
+	recognizer, err := patterns.NewDefaultRecognizer(pattern)
+	Expect(err).To(BeNil())
+	for i := range candles {
+		recognizer.ReceiveDOHLCVTick(candles[i], i+1)
+	}
+	Expect(len(recognizer.Data)).To(BeNumerically(">", 0))
+	return recognizer.Data[len(recognizer.Data)-1]
+}
@@ -0,0 +1,156 @@
+package patterns_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/jaybutera/gotrade"
+	"github.com/jaybutera/gotrade/patterns"
+)
+
+var _ = Describe("when listing the candlestick patterns", func() {
+	It("should list every TA-Lib candlestick pattern", func() {
+		Expect(len(patterns.Patterns())).To(Equal(61))
+	})
+
+	It("each pattern should be named after its TA-Lib function", func() {
+		Expect(patterns.Doji.String()).To(Equal("CDLDOJI"))
+		Expect(patterns.ThreeBlackCrows.String()).To(Equal("CDL3BLACKCROWS"))
+		Expect(patterns.Pattern(-1).String()).To(Equal("CDLUNKNOWN"))
+	})
+
+	It("the patterns penetrating a prior body should have the TA-Lib default penetration", func() {
+		Expect(patterns.MorningStar.DefaultPenetration()).To(Equal(0.3))
+		Expect(patterns.DarkCloudCover.DefaultPenetration()).To(Equal(0.5))
+		Expect(patterns.Doji.DefaultPenetration()).To(Equal(0.0))
+	})
+})
+
+var _ = Describe("when recognizing candlestick patterns from known candles", func() {
+	var (
+		candles []gotrade.DOHLCV
+	)
+
+	BeforeEach(func() {
+		candles = neutralCandles(12)
+	})
+
+	Context("given a candle with an open and close nearly equal", func() {
+		BeforeEach(func() {
+			candles = append(candles, candle(100.0, 101.0, 99.5, 100.05))
+		})
+
+		It("should recognize a doji", func() {
+			Expect(recognize(patterns.Doji, candles)).To(Equal(int64(100)))
+		})
+	})
+
+	Context("given a candle with an ordinary body", func() {
+		BeforeEach(func() {
+			candles = append(candles, candle(100.0, 101.0, 99.5, 100.5))
+		})
+
+		It("should not recognize a doji", func() {
+			Expect(recognize(patterns.Doji, candles)).To(Equal(int64(0)))
+		})
+	})
+
+	Context("given a small body with a long lower shadow near the prior low", func() {
+		BeforeEach(func() {
+			candles = append(candles, candle(99.6, 99.82, 98.0, 99.8))
+		})
+
+		It("should recognize a hammer", func() {
+			Expect(recognize(patterns.Hammer, candles)).To(Equal(int64(100)))
+		})
+	})
+
+	Context("given a white candle engulfing a black candle", func() {
+		BeforeEach(func() {
+			candles = append(candles, candle(100.5, 101.0, 99.5, 100.0), candle(99.8, 101.2, 99.7, 100.9))
+		})
+
+		It("should recognize a bullish engulfing", func() {
+			Expect(recognize(patterns.Engulfing, candles)).To(Equal(int64(100)))
+		})
+	})
+
+	Context("given a black candle engulfing a white candle", func() {
+		BeforeEach(func() {
+			candles = append(candles, candle(100.0, 101.0, 99.5, 100.5), candle(100.7, 100.9, 99.6, 99.8))
+		})
+
+		It("should recognize a bearish engulfing", func() {
+			Expect(recognize(patterns.Engulfing, candles)).To(Equal(int64(-100)))
+		})
+	})
+
+	Context("given a long white candle followed by a short candle within its body", func() {
+		BeforeEach(func() {
+			candles = append(candles, candle(100.0, 102.2, 99.9, 102.0), candle(101.2, 101.5, 100.9, 101.0))
+		})
+
+		It("should recognize a bearish harami", func() {
+			Expect(recognize(patterns.Harami, candles)).To(Equal(int64(-100)))
+		})
+	})
+
+	Context("given a long black candle, a short candle gapping down and a white candle closing well into the first", func() {
+		BeforeEach(func() {
+			candles = append(candles, candle(102.0, 102.1, 99.9, 100.0), candle(99.5, 99.7, 99.2, 99.4), candle(99.6, 101.3, 99.5, 101.2))
+		})
+
+		It("should recognize a morning star", func() {
+			Expect(recognize(patterns.MorningStar, candles)).To(Equal(int64(100)))
+		})
+	})
+
+	Context("given a long white candle, a short candle gapping up and a black candle closing well into the first", func() {
+		BeforeEach(func() {
+			candles = append(candles, candle(100.0, 102.1, 99.9, 102.0), candle(102.5, 102.8, 102.3, 102.6), candle(102.4, 102.5, 100.7, 100.8))
+		})
+
+		It("should recognize an evening star", func() {
+			Expect(recognize(patterns.EveningStar, candles)).To(Equal(int64(-100)))
+		})
+	})
+
+	Context("given three rising white candles closing near their highs", func() {
+		BeforeEach(func() {
+			candles = append(candles, candle(100.0, 101.02, 99.9, 101.0), candle(100.9, 102.02, 100.8, 102.0), candle(101.9, 103.02, 101.8, 103.0))
+		})
+
+		It("should recognize three white soldiers", func() {
+			Expect(recognize(patterns.ThreeWhiteSoldiers, candles)).To(Equal(int64(100)))
+		})
+	})
+
+	Context("given a white candle followed by three falling black candles closing near their lows", func() {
+		BeforeEach(func() {
+			candles = append(candles, candle(100.0, 101.1, 99.9, 101.0), candle(100.9, 100.95, 99.9, 99.95),
+				candle(100.0, 100.05, 98.95, 99.0), candle(99.1, 99.15, 98.0, 98.05))
+		})
+
+		It("should recognize three black crows", func() {
+			Expect(recognize(patterns.ThreeBlackCrows, candles)).To(Equal(int64(-100)))
+		})
+	})
+
+	Context("given an inside bar, a false breakout below it and a close above it", func() {
+		var (
+			recognizer *patterns.Recognizer
+		)
+
+		BeforeEach(func() {
+			candles = append(candles, candle(100.0, 102.0, 98.0, 101.0), candle(100.5, 101.5, 99.0, 100.0),
+				candle(99.5, 101.0, 98.5, 99.0), candle(99.5, 102.0, 99.4, 101.8))
+			recognizer, _ = patterns.NewDefaultRecognizer(patterns.Hikkake)
+			for i := range candles {
+				recognizer.ReceiveDOHLCVTick(candles[i], i+1)
+			}
+		})
+
+		It("should recognize a bullish hikkake on the breakout and confirm it on the close", func() {
+			Expect(recognizer.Data[len(recognizer.Data)-2:]).To(Equal([]int64{100, 200}))
+		})
+	})
+})
@@ -0,0 +1,185 @@
+// Candlestick Pattern Recognizer (Recognizer)
+package patterns
+
+import (
+	"errors"
+	"github.com/jaybutera/gotrade"
+	"github.com/jaybutera/gotrade/indicators"
+)
+
+// A Candlestick Pattern Recognizer (Recognizer), no storage, for use in other indicators
+type RecognizerWithoutStorage struct {
+	pattern        Pattern
+	definition     *patternDefinition
+	window         *candleWindow
+	validFromBar   int
+	dataLength     int
+	lookbackPeriod int
+
+	valueAvailableAction indicators.ValueAvailableActionInt
+}
+
+// NewRecognizerWithoutStorage creates a Candlestick Pattern Recognizer (Recognizer) without storage
+func NewRecognizerWithoutStorage(pattern Pattern, penetration float64, settings *CandleSettings, valueAvailableAction indicators.ValueAvailableActionInt) (recognizer *RecognizerWithoutStorage, err error) {
+
+	// a recognizer without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, indicators.ErrValueAvailableActionIsNil
+	}
+
+	if pattern < 0 || pattern >= patternCount {
+		return nil, ErrUnknownPattern
+	}
+
+	if settings == nil {
+		return nil, ErrCandleSettingsIsNil
+	}
+
+	if err := settings.validate(); err != nil {
+		return nil, err
+	}
+
+	// the minimum penetration for this recognizer is 0.0
+	if penetration < 0.0 {
+		return nil, errors.New("penetration is less than the minimum (0.0)")
+	}
+
+	// the settings are copied so later changes to them do not change the recognizer
+	var ownSettings CandleSettings = *settings
+
+	definition := &patternDefinitions[pattern]
+	lookback := definition.lookback(&ownSettings)
+	rec := RecognizerWithoutStorage{
+		pattern:              pattern,
+		definition:           definition,
+		window:               newCandleWindow(lookback+1, &ownSettings, penetration),
+		validFromBar:         -1,
+		lookbackPeriod:       lookback,
+		valueAvailableAction: valueAvailableAction,
+	}
+
+	return &rec, nil
+}
+
+// Pattern returns the candlestick pattern the recognizer recognizes
+func (rec *RecognizerWithoutStorage) Pattern() Pattern {
+	return rec.pattern
+}
+
+// ValidFromBar returns the source data bar number from which this recognizer is valid, starts at bar 1
+func (rec *RecognizerWithoutStorage) ValidFromBar() int {
+	return rec.validFromBar
+}
+
+// GetLookbackPeriod returns the number of bars before the first signal
+func (rec *RecognizerWithoutStorage) GetLookbackPeriod() int {
+	return rec.lookbackPeriod
+}
+
+// Length returns the number of signals emitted
+func (rec *RecognizerWithoutStorage) Length() int {
+	return rec.dataLength
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (rec *RecognizerWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	rec.window.add(tickData)
+
+	// wait for enough candles to recognize the pattern, some patterns prime their state before the first signal
+	if rec.window.barIndex <= rec.lookbackPeriod-rec.definition.primingBars {
+		return
+	}
+
+	var signal int64 = rec.definition.recognize(rec.window)
+
+	if rec.window.barIndex > rec.lookbackPeriod {
+		rec.dataLength += 1
+
+		// set the streamBarIndex from which this recognizer returns valid results
+		if rec.validFromBar == -1 {
+			rec.validFromBar = streamBarIndex
+		}
+
+		rec.valueAvailableAction(signal, streamBarIndex)
+	}
+}
+
+// A Candlestick Pattern Recognizer (Recognizer)
+type Recognizer struct {
+	*RecognizerWithoutStorage
+
+	// public variables
+	Data []int64
+}
+
+// NewRecognizer creates a Candlestick Pattern Recognizer (Recognizer) for online usage
+func NewRecognizer(pattern Pattern, penetration float64, settings *CandleSettings) (recognizer *Recognizer, err error) {
+	rec := Recognizer{}
+	rec.RecognizerWithoutStorage, err = NewRecognizerWithoutStorage(pattern, penetration, settings,
+		func(dataItem int64, streamBarIndex int) {
+			rec.Data = append(rec.Data, dataItem)
+		})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &rec, nil
+}
+
+// NewDefaultRecognizer creates a Candlestick Pattern Recognizer (Recognizer) for online usage with default parameters
+//	- penetration: the pattern's TA-Lib default
+//	- settings: the TA-Lib default candle settings
+func NewDefaultRecognizer(pattern Pattern) (recognizer *Recognizer, err error) {
+	return NewRecognizer(pattern, pattern.DefaultPenetration(), DefaultCandleSettings())
+}
+
+// NewRecognizerWithSrcLen creates a Candlestick Pattern Recognizer (Recognizer) for offline usage
+func NewRecognizerWithSrcLen(sourceLength uint, pattern Pattern, penetration float64, settings *CandleSettings) (recognizer *Recognizer, err error) {
+	rec, err := NewRecognizer(pattern, penetration, settings)
+	if err != nil {
+		return nil, err
+	}
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength > uint(rec.GetLookbackPeriod())+1 {
+		rec.Data = make([]int64, 0, sourceLength-uint(rec.GetLookbackPeriod()))
+	}
+
+	return rec, nil
+}
+
+// NewDefaultRecognizerWithSrcLen creates a Candlestick Pattern Recognizer (Recognizer) for offline usage with default parameters
+func NewDefaultRecognizerWithSrcLen(sourceLength uint, pattern Pattern) (recognizer *Recognizer, err error) {
+	return NewRecognizerWithSrcLen(sourceLength, pattern, pattern.DefaultPenetration(), DefaultCandleSettings())
+}
+
+// NewRecognizerForStream creates a Candlestick Pattern Recognizer (Recognizer) for online usage with a source data stream
+func NewRecognizerForStream(priceStream gotrade.DOHLCVStreamSubscriber, pattern Pattern, penetration float64, settings *CandleSettings) (recognizer *Recognizer, err error) {
+	rec, err := NewRecognizer(pattern, penetration, settings)
+	if err != nil {
+		return nil, err
+	}
+	priceStream.AddTickSubscription(rec)
+	return rec, nil
+}
+
+// NewDefaultRecognizerForStream creates a Candlestick Pattern Recognizer (Recognizer) for online usage with a source data stream
+func NewDefaultRecognizerForStream(priceStream gotrade.DOHLCVStreamSubscriber, pattern Pattern) (recognizer *Recognizer, err error) {
+	return NewRecognizerForStream(priceStream, pattern, pattern.DefaultPenetration(), DefaultCandleSettings())
+}
+
+// NewRecognizerForStreamWithSrcLen creates a Candlestick Pattern Recognizer (Recognizer) for offline usage with a source data stream
+func NewRecognizerForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, pattern Pattern, penetration float64, settings *CandleSettings) (recognizer *Recognizer, err error) {
+	rec, err := NewRecognizerWithSrcLen(sourceLength, pattern, penetration, settings)
+	if err != nil {
+		return nil, err
+	}
+	priceStream.AddTickSubscription(rec)
+	return rec, nil
+}
+
+// NewDefaultRecognizerForStreamWithSrcLen creates a Candlestick Pattern Recognizer (Recognizer) for offline usage with a source data stream
+func NewDefaultRecognizerForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, pattern Pattern) (recognizer *Recognizer, err error) {
+	return NewRecognizerForStreamWithSrcLen(sourceLength, priceStream, pattern, pattern.DefaultPenetration(), DefaultCandleSettings())
+}
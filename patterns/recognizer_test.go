@@ -0,0 +1,259 @@
+package patterns_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/jaybutera/gotrade"
+	"github.com/jaybutera/gotrade/indicators"
+	"github.com/jaybutera/gotrade/patterns"
+)
+
+var _ = Describe("when creating a recognizerwithoutstorage", func() {
+	var (
+		recognizer      *patterns.RecognizerWithoutStorage
+		recognizerError error
+		settings        *patterns.CandleSettings
+	)
+
+	BeforeEach(func() {
+		settings = patterns.DefaultCandleSettings()
+	})
+
+	Context("and the recognizer was not given a value available action", func() {
+		BeforeEach(func() {
+			recognizer, recognizerError = patterns.NewRecognizerWithoutStorage(patterns.Doji, 0.0, settings, nil)
+		})
+
+		It("the recognizer should not be created and return the appropriate error message", func() {
+			Expect(recognizer).To(BeNil())
+			Expect(recognizerError).To(Equal(indicators.ErrValueAvailableActionIsNil))
+		})
+	})
+
+	Context("and the recognizer was given an unknown pattern", func() {
+		BeforeEach(func() {
+			recognizer, recognizerError = patterns.NewRecognizerWithoutStorage(patterns.Pattern(len(patterns.Patterns())), 0.0, settings,
+				func(dataItem int64, streamBarIndex int) {})
+		})
+
+		It("the recognizer should not be created and return the appropriate error message", func() {
+			Expect(recognizer).To(BeNil())
+			Expect(recognizerError).To(Equal(patterns.ErrUnknownPattern))
+		})
+	})
+
+	Context("and the recognizer was not given candle settings", func() {
+		BeforeEach(func() {
+			recognizer, recognizerError = patterns.NewRecognizerWithoutStorage(patterns.Doji, 0.0, nil,
+				func(dataItem int64, streamBarIndex int) {})
+		})
+
+		It("the recognizer should not be created and return the appropriate error message", func() {
+			Expect(recognizer).To(BeNil())
+			Expect(recognizerError).To(Equal(patterns.ErrCandleSettingsIsNil))
+		})
+	})
+
+	Context("and the recognizer was given a candle setting with an average period below 0", func() {
+		BeforeEach(func() {
+			settings.Near.AvgPeriod = -1
+			recognizer, recognizerError = patterns.NewRecognizerWithoutStorage(patterns.Doji, 0.0, settings,
+				func(dataItem int64, streamBarIndex int) {})
+		})
+
+		It("the recognizer should not be created and return the appropriate error message", func() {
+			Expect(recognizer).To(BeNil())
+			Expect(recognizerError).To(Equal(patterns.ErrCandleSettingAvgPeriodBelow0))
+		})
+	})
+
+	Context("and the recognizer was given a candle setting with a factor below 0", func() {
+		BeforeEach(func() {
+			settings.BodyDoji.Factor = -0.1
+			recognizer, recognizerError = patterns.NewRecognizerWithoutStorage(patterns.Doji, 0.0, settings,
+				func(dataItem int64, streamBarIndex int) {})
+		})
+
+		It("the recognizer should not be created and return the appropriate error message", func() {
+			Expect(recognizer).To(BeNil())
+			Expect(recognizerError).To(Equal(patterns.ErrCandleSettingFactorBelow0))
+		})
+	})
+
+	Context("and the recognizer was given a penetration below the minimum", func() {
+		BeforeEach(func() {
+			recognizer, recognizerError = patterns.NewRecognizerWithoutStorage(patterns.MorningStar, -0.1, settings,
+				func(dataItem int64, streamBarIndex int) {})
+		})
+
+		It("the recognizer should not be created and return the appropriate error message", func() {
+			Expect(recognizer).To(BeNil())
+			Expect(recognizerError).To(MatchError("penetration is less than the minimum (0.0)"))
+		})
+	})
+
+	Context("and the candle settings are changed after the recognizer was created", func() {
+		BeforeEach(func() {
+			recognizer, recognizerError = patterns.NewRecognizerWithoutStorage(patterns.Doji, 0.0, settings,
+				func(dataItem int64, streamBarIndex int) {})
+			settings.BodyDoji.AvgPeriod = 20
+		})
+
+		It("the recognizer should keep its own copy of the settings", func() {
+			Expect(recognizerError).To(BeNil())
+			Expect(recognizer.GetLookbackPeriod()).To(Equal(10))
+		})
+	})
+})
+
+var _ = Describe("when recognizing a candlestick pattern with DOHLCV source data", func() {
+	var (
+		recognizer  *patterns.Recognizer
+		priceStream *gotrade.InterDayDOHLCVStream
+	)
+
+	BeforeEach(func() {
+		priceStream = gotrade.NewDailyDOHLCVStream()
+	})
+
+	Context("given the recognizer is created via the standard constructor", func() {
+		BeforeEach(func() {
+			recognizer, _ = patterns.NewRecognizer(patterns.MorningStar, 0.5, patterns.DefaultCandleSettings())
+		})
+
+		It("should recognize the given pattern", func() {
+			Expect(recognizer.Pattern()).To(Equal(patterns.MorningStar))
+		})
+
+		It("should have a lookback period of the longest average period plus the candles before the last", func() {
+			Expect(recognizer.GetLookbackPeriod()).To(Equal(12))
+		})
+
+		Context("and the recognizer has not yet received any ticks", func() {
+			It("should not have any signals", func() {
+				Expect(recognizer.Length()).To(Equal(0))
+				Expect(recognizer.ValidFromBar()).To(Equal(-1))
+			})
+		})
+
+		Context("and the recognizer has received ticks equal to the lookback period", func() {
+			BeforeEach(func() {
+				candles := neutralCandles(recognizer.GetLookbackPeriod())
+				for i := range candles {
+					recognizer.ReceiveDOHLCVTick(candles[i], i+1)
+				}
+			})
+
+			It("should not have any signals", func() {
+				Expect(recognizer.Length()).To(Equal(0))
+				Expect(len(recognizer.Data)).To(Equal(0))
+			})
+		})
+
+		Context("and the recognizer has received more ticks than the lookback period", func() {
+			BeforeEach(func() {
+				candles := neutralCandles(recognizer.GetLookbackPeriod() + 5)
+				for i := range candles {
+					recognizer.ReceiveDOHLCVTick(candles[i], i+1)
+				}
+			})
+
+			It("should have a signal for each tick after the lookback period", func() {
+				Expect(recognizer.Length()).To(Equal(5))
+				Expect(recognizer.Data).To(Equal([]int64{0, 0, 0, 0, 0}))
+			})
+
+			It("should be valid from the first tick after the lookback period", func() {
+				Expect(recognizer.ValidFromBar()).To(Equal(recognizer.GetLookbackPeriod() + 1))
+			})
+		})
+	})
+
+	Context("given the recognizer is created via the constructor with defaulted parameters", func() {
+		BeforeEach(func() {
+			recognizer, _ = patterns.NewDefaultRecognizer(patterns.MorningStar)
+		})
+
+		It("should have a lookback period based on the default candle settings", func() {
+			Expect(recognizer.GetLookbackPeriod()).To(Equal(patterns.MorningStar.Lookback(patterns.DefaultCandleSettings())))
+		})
+	})
+
+	Context("given the recognizer is created via the constructor with fixed source length", func() {
+		BeforeEach(func() {
+			recognizer, _ = patterns.NewDefaultRecognizerWithSrcLen(100, patterns.Doji)
+		})
+
+		It("should have pre-allocated storge for the output data", func() {
+			Expect(cap(recognizer.Data)).To(Equal(100 - recognizer.GetLookbackPeriod()))
+		})
+
+		Context("and the recognizer has recieved all of its ticks", func() {
+			BeforeEach(func() {
+				candles := neutralCandles(100)
+				for i := range candles {
+					recognizer.ReceiveDOHLCVTick(candles[i], i+1)
+				}
+			})
+
+			It("no new storage capcity should have been allocated", func() {
+				Expect(len(recognizer.Data)).To(Equal(cap(recognizer.Data)))
+			})
+		})
+	})
+
+	Context("given the recognizer is created via the constructor with a source data stream", func() {
+		BeforeEach(func() {
+			recognizer, _ = patterns.NewDefaultRecognizerForStream(priceStream, patterns.Engulfing)
+			csvFeed.FillDOHLCVStream(priceStream)
+		})
+
+		It("should have a signal for each bar after the lookback period", func() {
+			Expect(len(recognizer.Data)).To(Equal(len(priceStream.Data) - recognizer.GetLookbackPeriod()))
+		})
+	})
+
+	Context("given the recognizer is created via the constructor with fixed source length and a source data stream", func() {
+		BeforeEach(func() {
+			recognizer, _ = patterns.NewDefaultRecognizerForStreamWithSrcLen(uint(len(priceStream.Data)), priceStream, patterns.Engulfing)
+			csvFeed.FillDOHLCVStream(priceStream)
+		})
+
+		It("should have a signal for each bar after the lookback period", func() {
+			Expect(len(recognizer.Data)).To(Equal(len(priceStream.Data) - recognizer.GetLookbackPeriod()))
+		})
+	})
+})
+
+var _ = Describe("when recognizing every candlestick pattern with a years data", func() {
+	var (
+		recognizers []*patterns.Recognizer
+		priceStream *gotrade.InterDayDOHLCVStream
+	)
+
+	BeforeEach(func() {
+		priceStream = gotrade.NewDailyDOHLCVStream()
+		recognizers = nil
+		for _, pattern := range patterns.Patterns() {
+			recognizer, err := patterns.NewDefaultRecognizerForStream(priceStream, pattern)
+			Expect(err).To(BeNil())
+			recognizers = append(recognizers, recognizer)
+		}
+		csvFeed.FillDOHLCVStream(priceStream)
+	})
+
+	It("each recognizer should have a signal for each bar after its lookback period", func() {
+		for _, recognizer := range recognizers {
+			Expect(len(recognizer.Data)).To(Equal(len(priceStream.Data)-recognizer.GetLookbackPeriod()), recognizer.Pattern().String())
+			Expect(recognizer.Length()).To(Equal(len(recognizer.Data)), recognizer.Pattern().String())
+		}
+	})
+
+	It("each signal should be none, bullish or bearish, or a confirmed hikkake", func() {
+		for _, recognizer := range recognizers {
+			for _, signal := range recognizer.Data {
+				Expect(signal).To(BeElementOf(int64(0), int64(100), int64(-100), int64(200), int64(-200)), recognizer.Pattern().String())
+			}
+		}
+	})
+})
@@ -0,0 +1,221 @@
+package patterns
+
+// CDLBELTHOLD: a long candle opening at its extreme, white opening on its low or black opening on its high
+var beltHold = patternDefinition{
+	name: "CDLBELTHOLD",
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.BodyLong, s.ShadowVeryShort)
+	},
+	recognize: func(w *candleWindow) int64 {
+		s := w.settings
+		if w.realBody(0) > w.average(s.BodyLong, 0) &&
+			((w.color(0) == 1 && w.lowerShadow(0) < w.average(s.ShadowVeryShort, 0)) ||
+				(w.color(0) == -1 && w.upperShadow(0) < w.average(s.ShadowVeryShort, 0))) {
+			return w.color(0) * 100
+		}
+		return 0
+	},
+}
+
+// CDLCLOSINGMARUBOZU: a long candle closing at its extreme, white closing on its high or black closing on its low
+var closingMarubozu = patternDefinition{
+	name: "CDLCLOSINGMARUBOZU",
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.BodyLong, s.ShadowVeryShort)
+	},
+	recognize: func(w *candleWindow) int64 {
+		s := w.settings
+		if w.realBody(0) > w.average(s.BodyLong, 0) &&
+			((w.color(0) == 1 && w.upperShadow(0) < w.average(s.ShadowVeryShort, 0)) ||
+				(w.color(0) == -1 && w.lowerShadow(0) < w.average(s.ShadowVeryShort, 0))) {
+			return w.color(0) * 100
+		}
+		return 0
+	},
+}
+
+// CDLDOJI: the open and close are nearly equal
+var doji = patternDefinition{
+	name: "CDLDOJI",
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.BodyDoji)
+	},
+	recognize: func(w *candleWindow) int64 {
+		if w.realBody(0) <= w.average(w.settings.BodyDoji, 0) {
+			return 100
+		}
+		return 0
+	},
+}
+
+// CDLDRAGONFLYDOJI: a doji with no upper shadow and a long lower shadow
+var dragonflyDoji = patternDefinition{
+	name: "CDLDRAGONFLYDOJI",
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.BodyDoji, s.ShadowVeryShort)
+	},
+	recognize: func(w *candleWindow) int64 {
+		s := w.settings
+		if w.realBody(0) <= w.average(s.BodyDoji, 0) &&
+			w.upperShadow(0) < w.average(s.ShadowVeryShort, 0) &&
+			w.lowerShadow(0) > w.average(s.ShadowVeryShort, 0) {
+			return 100
+		}
+		return 0
+	},
+}
+
+// CDLGRAVESTONEDOJI: a doji with no lower shadow and a long upper shadow
+var gravestoneDoji = patternDefinition{
+	name: "CDLGRAVESTONEDOJI",
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.BodyDoji, s.ShadowVeryShort)
+	},
+	recognize: func(w *candleWindow) int64 {
+		s := w.settings
+		if w.realBody(0) <= w.average(s.BodyDoji, 0) &&
+			w.lowerShadow(0) < w.average(s.ShadowVeryShort, 0) &&
+			w.upperShadow(0) > w.average(s.ShadowVeryShort, 0) {
+			return 100
+		}
+		return 0
+	},
+}
+
+// CDLHIGHWAVE: a short body with very long upper and lower shadows
+var highWave = patternDefinition{
+	name: "CDLHIGHWAVE",
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.BodyShort, s.ShadowVeryLong)
+	},
+	recognize: func(w *candleWindow) int64 {
+		s := w.settings
+		if w.realBody(0) < w.average(s.BodyShort, 0) &&
+			w.upperShadow(0) > w.average(s.ShadowVeryLong, 0) &&
+			w.lowerShadow(0) > w.average(s.ShadowVeryLong, 0) {
+			return w.color(0) * 100
+		}
+		return 0
+	},
+}
+
+// CDLLONGLEGGEDDOJI: a doji with a long upper or lower shadow
+var longLeggedDoji = patternDefinition{
+	name: "CDLLONGLEGGEDDOJI",
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.BodyDoji, s.ShadowLong)
+	},
+	recognize: func(w *candleWindow) int64 {
+		s := w.settings
+		if w.realBody(0) <= w.average(s.BodyDoji, 0) &&
+			(w.lowerShadow(0) > w.average(s.ShadowLong, 0) ||
+				w.upperShadow(0) > w.average(s.ShadowLong, 0)) {
+			return 100
+		}
+		return 0
+	},
+}
+
+// CDLLONGLINE: a long body with short shadows
+var longLine = patternDefinition{
+	name: "CDLLONGLINE",
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.BodyLong, s.ShadowShort)
+	},
+	recognize: func(w *candleWindow) int64 {
+		s := w.settings
+		if w.realBody(0) > w.average(s.BodyLong, 0) &&
+			w.upperShadow(0) < w.average(s.ShadowShort, 0) &&
+			w.lowerShadow(0) < w.average(s.ShadowShort, 0) {
+			return w.color(0) * 100
+		}
+		return 0
+	},
+}
+
+// CDLMARUBOZU: a long body with very short shadows
+var marubozu = patternDefinition{
+	name: "CDLMARUBOZU",
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.BodyLong, s.ShadowVeryShort)
+	},
+	recognize: func(w *candleWindow) int64 {
+		s := w.settings
+		if w.realBody(0) > w.average(s.BodyLong, 0) &&
+			w.upperShadow(0) < w.average(s.ShadowVeryShort, 0) &&
+			w.lowerShadow(0) < w.average(s.ShadowVeryShort, 0) {
+			return w.color(0) * 100
+		}
+		return 0
+	},
+}
+
+// CDLRICKSHAWMAN: a doji with long shadows and its body near the middle of the range
+var rickshawMan = patternDefinition{
+	name: "CDLRICKSHAWMAN",
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.BodyDoji, s.ShadowLong, s.Near)
+	},
+	recognize: func(w *candleWindow) int64 {
+		s := w.settings
+		var middle float64 = w.l(0) + w.highLowRange(0)/2.0
+		if w.realBody(0) <= w.average(s.BodyDoji, 0) &&
+			w.lowerShadow(0) > w.average(s.ShadowLong, 0) &&
+			w.upperShadow(0) > w.average(s.ShadowLong, 0) &&
+			w.bodyBottom(0) <= middle+w.average(s.Near, 0) &&
+			w.bodyTop(0) >= middle-w.average(s.Near, 0) {
+			return 100
+		}
+		return 0
+	},
+}
+
+// CDLSHORTLINE: a short body with short shadows
+var shortLine = patternDefinition{
+	name: "CDLSHORTLINE",
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.BodyShort, s.ShadowShort)
+	},
+	recognize: func(w *candleWindow) int64 {
+		s := w.settings
+		if w.realBody(0) < w.average(s.BodyShort, 0) &&
+			w.upperShadow(0) < w.average(s.ShadowShort, 0) &&
+			w.lowerShadow(0) < w.average(s.ShadowShort, 0) {
+			return w.color(0) * 100
+		}
+		return 0
+	},
+}
+
+// CDLSPINNINGTOP: a short body with shadows longer than the body
+var spinningTop = patternDefinition{
+	name: "CDLSPINNINGTOP",
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.BodyShort)
+	},
+	recognize: func(w *candleWindow) int64 {
+		if w.realBody(0) < w.average(w.settings.BodyShort, 0) &&
+			w.upperShadow(0) > w.realBody(0) &&
+			w.lowerShadow(0) > w.realBody(0) {
+			return w.color(0) * 100
+		}
+		return 0
+	},
+}
+
+// CDLTAKURI: a dragonfly doji with a very long lower shadow
+var takuri = patternDefinition{
+	name: "CDLTAKURI",
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.BodyDoji, s.ShadowVeryShort, s.ShadowVeryLong)
+	},
+	recognize: func(w *candleWindow) int64 {
+		s := w.settings
+		if w.realBody(0) <= w.average(s.BodyDoji, 0) &&
+			w.upperShadow(0) < w.average(s.ShadowVeryShort, 0) &&
+			w.lowerShadow(0) > w.average(s.ShadowVeryLong, 0) {
+			return 100
+		}
+		return 0
+	},
+}
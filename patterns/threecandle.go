@@ -0,0 +1,513 @@
+package patterns
+
+import (
+	"math"
+)
+
+// CDL2CROWS: a long white candle, a black candle gapping up, then a black candle opening within the
+// second body and closing within the first
+var twoCrows = patternDefinition{
+	name: "CDL2CROWS",
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.BodyLong) + 2
+	},
+	recognize: func(w *candleWindow) int64 {
+		if w.color(2) == 1 &&
+			w.realBody(2) > w.average(w.settings.BodyLong, 2) &&
+			w.color(1) == -1 &&
+			w.realBodyGapUp(1, 2) &&
+			w.color(0) == -1 &&
+			w.o(0) < w.o(1) && w.o(0) > w.c(1) &&
+			w.c(0) > w.o(2) && w.c(0) < w.c(2) {
+			return -100
+		}
+		return 0
+	},
+}
+
+// CDL3INSIDE: a harami, then a candle closing beyond the open of the long candle
+var threeInside = patternDefinition{
+	name: "CDL3INSIDE",
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.BodyShort, s.BodyLong) + 2
+	},
+	recognize: func(w *candleWindow) int64 {
+		s := w.settings
+		if w.realBody(2) > w.average(s.BodyLong, 2) &&
+			w.realBody(1) <= w.average(s.BodyShort, 1) &&
+			w.bodyTop(1) < w.bodyTop(2) &&
+			w.bodyBottom(1) > w.bodyBottom(2) &&
+			((w.color(2) == 1 && w.color(0) == -1 && w.c(0) < w.o(2)) ||
+				(w.color(2) == -1 && w.color(0) == 1 && w.c(0) > w.o(2))) {
+			return -w.color(2) * 100
+		}
+		return 0
+	},
+}
+
+// CDL3OUTSIDE: an engulfing pattern, then a candle closing further in the engulfing direction
+var threeOutside = patternDefinition{
+	name: "CDL3OUTSIDE",
+	lookback: func(s *CandleSettings) int {
+		return 3
+	},
+	recognize: func(w *candleWindow) int64 {
+		if w.color(1) == 1 && w.color(2) == -1 &&
+			w.c(1) > w.o(2) && w.o(1) < w.c(2) &&
+			w.c(0) > w.c(1) {
+			return 100
+		}
+		if w.color(1) == -1 && w.color(2) == 1 &&
+			w.o(1) > w.c(2) && w.c(1) < w.o(2) &&
+			w.c(0) < w.c(1) {
+			return -100
+		}
+		return 0
+	},
+}
+
+// CDL3STARSINSOUTH: three black candles of shrinking size and range, the first with a long lower shadow
+// and the last a small marubozu within the range of the second
+var threeStarsInSouth = patternDefinition{
+	name: "CDL3STARSINSOUTH",
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.ShadowVeryShort, s.ShadowLong, s.BodyLong, s.BodyShort) + 2
+	},
+	recognize: func(w *candleWindow) int64 {
+		s := w.settings
+		if w.color(2) == -1 && w.color(1) == -1 && w.color(0) == -1 &&
+			// 1st: long body with a long lower shadow
+			w.realBody(2) > w.average(s.BodyLong, 2) &&
+			w.lowerShadow(2) > w.average(s.ShadowLong, 2) &&
+			// 2nd: smaller body, opening within the 1st range and trading lower but not below the 1st low
+			w.realBody(1) < w.realBody(2) &&
+			w.o(1) > w.c(2) && w.o(1) <= w.h(2) &&
+			w.l(1) < w.c(2) && w.l(1) >= w.l(2) &&
+			w.lowerShadow(1) > w.average(s.ShadowVeryShort, 1) &&
+			// 3rd: small marubozu within the 2nd range
+			w.realBody(0) < w.average(s.BodyShort, 0) &&
+			w.lowerShadow(0) < w.average(s.ShadowVeryShort, 0) &&
+			w.upperShadow(0) < w.average(s.ShadowVeryShort, 0) &&
+			w.l(0) > w.l(1) && w.h(0) < w.h(1) {
+			return 100
+		}
+		return 0
+	},
+}
+
+// CDL3WHITESOLDIERS: three white candles with very short upper shadows, each opening within or near
+// the prior body and closing higher
+var threeWhiteSoldiers = patternDefinition{
+	name: "CDL3WHITESOLDIERS",
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.ShadowVeryShort, s.BodyShort, s.Far, s.Near) + 2
+	},
+	recognize: func(w *candleWindow) int64 {
+		s := w.settings
+		if w.color(2) == 1 && w.color(1) == 1 && w.color(0) == 1 &&
+			w.upperShadow(2) < w.average(s.ShadowVeryShort, 2) &&
+			w.upperShadow(1) < w.average(s.ShadowVeryShort, 1) &&
+			w.upperShadow(0) < w.average(s.ShadowVeryShort, 0) &&
+			w.c(0) > w.c(1) && w.c(1) > w.c(2) &&
+			w.o(1) > w.o(2) && w.o(1) <= w.c(2)+w.average(s.Near, 2) &&
+			w.o(0) > w.o(1) && w.o(0) <= w.c(1)+w.average(s.Near, 1) &&
+			// not far shorter than the prior candle
+			w.realBody(1) > w.realBody(2)-w.average(s.Far, 2) &&
+			w.realBody(0) > w.realBody(1)-w.average(s.Far, 1) &&
+			w.realBody(0) > w.average(s.BodyShort, 0) {
+			return 100
+		}
+		return 0
+	},
+}
+
+// CDLABANDONEDBABY: a long candle, a doji gapping away from it, then a candle gapping back and closing
+// well into the first body
+var abandonedBaby = patternDefinition{
+	name:               "CDLABANDONEDBABY",
+	defaultPenetration: 0.3,
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.BodyDoji, s.BodyLong, s.BodyShort) + 2
+	},
+	recognize: func(w *candleWindow) int64 {
+		s := w.settings
+		if w.realBody(2) > w.average(s.BodyLong, 2) &&
+			w.realBody(1) <= w.average(s.BodyDoji, 1) &&
+			w.realBody(0) > w.average(s.BodyShort, 0) &&
+			((w.color(2) == 1 && w.color(0) == -1 &&
+				w.c(0) < w.c(2)-w.realBody(2)*w.penetration &&
+				w.candleGapUp(1, 2) && w.candleGapDown(0, 1)) ||
+				(w.color(2) == -1 && w.color(0) == 1 &&
+					w.c(0) > w.c(2)+w.realBody(2)*w.penetration &&
+					w.candleGapDown(1, 2) && w.candleGapUp(0, 1))) {
+			return w.color(0) * 100
+		}
+		return 0
+	},
+}
+
+// CDLADVANCEBLOCK: three rising white candles showing weakness, shrinking bodies or lengthening upper shadows
+var advanceBlock = patternDefinition{
+	name: "CDLADVANCEBLOCK",
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.ShadowLong, s.ShadowShort, s.Far, s.Near, s.BodyLong) + 2
+	},
+	recognize: func(w *candleWindow) int64 {
+		s := w.settings
+		// the 2nd is far smaller than the 1st and the 3rd no longer than the 2nd
+		var secondShrinks bool = w.realBody(1) < w.realBody(2)-w.average(s.Far, 2) &&
+			w.realBody(0) < w.realBody(1)+w.average(s.Near, 1)
+		// the 3rd is far smaller than the 2nd
+		var thirdShrinks bool = w.realBody(0) < w.realBody(1)-w.average(s.Far, 1)
+		// the bodies shrink with a long upper shadow on the 2nd or 3rd
+		var shadowsLengthen bool = w.realBody(0) < w.realBody(1) && w.realBody(1) < w.realBody(2) &&
+			(w.upperShadow(0) > w.average(s.ShadowShort, 0) || w.upperShadow(1) > w.average(s.ShadowShort, 1))
+		// the 3rd is smaller with a long upper shadow
+		var thirdHasLongShadow bool = w.realBody(0) < w.realBody(1) && w.upperShadow(0) > w.average(s.ShadowLong, 0)
+
+		if w.color(2) == 1 && w.color(1) == 1 && w.color(0) == 1 &&
+			w.c(0) > w.c(1) && w.c(1) > w.c(2) &&
+			w.o(1) > w.o(2) && w.o(1) <= w.c(2)+w.average(s.Near, 2) &&
+			w.o(0) > w.o(1) && w.o(0) <= w.c(1)+w.average(s.Near, 1) &&
+			w.realBody(2) > w.average(s.BodyLong, 2) &&
+			w.upperShadow(2) < w.average(s.ShadowShort, 2) &&
+			(secondShrinks || thirdShrinks || shadowsLengthen || thirdHasLongShadow) {
+			return -100
+		}
+		return 0
+	},
+}
+
+// CDLEVENINGDOJISTAR: a long white candle, a doji gapping up, then a black candle closing well into the first body
+var eveningDojiStar = patternDefinition{
+	name:               "CDLEVENINGDOJISTAR",
+	defaultPenetration: 0.3,
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.BodyDoji, s.BodyLong, s.BodyShort) + 2
+	},
+	recognize: func(w *candleWindow) int64 {
+		s := w.settings
+		if w.realBody(2) > w.average(s.BodyLong, 2) &&
+			w.color(2) == 1 &&
+			w.realBody(1) <= w.average(s.BodyDoji, 1) &&
+			w.realBodyGapUp(1, 2) &&
+			w.realBody(0) > w.average(s.BodyShort, 0) &&
+			w.color(0) == -1 &&
+			w.c(0) < w.c(2)-w.realBody(2)*w.penetration {
+			return -100
+		}
+		return 0
+	},
+}
+
+// CDLEVENINGSTAR: a long white candle, a short candle gapping up, then a black candle closing well into the first body
+var eveningStar = patternDefinition{
+	name:               "CDLEVENINGSTAR",
+	defaultPenetration: 0.3,
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.BodyShort, s.BodyLong) + 2
+	},
+	recognize: func(w *candleWindow) int64 {
+		s := w.settings
+		if w.realBody(2) > w.average(s.BodyLong, 2) &&
+			w.color(2) == 1 &&
+			w.realBody(1) <= w.average(s.BodyShort, 1) &&
+			w.realBodyGapUp(1, 2) &&
+			w.realBody(0) > w.average(s.BodyShort, 0) &&
+			w.color(0) == -1 &&
+			w.c(0) < w.c(2)-w.realBody(2)*w.penetration {
+			return -100
+		}
+		return 0
+	},
+}
+
+// CDLGAPSIDESIDEWHITE: two similar white candles side by side, gapping away from the candle before them
+var gapSideSideWhite = patternDefinition{
+	name: "CDLGAPSIDESIDEWHITE",
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.Near, s.Equal) + 2
+	},
+	recognize: func(w *candleWindow) int64 {
+		s := w.settings
+		if ((w.realBodyGapUp(1, 2) && w.realBodyGapUp(0, 2)) ||
+			(w.realBodyGapDown(1, 2) && w.realBodyGapDown(0, 2))) &&
+			w.color(1) == 1 && w.color(0) == 1 &&
+			w.realBody(0) >= w.realBody(1)-w.average(s.Near, 1) &&
+			w.realBody(0) <= w.realBody(1)+w.average(s.Near, 1) &&
+			w.o(0) >= w.o(1)-w.average(s.Equal, 1) &&
+			w.o(0) <= w.o(1)+w.average(s.Equal, 1) {
+			if w.realBodyGapUp(1, 2) {
+				return 100
+			}
+			return -100
+		}
+		return 0
+	},
+}
+
+// rememberHikkake records a hikkake found on the latest candle, awaiting confirmation within 3 bars
+func (w *candleWindow) rememberHikkake() int64 {
+	if w.h(0) < w.h(1) {
+		w.patternResult = 100
+	} else {
+		w.patternResult = -100
+	}
+	w.patternBarIndex = w.barIndex - 1
+	w.patternHigh = w.h(1)
+	w.patternLow = w.l(1)
+	return w.patternResult
+}
+
+// confirmHikkake returns +200 or -200 if the latest candle closes beyond the inside bar of a hikkake
+// found no more than 3 bars ago
+func (w *candleWindow) confirmHikkake() int64 {
+	if w.barIndex-1 <= w.patternBarIndex+3 &&
+		((w.patternResult > 0 && w.c(0) > w.patternHigh) ||
+			(w.patternResult < 0 && w.c(0) < w.patternLow)) {
+		w.patternBarIndex = 0
+		if w.patternResult > 0 {
+			return w.patternResult + 100
+		}
+		return w.patternResult - 100
+	}
+	return 0
+}
+
+// CDLHIKKAKE: an inside bar, then a bar breaking out of it on one side, a false breakout which is
+// confirmed when a close beyond the other side of the inside bar follows within 3 bars
+var hikkake = patternDefinition{
+	name: "CDLHIKKAKE",
+	lookback: func(s *CandleSettings) int {
+		return 5
+	},
+	primingBars: 3,
+	recognize: func(w *candleWindow) int64 {
+		if w.h(1) < w.h(2) && w.l(1) > w.l(2) &&
+			((w.h(0) < w.h(1) && w.l(0) < w.l(1)) ||
+				(w.h(0) > w.h(1) && w.l(0) > w.l(1))) {
+			return w.rememberHikkake()
+		}
+		return w.confirmHikkake()
+	},
+}
+
+// CDLIDENTICAL3CROWS: three black candles, each opening at the prior close and closing lower
+var identicalThreeCrows = patternDefinition{
+	name: "CDLIDENTICAL3CROWS",
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.ShadowVeryShort, s.Equal) + 2
+	},
+	recognize: func(w *candleWindow) int64 {
+		s := w.settings
+		if w.color(2) == -1 && w.color(1) == -1 && w.color(0) == -1 &&
+			w.lowerShadow(2) < w.average(s.ShadowVeryShort, 2) &&
+			w.lowerShadow(1) < w.average(s.ShadowVeryShort, 1) &&
+			w.lowerShadow(0) < w.average(s.ShadowVeryShort, 0) &&
+			w.c(2) > w.c(1) && w.c(1) > w.c(0) &&
+			w.o(1) <= w.c(2)+w.average(s.Equal, 2) &&
+			w.o(1) >= w.c(2)-w.average(s.Equal, 2) &&
+			w.o(0) <= w.c(1)+w.average(s.Equal, 1) &&
+			w.o(0) >= w.c(1)-w.average(s.Equal, 1) {
+			return -100
+		}
+		return 0
+	},
+}
+
+// CDLMORNINGDOJISTAR: a long black candle, a doji gapping down, then a white candle closing well into the first body
+var morningDojiStar = patternDefinition{
+	name:               "CDLMORNINGDOJISTAR",
+	defaultPenetration: 0.3,
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.BodyDoji, s.BodyLong, s.BodyShort) + 2
+	},
+	recognize: func(w *candleWindow) int64 {
+		s := w.settings
+		if w.realBody(2) > w.average(s.BodyLong, 2) &&
+			w.color(2) == -1 &&
+			w.realBody(1) <= w.average(s.BodyDoji, 1) &&
+			w.realBodyGapDown(1, 2) &&
+			w.realBody(0) > w.average(s.BodyShort, 0) &&
+			w.color(0) == 1 &&
+			w.c(0) > w.c(2)+w.realBody(2)*w.penetration {
+			return 100
+		}
+		return 0
+	},
+}
+
+// CDLMORNINGSTAR: a long black candle, a short candle gapping down, then a white candle closing well into the first body
+var morningStar = patternDefinition{
+	name:               "CDLMORNINGSTAR",
+	defaultPenetration: 0.3,
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.BodyShort, s.BodyLong) + 2
+	},
+	recognize: func(w *candleWindow) int64 {
+		s := w.settings
+		if w.realBody(2) > w.average(s.BodyLong, 2) &&
+			w.color(2) == -1 &&
+			w.realBody(1) <= w.average(s.BodyShort, 1) &&
+			w.realBodyGapDown(1, 2) &&
+			w.realBody(0) > w.average(s.BodyShort, 0) &&
+			w.color(0) == 1 &&
+			w.c(0) > w.c(2)+w.realBody(2)*w.penetration {
+			return 100
+		}
+		return 0
+	},
+}
+
+// CDLSTALLEDPATTERN: two long rising white candles, then a small white candle opening near the prior close
+var stalledPattern = patternDefinition{
+	name: "CDLSTALLEDPATTERN",
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.BodyLong, s.BodyShort, s.ShadowVeryShort, s.Near) + 2
+	},
+	recognize: func(w *candleWindow) int64 {
+		s := w.settings
+		if w.color(2) == 1 && w.color(1) == 1 && w.color(0) == 1 &&
+			w.c(0) > w.c(1) && w.c(1) > w.c(2) &&
+			w.realBody(2) > w.average(s.BodyLong, 2) &&
+			w.realBody(1) > w.average(s.BodyLong, 1) &&
+			w.upperShadow(1) < w.average(s.ShadowVeryShort, 1) &&
+			w.o(1) > w.o(2) &&
+			w.o(1) <= w.c(2)+w.average(s.Near, 2) &&
+			w.realBody(0) < w.average(s.BodyShort, 0) &&
+			w.o(0) >= w.c(1)-w.realBody(0)-w.average(s.Near, 1) {
+			return -100
+		}
+		return 0
+	},
+}
+
+// CDLSTICKSANDWICH: two black candles closing at the same price with a white candle trading above between them
+var stickSandwich = patternDefinition{
+	name: "CDLSTICKSANDWICH",
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.Equal) + 2
+	},
+	recognize: func(w *candleWindow) int64 {
+		s := w.settings
+		if w.color(2) == -1 && w.color(1) == 1 && w.color(0) == -1 &&
+			w.l(1) > w.c(2) &&
+			w.c(0) <= w.c(2)+w.average(s.Equal, 2) &&
+			w.c(0) >= w.c(2)-w.average(s.Equal, 2) {
+			return 100
+		}
+		return 0
+	},
+}
+
+// CDLTASUKIGAP: two candles gapping away from the candle before them, the second of the opposite color
+// and not closing the gap
+var tasukiGap = patternDefinition{
+	name: "CDLTASUKIGAP",
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.Near) + 2
+	},
+	recognize: func(w *candleWindow) int64 {
+		s := w.settings
+		var similarBodies bool = math.Abs(w.realBody(1)-w.realBody(0)) < w.average(s.Near, 1)
+		if (w.realBodyGapUp(1, 2) &&
+			w.color(1) == 1 && w.color(0) == -1 &&
+			w.o(0) < w.c(1) && w.o(0) > w.o(1) &&
+			w.c(0) < w.o(1) && w.c(0) > w.bodyTop(2) &&
+			similarBodies) ||
+			(w.realBodyGapDown(1, 2) &&
+				w.color(1) == -1 && w.color(0) == 1 &&
+				w.o(0) < w.o(1) && w.o(0) > w.c(1) &&
+				w.c(0) > w.o(1) && w.c(0) < w.bodyBottom(2) &&
+				similarBodies) {
+			return w.color(1) * 100
+		}
+		return 0
+	},
+}
+
+// CDLTRISTAR: three dojis, the middle one gapping away from the others
+var tristar = patternDefinition{
+	name: "CDLTRISTAR",
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.BodyDoji) + 2
+	},
+	recognize: func(w *candleWindow) int64 {
+		// all three candles are measured against the average before the first, as in TA-Lib
+		var dojiBody float64 = w.average(w.settings.BodyDoji, 2)
+		if w.realBody(2) <= dojiBody && w.realBody(1) <= dojiBody && w.realBody(0) <= dojiBody {
+			if w.realBodyGapUp(1, 2) && w.bodyTop(0) < w.bodyTop(1) {
+				return -100
+			}
+			if w.realBodyGapDown(1, 2) && w.bodyBottom(0) > w.bodyBottom(1) {
+				return 100
+			}
+		}
+		return 0
+	},
+}
+
+// CDLUNIQUE3RIVER: a long black candle, a black harami with a lower low, then a small white candle
+// opening above that low
+var uniqueThreeRiver = patternDefinition{
+	name: "CDLUNIQUE3RIVER",
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.BodyShort, s.BodyLong) + 2
+	},
+	recognize: func(w *candleWindow) int64 {
+		s := w.settings
+		if w.realBody(2) > w.average(s.BodyLong, 2) &&
+			w.color(2) == -1 &&
+			w.color(1) == -1 &&
+			w.c(1) > w.c(2) && w.o(1) <= w.o(2) && w.l(1) < w.l(2) &&
+			w.realBody(0) < w.average(s.BodyShort, 0) &&
+			w.color(0) == 1 &&
+			w.o(0) > w.l(1) {
+			return 100
+		}
+		return 0
+	},
+}
+
+// CDLUPSIDEGAP2CROWS: a long white candle, a small black candle gapping up, then a black candle
+// engulfing it but closing above the first close
+var upsideGapTwoCrows = patternDefinition{
+	name: "CDLUPSIDEGAP2CROWS",
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.BodyShort, s.BodyLong) + 2
+	},
+	recognize: func(w *candleWindow) int64 {
+		s := w.settings
+		if w.color(2) == 1 &&
+			w.realBody(2) > w.average(s.BodyLong, 2) &&
+			w.color(1) == -1 &&
+			w.realBody(1) <= w.average(s.BodyShort, 1) &&
+			w.realBodyGapUp(1, 2) &&
+			w.color(0) == -1 &&
+			w.o(0) > w.o(1) && w.c(0) < w.c(1) &&
+			w.c(0) > w.c(2) {
+			return -100
+		}
+		return 0
+	},
+}
+
+// CDLXSIDEGAP3METHODS: two candles of the same color with a gap between them, then a candle of the
+// opposite color closing the gap
+var xSideGapThreeMethods = patternDefinition{
+	name: "CDLXSIDEGAP3METHODS",
+	lookback: func(s *CandleSettings) int {
+		return 2
+	},
+	recognize: func(w *candleWindow) int64 {
+		if w.color(2) == w.color(1) &&
+			w.color(1) == -w.color(0) &&
+			w.o(0) < w.bodyTop(1) && w.o(0) > w.bodyBottom(1) &&
+			w.c(0) < w.bodyTop(2) && w.c(0) > w.bodyBottom(2) &&
+			((w.color(2) == 1 && w.realBodyGapUp(1, 2)) ||
+				(w.color(2) == -1 && w.realBodyGapDown(1, 2))) {
+			return w.color(2) * 100
+		}
+		return 0
+	},
+}
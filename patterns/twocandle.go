@@ -0,0 +1,368 @@
+package patterns
+
+// CDLCOUNTERATTACK: two long candles of opposite colors closing at the same price
+var counterAttack = patternDefinition{
+	name: "CDLCOUNTERATTACK",
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.Equal, s.BodyLong) + 1
+	},
+	recognize: func(w *candleWindow) int64 {
+		s := w.settings
+		if w.color(1) == -w.color(0) &&
+			w.realBody(1) > w.average(s.BodyLong, 1) &&
+			w.realBody(0) > w.average(s.BodyLong, 0) &&
+			w.c(0) <= w.c(1)+w.average(s.Equal, 1) &&
+			w.c(0) >= w.c(1)-w.average(s.Equal, 1) {
+			return w.color(0) * 100
+		}
+		return 0
+	},
+}
+
+// CDLDARKCLOUDCOVER: a long white candle, then a black candle opening above its high and closing
+// well into its body
+var darkCloudCover = patternDefinition{
+	name:               "CDLDARKCLOUDCOVER",
+	defaultPenetration: 0.5,
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.BodyLong) + 1
+	},
+	recognize: func(w *candleWindow) int64 {
+		if w.color(1) == 1 &&
+			w.realBody(1) > w.average(w.settings.BodyLong, 1) &&
+			w.color(0) == -1 &&
+			w.o(0) > w.h(1) &&
+			w.c(0) > w.o(1) &&
+			w.c(0) < w.c(1)-w.realBody(1)*w.penetration {
+			return -100
+		}
+		return 0
+	},
+}
+
+// CDLDOJISTAR: a long candle, then a doji gapping away in the direction of the candle
+var dojiStar = patternDefinition{
+	name: "CDLDOJISTAR",
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.BodyDoji, s.BodyLong) + 1
+	},
+	recognize: func(w *candleWindow) int64 {
+		s := w.settings
+		if w.realBody(1) > w.average(s.BodyLong, 1) &&
+			w.realBody(0) <= w.average(s.BodyDoji, 0) &&
+			((w.color(1) == 1 && w.realBodyGapUp(0, 1)) ||
+				(w.color(1) == -1 && w.realBodyGapDown(0, 1))) {
+			return -w.color(1) * 100
+		}
+		return 0
+	},
+}
+
+// CDLENGULFING: a candle whose real body engulfs the opposite colored real body before it
+var engulfing = patternDefinition{
+	name: "CDLENGULFING",
+	lookback: func(s *CandleSettings) int {
+		return 2
+	},
+	recognize: func(w *candleWindow) int64 {
+		if (w.color(0) == 1 && w.color(1) == -1 && w.c(0) > w.o(1) && w.o(0) < w.c(1)) ||
+			(w.color(0) == -1 && w.color(1) == 1 && w.o(0) > w.c(1) && w.c(0) < w.o(1)) {
+			return w.color(0) * 100
+		}
+		return 0
+	},
+}
+
+// CDLHAMMER: a small body with a long lower shadow and no upper shadow, near the low of the prior candle
+var hammer = patternDefinition{
+	name: "CDLHAMMER",
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.BodyShort, s.ShadowLong, s.ShadowVeryShort, s.Near) + 1
+	},
+	recognize: func(w *candleWindow) int64 {
+		s := w.settings
+		if w.realBody(0) < w.average(s.BodyShort, 0) &&
+			w.lowerShadow(0) > w.average(s.ShadowLong, 0) &&
+			w.upperShadow(0) < w.average(s.ShadowVeryShort, 0) &&
+			w.bodyBottom(0) <= w.l(1)+w.average(s.Near, 1) {
+			return 100
+		}
+		return 0
+	},
+}
+
+// CDLHANGINGMAN: a small body with a long lower shadow and no upper shadow, near the high of the prior candle
+var hangingMan = patternDefinition{
+	name: "CDLHANGINGMAN",
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.BodyShort, s.ShadowLong, s.ShadowVeryShort, s.Near) + 1
+	},
+	recognize: func(w *candleWindow) int64 {
+		s := w.settings
+		if w.realBody(0) < w.average(s.BodyShort, 0) &&
+			w.lowerShadow(0) > w.average(s.ShadowLong, 0) &&
+			w.upperShadow(0) < w.average(s.ShadowVeryShort, 0) &&
+			w.bodyBottom(0) >= w.h(1)-w.average(s.Near, 1) {
+			return -100
+		}
+		return 0
+	},
+}
+
+// CDLHARAMI: a long candle, then a short candle whose real body is within the long real body
+var harami = patternDefinition{
+	name: "CDLHARAMI",
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.BodyShort, s.BodyLong) + 1
+	},
+	recognize: func(w *candleWindow) int64 {
+		s := w.settings
+		if w.realBody(1) > w.average(s.BodyLong, 1) &&
+			w.realBody(0) <= w.average(s.BodyShort, 0) &&
+			w.bodyTop(0) < w.bodyTop(1) &&
+			w.bodyBottom(0) > w.bodyBottom(1) {
+			return -w.color(1) * 100
+		}
+		return 0
+	},
+}
+
+// CDLHARAMICROSS: a long candle, then a doji within the long real body
+var haramiCross = patternDefinition{
+	name: "CDLHARAMICROSS",
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.BodyDoji, s.BodyLong) + 1
+	},
+	recognize: func(w *candleWindow) int64 {
+		s := w.settings
+		if w.realBody(1) > w.average(s.BodyLong, 1) &&
+			w.realBody(0) <= w.average(s.BodyDoji, 0) &&
+			w.bodyTop(0) < w.bodyTop(1) &&
+			w.bodyBottom(0) > w.bodyBottom(1) {
+			return -w.color(1) * 100
+		}
+		return 0
+	},
+}
+
+// CDLHOMINGPIGEON: a long black candle, then a short black candle within its real body
+var homingPigeon = patternDefinition{
+	name: "CDLHOMINGPIGEON",
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.BodyShort, s.BodyLong) + 1
+	},
+	recognize: func(w *candleWindow) int64 {
+		s := w.settings
+		if w.color(1) == -1 &&
+			w.color(0) == -1 &&
+			w.realBody(1) > w.average(s.BodyLong, 1) &&
+			w.realBody(0) <= w.average(s.BodyShort, 0) &&
+			w.o(0) < w.o(1) &&
+			w.c(0) > w.c(1) {
+			return 100
+		}
+		return 0
+	},
+}
+
+// CDLINNECK: a long black candle, then a white candle opening below its low and closing just into its body
+var inNeck = patternDefinition{
+	name: "CDLINNECK",
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.Equal, s.BodyLong) + 1
+	},
+	recognize: func(w *candleWindow) int64 {
+		s := w.settings
+		if w.color(1) == -1 &&
+			w.realBody(1) > w.average(s.BodyLong, 1) &&
+			w.color(0) == 1 &&
+			w.o(0) < w.l(1) &&
+			w.c(0) <= w.c(1)+w.average(s.Equal, 1) &&
+			w.c(0) >= w.c(1) {
+			return -100
+		}
+		return 0
+	},
+}
+
+// CDLINVERTEDHAMMER: a small body with a long upper shadow and no lower shadow, gapping down
+var invertedHammer = patternDefinition{
+	name: "CDLINVERTEDHAMMER",
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.BodyShort, s.ShadowLong, s.ShadowVeryShort) + 1
+	},
+	recognize: func(w *candleWindow) int64 {
+		s := w.settings
+		if w.realBody(0) < w.average(s.BodyShort, 0) &&
+			w.upperShadow(0) > w.average(s.ShadowLong, 0) &&
+			w.lowerShadow(0) < w.average(s.ShadowVeryShort, 0) &&
+			w.realBodyGapDown(0, 1) {
+			return 100
+		}
+		return 0
+	},
+}
+
+// kicks returns true if both candles are marubozu of opposite colors with a gap between them
+func kicks(w *candleWindow) bool {
+	s := w.settings
+	return w.color(1) == -w.color(0) &&
+		w.realBody(1) > w.average(s.BodyLong, 1) &&
+		w.upperShadow(1) < w.average(s.ShadowVeryShort, 1) &&
+		w.lowerShadow(1) < w.average(s.ShadowVeryShort, 1) &&
+		w.realBody(0) > w.average(s.BodyLong, 0) &&
+		w.upperShadow(0) < w.average(s.ShadowVeryShort, 0) &&
+		w.lowerShadow(0) < w.average(s.ShadowVeryShort, 0) &&
+		((w.color(1) == -1 && w.candleGapUp(0, 1)) ||
+			(w.color(1) == 1 && w.candleGapDown(0, 1)))
+}
+
+// CDLKICKING: a marubozu, then a marubozu of the opposite color gapping away from it
+var kicking = patternDefinition{
+	name: "CDLKICKING",
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.ShadowVeryShort, s.BodyLong) + 1
+	},
+	recognize: func(w *candleWindow) int64 {
+		if kicks(w) {
+			return w.color(0) * 100
+		}
+		return 0
+	},
+}
+
+// CDLKICKINGBYLENGTH: a kicking pattern, bullish or bearish by the color of the longer marubozu
+var kickingByLength = patternDefinition{
+	name: "CDLKICKINGBYLENGTH",
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.ShadowVeryShort, s.BodyLong) + 1
+	},
+	recognize: func(w *candleWindow) int64 {
+		if kicks(w) {
+			if w.realBody(0) > w.realBody(1) {
+				return w.color(0) * 100
+			}
+			return w.color(1) * 100
+		}
+		return 0
+	},
+}
+
+// CDLMATCHINGLOW: two black candles closing at the same price
+var matchingLow = patternDefinition{
+	name: "CDLMATCHINGLOW",
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.Equal) + 1
+	},
+	recognize: func(w *candleWindow) int64 {
+		s := w.settings
+		if w.color(1) == -1 &&
+			w.color(0) == -1 &&
+			w.c(0) <= w.c(1)+w.average(s.Equal, 1) &&
+			w.c(0) >= w.c(1)-w.average(s.Equal, 1) {
+			return 100
+		}
+		return 0
+	},
+}
+
+// CDLONNECK: a long black candle, then a white candle opening below its low and closing at its low
+var onNeck = patternDefinition{
+	name: "CDLONNECK",
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.Equal, s.BodyLong) + 1
+	},
+	recognize: func(w *candleWindow) int64 {
+		s := w.settings
+		if w.color(1) == -1 &&
+			w.realBody(1) > w.average(s.BodyLong, 1) &&
+			w.color(0) == 1 &&
+			w.o(0) < w.l(1) &&
+			w.c(0) <= w.l(1)+w.average(s.Equal, 1) &&
+			w.c(0) >= w.l(1)-w.average(s.Equal, 1) {
+			return -100
+		}
+		return 0
+	},
+}
+
+// CDLPIERCING: a long black candle, then a long white candle opening below its low and closing above
+// the middle of its body
+var piercing = patternDefinition{
+	name: "CDLPIERCING",
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.BodyLong) + 1
+	},
+	recognize: func(w *candleWindow) int64 {
+		s := w.settings
+		if w.color(1) == -1 &&
+			w.realBody(1) > w.average(s.BodyLong, 1) &&
+			w.color(0) == 1 &&
+			w.realBody(0) > w.average(s.BodyLong, 0) &&
+			w.o(0) < w.l(1) &&
+			w.c(0) < w.o(1) &&
+			w.c(0) > w.c(1)+w.realBody(1)*0.5 {
+			return 100
+		}
+		return 0
+	},
+}
+
+// CDLSEPARATINGLINES: two candles of opposite colors opening at the same price, the second a belt hold
+var separatingLines = patternDefinition{
+	name: "CDLSEPARATINGLINES",
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.ShadowVeryShort, s.BodyLong, s.Equal) + 1
+	},
+	recognize: func(w *candleWindow) int64 {
+		s := w.settings
+		if w.color(1) == -w.color(0) &&
+			w.o(0) <= w.o(1)+w.average(s.Equal, 1) &&
+			w.o(0) >= w.o(1)-w.average(s.Equal, 1) &&
+			w.realBody(0) > w.average(s.BodyLong, 0) &&
+			((w.color(0) == 1 && w.lowerShadow(0) < w.average(s.ShadowVeryShort, 0)) ||
+				(w.color(0) == -1 && w.upperShadow(0) < w.average(s.ShadowVeryShort, 0))) {
+			return w.color(0) * 100
+		}
+		return 0
+	},
+}
+
+// CDLSHOOTINGSTAR: a small body with a long upper shadow and no lower shadow, gapping up
+var shootingStar = patternDefinition{
+	name: "CDLSHOOTINGSTAR",
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.BodyShort, s.ShadowLong, s.ShadowVeryShort) + 1
+	},
+	recognize: func(w *candleWindow) int64 {
+		s := w.settings
+		if w.realBody(0) < w.average(s.BodyShort, 0) &&
+			w.upperShadow(0) > w.average(s.ShadowLong, 0) &&
+			w.lowerShadow(0) < w.average(s.ShadowVeryShort, 0) &&
+			w.realBodyGapUp(0, 1) {
+			return -100
+		}
+		return 0
+	},
+}
+
+// CDLTHRUSTING: a long black candle, then a white candle opening below its low and closing into,
+// but below the middle of, its body
+var thrusting = patternDefinition{
+	name: "CDLTHRUSTING",
+	lookback: func(s *CandleSettings) int {
+		return maxAvgPeriod(s.Equal, s.BodyLong) + 1
+	},
+	recognize: func(w *candleWindow) int64 {
+		s := w.settings
+		if w.color(1) == -1 &&
+			w.realBody(1) > w.average(s.BodyLong, 1) &&
+			w.color(0) == 1 &&
+			w.o(0) < w.l(1) &&
+			w.c(0) > w.c(1)+w.average(s.Equal, 1) &&
+			w.c(0) <= w.c(1)+w.realBody(1)*0.5 {
+			return -100
+		}
+		return 0
+	},
+}
@@ -0,0 +1,269 @@
+package pipeline
+
+import (
+	"github.com/jaybutera/gotrade"
+	"github.com/jaybutera/gotrade/indicators"
+)
+
+// DefaultRegistry creates a registry with factories for all the indicators of the indicators package,
+// the parameter defaults are those of the indicators' NewDefault creation functions
+func DefaultRegistry() *Registry {
+	r := NewRegistry()
+	for _, factory := range builtinFactories() {
+		if err := r.Register(factory); err != nil {
+			panic(err)
+		}
+	}
+	return r
+}
+
+func intParameter(name string, defaultValue int) Parameter {
+	return Parameter{Name: name, Type: IntParameter, Default: defaultValue}
+}
+
+func floatParameter(name string, defaultValue float64) Parameter {
+	return Parameter{Name: name, Type: FloatParameter, Default: defaultValue}
+}
+
+func timePeriodParameter(defaultValue int) Parameter {
+	return intParameter("timePeriod", defaultValue)
+}
+
+var selectDataParameter = Parameter{Name: "selectData", Type: PriceParameter, Default: "close"}
+
+// created drops the type of an indicator returned by its creation function, a failed creation
+// returns a nil interface rather than a nil pointer
+func created(indicator interface{}, err error) (interface{}, error) {
+	if err != nil {
+		return nil, err
+	}
+	return indicator, nil
+}
+
+func builtinFactories() []*IndicatorFactory {
+	return []*IndicatorFactory{
+		// price transforms and volume
+		{Name: "adl", Create: func(a Arguments) (interface{}, error) { return created(indicators.NewAdl()) }},
+		{Name: "avgprice", Create: func(a Arguments) (interface{}, error) { return created(indicators.NewAvgPrice()) }},
+		{Name: "medprice", Create: func(a Arguments) (interface{}, error) { return created(indicators.NewMedPrice()) }},
+		{Name: "obv", Create: func(a Arguments) (interface{}, error) { return created(indicators.NewObv()) }},
+		{Name: "truerange", Create: func(a Arguments) (interface{}, error) { return created(indicators.NewTrueRange()) }},
+		{Name: "typprice", Create: func(a Arguments) (interface{}, error) { return created(indicators.NewTypPrice()) }},
+
+		// indicators of the high, low and close of each bar
+		{Name: "adx", Parameters: []Parameter{timePeriodParameter(14)},
+			Create: func(a Arguments) (interface{}, error) { return created(indicators.NewAdx(a.Int("timePeriod"))) }},
+		{Name: "adxr", Parameters: []Parameter{timePeriodParameter(14)},
+			Create: func(a Arguments) (interface{}, error) { return created(indicators.NewAdxr(a.Int("timePeriod"))) }},
+		{Name: "aroon", Parameters: []Parameter{timePeriodParameter(14)},
+			Outputs: map[string]OutputFunc{
+				"up":   func(ind interface{}) gotrade.FloatStreamSubscriber { return ind.(*indicators.Aroon).AroonUpStream() },
+				"down": func(ind interface{}) gotrade.FloatStreamSubscriber { return ind.(*indicators.Aroon).AroonDownStream() },
+			},
+			Create: func(a Arguments) (interface{}, error) { return created(indicators.NewAroon(a.Int("timePeriod"))) }},
+		{Name: "aroonosc", Parameters: []Parameter{timePeriodParameter(14)},
+			Create: func(a Arguments) (interface{}, error) { return created(indicators.NewAroonOsc(a.Int("timePeriod"))) }},
+		{Name: "atr", Parameters: []Parameter{timePeriodParameter(14)},
+			Create: func(a Arguments) (interface{}, error) { return created(indicators.NewAtr(a.Int("timePeriod"))) }},
+		{Name: "cci", Parameters: []Parameter{timePeriodParameter(14)},
+			Create: func(a Arguments) (interface{}, error) { return created(indicators.NewCci(a.Int("timePeriod"))) }},
+		{Name: "chaikinosc", Parameters: []Parameter{intParameter("fastTimePeriod", 3), intParameter("slowTimePeriod", 10)},
+			Create: func(a Arguments) (interface{}, error) {
+				return created(indicators.NewChaikinOsc(a.Int("fastTimePeriod"), a.Int("slowTimePeriod")))
+			}},
+		{Name: "dx", Parameters: []Parameter{timePeriodParameter(14)},
+			Create: func(a Arguments) (interface{}, error) { return created(indicators.NewDx(a.Int("timePeriod"))) }},
+		{Name: "mfi", Parameters: []Parameter{timePeriodParameter(25)},
+			Create: func(a Arguments) (interface{}, error) { return created(indicators.NewMfi(a.Int("timePeriod"))) }},
+		{Name: "minusdi", Parameters: []Parameter{timePeriodParameter(14)},
+			Create: func(a Arguments) (interface{}, error) { return created(indicators.NewMinusDi(a.Int("timePeriod"))) }},
+		{Name: "minusdm", Parameters: []Parameter{timePeriodParameter(14)},
+			Create: func(a Arguments) (interface{}, error) { return created(indicators.NewMinusDm(a.Int("timePeriod"))) }},
+		{Name: "plusdi", Parameters: []Parameter{timePeriodParameter(14)},
+			Create: func(a Arguments) (interface{}, error) { return created(indicators.NewPlusDi(a.Int("timePeriod"))) }},
+		{Name: "plusdm", Parameters: []Parameter{timePeriodParameter(14)},
+			Create: func(a Arguments) (interface{}, error) { return created(indicators.NewPlusDm(a.Int("timePeriod"))) }},
+		{Name: "sar", Parameters: []Parameter{floatParameter("accelerationFactor", 0.02), floatParameter("accelerationFactorMax", 0.2)},
+			Create: func(a Arguments) (interface{}, error) {
+				return created(indicators.NewSar(a.Float("accelerationFactor"), a.Float("accelerationFactorMax")))
+			}},
+		{Name: "stochosc", Parameters: []Parameter{intParameter("fastKTimePeriod", 5), intParameter("slowKTimePeriod", 3), intParameter("slowDTimePeriod", 3)},
+			Outputs: map[string]OutputFunc{
+				"k": func(ind interface{}) gotrade.FloatStreamSubscriber { return ind.(*indicators.StochOsc).KStream() },
+				"d": func(ind interface{}) gotrade.FloatStreamSubscriber { return ind.(*indicators.StochOsc).DStream() },
+			},
+			Create: func(a Arguments) (interface{}, error) {
+				return created(indicators.NewStochOsc(a.Int("fastKTimePeriod"), a.Int("slowKTimePeriod"), a.Int("slowDTimePeriod")))
+			}},
+		{Name: "stochrsi", Parameters: []Parameter{timePeriodParameter(14), intParameter("fastKTimePeriod", 5), intParameter("fastDTimePeriod", 3)},
+			Outputs: map[string]OutputFunc{
+				"k": func(ind interface{}) gotrade.FloatStreamSubscriber { return ind.(*indicators.StochRsi).KStream() },
+				"d": func(ind interface{}) gotrade.FloatStreamSubscriber { return ind.(*indicators.StochRsi).DStream() },
+			},
+			Create: func(a Arguments) (interface{}, error) {
+				return created(indicators.NewStochRsi(a.Int("timePeriod"), a.Int("fastKTimePeriod"), a.Int("fastDTimePeriod")))
+			}},
+		{Name: "willr", Parameters: []Parameter{timePeriodParameter(14)},
+			Create: func(a Arguments) (interface{}, error) { return created(indicators.NewWillR(a.Int("timePeriod"))) }},
+
+		// indicators of a selected price, or of the results of another indicator
+		{Name: "bbands", Parameters: []Parameter{timePeriodParameter(5), selectDataParameter},
+			Outputs: map[string]OutputFunc{
+				"upper": func(ind interface{}) gotrade.FloatStreamSubscriber {
+					return ind.(*indicators.BollingerBands).UpperBandStream()
+				},
+				"middle": func(ind interface{}) gotrade.FloatStreamSubscriber {
+					return ind.(*indicators.BollingerBands).MiddleBandStream()
+				},
+				"lower": func(ind interface{}) gotrade.FloatStreamSubscriber {
+					return ind.(*indicators.BollingerBands).LowerBandStream()
+				},
+			},
+			Create: func(a Arguments) (interface{}, error) {
+				return created(indicators.NewBollingerBands(a.Int("timePeriod"), a.Price("selectData")))
+			}},
+		{Name: "dema", Parameters: []Parameter{timePeriodParameter(30), selectDataParameter},
+			Create: func(a Arguments) (interface{}, error) {
+				return created(indicators.NewDema(a.Int("timePeriod"), a.Price("selectData")))
+			}},
+		{Name: "ema", Parameters: []Parameter{timePeriodParameter(25), selectDataParameter},
+			Create: func(a Arguments) (interface{}, error) {
+				return created(indicators.NewEma(a.Int("timePeriod"), a.Price("selectData")))
+			}},
+		{Name: "hhv", Parameters: []Parameter{timePeriodParameter(25), selectDataParameter},
+			Create: func(a Arguments) (interface{}, error) {
+				return created(indicators.NewHhv(a.Int("timePeriod"), a.Price("selectData")))
+			}},
+		{Name: "hhvbars", Parameters: []Parameter{timePeriodParameter(25), selectDataParameter},
+			Create: func(a Arguments) (interface{}, error) {
+				return created(indicators.NewHhvBars(a.Int("timePeriod"), a.Price("selectData")))
+			}},
+		{Name: "kama", Parameters: []Parameter{timePeriodParameter(25), selectDataParameter},
+			Create: func(a Arguments) (interface{}, error) {
+				return created(indicators.NewKama(a.Int("timePeriod"), a.Price("selectData")))
+			}},
+		{Name: "linreg", Parameters: []Parameter{timePeriodParameter(14), selectDataParameter},
+			Create: func(a Arguments) (interface{}, error) {
+				return created(indicators.NewLinReg(a.Int("timePeriod"), a.Price("selectData")))
+			}},
+		{Name: "linregang", Parameters: []Parameter{timePeriodParameter(14), selectDataParameter},
+			Create: func(a Arguments) (interface{}, error) {
+				return created(indicators.NewLinRegAng(a.Int("timePeriod"), a.Price("selectData")))
+			}},
+		{Name: "linregint", Parameters: []Parameter{timePeriodParameter(14), selectDataParameter},
+			Create: func(a Arguments) (interface{}, error) {
+				return created(indicators.NewLinRegInt(a.Int("timePeriod"), a.Price("selectData")))
+			}},
+		{Name: "linregslp", Parameters: []Parameter{timePeriodParameter(14), selectDataParameter},
+			Create: func(a Arguments) (interface{}, error) {
+				return created(indicators.NewLinRegSlp(a.Int("timePeriod"), a.Price("selectData")))
+			}},
+		{Name: "llv", Parameters: []Parameter{timePeriodParameter(25), selectDataParameter},
+			Create: func(a Arguments) (interface{}, error) {
+				return created(indicators.NewLlv(a.Int("timePeriod"), a.Price("selectData")))
+			}},
+		{Name: "llvbars", Parameters: []Parameter{timePeriodParameter(25), selectDataParameter},
+			Create: func(a Arguments) (interface{}, error) {
+				return created(indicators.NewLlvBars(a.Int("timePeriod"), a.Price("selectData")))
+			}},
+		{Name: "macd", Parameters: []Parameter{intParameter("fastTimePeriod", 12), intParameter("slowTimePeriod", 26), intParameter("signalTimePeriod", 9), selectDataParameter},
+			Outputs: map[string]OutputFunc{
+				"macd":      func(ind interface{}) gotrade.FloatStreamSubscriber { return ind.(*indicators.Macd).MacdStream() },
+				"signal":    func(ind interface{}) gotrade.FloatStreamSubscriber { return ind.(*indicators.Macd).SignalStream() },
+				"histogram": func(ind interface{}) gotrade.FloatStreamSubscriber { return ind.(*indicators.Macd).HistogramStream() },
+			},
+			Create: func(a Arguments) (interface{}, error) {
+				return created(indicators.NewMacd(a.Int("fastTimePeriod"), a.Int("slowTimePeriod"), a.Int("signalTimePeriod"), a.Price("selectData")))
+			}},
+		{Name: "mama", Parameters: []Parameter{floatParameter("fastLimit", 0.5), floatParameter("slowLimit", 0.05), selectDataParameter},
+			Outputs: map[string]OutputFunc{
+				"mama": func(ind interface{}) gotrade.FloatStreamSubscriber { return ind.(*indicators.Mama).MamaStream() },
+				"fama": func(ind interface{}) gotrade.FloatStreamSubscriber { return ind.(*indicators.Mama).FamaStream() },
+			},
+			Create: func(a Arguments) (interface{}, error) {
+				return created(indicators.NewMama(a.Float("fastLimit"), a.Float("slowLimit"), a.Price("selectData")))
+			}},
+		{Name: "mom", Parameters: []Parameter{timePeriodParameter(10), selectDataParameter},
+			Create: func(a Arguments) (interface{}, error) {
+				return created(indicators.NewMom(a.Int("timePeriod"), a.Price("selectData")))
+			}},
+		{Name: "roc", Parameters: []Parameter{timePeriodParameter(10), selectDataParameter},
+			Create: func(a Arguments) (interface{}, error) {
+				return created(indicators.NewRoc(a.Int("timePeriod"), a.Price("selectData")))
+			}},
+		{Name: "rocp", Parameters: []Parameter{timePeriodParameter(10), selectDataParameter},
+			Create: func(a Arguments) (interface{}, error) {
+				return created(indicators.NewRocP(a.Int("timePeriod"), a.Price("selectData")))
+			}},
+		{Name: "rocr", Parameters: []Parameter{timePeriodParameter(10), selectDataParameter},
+			Create: func(a Arguments) (interface{}, error) {
+				return created(indicators.NewRocR(a.Int("timePeriod"), a.Price("selectData")))
+			}},
+		{Name: "rocr100", Parameters: []Parameter{timePeriodParameter(10), selectDataParameter},
+			Create: func(a Arguments) (interface{}, error) {
+				return created(indicators.NewRocR100(a.Int("timePeriod"), a.Price("selectData")))
+			}},
+		{Name: "rsi", Parameters: []Parameter{timePeriodParameter(14), selectDataParameter},
+			Create: func(a Arguments) (interface{}, error) {
+				return created(indicators.NewRsi(a.Int("timePeriod"), a.Price("selectData")))
+			}},
+		{Name: "sma", Parameters: []Parameter{timePeriodParameter(10), selectDataParameter},
+			Create: func(a Arguments) (interface{}, error) {
+				return created(indicators.NewSma(a.Int("timePeriod"), a.Price("selectData")))
+			}},
+		{Name: "stddev", Parameters: []Parameter{timePeriodParameter(10), selectDataParameter},
+			Create: func(a Arguments) (interface{}, error) {
+				return created(indicators.NewStdDev(a.Int("timePeriod"), a.Price("selectData")))
+			}},
+		{Name: "tema", Parameters: []Parameter{timePeriodParameter(30), selectDataParameter},
+			Create: func(a Arguments) (interface{}, error) {
+				return created(indicators.NewTema(a.Int("timePeriod"), a.Price("selectData")))
+			}},
+		{Name: "trima", Parameters: []Parameter{timePeriodParameter(30), selectDataParameter},
+			Create: func(a Arguments) (interface{}, error) {
+				return created(indicators.NewTrima(a.Int("timePeriod"), a.Price("selectData")))
+			}},
+		{Name: "tsf", Parameters: []Parameter{timePeriodParameter(10), selectDataParameter},
+			Create: func(a Arguments) (interface{}, error) {
+				return created(indicators.NewTsf(a.Int("timePeriod"), a.Price("selectData")))
+			}},
+		{Name: "var", Parameters: []Parameter{timePeriodParameter(10), selectDataParameter},
+			Create: func(a Arguments) (interface{}, error) {
+				return created(indicators.NewVar(a.Int("timePeriod"), a.Price("selectData")))
+			}},
+		{Name: "wma", Parameters: []Parameter{timePeriodParameter(10), selectDataParameter},
+			Create: func(a Arguments) (interface{}, error) {
+				return created(indicators.NewWma(a.Int("timePeriod"), a.Price("selectData")))
+			}},
+
+		// hilbert transform cycle indicators
+		{Name: "htdcperiod", Parameters: []Parameter{selectDataParameter},
+			Create: func(a Arguments) (interface{}, error) {
+				return created(indicators.NewHtDcPeriod(a.Price("selectData")))
+			}},
+		{Name: "htdcphase", Parameters: []Parameter{selectDataParameter},
+			Create: func(a Arguments) (interface{}, error) { return created(indicators.NewHtDcPhase(a.Price("selectData"))) }},
+		{Name: "htphasor", Parameters: []Parameter{selectDataParameter},
+			Outputs: map[string]OutputFunc{
+				"inphase": func(ind interface{}) gotrade.FloatStreamSubscriber { return ind.(*indicators.HtPhasor).InPhaseStream() },
+				"quadrature": func(ind interface{}) gotrade.FloatStreamSubscriber {
+					return ind.(*indicators.HtPhasor).QuadratureStream()
+				},
+			},
+			Create: func(a Arguments) (interface{}, error) { return created(indicators.NewHtPhasor(a.Price("selectData"))) }},
+		{Name: "htsine", Parameters: []Parameter{selectDataParameter},
+			Outputs: map[string]OutputFunc{
+				"sine":     func(ind interface{}) gotrade.FloatStreamSubscriber { return ind.(*indicators.HtSine).SineStream() },
+				"leadsine": func(ind interface{}) gotrade.FloatStreamSubscriber { return ind.(*indicators.HtSine).LeadSineStream() },
+			},
+			Create: func(a Arguments) (interface{}, error) { return created(indicators.NewHtSine(a.Price("selectData"))) }},
+		{Name: "httrendline", Parameters: []Parameter{selectDataParameter},
+			Create: func(a Arguments) (interface{}, error) {
+				return created(indicators.NewHtTrendline(a.Price("selectData")))
+			}},
+		{Name: "httrendmode", Parameters: []Parameter{selectDataParameter},
+			Create: func(a Arguments) (interface{}, error) {
+				return created(indicators.NewHtTrendMode(a.Price("selectData")))
+			}},
+	}
+}
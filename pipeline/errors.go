@@ -0,0 +1,43 @@
+package pipeline
+
+import (
+	"strings"
+)
+
+// A ValidationError is a problem with one field of a pipeline document or of an indicator's parameters
+type ValidationError struct {
+	// the path of the field, e.g. indicators[2].params.timePeriod, empty for the document as a whole
+	Path string
+	Err  error
+}
+
+func (e *ValidationError) Error() string {
+	if e.Path == "" {
+		return e.Err.Error()
+	}
+	return e.Path + ": " + e.Err.Error()
+}
+
+// ValidationErrors are all the problems found whilst validating, in document order
+type ValidationErrors []*ValidationError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// within returns the errors with their paths nested in the parent path
+func (e ValidationErrors) within(parent string) ValidationErrors {
+	nested := make(ValidationErrors, len(e))
+	for i, err := range e {
+		path := parent
+		if err.Path != "" {
+			path = parent + "." + err.Path
+		}
+		nested[i] = &ValidationError{Path: path, Err: err.Err}
+	}
+	return nested
+}
@@ -0,0 +1,229 @@
+package pipeline
+
+import (
+	"errors"
+	"fmt"
+	"github.com/jaybutera/gotrade"
+	"strings"
+)
+
+// A Pipeline holds the price streams and indicators built from a spec, by name
+type Pipeline struct {
+	Streams    map[string]*gotrade.DOHLCVStream
+	Indicators map[string]interface{}
+}
+
+// LoadJSON builds a pipeline from a JSON document
+func (r *Registry) LoadJSON(data []byte) (pipeline *Pipeline, err error) {
+	spec, err := ParseJSONSpec(data)
+	if err != nil {
+		return nil, err
+	}
+	return r.Build(spec)
+}
+
+// LoadYAML builds a pipeline from a YAML document
+func (r *Registry) LoadYAML(data []byte) (pipeline *Pipeline, err error) {
+	spec, err := ParseYAMLSpec(data)
+	if err != nil {
+		return nil, err
+	}
+	return r.Build(spec)
+}
+
+// Build creates the streams and indicators of a spec and attaches each indicator to its source.
+// The spec is validated as a whole, all the problems found are returned as ValidationErrors.
+func (r *Registry) Build(spec *Spec) (pipeline *Pipeline, err error) {
+	b := builder{
+		registry:  r,
+		pipeline:  &Pipeline{Streams: make(map[string]*gotrade.DOHLCVStream), Indicators: make(map[string]interface{})},
+		factories: make(map[string]*IndicatorFactory),
+		declared:  make(map[string]bool),
+	}
+
+	for i, streamSpec := range spec.Streams {
+		b.buildStream(fmt.Sprintf("streams[%d]", i), streamSpec)
+	}
+
+	for i, indicatorSpec := range spec.Indicators {
+		b.buildIndicator(fmt.Sprintf("indicators[%d]", i), indicatorSpec)
+	}
+
+	if len(b.errs) > 0 {
+		return nil, b.errs
+	}
+	return b.pipeline, nil
+}
+
+type builder struct {
+	registry *Registry
+	pipeline *Pipeline
+	// the factories of the indicators built
+	factories map[string]*IndicatorFactory
+	// the names of all the streams and indicators so far, including those that failed to build
+	declared map[string]bool
+	errs     ValidationErrors
+}
+
+func (b *builder) fail(path string, err error) {
+	b.errs = append(b.errs, &ValidationError{Path: path, Err: err})
+}
+
+func (b *builder) declare(path string, name string) bool {
+	if name == "" {
+		b.fail(path+".name", errors.New("name is required"))
+		return false
+	}
+
+	if strings.Contains(name, ".") {
+		b.fail(path+".name", fmt.Errorf("name %q contains a '.', which separates an indicator from its output", name))
+		return false
+	}
+
+	if b.declared[name] {
+		b.fail(path+".name", fmt.Errorf("name %q is already used", name))
+		return false
+	}
+
+	b.declared[name] = true
+	return true
+}
+
+func (b *builder) buildStream(path string, spec StreamSpec) {
+	if !b.declare(path, spec.Name) {
+		return
+	}
+
+	if spec.Interval != 0 && spec.Type != "intraday" {
+		b.fail(path+".interval", errors.New("interval only applies to intraday streams"))
+		return
+	}
+
+	var stream *gotrade.DOHLCVStream
+	switch spec.Type {
+	case "bars":
+		stream = gotrade.NewDOHLCVStream()
+	case "daily":
+		stream = gotrade.NewDailyDOHLCVStream().DOHLCVStream
+	case "weekly":
+		stream = gotrade.NewWeeklyDOHLCVStream().DOHLCVStream
+	case "monthly":
+		stream = gotrade.NewMonthlyDOHLCVStream().DOHLCVStream
+	case "intraday":
+		if spec.Interval < 1 {
+			b.fail(path+".interval", fmt.Errorf("interval is less than the minimum (1)"))
+			return
+		}
+		stream = gotrade.NewIntraDayDOHLCVStream(spec.Interval).DOHLCVStream
+	default:
+		b.fail(path+".type", fmt.Errorf("unknown stream type %q, expected bars, daily, weekly, monthly or intraday", spec.Type))
+		return
+	}
+
+	b.pipeline.Streams[spec.Name] = stream
+}
+
+func (b *builder) buildIndicator(path string, spec IndicatorSpec) {
+	if !b.declare(path, spec.Name) {
+		return
+	}
+
+	factory, ok := b.registry.Lookup(spec.Type)
+	if !ok {
+		b.fail(path+".type", fmt.Errorf("unknown indicator type %q", spec.Type))
+		return
+	}
+
+	arguments, err := factory.Arguments(spec.Params)
+	if err != nil {
+		b.errs = append(b.errs, err.(ValidationErrors).within(path+".params")...)
+	}
+
+	priceStream, floatStream, sourceOk := b.resolveSource(path+".source", spec.Source)
+	if err != nil || !sourceOk {
+		return
+	}
+
+	indicator, err := factory.Create(arguments)
+	if err != nil {
+		parameterErr := factory.parameterError(err)
+		if parameterErr.Path != "" {
+			b.fail(path+".params."+parameterErr.Path, err)
+		} else {
+			b.fail(path, err)
+		}
+		return
+	}
+
+	if priceStream != nil {
+		receiver, ok := indicator.(gotrade.DOHLCVTickReceiver)
+		if !ok {
+			b.fail(path+".source", fmt.Errorf("%s can not receive price bars from stream %q", spec.Type, spec.Source))
+			return
+		}
+		priceStream.AddTickSubscription(receiver)
+	} else {
+		receiver, ok := indicator.(gotrade.TickReceiver)
+		if !ok {
+			b.fail(path+".source", fmt.Errorf("%s requires a price stream source, not indicator %q", spec.Type, spec.Source))
+			return
+		}
+		floatStream.AddTickSubscription(receiver)
+	}
+
+	b.pipeline.Indicators[spec.Name] = indicator
+	b.factories[spec.Name] = factory
+}
+
+// resolveSource finds the price stream or the float stream of an indicator named by a source,
+// a source that failed to build has already been reported and is not ok without a further error
+func (b *builder) resolveSource(path string, source string) (priceStream *gotrade.DOHLCVStream, floatStream gotrade.FloatStreamSubscriber, ok bool) {
+	if source == "" {
+		b.fail(path, errors.New("source is required"))
+		return nil, nil, false
+	}
+
+	name, output := source, ""
+	if i := strings.Index(source, "."); i >= 0 {
+		name, output = source[:i], source[i+1:]
+	}
+
+	if stream, found := b.pipeline.Streams[name]; found {
+		if output != "" {
+			b.fail(path, fmt.Errorf("stream %q has no outputs", name))
+			return nil, nil, false
+		}
+		return stream, nil, true
+	}
+
+	indicator, found := b.pipeline.Indicators[name]
+	if !found {
+		if !b.declared[name] {
+			b.fail(path, fmt.Errorf("unknown source %q, expected a stream or an earlier indicator", name))
+		}
+		return nil, nil, false
+	}
+
+	factory := b.factories[name]
+	if output != "" {
+		outputFunc, found := factory.Outputs[output]
+		if !found {
+			b.fail(path, fmt.Errorf("indicator %q has no output %q", name, output))
+			return nil, nil, false
+		}
+		return nil, outputFunc(indicator), true
+	}
+
+	if len(factory.Outputs) > 0 {
+		b.fail(path, fmt.Errorf("indicator %q has several outputs, expected one of %s.%s", name, name,
+			strings.Join(factory.OutputNames(), ", "+name+".")))
+		return nil, nil, false
+	}
+
+	floatStream, isFloatStream := indicator.(gotrade.FloatStreamSubscriber)
+	if !isFloatStream {
+		b.fail(path, fmt.Errorf("indicator %q does not publish its results for other indicators", name))
+		return nil, nil, false
+	}
+	return nil, floatStream, true
+}
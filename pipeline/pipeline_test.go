@@ -0,0 +1,284 @@
+package pipeline_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/jaybutera/gotrade"
+	"github.com/jaybutera/gotrade/indicators"
+	"github.com/jaybutera/gotrade/pipeline"
+)
+
+var _ = Describe("when loading a pipeline from a YAML document with a years data", func() {
+	var (
+		pipe        *pipeline.Pipeline
+		err         error
+		priceStream *gotrade.InterDayDOHLCVStream
+	)
+
+	BeforeEach(func() {
+		pipe, err = pipeline.DefaultRegistry().LoadYAML([]byte(`
+streams:
+  - name: daily
+    type: daily
+indicators:
+  - name: atr
+    type: atr
+    source: daily
+  - name: atrAverage
+    type: sma
+    source: atr
+    params:
+      timePeriod: 20
+  - name: macd
+    type: macd
+    source: daily
+    params:
+      fastTimePeriod: 10
+  - name: signalAverage
+    type: sma
+    source: macd.signal
+    params:
+      timePeriod: 5
+  - name: highs
+    type: hhv
+    source: daily
+    params:
+      selectData: high
+`))
+		Expect(err).NotTo(HaveOccurred())
+		csvFeed.FillDOHLCVStream(pipe.Streams["daily"])
+
+		priceStream = gotrade.NewDailyDOHLCVStream()
+		csvFeed.FillDOHLCVStream(priceStream)
+	})
+
+	It("should create the streams and indicators by name", func() {
+		Expect(pipe.Streams).To(HaveKey("daily"))
+		Expect(pipe.Indicators).To(HaveLen(5))
+		Expect(pipe.Indicators["atrAverage"]).To(BeAssignableToTypeOf(&indicators.Sma{}))
+		Expect(pipe.Indicators["macd"]).To(BeAssignableToTypeOf(&indicators.Macd{}))
+	})
+
+	It("should attach indicators to the price stream", func() {
+		atr, _ := indicators.NewAtrForStream(priceStream, 14)
+		csvFeed.FillDOHLCVStream(priceStream)
+		Expect(pipe.Indicators["atr"].(*indicators.Atr).Data).To(Equal(atr.Data))
+
+		highs := pipe.Indicators["highs"].(*indicators.Hhv)
+		expected, _ := indicators.NewHhv(25, gotrade.UseHighPrice)
+		for _, bar := range pipe.Streams["daily"].Data {
+			expected.ReceiveDOHLCVTick(bar, 0)
+		}
+		Expect(highs.Data).To(Equal(expected.Data))
+	})
+
+	It("should attach indicators to the results of other indicators", func() {
+		atr := pipe.Indicators["atr"].(*indicators.Atr)
+		atrAverage := pipe.Indicators["atrAverage"].(*indicators.Sma)
+		expected, _ := indicators.NewSma(20, gotrade.UseClosePrice)
+		for i, value := range atr.Data {
+			expected.ReceiveTick(value, atr.ValidFromBar()+i)
+		}
+		Expect(atrAverage.Data).To(Equal(expected.Data))
+		Expect(atrAverage.ValidFromBar()).To(Equal(expected.ValidFromBar()))
+	})
+
+	It("should attach indicators to a named output of another indicator", func() {
+		macd := pipe.Indicators["macd"].(*indicators.Macd)
+		signalAverage := pipe.Indicators["signalAverage"].(*indicators.Sma)
+		expected, _ := indicators.NewSma(5, gotrade.UseClosePrice)
+		for _, value := range macd.Signal {
+			expected.ReceiveTick(value, 0)
+		}
+		Expect(signalAverage.Data).To(Equal(expected.Data))
+	})
+})
+
+var _ = Describe("when loading a pipeline from a JSON document", func() {
+	It("should build the same pipeline as from YAML", func() {
+		pipe, err := pipeline.DefaultRegistry().LoadJSON([]byte(`{
+			"streams": [{"name": "hourly", "type": "intraday", "interval": 60}, {"name": "weekly", "type": "weekly"}],
+			"indicators": [
+				{"name": "bands", "type": "bbands", "source": "weekly", "params": {"timePeriod": 10}},
+				{"name": "upperRsi", "type": "rsi", "source": "bands.upper"},
+				{"name": "rsi", "type": "rsi", "source": "hourly", "params": {"timePeriod": 7, "selectData": "open"}}
+			]}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pipe.Streams).To(HaveLen(2))
+		Expect(pipe.Indicators).To(HaveLen(3))
+		Expect(pipe.Indicators["rsi"].(*indicators.Rsi).GetLookbackPeriod()).To(Equal(7))
+	})
+
+	It("should return an error for a field unknown to the spec", func() {
+		_, err := pipeline.DefaultRegistry().LoadJSON([]byte(`{"streams": [{"name": "daily", "kind": "daily"}]}`))
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("when validating a pipeline document", func() {
+	var (
+		errs pipeline.ValidationErrors
+	)
+
+	load := func(document string) {
+		_, err := pipeline.DefaultRegistry().LoadYAML([]byte(document))
+		Expect(err).To(HaveOccurred())
+		errs = err.(pipeline.ValidationErrors)
+	}
+
+	paths := func() []string {
+		result := make([]string, len(errs))
+		for i := range errs {
+			result[i] = errs[i].Path
+		}
+		return result
+	}
+
+	It("should report every invalid field with its path", func() {
+		load(`
+streams:
+  - name: daily
+    type: hourly
+  - name: minutes
+    type: intraday
+indicators:
+  - name: fast
+    type: sma
+    source: daily
+    params:
+      timePeriod: 1
+  - name: slow
+    type: smma
+    source: daily
+  - name: signal
+    type: macd
+    source: prices
+    params:
+      signalTimePeriod: nine
+      period: 3
+`)
+		Expect(paths()).To(Equal([]string{
+			"streams[0].type",
+			"streams[1].interval",
+			"indicators[1].type",
+			"indicators[2].params.period",
+			"indicators[2].params.signalTimePeriod",
+			"indicators[2].source",
+		}))
+	})
+
+	It("should report the range errors of the indicator against the parameter", func() {
+		load(`
+streams:
+  - name: daily
+    type: daily
+indicators:
+  - name: fast
+    type: sma
+    source: daily
+    params:
+      timePeriod: 1
+`)
+		Expect(paths()).To(Equal([]string{"indicators[0].params.timePeriod"}))
+		Expect(errs.Error()).To(Equal("indicators[0].params.timePeriod: timePeriod is less than the minimum (2)"))
+	})
+
+	It("should report names that are missing or used twice", func() {
+		load(`
+streams:
+  - name: daily
+    type: daily
+indicators:
+  - type: sma
+    source: daily
+  - name: daily
+    type: sma
+    source: daily
+`)
+		Expect(paths()).To(Equal([]string{"indicators[0].name", "indicators[1].name"}))
+	})
+
+	It("should report sources that are later indicators or multiple outputs", func() {
+		load(`
+streams:
+  - name: daily
+    type: daily
+indicators:
+  - name: early
+    type: sma
+    source: late
+  - name: late
+    type: sma
+    source: daily
+  - name: macd
+    type: macd
+    source: daily
+  - name: macdAverage
+    type: sma
+    source: macd
+  - name: bandAverage
+    type: sma
+    source: macd.upper
+  - name: atrOfAverage
+    type: atr
+    source: late
+`)
+		Expect(paths()).To(Equal([]string{
+			"indicators[0].source",
+			"indicators[3].source",
+			"indicators[4].source",
+			"indicators[5].source",
+		}))
+		Expect(errs[1].Err.Error()).To(Equal(`indicator "macd" has several outputs, expected one of macd.histogram, macd.macd, macd.signal`))
+	})
+
+	It("should report sources that do not publish their results", func() {
+		registry := pipeline.DefaultRegistry()
+		registry.Register(&pipeline.IndicatorFactory{Name: "linregnostorage",
+			Create: func(arguments pipeline.Arguments) (interface{}, error) {
+				linReg, err := indicators.NewLinRegWithoutStorage(14, func(float64, float64, float64, int) {})
+				return &unpublishedLinReg{linReg}, err
+			}})
+
+		_, err := registry.LoadYAML([]byte(`
+streams:
+  - name: daily
+    type: daily
+indicators:
+  - name: trend
+    type: linregnostorage
+    source: daily
+  - name: trendAverage
+    type: sma
+    source: trend
+`))
+		Expect(err).To(MatchError(`indicators[1].source: indicator "trend" does not publish its results for other indicators`))
+	})
+
+	It("should not report indicators using a source that failed", func() {
+		load(`
+streams:
+  - name: daily
+    type: daily
+indicators:
+  - name: fast
+    type: sma
+    source: daily
+    params:
+      timePeriod: 0
+  - name: fastAverage
+    type: sma
+    source: fast
+`)
+		Expect(paths()).To(Equal([]string{"indicators[0].params.timePeriod"}))
+	})
+})
+
+// A linear regression of the close price which does not publish its results
+type unpublishedLinReg struct {
+	*indicators.LinRegWithoutStorage
+}
+
+func (ind *unpublishedLinReg) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	ind.ReceiveTick(tickData.C(), streamBarIndex)
+}
@@ -0,0 +1,297 @@
+/*
+	import "github.com/jaybutera/gotrade/pipeline"
+
+	Package pipeline builds indicators by name from configuration, such as a dashboard definition.
+	It provides:
+		- a registry mapping indicator names, e.g. "sma", "macd" and "bbands", to factories.
+		- factories with a typed parameter schema, where parameters left out take the defaults
+		- of the indicator's NewDefault creation function.
+		- a loader building a graph of price streams and indicators from a JSON or YAML document.
+		- validation errors reporting the path of the offending field in the document.
+
+	A pipeline is described by a document such as
+
+		streams:
+		  - name: daily
+		    type: daily
+		indicators:
+		  - name: atr
+		    type: atr
+		    source: daily
+		  - name: atrAverage
+		    type: sma
+		    source: atr
+		    params:
+		      timePeriod: 20
+		  - name: macd
+		    type: macd
+		    source: daily
+		  - name: signalAverage
+		    type: sma
+		    source: macd.signal
+
+	where the source of an indicator is a price stream, an earlier indicator, or a named output of an
+	earlier indicator with several outputs. Once built, the pipeline's streams are filled from a feed, e.g.
+
+		pipe, err := pipeline.DefaultRegistry().LoadYAML(document)
+		csvFeed.FillDOHLCVStream(pipe.Streams["daily"])
+		atrAverage := pipe.Indicators["atrAverage"].(*indicators.Sma)
+*/
+package pipeline
+
+import (
+	"errors"
+	"fmt"
+	"github.com/jaybutera/gotrade"
+	"math"
+	"sort"
+)
+
+// The type of the value of an indicator parameter
+type ParameterType int
+
+const (
+	// a whole number, such as a time period
+	IntParameter ParameterType = iota
+	// a real number, such as an acceleration factor
+	FloatParameter
+	// the name of the price used from each bar, one of open, high, low, close or volume
+	PriceParameter
+)
+
+func (t ParameterType) String() string {
+	switch t {
+	case IntParameter:
+		return "int"
+	case FloatParameter:
+		return "float"
+	case PriceParameter:
+		return "price"
+	}
+	return "unknown"
+}
+
+var priceSelectors = map[string]gotrade.DOHLCVDataSelectionFunc{
+	"open":   gotrade.UseOpenPrice,
+	"high":   gotrade.UseHighPrice,
+	"low":    gotrade.UseLowPrice,
+	"close":  gotrade.UseClosePrice,
+	"volume": gotrade.UseVolume,
+}
+
+// A parameter of an indicator factory, named as in the indicator's creation functions
+type Parameter struct {
+	Name string
+	Type ParameterType
+	// an int, float64 or price name matching the type
+	Default interface{}
+}
+
+// The parameters of an indicator, validated against the factory's schema with defaults filled in
+type Arguments map[string]interface{}
+
+// Int returns the value of an int parameter
+func (a Arguments) Int(name string) int {
+	return a[name].(int)
+}
+
+// Float returns the value of a float parameter
+func (a Arguments) Float(name string) float64 {
+	return a[name].(float64)
+}
+
+// Price returns the data selection function of a price parameter
+func (a Arguments) Price(name string) gotrade.DOHLCVDataSelectionFunc {
+	return priceSelectors[a[name].(string)]
+}
+
+// OutputFunc returns one of the named outputs of an indicator created by a factory
+type OutputFunc func(indicator interface{}) gotrade.FloatStreamSubscriber
+
+// An IndicatorFactory creates an indicator with storage from its parameters
+type IndicatorFactory struct {
+	Name       string
+	Parameters []Parameter
+	// the outputs of an indicator with several, an indicator with a single output is its own float stream
+	Outputs map[string]OutputFunc
+	// creates the indicator from arguments validated against the parameters
+	Create func(arguments Arguments) (indicator interface{}, err error)
+}
+
+// Parameter returns the parameter with the name, if the factory has one
+func (f *IndicatorFactory) Parameter(name string) (parameter Parameter, ok bool) {
+	for _, parameter := range f.Parameters {
+		if parameter.Name == name {
+			return parameter, true
+		}
+	}
+	return Parameter{}, false
+}
+
+// OutputNames returns the names of the outputs of an indicator with several, in alphabetical order
+func (f *IndicatorFactory) OutputNames() []string {
+	names := make([]string, 0, len(f.Outputs))
+	for name := range f.Outputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Arguments validates parameter values against the schema, filling in the defaults of the
+// parameters left out. The errors are reported with the parameter name as the path.
+func (f *IndicatorFactory) Arguments(values map[string]interface{}) (arguments Arguments, err error) {
+	var errs ValidationErrors
+
+	for _, name := range sortedKeys(values) {
+		if _, ok := f.Parameter(name); !ok {
+			errs = append(errs, &ValidationError{Path: name, Err: fmt.Errorf("unknown parameter for %s", f.Name)})
+		}
+	}
+
+	arguments = make(Arguments, len(f.Parameters))
+	for _, parameter := range f.Parameters {
+		value, ok := values[parameter.Name]
+		if !ok {
+			arguments[parameter.Name] = parameter.Default
+			continue
+		}
+
+		converted, convertErr := convertValue(parameter.Type, value)
+		if convertErr != nil {
+			errs = append(errs, &ValidationError{Path: parameter.Name, Err: convertErr})
+			continue
+		}
+		arguments[parameter.Name] = converted
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return arguments, nil
+}
+
+// New creates an indicator from parameter values, validating them and filling in the defaults
+func (f *IndicatorFactory) New(values map[string]interface{}) (indicator interface{}, err error) {
+	arguments, err := f.Arguments(values)
+	if err != nil {
+		return nil, err
+	}
+
+	indicator, err = f.Create(arguments)
+	if err != nil {
+		return nil, ValidationErrors{f.parameterError(err)}
+	}
+	return indicator, nil
+}
+
+// parameterError attributes an error from an indicator's creation functions to the parameter it
+// starts with, e.g. "timePeriod is less than the minimum (2)"
+func (f *IndicatorFactory) parameterError(err error) *ValidationError {
+	message := err.Error()
+	for _, parameter := range f.Parameters {
+		name := parameter.Name
+		if len(message) > len(name) && message[:len(name)] == name && message[len(name)] == ' ' {
+			return &ValidationError{Path: name, Err: err}
+		}
+	}
+	return &ValidationError{Err: err}
+}
+
+func convertValue(parameterType ParameterType, value interface{}) (converted interface{}, err error) {
+	switch parameterType {
+	case IntParameter:
+		number, ok := toFloat(value)
+		if !ok || number != math.Trunc(number) || math.Abs(number) > math.MaxInt32 {
+			return nil, fmt.Errorf("expected an int, got %v", value)
+		}
+		return int(number), nil
+	case FloatParameter:
+		number, ok := toFloat(value)
+		if !ok {
+			return nil, fmt.Errorf("expected a float, got %v", value)
+		}
+		return number, nil
+	case PriceParameter:
+		name, ok := value.(string)
+		if _, known := priceSelectors[name]; !ok || !known {
+			return nil, fmt.Errorf("expected one of open, high, low, close or volume, got %v", value)
+		}
+		return name, nil
+	}
+	return nil, fmt.Errorf("unknown parameter type %v", parameterType)
+}
+
+// toFloat converts the numbers decoded from JSON, float64, and from YAML, int, int64, uint64 or float64
+func toFloat(value interface{}) (number float64, ok bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0.0, false
+}
+
+// A Registry maps indicator names to the factories creating them
+type Registry struct {
+	factories map[string]*IndicatorFactory
+}
+
+// NewRegistry creates a registry without any factories
+func NewRegistry() *Registry {
+	return &Registry{factories: make(map[string]*IndicatorFactory)}
+}
+
+// Register adds a factory to the registry under its name
+func (r *Registry) Register(factory *IndicatorFactory) error {
+	if factory.Name == "" {
+		return errors.New("factory name is empty")
+	}
+
+	if factory.Create == nil {
+		return fmt.Errorf("factory %s has no create function", factory.Name)
+	}
+
+	if _, ok := r.factories[factory.Name]; ok {
+		return fmt.Errorf("factory %s is already registered", factory.Name)
+	}
+
+	for _, parameter := range factory.Parameters {
+		if _, err := convertValue(parameter.Type, parameter.Default); err != nil {
+			return fmt.Errorf("factory %s parameter %s default is invalid, %s", factory.Name, parameter.Name, err)
+		}
+	}
+
+	r.factories[factory.Name] = factory
+	return nil
+}
+
+// Lookup returns the factory registered under the name
+func (r *Registry) Lookup(name string) (factory *IndicatorFactory, ok bool) {
+	factory, ok = r.factories[name]
+	return factory, ok
+}
+
+// Names returns the names of the registered factories in alphabetical order
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.factories))
+	for name := range r.factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func sortedKeys(values map[string]interface{}) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,178 @@
+package pipeline_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/jaybutera/gotrade"
+	"github.com/jaybutera/gotrade/indicators"
+	"github.com/jaybutera/gotrade/pipeline"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+	"time"
+)
+
+var _ = Describe("the default registry", func() {
+	var (
+		registry *pipeline.Registry
+	)
+
+	BeforeEach(func() {
+		registry = pipeline.DefaultRegistry()
+	})
+
+	It("should have a factory for each indicator", func() {
+		Expect(registry.Names()).To(HaveLen(56))
+		Expect(registry.Names()).To(ContainElement("sma"))
+		Expect(registry.Names()).To(ContainElement("macd"))
+		Expect(registry.Names()).To(ContainElement("bbands"))
+	})
+
+	It("should have a factory for each indicator with a default creation function", func() {
+		packages, err := parser.ParseDir(token.NewFileSet(), "../indicators", nil, 0)
+		Expect(err).To(BeNil())
+
+		// the names registered differently to their indicator
+		names := map[string]string{"bollingerbands": "bbands"}
+		for _, file := range packages["indicators"].Files {
+			for _, declaration := range file.Decls {
+				function, ok := declaration.(*ast.FuncDecl)
+				if !ok || function.Recv != nil || !strings.HasPrefix(function.Name.Name, "NewDefault") ||
+					strings.Contains(function.Name.Name, "ForStream") || strings.HasSuffix(function.Name.Name, "WithSrcLen") {
+					continue
+				}
+
+				name := strings.ToLower(strings.TrimPrefix(function.Name.Name, "NewDefault"))
+				if registered, ok := names[name]; ok {
+					name = registered
+				}
+				_, ok = registry.Lookup(name)
+				Expect(ok).To(BeTrue(), function.Name.Name)
+			}
+		}
+	})
+
+	It("should create every indicator from the default parameters", func() {
+		for _, name := range registry.Names() {
+			factory, _ := registry.Lookup(name)
+			indicator, err := factory.New(nil)
+			Expect(err).NotTo(HaveOccurred(), name)
+			_, ok := indicator.(gotrade.DOHLCVTickReceiver)
+			Expect(ok).To(BeTrue(), name)
+		}
+	})
+
+	It("should take the parameter defaults from the default creation functions", func() {
+		factory, _ := registry.Lookup("macd")
+		indicator, _ := factory.New(nil)
+		defaultMacd, _ := indicators.NewDefaultMacd()
+		Expect(indicator.(*indicators.Macd).GetLookbackPeriod()).To(Equal(defaultMacd.GetLookbackPeriod()))
+
+		factory, _ = registry.Lookup("sma")
+		indicator, _ = factory.New(nil)
+		defaultSma, _ := indicators.NewDefaultSma()
+		Expect(indicator.(*indicators.Sma).GetLookbackPeriod()).To(Equal(defaultSma.GetLookbackPeriod()))
+
+		factory, _ = registry.Lookup("stochrsi")
+		indicator, _ = factory.New(nil)
+		defaultStochRsi, _ := indicators.NewDefaultStochRsi()
+		Expect(indicator.(*indicators.StochRsi).GetLookbackPeriod()).To(Equal(defaultStochRsi.GetLookbackPeriod()))
+	})
+
+	It("should describe the parameters of a factory", func() {
+		factory, ok := registry.Lookup("bbands")
+		Expect(ok).To(BeTrue())
+		Expect(factory.Parameters).To(Equal([]pipeline.Parameter{
+			{Name: "timePeriod", Type: pipeline.IntParameter, Default: 5},
+			{Name: "selectData", Type: pipeline.PriceParameter, Default: "close"}}))
+		Expect(factory.OutputNames()).To(Equal([]string{"lower", "middle", "upper"}))
+	})
+
+	It("should not find an unregistered name", func() {
+		_, ok := registry.Lookup("nothing")
+		Expect(ok).To(BeFalse())
+	})
+})
+
+var _ = Describe("when validating the parameters of a factory", func() {
+	var (
+		factory *pipeline.IndicatorFactory
+	)
+
+	BeforeEach(func() {
+		factory, _ = pipeline.DefaultRegistry().Lookup("macd")
+	})
+
+	It("should fill in the defaults of the parameters left out", func() {
+		arguments, err := factory.Arguments(map[string]interface{}{"fastTimePeriod": 5.0})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(arguments.Int("fastTimePeriod")).To(Equal(5))
+		Expect(arguments.Int("slowTimePeriod")).To(Equal(26))
+		Expect(arguments.Int("signalTimePeriod")).To(Equal(9))
+	})
+
+	It("should accept numbers decoded as ints", func() {
+		arguments, err := factory.Arguments(map[string]interface{}{"fastTimePeriod": 5, "selectData": "high"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(arguments.Int("fastTimePeriod")).To(Equal(5))
+		Expect(arguments.Price("selectData")(gotrade.NewDOHLCVDataItem(time.Now(), 1.0, 4.0, 0.5, 2.0, 10.0))).To(Equal(4.0))
+	})
+
+	It("should report every invalid parameter by name", func() {
+		_, err := factory.Arguments(map[string]interface{}{"fastTimePeriod": 5.5, "selectData": "mid", "period": 3})
+		Expect(err).To(HaveOccurred())
+		errs := err.(pipeline.ValidationErrors)
+		Expect(errs).To(HaveLen(3))
+		Expect(errs[0].Path).To(Equal("period"))
+		Expect(errs[1].Path).To(Equal("fastTimePeriod"))
+		Expect(errs[2].Path).To(Equal("selectData"))
+	})
+
+	It("should attribute range errors to the parameter", func() {
+		_, err := factory.New(map[string]interface{}{"slowTimePeriod": 1})
+		Expect(err).To(HaveOccurred())
+		errs := err.(pipeline.ValidationErrors)
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Path).To(Equal("slowTimePeriod"))
+	})
+})
+
+var _ = Describe("when registering a factory", func() {
+	var (
+		registry *pipeline.Registry
+		factory  *pipeline.IndicatorFactory
+	)
+
+	BeforeEach(func() {
+		registry = pipeline.NewRegistry()
+		factory = &pipeline.IndicatorFactory{Name: "fastsma",
+			Parameters: []pipeline.Parameter{{Name: "timePeriod", Type: pipeline.IntParameter, Default: 3}},
+			Create: func(arguments pipeline.Arguments) (interface{}, error) {
+				return indicators.NewSma(arguments.Int("timePeriod"), gotrade.UseClosePrice)
+			}}
+	})
+
+	It("should be found by its name", func() {
+		Expect(registry.Register(factory)).To(Succeed())
+		found, ok := registry.Lookup("fastsma")
+		Expect(ok).To(BeTrue())
+		Expect(found).To(BeIdenticalTo(factory))
+		Expect(registry.Names()).To(Equal([]string{"fastsma"}))
+	})
+
+	It("should return an error for a name already registered", func() {
+		Expect(registry.Register(factory)).To(Succeed())
+		Expect(registry.Register(factory)).NotTo(Succeed())
+	})
+
+	It("should return an error for an empty name", func() {
+		factory.Name = ""
+		Expect(registry.Register(factory)).NotTo(Succeed())
+	})
+
+	It("should return an error for a default not matching the parameter type", func() {
+		factory.Parameters[0].Default = "three"
+		Expect(registry.Register(factory)).NotTo(Succeed())
+	})
+})
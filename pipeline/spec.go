@@ -0,0 +1,53 @@
+package pipeline
+
+import (
+	"bytes"
+	"encoding/json"
+	"gopkg.in/yaml.v2"
+)
+
+// A Spec describes a pipeline of price streams and the indicators attached to them
+type Spec struct {
+	Streams    []StreamSpec    `json:"streams" yaml:"streams"`
+	Indicators []IndicatorSpec `json:"indicators" yaml:"indicators"`
+}
+
+// A StreamSpec describes a price stream, filled from a feed once the pipeline is built
+type StreamSpec struct {
+	Name string `json:"name" yaml:"name"`
+	// bars, publishing every tick received as a bar, daily, weekly, monthly or intraday
+	Type string `json:"type" yaml:"type"`
+	// the bar interval in minutes of an intraday stream
+	Interval int `json:"interval,omitempty" yaml:"interval,omitempty"`
+}
+
+// An IndicatorSpec describes an indicator created by the factory registered as its type
+type IndicatorSpec struct {
+	Name string `json:"name" yaml:"name"`
+	Type string `json:"type" yaml:"type"`
+	// a price stream, an earlier indicator, or a named output of an earlier indicator, e.g. macd.signal
+	Source string `json:"source" yaml:"source"`
+	// the parameters differing from the defaults of the factory
+	Params map[string]interface{} `json:"params,omitempty" yaml:"params,omitempty"`
+}
+
+// ParseJSONSpec reads a pipeline spec from a JSON document, fields unknown to the spec are an error
+func ParseJSONSpec(data []byte) (spec *Spec, err error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	spec = &Spec{}
+	if err = decoder.Decode(spec); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// ParseYAMLSpec reads a pipeline spec from a YAML document, fields unknown to the spec are an error
+func ParseYAMLSpec(data []byte) (spec *Spec, err error) {
+	spec = &Spec{}
+	if err = yaml.UnmarshalStrict(data, spec); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
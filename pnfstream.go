@@ -1 +1,453 @@
 package gotrade
+
+import (
+	"errors"
+	"io"
+	"math"
+	"sync"
+	"time"
+)
+
+// The type of a point and figure column, rising X's or falling O's
+type PnFColumnType int
+
+const (
+	XColumn PnFColumnType = iota
+	OColumn
+)
+
+// The method used to determine the box size of a point and figure stream
+type PnFBoxSizeMethod int
+
+const (
+	// every box is the same fixed price amount
+	FixedBoxSize PnFBoxSizeMethod = iota
+	// every box is a fixed percentage of the box below it, i.e. a logarithmic scale
+	PercentageBoxSize
+	// the box size is fixed to the average true range once enough bars have been received
+	AtrBoxSize
+)
+
+// The price data used to construct point and figure columns
+type PnFConstructionMethod int
+
+const (
+	// only the close price of each bar is considered
+	CloseOnlyConstruction PnFConstructionMethod = iota
+	// the high price is used to extend X's and reverse O's, the low price to extend O's and reverse X's
+	HighLowConstruction
+)
+
+// prices within this fraction of a box of a box boundary are treated as on the boundary
+const pnfBoxIndexTolerance float64 = 1e-9
+
+var (
+	ErrPnFBoxSizeMustBeGreaterThanZero       = errors.New("Box size must be greater than 0")
+	ErrPnFReversalCountMustBeGreaterThanZero = errors.New("Reversal count must be greater than 0")
+	ErrPnFAtrPeriodMustBeGreaterThanZero     = errors.New("ATR time period must be greater than 0")
+)
+
+// A single filled box in a point and figure column
+type PnFBox struct {
+	// the index of the box on the box scale, comparable across columns of the same stream
+	BoxIndex int
+	// the price at which the box is drawn
+	Price float64
+	// the date of the bar that filled the box
+	Date time.Time
+	// the source stream bar that filled the box
+	StreamBarIndex int
+}
+
+// A point and figure column of X's or O's
+type PnFColumn struct {
+	ColumnType PnFColumnType
+	// the filled boxes in the order they were filled, ascending for X's and descending for O's
+	Boxes []PnFBox
+}
+
+// BoxCount returns the number of boxes in the column
+func (c *PnFColumn) BoxCount() int {
+	return len(c.Boxes)
+}
+
+// HighBox returns the highest box in the column
+func (c *PnFColumn) HighBox() PnFBox {
+	if c.ColumnType == XColumn {
+		return c.Boxes[len(c.Boxes)-1]
+	}
+	return c.Boxes[0]
+}
+
+// LowBox returns the lowest box in the column
+func (c *PnFColumn) LowBox() PnFBox {
+	if c.ColumnType == XColumn {
+		return c.Boxes[0]
+	}
+	return c.Boxes[len(c.Boxes)-1]
+}
+
+// High returns the price of the highest box in the column
+func (c *PnFColumn) High() float64 {
+	return c.HighBox().Price
+}
+
+// Low returns the price of the lowest box in the column
+func (c *PnFColumn) Low() float64 {
+	return c.LowBox().Price
+}
+
+// StartDate returns the date of the bar that started the column
+func (c *PnFColumn) StartDate() time.Time {
+	return c.Boxes[0].Date
+}
+
+// EndDate returns the date of the bar that filled the latest box in the column
+func (c *PnFColumn) EndDate() time.Time {
+	return c.Boxes[len(c.Boxes)-1].Date
+}
+
+// Consumer of point and figure columns, notified whenever a column is started or extended
+type PnFColumnReceiver interface {
+	ReceivePnFColumn(column *PnFColumn, columnIndex int)
+}
+
+type PnFColumnSubscriber interface {
+	AddColumnSubscription(subscriber PnFColumnReceiver) io.Closer
+}
+
+// A point and figure price stream built from a DOHLCV stream
+type PnFStream struct {
+	Columns []*PnFColumn
+
+	// private variables
+	subscriberMutex    sync.RWMutex
+	subscribers        []PnFColumnReceiver
+	boxSizeMethod      PnFBoxSizeMethod
+	constructionMethod PnFConstructionMethod
+	reversalCount      int
+	boxSize            float64
+	basePrice          float64
+	logBoxRatio        float64
+	atrTimePeriod      int
+	atrPeriodCounter   int
+	atrPeriodTotal     float64
+	previousClose      float64
+	hasReferencePrice  bool
+	referencePrice     float64
+	minValue           float64
+	maxValue           float64
+}
+
+func newPnFStream(boxSizeMethod PnFBoxSizeMethod, boxSize float64, atrTimePeriod int, reversalCount int, constructionMethod PnFConstructionMethod) (*PnFStream, error) {
+	if reversalCount < 1 {
+		return nil, ErrPnFReversalCountMustBeGreaterThanZero
+	}
+
+	s := PnFStream{
+		boxSizeMethod:      boxSizeMethod,
+		constructionMethod: constructionMethod,
+		reversalCount:      reversalCount,
+		boxSize:            boxSize,
+		atrTimePeriod:      atrTimePeriod,
+		minValue:           math.MaxFloat64,
+		maxValue:           math.SmallestNonzeroFloat64,
+	}
+
+	if boxSizeMethod == PercentageBoxSize {
+		s.logBoxRatio = math.Log(1.0 + boxSize/100.0)
+	}
+
+	return &s, nil
+}
+
+// NewFixedBoxPnFStream creates a point and figure stream where every box is boxSize in price
+func NewFixedBoxPnFStream(boxSize float64, reversalCount int, constructionMethod PnFConstructionMethod) (stream *PnFStream, err error) {
+	if boxSize <= 0 {
+		return nil, ErrPnFBoxSizeMustBeGreaterThanZero
+	}
+	return newPnFStream(FixedBoxSize, boxSize, 0, reversalCount, constructionMethod)
+}
+
+// NewPercentageBoxPnFStream creates a point and figure stream where every box is boxPercentage percent larger than the box below it
+func NewPercentageBoxPnFStream(boxPercentage float64, reversalCount int, constructionMethod PnFConstructionMethod) (stream *PnFStream, err error) {
+	if boxPercentage <= 0 {
+		return nil, ErrPnFBoxSizeMustBeGreaterThanZero
+	}
+	return newPnFStream(PercentageBoxSize, boxPercentage, 0, reversalCount, constructionMethod)
+}
+
+// NewAtrBoxPnFStream creates a point and figure stream where the box size is the average true range
+// over the first atrTimePeriod bars, no columns are built until the box size is known
+func NewAtrBoxPnFStream(atrTimePeriod int, reversalCount int, constructionMethod PnFConstructionMethod) (stream *PnFStream, err error) {
+	if atrTimePeriod < 1 {
+		return nil, ErrPnFAtrPeriodMustBeGreaterThanZero
+	}
+	return newPnFStream(AtrBoxSize, 0, atrTimePeriod, reversalCount, constructionMethod)
+}
+
+// NewDefaultPnFStream creates a point and figure stream with default parameters
+//	- box size: 1%
+//	- reversal count: 3
+//	- construction: close only
+func NewDefaultPnFStream() (stream *PnFStream, err error) {
+	return NewPercentageBoxPnFStream(1.0, 3, CloseOnlyConstruction)
+}
+
+// NewFixedBoxPnFStreamForStream creates a fixed box point and figure stream attached to a source data stream
+func NewFixedBoxPnFStreamForStream(priceStream DOHLCVStreamSubscriber, boxSize float64, reversalCount int, constructionMethod PnFConstructionMethod) (stream *PnFStream, err error) {
+	s, err := NewFixedBoxPnFStream(boxSize, reversalCount, constructionMethod)
+	if err != nil {
+		return nil, err
+	}
+	priceStream.AddTickSubscription(s)
+	return s, nil
+}
+
+// NewPercentageBoxPnFStreamForStream creates a percentage box point and figure stream attached to a source data stream
+func NewPercentageBoxPnFStreamForStream(priceStream DOHLCVStreamSubscriber, boxPercentage float64, reversalCount int, constructionMethod PnFConstructionMethod) (stream *PnFStream, err error) {
+	s, err := NewPercentageBoxPnFStream(boxPercentage, reversalCount, constructionMethod)
+	if err != nil {
+		return nil, err
+	}
+	priceStream.AddTickSubscription(s)
+	return s, nil
+}
+
+// NewAtrBoxPnFStreamForStream creates an average true range box point and figure stream attached to a source data stream
+func NewAtrBoxPnFStreamForStream(priceStream DOHLCVStreamSubscriber, atrTimePeriod int, reversalCount int, constructionMethod PnFConstructionMethod) (stream *PnFStream, err error) {
+	s, err := NewAtrBoxPnFStream(atrTimePeriod, reversalCount, constructionMethod)
+	if err != nil {
+		return nil, err
+	}
+	priceStream.AddTickSubscription(s)
+	return s, nil
+}
+
+// NewDefaultPnFStreamForStream creates a point and figure stream with default parameters attached to a source data stream
+func NewDefaultPnFStreamForStream(priceStream DOHLCVStreamSubscriber) (stream *PnFStream, err error) {
+	s, err := NewDefaultPnFStream()
+	if err != nil {
+		return nil, err
+	}
+	priceStream.AddTickSubscription(s)
+	return s, nil
+}
+
+// BoxSize returns the price size of a box, for percentage box streams this is the percentage,
+// for average true range box streams it is 0 until enough bars have been received
+func (p *PnFStream) BoxSize() float64 {
+	return p.boxSize
+}
+
+// ReversalCount returns the number of boxes required to start a new column
+func (p *PnFStream) ReversalCount() int {
+	return p.reversalCount
+}
+
+// BoxPrice returns the price at which the box with the given index is drawn
+func (p *PnFStream) BoxPrice(boxIndex int) float64 {
+	if p.boxSizeMethod == PercentageBoxSize {
+		return p.basePrice * math.Exp(float64(boxIndex)*p.logBoxRatio)
+	}
+	return float64(boxIndex) * p.boxSize
+}
+
+// the index of the highest box at or below price
+func (p *PnFStream) boxIndexAtOrBelow(price float64) int {
+	return int(math.Floor(p.rawBoxIndex(price) + pnfBoxIndexTolerance))
+}
+
+// the index of the lowest box at or above price
+func (p *PnFStream) boxIndexAtOrAbove(price float64) int {
+	return int(math.Ceil(p.rawBoxIndex(price) - pnfBoxIndexTolerance))
+}
+
+func (p *PnFStream) rawBoxIndex(price float64) float64 {
+	if p.boxSizeMethod == PercentageBoxSize {
+		return math.Log(price/p.basePrice) / p.logBoxRatio
+	}
+	return price / p.boxSize
+}
+
+// MinValue returns the lowest box price of the stream
+func (p *PnFStream) MinValue() float64 {
+	return p.minValue
+}
+
+// MaxValue returns the highest box price of the stream
+func (p *PnFStream) MaxValue() float64 {
+	return p.maxValue
+}
+
+// CurrentColumn returns the column currently being built, or nil if no column has been started
+func (p *PnFStream) CurrentColumn() *PnFColumn {
+	if len(p.Columns) == 0 {
+		return nil
+	}
+	return p.Columns[len(p.Columns)-1]
+}
+
+// AddColumnSubscription attaches a subscriber to the stream, closing the returned subscription detaches it
+func (p *PnFStream) AddColumnSubscription(subscriber PnFColumnReceiver) io.Closer {
+	p.subscriberMutex.Lock()
+	defer p.subscriberMutex.Unlock()
+
+	// copy on write, so that columns being published keep the subscribers they started with
+	subscribers := make([]PnFColumnReceiver, len(p.subscribers), len(p.subscribers)+1)
+	copy(subscribers, p.subscribers)
+	p.subscribers = append(subscribers, subscriber)
+
+	return newSubscription(func() { p.RemoveColumnSubscription(subscriber) })
+}
+
+func (p *PnFStream) RemoveColumnSubscription(subscriber PnFColumnReceiver) {
+	p.subscriberMutex.Lock()
+	defer p.subscriberMutex.Unlock()
+
+	for i := range p.subscribers {
+		if p.subscribers[i] == subscriber {
+			subscribers := make([]PnFColumnReceiver, 0, len(p.subscribers)-1)
+			subscribers = append(subscribers, p.subscribers[:i]...)
+			p.subscribers = append(subscribers, p.subscribers[i+1:]...)
+			return
+		}
+	}
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (p *PnFStream) ReceiveDOHLCVTick(tickData DOHLCV, streamBarIndex int) {
+
+	if p.boxSizeMethod == AtrBoxSize && p.boxSize == 0 {
+		p.receiveAtrTick(tickData)
+		return
+	}
+
+	if !p.hasReferencePrice {
+		p.referencePrice = tickData.C()
+		p.hasReferencePrice = true
+		if p.boxSizeMethod == PercentageBoxSize {
+			p.basePrice = p.referencePrice
+		}
+		return
+	}
+
+	high, low := tickData.C(), tickData.C()
+	if p.constructionMethod == HighLowConstruction {
+		high, low = tickData.H(), tickData.L()
+	}
+
+	column := p.CurrentColumn()
+	if column == nil {
+		p.startFirstColumn(tickData, high, low, streamBarIndex)
+		return
+	}
+
+	if column.ColumnType == XColumn {
+		top := column.HighBox().BoxIndex
+		if p.boxIndexAtOrBelow(high) > top {
+			p.extendColumn(column, p.boxIndexAtOrBelow(high), tickData.D(), streamBarIndex)
+		} else if p.boxIndexAtOrAbove(low) <= top-p.reversalCount {
+			p.startColumn(OColumn, top-1, p.boxIndexAtOrAbove(low), tickData.D(), streamBarIndex)
+		}
+	} else {
+		bottom := column.LowBox().BoxIndex
+		if p.boxIndexAtOrAbove(low) < bottom {
+			p.extendColumn(column, p.boxIndexAtOrAbove(low), tickData.D(), streamBarIndex)
+		} else if p.boxIndexAtOrBelow(high) >= bottom+p.reversalCount {
+			p.startColumn(XColumn, bottom+1, p.boxIndexAtOrBelow(high), tickData.D(), streamBarIndex)
+		}
+	}
+}
+
+// the average true range box size is the simple average of the first atrTimePeriod true ranges
+func (p *PnFStream) receiveAtrTick(tickData DOHLCV) {
+	p.atrPeriodCounter++
+	if p.atrPeriodCounter > 1 {
+		trueHigh := math.Max(tickData.H(), p.previousClose)
+		trueLow := math.Min(tickData.L(), p.previousClose)
+		p.atrPeriodTotal += trueHigh - trueLow
+	}
+	p.previousClose = tickData.C()
+
+	if p.atrPeriodCounter > p.atrTimePeriod {
+		p.boxSize = p.atrPeriodTotal / float64(p.atrTimePeriod)
+
+		// a flat market has no range, keep waiting for some movement
+		if p.boxSize <= 0 {
+			p.boxSize = 0
+			p.atrPeriodCounter = 0
+			p.atrPeriodTotal = 0
+			return
+		}
+
+		// the bar that completes the average true range is the reference for the first column
+		p.referencePrice = tickData.C()
+		p.hasReferencePrice = true
+	}
+}
+
+func (p *PnFStream) startFirstColumn(tickData DOHLCV, high float64, low float64, streamBarIndex int) {
+	upStart, upEnd := p.boxIndexAtOrAbove(p.referencePrice), p.boxIndexAtOrBelow(high)
+	downStart, downEnd := p.boxIndexAtOrBelow(p.referencePrice), p.boxIndexAtOrAbove(low)
+
+	canRise := upEnd > upStart
+	canFall := downEnd < downStart
+
+	// when a single bar spans both directions, follow the direction of the bar
+	if canRise && canFall {
+		canRise = tickData.C() >= tickData.O()
+		canFall = !canRise
+	}
+
+	if canRise {
+		p.startColumn(XColumn, upStart, upEnd, tickData.D(), streamBarIndex)
+	} else if canFall {
+		p.startColumn(OColumn, downStart, downEnd, tickData.D(), streamBarIndex)
+	}
+}
+
+func (p *PnFStream) startColumn(columnType PnFColumnType, fromBoxIndex int, toBoxIndex int, date time.Time, streamBarIndex int) {
+	column := &PnFColumn{ColumnType: columnType}
+	p.Columns = append(p.Columns, column)
+	p.fillBoxes(column, fromBoxIndex, toBoxIndex, date, streamBarIndex)
+}
+
+func (p *PnFStream) extendColumn(column *PnFColumn, toBoxIndex int, date time.Time, streamBarIndex int) {
+	var fromBoxIndex int
+	if column.ColumnType == XColumn {
+		fromBoxIndex = column.HighBox().BoxIndex + 1
+	} else {
+		fromBoxIndex = column.LowBox().BoxIndex - 1
+	}
+	p.fillBoxes(column, fromBoxIndex, toBoxIndex, date, streamBarIndex)
+}
+
+func (p *PnFStream) fillBoxes(column *PnFColumn, fromBoxIndex int, toBoxIndex int, date time.Time, streamBarIndex int) {
+	step := 1
+	if column.ColumnType == OColumn {
+		step = -1
+	}
+
+	for boxIndex := fromBoxIndex; boxIndex != toBoxIndex+step; boxIndex += step {
+		price := p.BoxPrice(boxIndex)
+		column.Boxes = append(column.Boxes, PnFBox{BoxIndex: boxIndex, Price: price, Date: date, StreamBarIndex: streamBarIndex})
+
+		if p.minValue > price {
+			p.minValue = price
+		}
+
+		if p.maxValue < price {
+			p.maxValue = price
+		}
+	}
+
+	p.subscriberMutex.RLock()
+	subscribers := p.subscribers
+	p.subscriberMutex.RUnlock()
+
+	// notify all the subscribers of the new or updated column
+	for _, subscriber := range subscribers {
+		subscriber.ReceivePnFColumn(column, len(p.Columns)-1)
+	}
+}
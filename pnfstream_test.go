@@ -1 +1,239 @@
 package gotrade_test
+
+import (
+	. "github.com/jaybutera/gotrade"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"time"
+)
+
+type fakePnFColumnReceiver struct {
+	columnIndexes []int
+	boxCounts     []int
+}
+
+func (f *fakePnFColumnReceiver) ReceivePnFColumn(column *PnFColumn, columnIndex int) {
+	f.columnIndexes = append(f.columnIndexes, columnIndex)
+	f.boxCounts = append(f.boxCounts, column.BoxCount())
+}
+
+func closePricesToDOHLCV(closePrices []float64) []DOHLCV {
+	startDate := time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC)
+	var data []DOHLCV
+	for i, closePrice := range closePrices {
+		data = append(data, NewDOHLCVDataItem(startDate.AddDate(0, 0, i), closePrice, closePrice, closePrice, closePrice, 0.0))
+	}
+	return data
+}
+
+func boxIndexes(column *PnFColumn) []int {
+	var indexes []int
+	for _, box := range column.Boxes {
+		indexes = append(indexes, box.BoxIndex)
+	}
+	return indexes
+}
+
+var _ = Describe("when creating a point and figure stream", func() {
+	var (
+		stream    *PnFStream
+		streamErr error
+	)
+
+	Context("and the stream was given a box size of zero", func() {
+		BeforeEach(func() {
+			stream, streamErr = NewFixedBoxPnFStream(0, 3, CloseOnlyConstruction)
+		})
+
+		It("the stream should not be created and return the appropriate error message", func() {
+			Expect(stream).To(BeNil())
+			Expect(streamErr).To(Equal(ErrPnFBoxSizeMustBeGreaterThanZero))
+		})
+	})
+
+	Context("and the stream was given a reversal count of zero", func() {
+		BeforeEach(func() {
+			stream, streamErr = NewPercentageBoxPnFStream(1.0, 0, CloseOnlyConstruction)
+		})
+
+		It("the stream should not be created and return the appropriate error message", func() {
+			Expect(stream).To(BeNil())
+			Expect(streamErr).To(Equal(ErrPnFReversalCountMustBeGreaterThanZero))
+		})
+	})
+
+	Context("and the stream was given an average true range period of zero", func() {
+		BeforeEach(func() {
+			stream, streamErr = NewAtrBoxPnFStream(0, 3, HighLowConstruction)
+		})
+
+		It("the stream should not be created and return the appropriate error message", func() {
+			Expect(stream).To(BeNil())
+			Expect(streamErr).To(Equal(ErrPnFAtrPeriodMustBeGreaterThanZero))
+		})
+	})
+})
+
+var _ = Describe("when building a fixed box point and figure stream from close prices", func() {
+	var (
+		stream     *PnFStream
+		receiver   *fakePnFColumnReceiver
+		sourceData []DOHLCV
+	)
+
+	BeforeEach(func() {
+		stream, _ = NewFixedBoxPnFStream(1.0, 3, CloseOnlyConstruction)
+		receiver = &fakePnFColumnReceiver{}
+		stream.AddColumnSubscription(receiver)
+		sourceData = closePricesToDOHLCV([]float64{10, 11.5, 12, 13.2, 12.5, 11, 10, 9.4, 11, 13})
+		for i := range sourceData {
+			stream.ReceiveDOHLCVTick(sourceData[i], i+1)
+		}
+	})
+
+	It("should have built three columns", func() {
+		Expect(len(stream.Columns)).To(Equal(3))
+	})
+
+	It("should have started with a column of X's", func() {
+		Expect(stream.Columns[0].ColumnType).To(Equal(XColumn))
+		Expect(boxIndexes(stream.Columns[0])).To(Equal([]int{10, 11, 12, 13}))
+	})
+
+	It("should have reversed into a column of O's once the price fell by the reversal count", func() {
+		Expect(stream.Columns[1].ColumnType).To(Equal(OColumn))
+		Expect(boxIndexes(stream.Columns[1])).To(Equal([]int{12, 11, 10}))
+		Expect(stream.Columns[1].StartDate()).To(Equal(sourceData[6].D()))
+	})
+
+	It("should have reversed back into a column of X's", func() {
+		Expect(stream.Columns[2].ColumnType).To(Equal(XColumn))
+		Expect(stream.Columns[2].Low()).To(Equal(11.0))
+		Expect(stream.Columns[2].High()).To(Equal(13.0))
+	})
+
+	It("should record the bar that filled each box", func() {
+		Expect(stream.Columns[0].Boxes[2].StreamBarIndex).To(Equal(3))
+		Expect(stream.Columns[0].Boxes[3].Date).To(Equal(sourceData[3].D()))
+	})
+
+	It("should have notified the column subscribers of every new and extended column", func() {
+		Expect(receiver.columnIndexes).To(Equal([]int{0, 0, 0, 1, 2}))
+		Expect(receiver.boxCounts).To(Equal([]int{2, 3, 4, 3, 3}))
+	})
+
+	It("should have set the min and max box prices", func() {
+		Expect(stream.MinValue()).To(Equal(10.0))
+		Expect(stream.MaxValue()).To(Equal(13.0))
+	})
+})
+
+var _ = Describe("when building a fixed box point and figure stream from high and low prices", func() {
+	var (
+		stream *PnFStream
+	)
+
+	BeforeEach(func() {
+		stream, _ = NewFixedBoxPnFStream(1.0, 2, HighLowConstruction)
+		startDate := time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC)
+		sourceData := []DOHLCV{
+			NewDOHLCVDataItem(startDate, 10, 10.5, 9.5, 10, 0),
+			NewDOHLCVDataItem(startDate.AddDate(0, 0, 1), 10, 12.2, 9.8, 11, 0),
+			NewDOHLCVDataItem(startDate.AddDate(0, 0, 2), 11, 13.1, 9.5, 10, 0),
+			NewDOHLCVDataItem(startDate.AddDate(0, 0, 3), 10, 11.5, 10.9, 11, 0),
+		}
+		for i := range sourceData {
+			stream.ReceiveDOHLCVTick(sourceData[i], i+1)
+		}
+	})
+
+	It("should extend the column of X's using the high price in preference to reversing on the low", func() {
+		Expect(len(stream.Columns)).To(Equal(2))
+		Expect(boxIndexes(stream.Columns[0])).To(Equal([]int{10, 11, 12, 13}))
+	})
+
+	It("should reverse into a column of O's using the low price", func() {
+		Expect(stream.Columns[1].ColumnType).To(Equal(OColumn))
+		Expect(boxIndexes(stream.Columns[1])).To(Equal([]int{12, 11}))
+	})
+})
+
+var _ = Describe("when building a percentage box point and figure stream", func() {
+	var (
+		stream *PnFStream
+	)
+
+	BeforeEach(func() {
+		stream, _ = NewPercentageBoxPnFStream(10.0, 1, CloseOnlyConstruction)
+		sourceData := closePricesToDOHLCV([]float64{100, 121, 133.1})
+		for i := range sourceData {
+			stream.ReceiveDOHLCVTick(sourceData[i], i+1)
+		}
+	})
+
+	It("should size each box as a percentage of the box below it", func() {
+		Expect(boxIndexes(stream.Columns[0])).To(Equal([]int{0, 1, 2, 3}))
+		Expect(stream.Columns[0].Boxes[1].Price).To(BeNumerically("~", 110.0, 0.0001))
+		Expect(stream.Columns[0].High()).To(BeNumerically("~", 133.1, 0.0001))
+	})
+})
+
+var _ = Describe("when building an average true range box point and figure stream", func() {
+	var (
+		stream      *PnFStream
+		priceStream *InterDayDOHLCVStream
+	)
+
+	BeforeEach(func() {
+		priceStream = NewDailyDOHLCVStream()
+		stream, _ = NewAtrBoxPnFStreamForStream(priceStream, 2, 1, CloseOnlyConstruction)
+		startDate := time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC)
+		priceStream.ReceiveTick(NewDOHLCVDataItem(startDate, 10, 11, 9, 10, 0))
+		priceStream.ReceiveTick(NewDOHLCVDataItem(startDate.AddDate(0, 0, 1), 10, 11, 9, 10, 0))
+	})
+
+	It("should not know its box size before the average true range is available", func() {
+		Expect(stream.BoxSize()).To(Equal(0.0))
+		Expect(len(stream.Columns)).To(Equal(0))
+	})
+
+	Context("and the stream has received enough bars to calculate the average true range", func() {
+		BeforeEach(func() {
+			startDate := time.Date(2014, 1, 3, 0, 0, 0, 0, time.UTC)
+			priceStream.ReceiveTick(NewDOHLCVDataItem(startDate, 10, 12, 10, 12, 0))
+			priceStream.ReceiveTick(NewDOHLCVDataItem(startDate.AddDate(0, 0, 1), 12, 16, 12, 16, 0))
+		})
+
+		It("should use the average true range as the box size", func() {
+			Expect(stream.BoxSize()).To(Equal(2.0))
+		})
+
+		It("should build columns from the bar after the box size is known", func() {
+			Expect(len(stream.Columns)).To(Equal(1))
+			Expect(stream.Columns[0].Low()).To(Equal(12.0))
+			Expect(stream.Columns[0].High()).To(Equal(16.0))
+		})
+	})
+})
+
+var _ = Describe("when closing a point and figure column subscription", func() {
+	var (
+		stream   *PnFStream
+		receiver *fakePnFColumnReceiver
+	)
+
+	BeforeEach(func() {
+		stream, _ = NewFixedBoxPnFStream(1.0, 3, CloseOnlyConstruction)
+		receiver = &fakePnFColumnReceiver{}
+		subscription := stream.AddColumnSubscription(receiver)
+		sourceData := closePricesToDOHLCV([]float64{10, 11, 12, 13, 14})
+		stream.ReceiveDOHLCVTick(sourceData[0], 1)
+		stream.ReceiveDOHLCVTick(sourceData[1], 2)
+		subscription.Close()
+		stream.ReceiveDOHLCVTick(sourceData[2], 3)
+	})
+
+	It("should no longer notify the receiver", func() {
+		Expect(receiver.columnIndexes).To(Equal([]int{0}))
+	})
+})
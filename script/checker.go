@@ -0,0 +1,337 @@
+package script
+
+import (
+	"fmt"
+	"github.com/jaybutera/gotrade"
+	"github.com/jaybutera/gotrade/pipeline"
+	"math"
+	"strings"
+)
+
+// The Type of the value of an expression
+type Type int
+
+const (
+	// a number known before any bars are received, such as a literal or a time period
+	NumberType Type = iota
+	// a number for each bar, such as a price or the result of an indicator
+	SeriesType
+	// a condition for each bar, such as a comparison or a crossing
+	BoolType
+)
+
+func (t Type) String() string {
+	switch t {
+	case NumberType:
+		return "number"
+	case SeriesType:
+		return "series"
+	case BoolType:
+		return "bool"
+	}
+	return "unknown"
+}
+
+func (t Type) isNumeric() bool {
+	return t == NumberType || t == SeriesType
+}
+
+var priceSelectors = map[string]gotrade.DOHLCVDataSelectionFunc{
+	"open":   gotrade.UseOpenPrice,
+	"high":   gotrade.UseHighPrice,
+	"low":    gotrade.UseLowPrice,
+	"close":  gotrade.UseClosePrice,
+	"volume": gotrade.UseVolume,
+}
+
+// how an indicator call is created and fed
+type callInfo struct {
+	factory *pipeline.IndicatorFactory
+	// the series the indicator is calculated from, nil for an indicator calculated from the bars
+	source    expr
+	arguments pipeline.Arguments
+	output    pipeline.OutputFunc
+}
+
+type checker struct {
+	registry  *pipeline.Registry
+	types     map[expr]Type
+	constants map[expr]float64
+	calls     map[*callExpr]*callInfo
+	errs      Errors
+}
+
+func (c *checker) fail(pos Position, format string, args ...interface{}) {
+	c.errs = append(c.errs, &Error{Pos: pos, Msg: fmt.Sprintf(format, args...)})
+}
+
+// check returns the type of an expression, or false if it has errors, which have been reported
+func (c *checker) check(e expr) (t Type, ok bool) {
+	switch e := e.(type) {
+	case *numberExpr:
+		c.constants[e] = e.value
+		t, ok = NumberType, true
+	case *boolExpr:
+		t, ok = BoolType, true
+	case *identExpr:
+		t, ok = c.checkIdent(e)
+	case *callExpr:
+		t, ok = c.checkCall(e)
+	case *unaryExpr:
+		t, ok = c.checkUnary(e)
+	case *binaryExpr:
+		t, ok = c.checkBinary(e)
+	case *crossesExpr:
+		t, ok = c.checkCrosses(e)
+	}
+
+	if ok {
+		c.types[e] = t
+	}
+	return t, ok
+}
+
+func (c *checker) checkIdent(e *identExpr) (t Type, ok bool) {
+	if _, found := priceSelectors[e.name]; found {
+		return SeriesType, true
+	}
+
+	if _, found := c.registry.Lookup(e.name); found {
+		c.fail(e.pos, "%s is an indicator, call it as %s()", e.name, e.name)
+	} else {
+		c.fail(e.pos, "unknown name %q, expected one of open, high, low, close or volume", e.name)
+	}
+	return t, false
+}
+
+func (c *checker) checkUnary(e *unaryExpr) (t Type, ok bool) {
+	operandType, ok := c.check(e.operand)
+	if !ok {
+		return t, false
+	}
+
+	if e.op == tokenNot {
+		if operandType != BoolType {
+			c.fail(e.pos, "'not' needs a bool, found a %s", operandType)
+			return t, false
+		}
+		return BoolType, true
+	}
+
+	if !operandType.isNumeric() {
+		c.fail(e.pos, "'-' needs a number or series, found a %s", operandType)
+		return t, false
+	}
+
+	if operandType == NumberType {
+		c.constants[e] = -c.constants[e.operand]
+	}
+	return operandType, true
+}
+
+func (c *checker) checkBinary(e *binaryExpr) (t Type, ok bool) {
+	leftType, leftOk := c.check(e.left)
+	rightType, rightOk := c.check(e.right)
+	if !leftOk || !rightOk {
+		return t, false
+	}
+
+	switch e.op {
+	case tokenAnd, tokenOr:
+		if leftType != BoolType || rightType != BoolType {
+			c.fail(e.pos, "%s needs bools, found a %s and a %s", e.op, leftType, rightType)
+			return t, false
+		}
+		return BoolType, true
+	case tokenLess, tokenLessEqual, tokenGreater, tokenGreaterEqual, tokenEqual, tokenNotEqual:
+		if !leftType.isNumeric() || !rightType.isNumeric() {
+			c.fail(e.pos, "%s needs numbers or series, found a %s and a %s", e.op, leftType, rightType)
+			return t, false
+		}
+		return BoolType, true
+	}
+
+	// arithmetic
+	if !leftType.isNumeric() || !rightType.isNumeric() {
+		c.fail(e.pos, "%s needs numbers or series, found a %s and a %s", e.op, leftType, rightType)
+		return t, false
+	}
+
+	if leftType == NumberType && rightType == NumberType {
+		c.constants[e] = arithmetic(e.op, c.constants[e.left], c.constants[e.right])
+		return NumberType, true
+	}
+	return SeriesType, true
+}
+
+func (c *checker) checkCrosses(e *crossesExpr) (t Type, ok bool) {
+	leftType, leftOk := c.check(e.left)
+	rightType, rightOk := c.check(e.right)
+	if !leftOk || !rightOk {
+		return t, false
+	}
+
+	if !leftType.isNumeric() || !rightType.isNumeric() {
+		c.fail(e.pos, "'crosses' needs numbers or series, found a %s and a %s", leftType, rightType)
+		return t, false
+	}
+
+	if leftType == NumberType && rightType == NumberType {
+		c.fail(e.pos, "'crosses' needs a series, two numbers never cross")
+		return t, false
+	}
+	return BoolType, true
+}
+
+func (c *checker) checkCall(e *callExpr) (t Type, ok bool) {
+	factory, found := c.registry.Lookup(e.name)
+	if !found {
+		c.fail(e.pos, "unknown indicator %q", e.name)
+		return t, false
+	}
+
+	info := &callInfo{factory: factory}
+
+	// an indicator with the default parameters shows how an indicator of the factory is fed and publishes its results
+	probe, err := factory.New(nil)
+	if err != nil {
+		c.fail(e.pos, "%s can not be created, %s", e.name, err)
+		return t, false
+	}
+
+	if len(factory.Outputs) > 0 {
+		if e.output == "" {
+			c.fail(e.pos, "%s has several outputs, expected one of %s", e.name, outputList(factory))
+			return t, false
+		}
+
+		if info.output, found = factory.Outputs[e.output]; !found {
+			c.fail(e.pos, "%s has no output %q, expected one of %s", e.name, e.output, outputList(factory))
+			return t, false
+		}
+	} else {
+		if e.output != "" {
+			c.fail(e.pos, "%s has a single output, %q is not needed", e.name, e.output)
+			return t, false
+		}
+
+		if _, publishes := probe.(gotrade.FloatStreamSubscriber); !publishes {
+			c.fail(e.pos, "%s does not publish its results for use in scripts", e.name)
+			return t, false
+		}
+	}
+
+	argTypes := make([]Type, len(e.args))
+	for i, arg := range e.args {
+		if argTypes[i], ok = c.check(arg); !ok {
+			return t, false
+		}
+	}
+
+	// a leading series argument is the source of the indicator, otherwise it is calculated from the bars
+	args := e.args
+	if len(args) > 0 && argTypes[0] == SeriesType {
+		if _, receivesSeries := probe.(gotrade.TickReceiver); !receivesSeries {
+			c.fail(args[0].position(), "%s is calculated from the bars and takes no source series", e.name)
+			return t, false
+		}
+		info.source = args[0]
+		args, argTypes = args[1:], argTypes[1:]
+	}
+
+	// the remaining arguments are the numeric parameters in order, the price parameters are replaced by the source
+	var parameters []pipeline.Parameter
+	for _, parameter := range factory.Parameters {
+		if parameter.Type != pipeline.PriceParameter {
+			parameters = append(parameters, parameter)
+		}
+	}
+
+	if len(args) > len(parameters) {
+		c.fail(args[len(parameters)].position(), "%s takes at most %d parameters, %s", e.name, len(parameters), parameterList(parameters))
+		return t, false
+	}
+
+	values := make(map[string]interface{}, len(args))
+	for i, arg := range args {
+		if argTypes[i] != NumberType {
+			c.fail(arg.position(), "%s parameter %s must be a number, found a %s", e.name, parameters[i].Name, argTypes[i])
+			return t, false
+		}
+		values[parameters[i].Name] = c.constants[arg]
+	}
+
+	if info.arguments, err = factory.Arguments(values); err != nil {
+		for _, validationErr := range err.(pipeline.ValidationErrors) {
+			c.fail(argumentPosition(e, args, parameters, validationErr.Path), "%s parameter %s", e.name, validationErr)
+		}
+		return t, false
+	}
+
+	// catch the range errors of the indicator now rather than when the script is evaluated
+	if _, err = factory.Create(info.arguments); err != nil {
+		c.fail(argumentPosition(e, args, parameters, strings.SplitN(err.Error(), " ", 2)[0]), "%s %s", e.name, err)
+		return t, false
+	}
+
+	c.calls[e] = info
+	return SeriesType, true
+}
+
+// argumentPosition returns the position of the argument given for a parameter, or of the call itself
+func argumentPosition(e *callExpr, args []expr, parameters []pipeline.Parameter, name string) Position {
+	for i := range args {
+		if parameters[i].Name == name {
+			return args[i].position()
+		}
+	}
+	return e.pos
+}
+
+func outputList(factory *pipeline.IndicatorFactory) string {
+	return "." + strings.Join(factory.OutputNames(), ", .")
+}
+
+func parameterList(parameters []pipeline.Parameter) string {
+	if len(parameters) == 0 {
+		return "it has none"
+	}
+
+	names := make([]string, len(parameters))
+	for i, parameter := range parameters {
+		names[i] = parameter.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+func arithmetic(op tokenKind, left float64, right float64) float64 {
+	switch op {
+	case tokenPlus:
+		return left + right
+	case tokenMinus:
+		return left - right
+	case tokenStar:
+		return left * right
+	case tokenSlash:
+		return left / right
+	}
+	return math.NaN()
+}
+
+func compare(op tokenKind, left float64, right float64) bool {
+	switch op {
+	case tokenLess:
+		return left < right
+	case tokenLessEqual:
+		return left <= right
+	case tokenGreater:
+		return left > right
+	case tokenGreaterEqual:
+		return left >= right
+	case tokenEqual:
+		return left == right
+	case tokenNotEqual:
+		return left != right
+	}
+	return false
+}
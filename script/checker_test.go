@@ -0,0 +1,79 @@
+package script_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/jaybutera/gotrade/indicators"
+	"github.com/jaybutera/gotrade/pipeline"
+	"github.com/jaybutera/gotrade/script"
+)
+
+var _ = Describe("when type checking a script", func() {
+	typeOf := func(source string) script.Type {
+		program, err := script.Compile(source)
+		Expect(err).NotTo(HaveOccurred())
+		return program.Type()
+	}
+
+	errorsOf := func(source string) []string {
+		_, err := script.Compile(source)
+		Expect(err).To(HaveOccurred())
+		errs := err.(script.Errors)
+		messages := make([]string, len(errs))
+		for i := range errs {
+			messages[i] = errs[i].Error()
+		}
+		return messages
+	}
+
+	It("should type numbers, series and bools", func() {
+		Expect(typeOf("2 * 10 + 1")).To(Equal(script.NumberType))
+		Expect(typeOf("(high + low) / 2")).To(Equal(script.SeriesType))
+		Expect(typeOf("macd(close).signal - 1")).To(Equal(script.SeriesType))
+		Expect(typeOf("close > open")).To(Equal(script.BoolType))
+		Expect(typeOf("atr(14) crosses below 5")).To(Equal(script.BoolType))
+	})
+
+	It("should report unknown prices and indicators", func() {
+		Expect(errorsOf("price > smma(close, 5)")).To(Equal([]string{
+			`1:1: unknown name "price", expected one of open, high, low, close or volume`,
+			`1:9: unknown indicator "smma"`,
+		}))
+		Expect(errorsOf("obv > 0")).To(Equal([]string{"1:1: obv is an indicator, call it as obv()"}))
+	})
+
+	It("should report mismatched operand types", func() {
+		Expect(errorsOf("close and open > 1")).To(Equal([]string{"1:7: 'and' needs bools, found a series and a bool"}))
+		Expect(errorsOf("(close > open) + 1")).To(Equal([]string{"1:16: '+' needs numbers or series, found a bool and a number"}))
+		Expect(errorsOf("not close")).To(Equal([]string{"1:1: 'not' needs a bool, found a series"}))
+		Expect(errorsOf("10 crosses above 20")).To(Equal([]string{"1:4: 'crosses' needs a series, two numbers never cross"}))
+	})
+
+	It("should report invalid indicator parameters at the argument", func() {
+		Expect(errorsOf("sma(close, 1) > 0")).To(Equal([]string{"1:12: sma timePeriod is less than the minimum (2)"}))
+		Expect(errorsOf("sma(close, 2.5) > 0")).To(Equal([]string{"1:12: sma parameter timePeriod: expected an int, got 2.5"}))
+		Expect(errorsOf("sma(close, high) > 0")).To(Equal([]string{"1:12: sma parameter timePeriod must be a number, found a series"}))
+		Expect(errorsOf("rsi(close, 14, 3) > 0")).To(Equal([]string{"1:16: rsi takes at most 1 parameters, timePeriod"}))
+	})
+
+	It("should report sources given to indicators calculated from the bars", func() {
+		Expect(errorsOf("atr(close, 14) > 0")).To(Equal([]string{"1:5: atr is calculated from the bars and takes no source series"}))
+	})
+
+	It("should report the outputs of indicators with several", func() {
+		Expect(errorsOf("macd(close) > 0")).To(Equal([]string{"1:1: macd has several outputs, expected one of .histogram, .macd, .signal"}))
+		Expect(errorsOf("bbands(close).top > 0")).To(Equal([]string{`1:1: bbands has no output "top", expected one of .lower, .middle, .upper`}))
+	})
+
+	It("should report indicators that do not publish their results", func() {
+		registry := pipeline.NewRegistry()
+		registry.Register(&pipeline.IndicatorFactory{Name: "linreg",
+			Create: func(arguments pipeline.Arguments) (interface{}, error) {
+				return indicators.NewLinRegWithoutStorage(14, func(float64, float64, float64, int) {})
+			}})
+
+		_, err := script.CompileWithRegistry("linreg() > 0", registry)
+		Expect(err).To(MatchError("1:1: linreg does not publish its results for use in scripts"))
+		Expect(typeOf("linreg(close) > 0")).To(Equal(script.BoolType))
+	})
+})
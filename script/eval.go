@@ -0,0 +1,182 @@
+package script
+
+import (
+	"github.com/jaybutera/gotrade"
+)
+
+// the value of an expression for a bar
+type value struct {
+	number    float64
+	condition bool
+}
+
+// a node evaluates an expression once per bar, ok is false until the expression has a value,
+// e.g. an indicator within its lookback period. Every node is evaluated on every bar, so that the
+// indicators within receive every bar regardless of the operators around them.
+type node interface {
+	evaluate(bar gotrade.DOHLCV, streamBarIndex int) (v value, ok bool)
+}
+
+type constantNode struct {
+	v value
+}
+
+func (n *constantNode) evaluate(bar gotrade.DOHLCV, streamBarIndex int) (v value, ok bool) {
+	return n.v, true
+}
+
+type priceNode struct {
+	selectData gotrade.DOHLCVDataSelectionFunc
+}
+
+func (n *priceNode) evaluate(bar gotrade.DOHLCV, streamBarIndex int) (v value, ok bool) {
+	return value{number: n.selectData(bar)}, true
+}
+
+// resultReceiver holds the latest result an indicator published
+type resultReceiver struct {
+	value          float64
+	streamBarIndex int
+}
+
+func (r *resultReceiver) ReceiveTick(tickData float64, streamBarIndex int) {
+	r.value = tickData
+	r.streamBarIndex = streamBarIndex
+}
+
+type indicatorNode struct {
+	// the series the indicator is calculated from, nil for an indicator calculated from the bars
+	source  node
+	series  gotrade.TickReceiver
+	bars    gotrade.DOHLCVTickReceiver
+	results *resultReceiver
+}
+
+func (n *indicatorNode) evaluate(bar gotrade.DOHLCV, streamBarIndex int) (v value, ok bool) {
+	if n.source == nil {
+		n.bars.ReceiveDOHLCVTick(bar, streamBarIndex)
+	} else if source, sourceOk := n.source.evaluate(bar, streamBarIndex); sourceOk {
+		n.series.ReceiveTick(source.number, streamBarIndex)
+	}
+
+	if n.results.streamBarIndex != streamBarIndex {
+		return v, false
+	}
+	return value{number: n.results.value}, true
+}
+
+type unaryNode struct {
+	op      tokenKind
+	operand node
+}
+
+func (n *unaryNode) evaluate(bar gotrade.DOHLCV, streamBarIndex int) (v value, ok bool) {
+	operand, ok := n.operand.evaluate(bar, streamBarIndex)
+	if !ok {
+		return v, false
+	}
+
+	if n.op == tokenNot {
+		return value{condition: !operand.condition}, true
+	}
+	return value{number: -operand.number}, true
+}
+
+type binaryNode struct {
+	op    tokenKind
+	left  node
+	right node
+}
+
+func (n *binaryNode) evaluate(bar gotrade.DOHLCV, streamBarIndex int) (v value, ok bool) {
+	left, leftOk := n.left.evaluate(bar, streamBarIndex)
+	right, rightOk := n.right.evaluate(bar, streamBarIndex)
+	if !leftOk || !rightOk {
+		return v, false
+	}
+
+	switch n.op {
+	case tokenAnd:
+		return value{condition: left.condition && right.condition}, true
+	case tokenOr:
+		return value{condition: left.condition || right.condition}, true
+	case tokenLess, tokenLessEqual, tokenGreater, tokenGreaterEqual, tokenEqual, tokenNotEqual:
+		return value{condition: compare(n.op, left.number, right.number)}, true
+	}
+	return value{number: arithmetic(n.op, left.number, right.number)}, true
+}
+
+// crossesNode is true on the bar the left moves from at or below the right to above it,
+// or from at or above to below it, starting from the second bar both sides have a value
+type crossesNode struct {
+	above         bool
+	left          node
+	right         node
+	previousOk    bool
+	previousLeft  float64
+	previousRight float64
+}
+
+func (n *crossesNode) evaluate(bar gotrade.DOHLCV, streamBarIndex int) (v value, ok bool) {
+	left, leftOk := n.left.evaluate(bar, streamBarIndex)
+	right, rightOk := n.right.evaluate(bar, streamBarIndex)
+	if !leftOk || !rightOk {
+		return v, false
+	}
+
+	previousOk, previousLeft, previousRight := n.previousOk, n.previousLeft, n.previousRight
+	n.previousOk, n.previousLeft, n.previousRight = true, left.number, right.number
+	if !previousOk {
+		return v, false
+	}
+
+	if n.above {
+		return value{condition: previousLeft <= previousRight && left.number > right.number}, true
+	}
+	return value{condition: previousLeft >= previousRight && left.number < right.number}, true
+}
+
+// build creates the nodes of an expression, with new indicators, for one evaluation of a program
+func (p *Program) build(e expr) node {
+	if p.types[e] == NumberType {
+		return &constantNode{v: value{number: p.constants[e]}}
+	}
+
+	switch e := e.(type) {
+	case *boolExpr:
+		return &constantNode{v: value{condition: e.value}}
+	case *identExpr:
+		return &priceNode{selectData: priceSelectors[e.name]}
+	case *callExpr:
+		return p.buildIndicator(e)
+	case *unaryExpr:
+		return &unaryNode{op: e.op, operand: p.build(e.operand)}
+	case *binaryExpr:
+		return &binaryNode{op: e.op, left: p.build(e.left), right: p.build(e.right)}
+	case *crossesExpr:
+		return &crossesNode{above: e.above, left: p.build(e.left), right: p.build(e.right)}
+	}
+	return nil
+}
+
+func (p *Program) buildIndicator(e *callExpr) node {
+	info := p.calls[e]
+
+	// the arguments were created successfully whilst checking
+	indicator, _ := info.factory.Create(info.arguments)
+
+	n := &indicatorNode{results: &resultReceiver{streamBarIndex: -1}}
+	if info.source != nil {
+		n.source = p.build(info.source)
+		n.series = indicator.(gotrade.TickReceiver)
+	} else {
+		n.bars = indicator.(gotrade.DOHLCVTickReceiver)
+	}
+
+	if info.output != nil {
+		info.output(indicator).AddTickSubscription(n.results)
+	} else {
+		indicator.(gotrade.FloatStreamSubscriber).AddTickSubscription(n.results)
+	}
+	return n
+}
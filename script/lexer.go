@@ -0,0 +1,205 @@
+package script
+
+import (
+	"fmt"
+	"strconv"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenNumber
+	tokenLeftParen
+	tokenRightParen
+	tokenComma
+	tokenDot
+	tokenPlus
+	tokenMinus
+	tokenStar
+	tokenSlash
+	tokenLess
+	tokenLessEqual
+	tokenGreater
+	tokenGreaterEqual
+	tokenEqual
+	tokenNotEqual
+	// keywords
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenTrue
+	tokenFalse
+	tokenCrosses
+	tokenAbove
+	tokenBelow
+)
+
+var keywords = map[string]tokenKind{
+	"and":     tokenAnd,
+	"or":      tokenOr,
+	"not":     tokenNot,
+	"true":    tokenTrue,
+	"false":   tokenFalse,
+	"crosses": tokenCrosses,
+	"above":   tokenAbove,
+	"below":   tokenBelow,
+}
+
+var tokenNames = map[tokenKind]string{
+	tokenEOF:          "end of script",
+	tokenIdent:        "name",
+	tokenNumber:       "number",
+	tokenLeftParen:    "'('",
+	tokenRightParen:   "')'",
+	tokenComma:        "','",
+	tokenDot:          "'.'",
+	tokenPlus:         "'+'",
+	tokenMinus:        "'-'",
+	tokenStar:         "'*'",
+	tokenSlash:        "'/'",
+	tokenLess:         "'<'",
+	tokenLessEqual:    "'<='",
+	tokenGreater:      "'>'",
+	tokenGreaterEqual: "'>='",
+	tokenEqual:        "'=='",
+	tokenNotEqual:     "'!='",
+	tokenAnd:          "'and'",
+	tokenOr:           "'or'",
+	tokenNot:          "'not'",
+	tokenTrue:         "'true'",
+	tokenFalse:        "'false'",
+	tokenCrosses:      "'crosses'",
+	tokenAbove:        "'above'",
+	tokenBelow:        "'below'",
+}
+
+func (k tokenKind) String() string {
+	return tokenNames[k]
+}
+
+type token struct {
+	kind   tokenKind
+	text   string
+	number float64
+	pos    Position
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenIdent, tokenNumber:
+		return fmt.Sprintf("%s %q", t.kind, t.text)
+	}
+	return t.kind.String()
+}
+
+// lex splits a script into tokens, ending with an end of script token
+func lex(source string) (tokens []token, err error) {
+	runes := []rune(source)
+	line, column := 1, 1
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		pos := Position{Line: line, Column: column}
+
+		// advance moves past n runes on the current line
+		advance := func(n int) {
+			i += n
+			column += n
+		}
+
+		switch {
+		case r == '\n':
+			i++
+			line++
+			column = 1
+		case unicode.IsSpace(r):
+			advance(1)
+		case r == '#':
+			// a comment runs to the end of the line
+			for i < len(runes) && runes[i] != '\n' {
+				advance(1)
+			}
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				advance(1)
+			}
+			text := string(runes[start:i])
+			kind, isKeyword := keywords[text]
+			if !isKeyword {
+				kind = tokenIdent
+			}
+			tokens = append(tokens, token{kind: kind, text: text, pos: pos})
+		case unicode.IsDigit(r) || (r == '.' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				advance(1)
+			}
+			text := string(runes[start:i])
+			number, parseErr := strconv.ParseFloat(text, 64)
+			if parseErr != nil {
+				return nil, &Error{Pos: pos, Msg: fmt.Sprintf("invalid number %q", text)}
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: text, number: number, pos: pos})
+		default:
+			kind, width := operatorToken(runes[i:])
+			if width == 0 {
+				return nil, &Error{Pos: pos, Msg: fmt.Sprintf("unexpected character %q", r)}
+			}
+			tokens = append(tokens, token{kind: kind, text: string(runes[i : i+width]), pos: pos})
+			advance(width)
+		}
+	}
+
+	tokens = append(tokens, token{kind: tokenEOF, pos: Position{Line: line, Column: column}})
+	return tokens, nil
+}
+
+// operatorToken matches the punctuation at the start of runes, returning a width of 0 for no match
+func operatorToken(runes []rune) (kind tokenKind, width int) {
+	next := rune(0)
+	if len(runes) > 1 {
+		next = runes[1]
+	}
+
+	switch runes[0] {
+	case '(':
+		return tokenLeftParen, 1
+	case ')':
+		return tokenRightParen, 1
+	case ',':
+		return tokenComma, 1
+	case '.':
+		return tokenDot, 1
+	case '+':
+		return tokenPlus, 1
+	case '-':
+		return tokenMinus, 1
+	case '*':
+		return tokenStar, 1
+	case '/':
+		return tokenSlash, 1
+	case '<':
+		if next == '=' {
+			return tokenLessEqual, 2
+		}
+		return tokenLess, 1
+	case '>':
+		if next == '=' {
+			return tokenGreaterEqual, 2
+		}
+		return tokenGreater, 1
+	case '=':
+		if next == '=' {
+			return tokenEqual, 2
+		}
+	case '!':
+		if next == '=' {
+			return tokenNotEqual, 2
+		}
+	}
+	return tokenEOF, 0
+}
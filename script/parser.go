@@ -0,0 +1,331 @@
+package script
+
+import (
+	"fmt"
+	"strings"
+)
+
+// A Position in a script, lines and columns start at 1
+type Position struct {
+	Line   int
+	Column int
+}
+
+func (p Position) String() string {
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
+// An Error is a problem found in a script whilst parsing or type checking it
+type Error struct {
+	Pos Position
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return e.Pos.String() + ": " + e.Msg
+}
+
+// Errors are all the problems found in a script, in the order they appear
+type Errors []*Error
+
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// the expressions of a parsed script
+type expr interface {
+	position() Position
+}
+
+type numberExpr struct {
+	pos   Position
+	value float64
+}
+
+type boolExpr struct {
+	pos   Position
+	value bool
+}
+
+// a price of the current bar, e.g. close
+type identExpr struct {
+	pos  Position
+	name string
+}
+
+// an indicator, with the output named for an indicator with several, e.g. macd(close).signal
+type callExpr struct {
+	pos    Position
+	name   string
+	args   []expr
+	output string
+}
+
+type unaryExpr struct {
+	pos     Position
+	op      tokenKind
+	operand expr
+}
+
+type binaryExpr struct {
+	pos   Position
+	op    tokenKind
+	left  expr
+	right expr
+}
+
+// the left crossing above, or below, the right
+type crossesExpr struct {
+	pos   Position
+	above bool
+	left  expr
+	right expr
+}
+
+func (e *numberExpr) position() Position  { return e.pos }
+func (e *boolExpr) position() Position    { return e.pos }
+func (e *identExpr) position() Position   { return e.pos }
+func (e *callExpr) position() Position    { return e.pos }
+func (e *unaryExpr) position() Position   { return e.pos }
+func (e *binaryExpr) position() Position  { return e.pos }
+func (e *crossesExpr) position() Position { return e.pos }
+
+// parse builds the expression of a script, by precedence from lowest to highest:
+//
+//	or
+//	and
+//	not
+//	< <= > >= == != crosses above, crosses below
+//	+ -
+//	* /
+//	unary -
+//	numbers, true, false, prices, indicators and parentheses
+func parse(source string) (e expr, err error) {
+	tokens, err := lex(source)
+	if err != nil {
+		return nil, err
+	}
+
+	p := parser{tokens: tokens}
+	if e, err = p.parseOr(); err != nil {
+		return nil, err
+	}
+
+	if p.peek().kind != tokenEOF {
+		return nil, p.unexpected("an operator")
+	}
+	return e, nil
+}
+
+type parser struct {
+	tokens []token
+	next   int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.next]
+}
+
+func (p *parser) take() token {
+	t := p.tokens[p.next]
+	if t.kind != tokenEOF {
+		p.next++
+	}
+	return t
+}
+
+func (p *parser) expect(kind tokenKind) (t token, err error) {
+	if p.peek().kind != kind {
+		return t, p.unexpected(kind.String())
+	}
+	return p.take(), nil
+}
+
+func (p *parser) unexpected(expected string) *Error {
+	t := p.peek()
+	return &Error{Pos: t.pos, Msg: fmt.Sprintf("expected %s, found %s", expected, t)}
+}
+
+func (p *parser) parseOr() (e expr, err error) {
+	if e, err = p.parseAnd(); err != nil {
+		return nil, err
+	}
+
+	for p.peek().kind == tokenOr {
+		op := p.take()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		e = &binaryExpr{pos: op.pos, op: op.kind, left: e, right: right}
+	}
+	return e, nil
+}
+
+func (p *parser) parseAnd() (e expr, err error) {
+	if e, err = p.parseNot(); err != nil {
+		return nil, err
+	}
+
+	for p.peek().kind == tokenAnd {
+		op := p.take()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		e = &binaryExpr{pos: op.pos, op: op.kind, left: e, right: right}
+	}
+	return e, nil
+}
+
+func (p *parser) parseNot() (e expr, err error) {
+	if p.peek().kind == tokenNot {
+		op := p.take()
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryExpr{pos: op.pos, op: op.kind, operand: operand}, nil
+	}
+	return p.parseComparison()
+}
+
+// comparisons do not chain, a < b < c is an error rather than comparing a bool with c
+func (p *parser) parseComparison() (e expr, err error) {
+	if e, err = p.parseSum(); err != nil {
+		return nil, err
+	}
+
+	switch op := p.peek(); op.kind {
+	case tokenLess, tokenLessEqual, tokenGreater, tokenGreaterEqual, tokenEqual, tokenNotEqual:
+		p.take()
+		right, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+		e = &binaryExpr{pos: op.pos, op: op.kind, left: e, right: right}
+	case tokenCrosses:
+		p.take()
+		direction := p.take()
+		if direction.kind != tokenAbove && direction.kind != tokenBelow {
+			p.next--
+			return nil, p.unexpected("'above' or 'below' after 'crosses'")
+		}
+		right, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+		e = &crossesExpr{pos: op.pos, above: direction.kind == tokenAbove, left: e, right: right}
+	}
+	return e, nil
+}
+
+func (p *parser) parseSum() (e expr, err error) {
+	if e, err = p.parseProduct(); err != nil {
+		return nil, err
+	}
+
+	for p.peek().kind == tokenPlus || p.peek().kind == tokenMinus {
+		op := p.take()
+		right, err := p.parseProduct()
+		if err != nil {
+			return nil, err
+		}
+		e = &binaryExpr{pos: op.pos, op: op.kind, left: e, right: right}
+	}
+	return e, nil
+}
+
+func (p *parser) parseProduct() (e expr, err error) {
+	if e, err = p.parseUnary(); err != nil {
+		return nil, err
+	}
+
+	for p.peek().kind == tokenStar || p.peek().kind == tokenSlash {
+		op := p.take()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		e = &binaryExpr{pos: op.pos, op: op.kind, left: e, right: right}
+	}
+	return e, nil
+}
+
+func (p *parser) parseUnary() (e expr, err error) {
+	if p.peek().kind == tokenMinus {
+		op := p.take()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryExpr{pos: op.pos, op: op.kind, operand: operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (e expr, err error) {
+	t := p.peek()
+	switch t.kind {
+	case tokenNumber:
+		p.take()
+		return &numberExpr{pos: t.pos, value: t.number}, nil
+	case tokenTrue, tokenFalse:
+		p.take()
+		return &boolExpr{pos: t.pos, value: t.kind == tokenTrue}, nil
+	case tokenLeftParen:
+		p.take()
+		if e, err = p.parseOr(); err != nil {
+			return nil, err
+		}
+		if _, err = p.expect(tokenRightParen); err != nil {
+			return nil, err
+		}
+		return e, nil
+	case tokenIdent:
+		p.take()
+		if p.peek().kind != tokenLeftParen {
+			return &identExpr{pos: t.pos, name: t.text}, nil
+		}
+		return p.parseCall(t)
+	}
+	return nil, p.unexpected("a number, price or indicator")
+}
+
+func (p *parser) parseCall(name token) (e expr, err error) {
+	call := &callExpr{pos: name.pos, name: name.text}
+	p.take()
+
+	if p.peek().kind != tokenRightParen {
+		for {
+			arg, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			call.args = append(call.args, arg)
+
+			if p.peek().kind != tokenComma {
+				break
+			}
+			p.take()
+		}
+	}
+
+	if _, err = p.expect(tokenRightParen); err != nil {
+		return nil, err
+	}
+
+	if p.peek().kind == tokenDot {
+		p.take()
+		output, err := p.expect(tokenIdent)
+		if err != nil {
+			return nil, err
+		}
+		call.output = output.text
+	}
+	return call, nil
+}
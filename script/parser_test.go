@@ -0,0 +1,45 @@
+package script_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/jaybutera/gotrade/script"
+)
+
+var _ = Describe("when parsing a script", func() {
+	It("should accept the operators, comments and several lines", func() {
+		_, err := script.Compile(`# entry
+			sma(close, 20) crosses above ema(close, 50)
+			and not (rsi(14) >= 70 or close / open - 1 > 0.05)`)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should report an unexpected character with its position", func() {
+		_, err := script.Compile("close > 10 & open > 10")
+		Expect(err).To(Equal(&script.Error{Pos: script.Position{Line: 1, Column: 12}, Msg: `unexpected character '&'`}))
+	})
+
+	It("should report a missing closing parenthesis", func() {
+		_, err := script.Compile("sma(close, 20")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("1:14: expected ')', found end of script"))
+	})
+
+	It("should report crosses without a direction", func() {
+		_, err := script.Compile("close crosses sma(close)")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal(`1:15: expected 'above' or 'below' after 'crosses', found name "sma"`))
+	})
+
+	It("should report chained comparisons", func() {
+		_, err := script.Compile("10 < close < 20")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("1:12: expected an operator, found '<'"))
+	})
+
+	It("should report the position on later lines", func() {
+		_, err := script.Compile("close > 10 and\n  open >")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("2:9: expected a number, price or indicator, found end of script"))
+	})
+})
@@ -0,0 +1,165 @@
+/*
+	import "github.com/jaybutera/gotrade/script"
+
+	Package script provides an expression language for prototyping indicators and trading conditions
+	without recompiling, evaluated bar by bar against a price stream, e.g.
+
+		sma(close, 20) crosses above ema(close, 50) and rsi(14) < 70
+
+	A script is made of:
+		- the prices of the current bar, open, high, low, close and volume.
+		- indicators called by their names in the pipeline registry, e.g. sma, macd and bbands,
+		- with an optional leading source series followed by their numeric parameters in order,
+		- an indicator without a source is calculated from the bars, using the close where it selects a price.
+		- the named output of an indicator with several, e.g. macd(close, 12, 26, 9).signal
+		- arithmetic + - * /, comparisons < <= > >= == !=, and the crossings 'crosses above' and 'crosses below'.
+		- the conditions and, or, not, true and false, parentheses, and comments from # to the end of the line.
+
+	Scripts are type checked when compiled, an expression is either a number known before any bars are
+	received, a series with a number for each bar, or a bool with a condition for each bar. The result
+	of a script is available from the first bar all the indicators within it have a value for.
+
+	A compiled program creates any number of scripts, each with its own indicators, e.g.
+
+		program, err := script.Compile("sma(close, 20) crosses above ema(close, 50) and rsi(14) < 70")
+		entry := program.NewScriptForStream(priceStream)
+		csvFeed.FillDOHLCVStream(priceStream)
+		fmt.Println(entry.Signals)
+*/
+package script
+
+import (
+	"github.com/jaybutera/gotrade"
+	"github.com/jaybutera/gotrade/pipeline"
+)
+
+// A Program is a parsed and type checked script
+type Program struct {
+	source    string
+	root      expr
+	types     map[expr]Type
+	constants map[expr]float64
+	calls     map[*callExpr]*callInfo
+}
+
+// Compile parses and type checks a script, with the indicators of the default pipeline registry
+func Compile(source string) (program *Program, err error) {
+	return CompileWithRegistry(source, pipeline.DefaultRegistry())
+}
+
+// CompileWithRegistry parses and type checks a script, with the indicators of the registry.
+// A syntax error is returned as an *Error, type errors are all returned together as Errors.
+func CompileWithRegistry(source string, registry *pipeline.Registry) (program *Program, err error) {
+	root, err := parse(source)
+	if err != nil {
+		return nil, err
+	}
+
+	c := checker{
+		registry:  registry,
+		types:     make(map[expr]Type),
+		constants: make(map[expr]float64),
+		calls:     make(map[*callExpr]*callInfo),
+	}
+
+	if _, ok := c.check(root); !ok {
+		return nil, c.errs
+	}
+
+	program = &Program{source: source, root: root, types: c.types, constants: c.constants, calls: c.calls}
+	return program, nil
+}
+
+// Source returns the text of the script
+func (p *Program) Source() string {
+	return p.source
+}
+
+// Type returns the type of the result of the script
+func (p *Program) Type() Type {
+	return p.types[p.root]
+}
+
+// NewScript creates a script evaluating the program for online usage
+func (p *Program) NewScript() *Script {
+	s := Script{program: p, root: p.build(p.root), validFromBar: -1}
+	return &s
+}
+
+// NewScriptForStream creates a script evaluating the program, attached to a price stream
+func (p *Program) NewScriptForStream(priceStream gotrade.DOHLCVStreamSubscriber) *Script {
+	s := p.NewScript()
+	priceStream.AddTickSubscription(s)
+	return s
+}
+
+// A Script evaluates a program bar by bar and stores the results
+type Script struct {
+	program      *Program
+	root         node
+	validFromBar int
+	dataLength   int
+
+	// public variables
+	// the results of a script of numbers or series
+	Data []float64
+	// the results of a script of bools
+	Signals []bool
+}
+
+// NewScript compiles a script with the default pipeline registry, for online usage
+func NewScript(source string) (s *Script, err error) {
+	program, err := Compile(source)
+	if err != nil {
+		return nil, err
+	}
+	return program.NewScript(), nil
+}
+
+// NewScriptForStream compiles a script with the default pipeline registry, attached to a price stream
+func NewScriptForStream(priceStream gotrade.DOHLCVStreamSubscriber, source string) (s *Script, err error) {
+	program, err := Compile(source)
+	if err != nil {
+		return nil, err
+	}
+	return program.NewScriptForStream(priceStream), nil
+}
+
+// Program returns the program the script evaluates
+func (s *Script) Program() *Program {
+	return s.program
+}
+
+// Type returns the type of the results of the script
+func (s *Script) Type() Type {
+	return s.program.Type()
+}
+
+// ValidFromBar returns the source data bar number from which this script is valid, starts at bar 1
+func (s *Script) ValidFromBar() int {
+	return s.validFromBar
+}
+
+// Length returns the number of results generated by the script
+func (s *Script) Length() int {
+	return s.dataLength
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (s *Script) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	result, ok := s.root.evaluate(tickData, streamBarIndex)
+	if !ok {
+		return
+	}
+
+	s.dataLength += 1
+	if s.validFromBar == -1 {
+		s.validFromBar = streamBarIndex
+	}
+
+	if s.Type() == BoolType {
+		s.Signals = append(s.Signals, result.condition)
+	} else {
+		s.Data = append(s.Data, result.number)
+	}
+}
@@ -0,0 +1,124 @@
+package script_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/jaybutera/gotrade"
+	"github.com/jaybutera/gotrade/indicators"
+	"github.com/jaybutera/gotrade/operators"
+	"github.com/jaybutera/gotrade/script"
+)
+
+var _ = Describe("when evaluating scripts with a years data", func() {
+	var (
+		priceStream *gotrade.InterDayDOHLCVStream
+	)
+
+	BeforeEach(func() {
+		priceStream = gotrade.NewDailyDOHLCVStream()
+	})
+
+	It("should evaluate prices and arithmetic on every bar", func() {
+		s, err := script.NewScriptForStream(priceStream, "(high + low) / 2 - -1")
+		Expect(err).NotTo(HaveOccurred())
+		csvFeed.FillDOHLCVStream(priceStream)
+
+		Expect(s.ValidFromBar()).To(Equal(1))
+		Expect(s.Length()).To(Equal(len(priceStream.Data)))
+		for i, bar := range priceStream.Data {
+			Expect(s.Data[i]).To(Equal((bar.H()+bar.L())/2 + 1))
+		}
+	})
+
+	It("should match the indicator of the same parameters", func() {
+		s, _ := script.NewScriptForStream(priceStream, "rsi(14)")
+		rsi, _ := indicators.NewRsiForStream(priceStream, 14, gotrade.UseClosePrice)
+		csvFeed.FillDOHLCVStream(priceStream)
+
+		Expect(s.ValidFromBar()).To(Equal(rsi.ValidFromBar()))
+		Expect(s.Data).To(Equal(rsi.Data))
+	})
+
+	It("should calculate indicators from a source series", func() {
+		s, _ := script.NewScriptForStream(priceStream, "sma(atr(14), 5)")
+		atr, _ := indicators.NewAtrForStream(priceStream, 14)
+		sma, _ := indicators.NewSma(5, gotrade.UseClosePrice)
+		atr.AddTickSubscription(sma)
+		csvFeed.FillDOHLCVStream(priceStream)
+
+		Expect(s.ValidFromBar()).To(Equal(sma.ValidFromBar()))
+		Expect(s.Data).To(Equal(sma.Data))
+	})
+
+	It("should evaluate the named output of an indicator", func() {
+		s, _ := script.NewScriptForStream(priceStream, "macd(close, 12, 26, 9).signal")
+		macd, _ := indicators.NewMacdForStream(priceStream, 12, 26, 9, gotrade.UseClosePrice)
+		csvFeed.FillDOHLCVStream(priceStream)
+
+		Expect(s.ValidFromBar()).To(Equal(macd.ValidFromBar()))
+		Expect(s.Data).To(Equal(macd.Signal))
+	})
+
+	It("should compare an output of bollinger bands", func() {
+		s, err := script.NewScriptForStream(priceStream, "bbands(close, 5).upper > close")
+		Expect(err).NotTo(HaveOccurred())
+		bollinger, _ := indicators.NewBollingerBandsForStream(priceStream, 5, gotrade.UseClosePrice)
+		csvFeed.FillDOHLCVStream(priceStream)
+
+		Expect(s.Type()).To(Equal(script.BoolType))
+		Expect(s.ValidFromBar()).To(Equal(bollinger.ValidFromBar()))
+		for k, signal := range s.Signals {
+			Expect(signal).To(Equal(bollinger.UpperBand[k] > priceStream.Data[bollinger.ValidFromBar()-1+k].C()))
+		}
+	})
+
+	It("should match the crosses above operator", func() {
+		s, _ := script.NewScriptForStream(priceStream, "sma(close, 10) crosses above sma(close, 20)")
+		fast, _ := indicators.NewSmaForStream(priceStream, 10, gotrade.UseClosePrice)
+		slow, _ := indicators.NewSmaForStream(priceStream, 20, gotrade.UseClosePrice)
+		crossesAbove, _ := operators.NewCrossesAboveForStream(fast, slow)
+		csvFeed.FillDOHLCVStream(priceStream)
+
+		Expect(s.Type()).To(Equal(script.BoolType))
+		Expect(s.ValidFromBar()).To(Equal(crossesAbove.ValidFromBar()))
+		Expect(s.Signals).To(Equal(crossesAbove.Data))
+		Expect(s.Signals).To(ContainElement(true))
+	})
+
+	It("should combine conditions from the first bar all of them have a value for", func() {
+		s, _ := script.NewScriptForStream(priceStream, "sma(close, 20) crosses above ema(close, 50) or rsi(14) < 40 and not false")
+		ema, _ := indicators.NewEmaForStream(priceStream, 50, gotrade.UseClosePrice)
+		rsi, _ := indicators.NewRsiForStream(priceStream, 14, gotrade.UseClosePrice)
+		sma, _ := indicators.NewSmaForStream(priceStream, 20, gotrade.UseClosePrice)
+		csvFeed.FillDOHLCVStream(priceStream)
+
+		// the crossing needs the ema for a bar before
+		Expect(s.ValidFromBar()).To(Equal(ema.ValidFromBar() + 1))
+		for k, signal := range s.Signals {
+			bar := s.ValidFromBar() + k
+			smaNow, smaBefore := sma.Data[bar-sma.ValidFromBar()], sma.Data[bar-1-sma.ValidFromBar()]
+			emaNow, emaBefore := ema.Data[bar-ema.ValidFromBar()], ema.Data[bar-1-ema.ValidFromBar()]
+			crosses := smaBefore <= emaBefore && smaNow > emaNow
+			Expect(signal).To(Equal(crosses || rsi.Data[bar-rsi.ValidFromBar()] < 40))
+		}
+	})
+
+	It("should create independent scripts from one program", func() {
+		program, _ := script.Compile("ema(close, 5)")
+		first := program.NewScriptForStream(priceStream)
+		second := program.NewScriptForStream(priceStream)
+		csvFeed.FillDOHLCVStream(priceStream)
+
+		Expect(first.Data).To(Equal(second.Data))
+		Expect(first.Length()).To(Equal(len(priceStream.Data) - 4))
+	})
+
+	It("should evaluate a script of a number on every bar", func() {
+		s, _ := script.NewScriptForStream(priceStream, "70 - 40")
+		csvFeed.FillDOHLCVStream(priceStream)
+
+		Expect(s.Type()).To(Equal(script.NumberType))
+		Expect(s.Length()).To(Equal(len(priceStream.Data)))
+		Expect(s.Data[0]).To(Equal(30.0))
+	})
+})
@@ -0,0 +1,140 @@
+package stats
+
+import (
+	"errors"
+	"math"
+)
+
+var (
+	ErrTooFewEquityValues     = errors.New("equity needs at least 2 values")
+	ErrEquityIsNotPositive    = errors.New("equity must be greater than 0")
+	ErrPeriodsPerYearTooSmall = errors.New("periodsPerYear is less than the minimum (1)")
+)
+
+// EquityStatistics are the performance and risk statistics of an equity series
+type EquityStatistics struct {
+	// the number of returns, one less than the number of equity values
+	Periods int
+	// the return from the first to the last equity value, 0.1 for 10%
+	TotalReturn float64
+	// the compound annual growth rate
+	AnnualizedReturn float64
+	// the annualised standard deviation of the returns
+	Volatility float64
+	// the annualised excess return over the volatility
+	Sharpe float64
+	// the annualised excess return over the downside deviation
+	Sortino float64
+	// the annualised return over the maximum drawdown
+	Calmar float64
+	// the largest fall from a peak, 0.2 for 20%
+	MaxDrawdown float64
+	// the most bars taken to recover a previous peak, or since the last peak when not yet recovered
+	MaxDrawdownDuration int
+}
+
+// NewEquityStatistics calculates the statistics of an equity series of one value per bar, with the
+// annual risk free rate, 0.05 for 5%, as the return the Sharpe and Sortino ratios are in excess of
+func NewEquityStatistics(equity []float64, periodsPerYear float64, riskFreeRate float64) (statistics *EquityStatistics, err error) {
+	if len(equity) < 2 {
+		return nil, ErrTooFewEquityValues
+	}
+
+	if periodsPerYear < 1.0 {
+		return nil, ErrPeriodsPerYearTooSmall
+	}
+
+	for _, value := range equity {
+		if value <= 0.0 {
+			return nil, ErrEquityIsNotPositive
+		}
+	}
+
+	returns := Returns(equity)
+	periods := float64(len(returns))
+	periodRiskFreeRate := riskFreeRate / periodsPerYear
+
+	s := EquityStatistics{Periods: len(returns)}
+	s.TotalReturn = equity[len(equity)-1]/equity[0] - 1.0
+	s.AnnualizedReturn = math.Pow(1.0+s.TotalReturn, periodsPerYear/periods) - 1.0
+
+	mean := average(returns)
+	standardDeviation := sampleStandardDeviation(returns, mean)
+	s.Volatility = standardDeviation * math.Sqrt(periodsPerYear)
+	s.Sharpe = (mean - periodRiskFreeRate) / standardDeviation * math.Sqrt(periodsPerYear)
+	s.Sortino = (mean - periodRiskFreeRate) / downsideDeviation(returns, periodRiskFreeRate) * math.Sqrt(periodsPerYear)
+
+	s.MaxDrawdown, s.MaxDrawdownDuration = Drawdown(equity)
+	s.Calmar = s.AnnualizedReturn / s.MaxDrawdown
+
+	return &s, nil
+}
+
+// Returns calculates the return of each equity value over the one before it, 0.01 for 1%
+func Returns(equity []float64) []float64 {
+	if len(equity) < 2 {
+		return nil
+	}
+
+	returns := make([]float64, len(equity)-1)
+	for i := 1; i < len(equity); i++ {
+		returns[i-1] = equity[i]/equity[i-1] - 1.0
+	}
+	return returns
+}
+
+// Drawdown calculates the largest fall of an equity series from a peak, as a fraction of the peak, and the
+// most bars spent below a peak
+func Drawdown(equity []float64) (maxDrawdown float64, maxDuration int) {
+	if len(equity) == 0 {
+		return 0.0, 0
+	}
+
+	peak := equity[0]
+	peakIndex := 0
+	for i, value := range equity {
+		if value >= peak {
+			peak = value
+			peakIndex = i
+			continue
+		}
+
+		maxDrawdown = math.Max(maxDrawdown, (peak-value)/peak)
+		if duration := i - peakIndex; duration > maxDuration {
+			maxDuration = duration
+		}
+	}
+	return maxDrawdown, maxDuration
+}
+
+func average(values []float64) float64 {
+	total := 0.0
+	for _, value := range values {
+		total += value
+	}
+	return total / float64(len(values))
+}
+
+func sampleStandardDeviation(values []float64, mean float64) float64 {
+	if len(values) < 2 {
+		return 0.0
+	}
+
+	total := 0.0
+	for _, value := range values {
+		total += (value - mean) * (value - mean)
+	}
+	return math.Sqrt(total / float64(len(values)-1))
+}
+
+// downsideDeviation is the root mean square of the returns below the target, counting the returns
+// above the target as 0
+func downsideDeviation(returns []float64, target float64) float64 {
+	total := 0.0
+	for _, value := range returns {
+		if value < target {
+			total += (value - target) * (value - target)
+		}
+	}
+	return math.Sqrt(total / float64(len(returns)))
+}
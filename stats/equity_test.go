@@ -0,0 +1,118 @@
+package stats_test
+
+import (
+	. "github.com/jaybutera/gotrade/stats"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/jaybutera/gotrade"
+	"math"
+)
+
+var _ = Describe("when calculating the statistics of an equity series", func() {
+	var (
+		statistics *EquityStatistics
+		err        error
+	)
+
+	Context("and the series rises, falls and recovers", func() {
+		BeforeEach(func() {
+			// returns of 10%, -10% and 22.2%, over a year of 3 periods
+			statistics, err = NewEquityStatistics([]float64{100.0, 110.0, 99.0, 121.0}, 3.0, 0.0)
+		})
+
+		It("should not return an error", func() {
+			Expect(err).To(BeNil())
+			Expect(statistics.Periods).To(Equal(3))
+		})
+
+		It("should have the total and annualized return", func() {
+			Expect(statistics.TotalReturn).To(BeNumerically("~", 0.21, 1e-9))
+			Expect(statistics.AnnualizedReturn).To(BeNumerically("~", 0.21, 1e-9))
+		})
+
+		It("should have the annualized volatility of the returns", func() {
+			Expect(statistics.Volatility).To(BeNumerically("~", 0.281749385, 1e-8))
+		})
+
+		It("should have the risk adjusted ratios", func() {
+			Expect(statistics.Sharpe).To(BeNumerically("~", 0.788723007, 1e-8))
+			Expect(statistics.Sortino).To(BeNumerically("~", 2.222222222, 1e-8))
+			Expect(statistics.Calmar).To(BeNumerically("~", 2.1, 1e-8))
+		})
+
+		It("should have the max drawdown and its duration", func() {
+			Expect(statistics.MaxDrawdown).To(BeNumerically("~", 0.1, 1e-9))
+			Expect(statistics.MaxDrawdownDuration).To(Equal(1))
+		})
+	})
+
+	Context("and the series is annualized over more periods than it has", func() {
+		It("should compound the annualized return", func() {
+			statistics, err = NewEquityStatistics([]float64{100.0, 105.0}, 12.0, 0.0)
+			Expect(err).To(BeNil())
+			Expect(statistics.AnnualizedReturn).To(BeNumerically("~", math.Pow(1.05, 12.0)-1.0, 1e-9))
+		})
+	})
+
+	Context("and the series has a risk free rate", func() {
+		It("should reduce the Sharpe ratio by the rate per period", func() {
+			equity := []float64{100.0, 110.0, 99.0, 121.0}
+			withoutRate, _ := NewEquityStatistics(equity, 3.0, 0.0)
+			statistics, err = NewEquityStatistics(equity, 3.0, 0.03)
+			Expect(err).To(BeNil())
+			Expect(statistics.Sharpe).To(BeNumerically("~", withoutRate.Sharpe-0.01/0.162668083*math.Sqrt(3.0), 1e-6))
+		})
+	})
+
+	Context("and the series is invalid", func() {
+		It("should return an error for fewer than 2 values", func() {
+			statistics, err = NewEquityStatistics([]float64{100.0}, 252.0, 0.0)
+			Expect(statistics).To(BeNil())
+			Expect(err).To(Equal(ErrTooFewEquityValues))
+		})
+
+		It("should return an error for an equity value that is not positive", func() {
+			statistics, err = NewEquityStatistics([]float64{100.0, 0.0, 10.0}, 252.0, 0.0)
+			Expect(statistics).To(BeNil())
+			Expect(err).To(Equal(ErrEquityIsNotPositive))
+		})
+
+		It("should return an error for less than 1 period per year", func() {
+			statistics, err = NewEquityStatistics([]float64{100.0, 110.0}, 0.0, 0.0)
+			Expect(statistics).To(BeNil())
+			Expect(err).To(Equal(ErrPeriodsPerYearTooSmall))
+		})
+	})
+})
+
+var _ = Describe("when calculating the drawdown of an equity series", func() {
+	It("should measure the duration from the peak to the recovery", func() {
+		maxDrawdown, duration := Drawdown([]float64{100.0, 120.0, 90.0, 100.0, 110.0, 121.0, 115.0})
+		Expect(maxDrawdown).To(BeNumerically("~", 0.25, 1e-9))
+		Expect(duration).To(Equal(3))
+	})
+
+	It("should measure the duration of a drawdown not yet recovered", func() {
+		maxDrawdown, duration := Drawdown([]float64{100.0, 95.0, 98.0, 99.0, 97.0, 96.0})
+		Expect(maxDrawdown).To(BeNumerically("~", 0.05, 1e-9))
+		Expect(duration).To(Equal(5))
+	})
+
+	It("should have no drawdown for a rising series", func() {
+		maxDrawdown, duration := Drawdown([]float64{100.0, 101.0, 102.0})
+		Expect(maxDrawdown).To(Equal(0.0))
+		Expect(duration).To(Equal(0))
+	})
+})
+
+var _ = Describe("when annualizing the statistics of a stream", func() {
+	It("should use the bars in a year of an inter day stream", func() {
+		Expect(PeriodsPerYearForInterDayStream(gotrade.NewDailyDOHLCVStream())).To(Equal(TradingDaysPerYear))
+		Expect(PeriodsPerYearForInterDayStream(gotrade.NewWeeklyDOHLCVStream())).To(Equal(WeeksPerYear))
+		Expect(PeriodsPerYearForInterDayStream(gotrade.NewMonthlyDOHLCVStream())).To(Equal(MonthsPerYear))
+	})
+
+	It("should use the bars in a trading day of an intra day stream", func() {
+		Expect(PeriodsPerYearForIntraDayStream(gotrade.NewIntraDayDOHLCVStream(30), 390)).To(Equal(13.0 * 252.0))
+	})
+})
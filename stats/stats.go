@@ -0,0 +1,45 @@
+/*
+	import "github.com/jaybutera/gotrade/stats"
+
+	Package stats provides performance and risk statistics for equity curves and trade lists,
+	such as those produced by a backtest.
+	Statistics are calculated from:
+		- an equity series, one value per bar, for the returns, volatility, risk adjusted ratios and drawdowns.
+		- a list of round trip trades, for the win rate, profit factor, expectancy, exposure and excursions.
+
+	Annualised statistics need the number of bars in a year, derived from the bar type of the stream, e.g.
+
+		priceStream := gotrade.NewDailyDOHLCVStream()
+		...
+		equityStats, err := stats.NewEquityStatistics(equity, stats.PeriodsPerYearForInterDayStream(priceStream), 0.0)
+
+	Ratios with a zero denominator follow float division, giving an infinity or NaN.
+*/
+package stats
+
+import (
+	"github.com/jaybutera/gotrade"
+)
+
+const (
+	TradingDaysPerYear float64 = 252.0
+	WeeksPerYear       float64 = 52.0
+	MonthsPerYear      float64 = 12.0
+)
+
+// PeriodsPerYearForInterDayStream returns the number of bars of a daily, weekly or monthly stream in a year
+func PeriodsPerYearForInterDayStream(stream *gotrade.InterDayDOHLCVStream) float64 {
+	switch stream.BarType() {
+	case gotrade.WeeklyBar:
+		return WeeksPerYear
+	case gotrade.MonthlyBar:
+		return MonthsPerYear
+	}
+	return TradingDaysPerYear
+}
+
+// PeriodsPerYearForIntraDayStream returns the number of bars of an intra day stream in a year,
+// for a market trading the given minutes each day
+func PeriodsPerYearForIntraDayStream(stream *gotrade.IntraDayDOHLCVStream, tradingMinutesPerDay int) float64 {
+	return TradingDaysPerYear * float64(tradingMinutesPerDay) / float64(stream.BarInterval())
+}
@@ -0,0 +1,126 @@
+package stats
+
+import (
+	"errors"
+	"github.com/jaybutera/gotrade/backtest"
+)
+
+var (
+	ErrBarsIsNegative = errors.New("bars is less than the minimum (0)")
+)
+
+// TradeStatistics are the statistics of a list of round trip trades
+type TradeStatistics struct {
+	Trades int
+	// trades with a profit, net of commission
+	Winners int
+	// trades without a profit, net of commission
+	Losers int
+	// the fraction of trades that are winners
+	WinRate float64
+
+	// the sum of the profits of the winners
+	GrossProfit float64
+	// the sum of the losses of the losers, as a positive amount
+	GrossLoss float64
+	// the sum of the profits and losses of all the trades
+	NetProfit float64
+	// the gross profit over the gross loss
+	ProfitFactor float64
+	// the average profit or loss of a trade
+	Expectancy float64
+	// the average profit of a winner
+	AverageWin float64
+	// the average loss of a loser, as a positive amount
+	AverageLoss float64
+
+	// the average most adverse and most favourable excursion of a trade
+	AverageMAE float64
+	AverageMFE float64
+
+	// the fraction of the bars a trade was open for
+	Exposure float64
+}
+
+// NewTradeStatistics calculates the statistics of the closed trades of a backtest over a number of bars,
+// a trade is taken to be open from its entry bar up to its exit bar
+func NewTradeStatistics(trades []*backtest.Trade, bars int) (statistics *TradeStatistics, err error) {
+	if bars < 0 {
+		return nil, ErrBarsIsNegative
+	}
+
+	s := TradeStatistics{Trades: len(trades)}
+	barsOpen := 0
+	totalMAE, totalMFE := 0.0, 0.0
+
+	for _, trade := range trades {
+		if trade.ProfitLoss > 0.0 {
+			s.Winners += 1
+			s.GrossProfit += trade.ProfitLoss
+		} else {
+			s.Losers += 1
+			s.GrossLoss -= trade.ProfitLoss
+		}
+
+		totalMAE += trade.MAE
+		totalMFE += trade.MFE
+		barsOpen += trade.ExitBar - trade.EntryBar
+	}
+
+	s.NetProfit = s.GrossProfit - s.GrossLoss
+	s.ProfitFactor = s.GrossProfit / s.GrossLoss
+
+	if s.Trades > 0 {
+		s.WinRate = float64(s.Winners) / float64(s.Trades)
+		s.Expectancy = s.NetProfit / float64(s.Trades)
+		s.AverageMAE = totalMAE / float64(s.Trades)
+		s.AverageMFE = totalMFE / float64(s.Trades)
+	}
+
+	if s.Winners > 0 {
+		s.AverageWin = s.GrossProfit / float64(s.Winners)
+	}
+
+	if s.Losers > 0 {
+		s.AverageLoss = s.GrossLoss / float64(s.Losers)
+	}
+
+	if bars > 0 {
+		s.Exposure = float64(barsOpen) / float64(bars)
+	}
+
+	return &s, nil
+}
+
+// The Statistics of a backtest result, from its equity curve and closed trades
+type Statistics struct {
+	*EquityStatistics
+	*TradeStatistics
+}
+
+// NewStatistics calculates the equity and trade statistics of a backtest result. A trade still open at the
+// end of the backtest counts towards the exposure, as open from its entry bar up to the end of the equity curve,
+// but not towards the other trade statistics, as its profit or loss is not yet realized.
+func NewStatistics(result *backtest.Result, periodsPerYear float64, riskFreeRate float64) (statistics *Statistics, err error) {
+	equity := make([]float64, len(result.EquityCurve))
+	for i, point := range result.EquityCurve {
+		equity[i] = point.Equity
+	}
+
+	equityStatistics, err := NewEquityStatistics(equity, periodsPerYear, riskFreeRate)
+	if err != nil {
+		return nil, err
+	}
+
+	tradeStatistics, err := NewTradeStatistics(result.Trades, len(result.EquityCurve))
+	if err != nil {
+		return nil, err
+	}
+
+	if result.OpenTrade != nil && len(result.EquityCurve) > 0 {
+		lastBar := result.EquityCurve[len(result.EquityCurve)-1].StreamBarIndex
+		tradeStatistics.Exposure += float64(lastBar+1-result.OpenTrade.EntryBar) / float64(len(result.EquityCurve))
+	}
+
+	return &Statistics{EquityStatistics: equityStatistics, TradeStatistics: tradeStatistics}, nil
+}
@@ -0,0 +1,137 @@
+package stats_test
+
+import (
+	. "github.com/jaybutera/gotrade/stats"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/jaybutera/gotrade"
+	"github.com/jaybutera/gotrade/backtest"
+	"math"
+)
+
+var _ = Describe("when calculating the statistics of trades", func() {
+	var (
+		trades     []*backtest.Trade
+		statistics *TradeStatistics
+		err        error
+	)
+
+	BeforeEach(func() {
+		trades = []*backtest.Trade{
+			{EntryBar: 1, ExitBar: 5, ProfitLoss: 300.0, MAE: 50.0, MFE: 400.0},
+			{EntryBar: 10, ExitBar: 12, ProfitLoss: -100.0, MAE: 150.0, MFE: 20.0},
+			{EntryBar: 15, ExitBar: 19, ProfitLoss: 100.0, MAE: 10.0, MFE: 180.0},
+			{EntryBar: 20, ExitBar: 30, ProfitLoss: -200.0, MAE: 250.0, MFE: 0.0},
+		}
+		statistics, err = NewTradeStatistics(trades, 40)
+	})
+
+	It("should not return an error", func() {
+		Expect(err).To(BeNil())
+		Expect(statistics.Trades).To(Equal(4))
+	})
+
+	It("should count the winners and losers", func() {
+		Expect(statistics.Winners).To(Equal(2))
+		Expect(statistics.Losers).To(Equal(2))
+		Expect(statistics.WinRate).To(Equal(0.5))
+	})
+
+	It("should have the profit factor and expectancy", func() {
+		Expect(statistics.GrossProfit).To(Equal(400.0))
+		Expect(statistics.GrossLoss).To(Equal(300.0))
+		Expect(statistics.NetProfit).To(Equal(100.0))
+		Expect(statistics.ProfitFactor).To(BeNumerically("~", 4.0/3.0, 1e-9))
+		Expect(statistics.Expectancy).To(Equal(25.0))
+		Expect(statistics.AverageWin).To(Equal(200.0))
+		Expect(statistics.AverageLoss).To(Equal(150.0))
+	})
+
+	It("should have the average excursions", func() {
+		Expect(statistics.AverageMAE).To(Equal(115.0))
+		Expect(statistics.AverageMFE).To(Equal(150.0))
+	})
+
+	It("should have the exposure over the bars", func() {
+		Expect(statistics.Exposure).To(Equal(0.5))
+	})
+
+	It("should have an infinite profit factor without a loser", func() {
+		statistics, err = NewTradeStatistics(trades[:1], 40)
+		Expect(math.IsInf(statistics.ProfitFactor, 1)).To(BeTrue())
+		Expect(statistics.AverageLoss).To(Equal(0.0))
+	})
+
+	It("should have zero statistics without a trade", func() {
+		statistics, err = NewTradeStatistics(nil, 40)
+		Expect(err).To(BeNil())
+		Expect(statistics.WinRate).To(Equal(0.0))
+		Expect(statistics.Expectancy).To(Equal(0.0))
+		Expect(statistics.Exposure).To(Equal(0.0))
+	})
+
+	It("should return an error for negative bars", func() {
+		statistics, err = NewTradeStatistics(trades, -1)
+		Expect(statistics).To(BeNil())
+		Expect(err).To(Equal(ErrBarsIsNegative))
+	})
+})
+
+var _ = Describe("when calculating the statistics of a backtest", func() {
+	var (
+		priceStream *gotrade.InterDayDOHLCVStream
+		result      *backtest.Result
+		statistics  *Statistics
+		err         error
+	)
+
+	BeforeEach(func() {
+		priceStream = gotrade.NewDailyDOHLCVStream()
+		result, err = backtest.NewBacktest(csvFeed, buyAndHold{}, backtest.DefaultConfig()).Run()
+		Expect(err).To(BeNil())
+
+		statistics, err = NewStatistics(result, PeriodsPerYearForInterDayStream(priceStream), 0.0)
+	})
+
+	It("should have the total return of the equity curve", func() {
+		Expect(err).To(BeNil())
+		Expect(statistics.TotalReturn).To(BeNumerically("~", result.FinalEquity/result.EquityCurve[0].Equity-1.0, 1e-9))
+		Expect(statistics.Periods).To(Equal(len(result.EquityCurve) - 1))
+	})
+
+	It("should have a drawdown within the equity curve", func() {
+		Expect(statistics.MaxDrawdown).To(BeNumerically(">", 0.0))
+		Expect(statistics.MaxDrawdown).To(BeNumerically("<", 1.0))
+		Expect(statistics.MaxDrawdownDuration).To(BeNumerically("<", len(result.EquityCurve)))
+	})
+
+	It("should have no closed trades whilst the position is held", func() {
+		Expect(result.OpenTrade).ToNot(BeNil())
+		Expect(statistics.Trades).To(Equal(0))
+		Expect(statistics.NetProfit).To(Equal(0.0))
+	})
+
+	It("should count the open trade in the exposure up to the last bar", func() {
+		barsHeld := 0
+		for _, point := range result.EquityCurve {
+			if point.Position != 0.0 {
+				barsHeld += 1
+			}
+		}
+		Expect(barsHeld).To(BeNumerically(">", 0))
+		Expect(statistics.Exposure).To(Equal(float64(barsHeld) / float64(len(result.EquityCurve))))
+	})
+})
+
+// buyAndHold buys one unit of the index on the first bar and holds it to the end
+type buyAndHold struct{}
+
+func (buyAndHold) Init(priceStream gotrade.DOHLCVStreamSubscriber, broker *backtest.Broker) error {
+	return nil
+}
+
+func (buyAndHold) OnBar(bar gotrade.DOHLCV, streamBarIndex int, broker *backtest.Broker) {
+	if streamBarIndex == 1 {
+		broker.Buy(1)
+	}
+}
@@ -939,6 +939,142 @@ namespace indicatortestgenerator
 				writer.Flush ();
 			}
 
+			// MAMA
+			using (var writer = new StreamWriter (@"/home/eugened/Development/go/src/github.com/thetruetrade/gotrade/testdata/mama_3_5_expectedresult.data")) 
+			{
+				int outBeginIndex = 0;
+				int outNBElement = 0;
+				int lookback = talib.Core.MamaLookback(0.5, 0.05);
+				int dataLength = closingPrices.Count - 1;
+				double[] outMama = new double[dataLength - lookback +1];
+				double[] outFama = new double[dataLength - lookback +1];
+				talib.Core.RetCode retCode =talib.Core.Mama(0, dataLength, closingPrices.ToArray(), 0.5, 0.05, out outBeginIndex, out outNBElement, outMama, outFama);
+				if (retCode == TicTacTec.TA.Library.Core.RetCode.Success) 
+				{
+					for (var i=0;i< outMama.Length;i++) 
+					{
+						writer.WriteLine ("{0}, {1}", outMama[i].ToString(CultureInfo.InvariantCulture), outFama[i].ToString(CultureInfo.InvariantCulture));
+					}
+				}
+				writer.Flush ();
+			}
+
+			// HT_TRENDLINE
+			using (var writer = new StreamWriter (@"/home/eugened/Development/go/src/github.com/thetruetrade/gotrade/testdata/ht_rendline_expectedresult.data")) 
+			{
+				int outBeginIndex = 0;
+				int outNBElement = 0;
+				int lookback = talib.Core.HtTrendlineLookback();
+				int dataLength = closingPrices.Count - 1;
+				double[] outData = new double[dataLength - lookback +1];
+				talib.Core.RetCode retCode =talib.Core.HtTrendline(0, dataLength, closingPrices.ToArray(), out outBeginIndex, out outNBElement, outData);
+				if (retCode == TicTacTec.TA.Library.Core.RetCode.Success) 
+				{
+					foreach (var item in outData) 
+					{
+						writer.WriteLine (item.ToString(CultureInfo.InvariantCulture));
+					}
+				}
+				writer.Flush ();
+			}
+
+			// HT_DCPERIOD
+			using (var writer = new StreamWriter (@"/home/eugened/Development/go/src/github.com/thetruetrade/gotrade/testdata/ht_dcperiod_expectedresult.data")) 
+			{
+				int outBeginIndex = 0;
+				int outNBElement = 0;
+				int lookback = talib.Core.HtDcPeriodLookback();
+				int dataLength = closingPrices.Count - 1;
+				double[] outData = new double[dataLength - lookback +1];
+				talib.Core.RetCode retCode =talib.Core.HtDcPeriod(0, dataLength, closingPrices.ToArray(), out outBeginIndex, out outNBElement, outData);
+				if (retCode == TicTacTec.TA.Library.Core.RetCode.Success) 
+				{
+					foreach (var item in outData) 
+					{
+						writer.WriteLine (item.ToString(CultureInfo.InvariantCulture));
+					}
+				}
+				writer.Flush ();
+			}
+
+			// HT_DCPHASE
+			using (var writer = new StreamWriter (@"/home/eugened/Development/go/src/github.com/thetruetrade/gotrade/testdata/ht_dcphase_expectedresult.data")) 
+			{
+				int outBeginIndex = 0;
+				int outNBElement = 0;
+				int lookback = talib.Core.HtDcPhaseLookback();
+				int dataLength = closingPrices.Count - 1;
+				double[] outData = new double[dataLength - lookback +1];
+				talib.Core.RetCode retCode =talib.Core.HtDcPhase(0, dataLength, closingPrices.ToArray(), out outBeginIndex, out outNBElement, outData);
+				if (retCode == TicTacTec.TA.Library.Core.RetCode.Success) 
+				{
+					foreach (var item in outData) 
+					{
+						writer.WriteLine (item.ToString(CultureInfo.InvariantCulture));
+					}
+				}
+				writer.Flush ();
+			}
+
+			// HT_PHASOR
+			using (var writer = new StreamWriter (@"/home/eugened/Development/go/src/github.com/thetruetrade/gotrade/testdata/ht_phasor_expectedresult.data")) 
+			{
+				int outBeginIndex = 0;
+				int outNBElement = 0;
+				int lookback = talib.Core.HtPhasorLookback();
+				int dataLength = closingPrices.Count - 1;
+				double[] outInPhase = new double[dataLength - lookback +1];
+				double[] outQuadrature = new double[dataLength - lookback +1];
+				talib.Core.RetCode retCode =talib.Core.HtPhasor(0, dataLength, closingPrices.ToArray(), out outBeginIndex, out outNBElement, outInPhase, outQuadrature);
+				if (retCode == TicTacTec.TA.Library.Core.RetCode.Success) 
+				{
+					for (var i=0;i< outInPhase.Length;i++) 
+					{
+						writer.WriteLine ("{0}, {1}", outInPhase[i].ToString(CultureInfo.InvariantCulture), outQuadrature[i].ToString(CultureInfo.InvariantCulture));
+					}
+				}
+				writer.Flush ();
+			}
+
+			// HT_SINE
+			using (var writer = new StreamWriter (@"/home/eugened/Development/go/src/github.com/thetruetrade/gotrade/testdata/ht_sine_expectedresult.data")) 
+			{
+				int outBeginIndex = 0;
+				int outNBElement = 0;
+				int lookback = talib.Core.HtSineLookback();
+				int dataLength = closingPrices.Count - 1;
+				double[] outSine = new double[dataLength - lookback +1];
+				double[] outLeadSine = new double[dataLength - lookback +1];
+				talib.Core.RetCode retCode =talib.Core.HtSine(0, dataLength, closingPrices.ToArray(), out outBeginIndex, out outNBElement, outSine, outLeadSine);
+				if (retCode == TicTacTec.TA.Library.Core.RetCode.Success) 
+				{
+					for (var i=0;i< outSine.Length;i++) 
+					{
+						writer.WriteLine ("{0}, {1}", outSine[i].ToString(CultureInfo.InvariantCulture), outLeadSine[i].ToString(CultureInfo.InvariantCulture));
+					}
+				}
+				writer.Flush ();
+			}
+
+			// HT_TRENDMODE
+			using (var writer = new StreamWriter (@"/home/eugened/Development/go/src/github.com/thetruetrade/gotrade/testdata/ht_trendmode_expectedresult.data")) 
+			{
+				int outBeginIndex = 0;
+				int outNBElement = 0;
+				int lookback = talib.Core.HtTrendModeLookback();
+				int dataLength = closingPrices.Count - 1;
+				int[] outData = new int[dataLength - lookback +1];
+				talib.Core.RetCode retCode =talib.Core.HtTrendMode(0, dataLength, closingPrices.ToArray(), out outBeginIndex, out outNBElement, outData);
+				if (retCode == TicTacTec.TA.Library.Core.RetCode.Success) 
+				{
+					foreach (var item in outData) 
+					{
+						writer.WriteLine (item.ToString(CultureInfo.InvariantCulture));
+					}
+				}
+				writer.Flush ();
+			}
+
 			// STOCHRSI
 			using (var writer = new StreamWriter (@"/home/eugened/Development/go/src/github.com/thetruetrade/gotrade/testdata/stochrsi_14_5_3_expectedresult.data")) 
 			{
@@ -0,0 +1,235 @@
+package gotrade
+
+import (
+	"errors"
+	"math"
+)
+
+var (
+	ErrTradeBarThresholdMustBeGreaterThanZero   = errors.New("Trade bar threshold must be greater than zero")
+	ErrImbalanceBarAlphaMustBeBetweenZeroAndOne = errors.New("Imbalance bar alpha must be greater than zero and at most one")
+)
+
+// What a trade bar samples to decide when a bar is complete
+type tradeBarType int
+
+const (
+	// a bar every N trades
+	TickBar tradeBarType = iota
+	// a bar every N units traded
+	VolumeBar
+	// a bar every N units of price * size traded
+	DollarBar
+	// a bar when the signed trade count imbalance exceeds its expected value
+	TickImbalanceBar
+	// a bar when the signed volume imbalance exceeds its expected value
+	VolumeImbalanceBar
+	// a bar when the signed dollar imbalance exceeds its expected value
+	DollarImbalanceBar
+)
+
+// A stream of bars built from trade prints rather than from time, bars are dated at their first trade.
+// A trade is never split across bars, the trade that reaches the threshold completes the bar.
+type TradeBarStream struct {
+	*DOHLCVStream
+	barType   tradeBarType
+	threshold float64
+
+	// the forming bar
+	tradeBar   *DOHLCVDataItem
+	tradeCount int
+	sampled    float64
+
+	// the tick rule for trades without a side
+	previousPrice float64
+	previousSign  float64
+
+	// imbalance bars, exponentially weighted estimates of the trades per bar and the signed value per trade
+	alpha                    float64
+	expectedTradeCount       float64
+	expectedSignedValue      float64
+	expectedSignedValueKnown bool
+	smallestTradeValue       float64
+}
+
+// NewTickBarStream creates a stream which publishes a bar every tradesPerBar trades
+func NewTickBarStream(tradesPerBar int) (stream *TradeBarStream, err error) {
+	return newTradeBarStream(TickBar, float64(tradesPerBar))
+}
+
+// NewVolumeBarStream creates a stream which publishes a bar every volumePerBar units traded
+func NewVolumeBarStream(volumePerBar float64) (stream *TradeBarStream, err error) {
+	return newTradeBarStream(VolumeBar, volumePerBar)
+}
+
+// NewDollarBarStream creates a stream which publishes a bar every dollarsPerBar of price * size traded
+func NewDollarBarStream(dollarsPerBar float64) (stream *TradeBarStream, err error) {
+	return newTradeBarStream(DollarBar, dollarsPerBar)
+}
+
+// NewTickImbalanceBarStream creates a stream which publishes a bar when the imbalance between buy and sell
+// trades exceeds the expected trades per bar times the expected imbalance per trade.
+//	- initialTradesPerBar: the trades in the first bar, which seeds the expectations
+//	- alpha: the weight of the latest bar in the exponentially weighted expectations, (0, 1]
+func NewTickImbalanceBarStream(initialTradesPerBar int, alpha float64) (stream *TradeBarStream, err error) {
+	return newImbalanceBarStream(TickImbalanceBar, initialTradesPerBar, alpha)
+}
+
+// NewVolumeImbalanceBarStream creates a stream which publishes a bar when the imbalance between buy and sell
+// volume exceeds the expected trades per bar times the expected signed volume per trade.
+//	- initialTradesPerBar: the trades in the first bar, which seeds the expectations
+//	- alpha: the weight of the latest bar in the exponentially weighted expectations, (0, 1]
+func NewVolumeImbalanceBarStream(initialTradesPerBar int, alpha float64) (stream *TradeBarStream, err error) {
+	return newImbalanceBarStream(VolumeImbalanceBar, initialTradesPerBar, alpha)
+}
+
+// NewDollarImbalanceBarStream creates a stream which publishes a bar when the imbalance between buy and sell
+// dollar value exceeds the expected trades per bar times the expected signed dollar value per trade.
+//	- initialTradesPerBar: the trades in the first bar, which seeds the expectations
+//	- alpha: the weight of the latest bar in the exponentially weighted expectations, (0, 1]
+func NewDollarImbalanceBarStream(initialTradesPerBar int, alpha float64) (stream *TradeBarStream, err error) {
+	return newImbalanceBarStream(DollarImbalanceBar, initialTradesPerBar, alpha)
+}
+
+func newTradeBarStream(barType tradeBarType, threshold float64) (stream *TradeBarStream, err error) {
+	if threshold <= 0 {
+		return nil, ErrTradeBarThresholdMustBeGreaterThanZero
+	}
+
+	s := TradeBarStream{DOHLCVStream: NewDOHLCVStream(),
+		barType:      barType,
+		threshold:    threshold,
+		previousSign: 1.0}
+	return &s, nil
+}
+
+func newImbalanceBarStream(barType tradeBarType, initialTradesPerBar int, alpha float64) (stream *TradeBarStream, err error) {
+	if alpha <= 0 || alpha > 1 {
+		return nil, ErrImbalanceBarAlphaMustBeBetweenZeroAndOne
+	}
+
+	s, err := newTradeBarStream(barType, float64(initialTradesPerBar))
+	if err != nil {
+		return nil, err
+	}
+	s.alpha = alpha
+	s.expectedTradeCount = float64(initialTradesPerBar)
+	return s, nil
+}
+
+// ReceiveTrade consumes a trade print, aggregating it into the forming bar
+func (s *TradeBarStream) ReceiveTrade(trade Trade) {
+	if s.tradeBar == nil {
+		s.tradeBar = NewDOHLCVDataItem(trade.D(), trade.P(), trade.P(), trade.P(), trade.P(), trade.S())
+		s.tradeCount = 0
+		s.sampled = 0
+	} else {
+		s.tradeBar.highPrice = math.Max(s.tradeBar.highPrice, trade.P())
+		s.tradeBar.lowPrice = math.Min(s.tradeBar.lowPrice, trade.P())
+		s.tradeBar.closePrice = trade.P()
+		s.tradeBar.volumePrice += trade.S()
+	}
+	s.tradeCount++
+
+	var complete bool
+	switch s.barType {
+	case TickBar:
+		complete = float64(s.tradeCount) >= s.threshold
+	case VolumeBar:
+		s.sampled += trade.S()
+		complete = s.sampled >= s.threshold
+	case DollarBar:
+		s.sampled += trade.P() * trade.S()
+		complete = s.sampled >= s.threshold
+	default:
+		weight := s.imbalanceWeight(trade)
+		if weight > 0 && (s.smallestTradeValue == 0 || weight < s.smallestTradeValue) {
+			s.smallestTradeValue = weight
+		}
+		s.sampled += s.tradeSign(trade) * weight
+		complete = s.imbalanceComplete()
+	}
+	s.previousPrice = trade.P()
+
+	if complete {
+		s.Flush()
+		return
+	}
+
+	s.publishFormingBar(s.tradeBar)
+}
+
+// Flush publishes the partially built bar, if any, e.g. at the end of a historical feed
+func (s *TradeBarStream) Flush() {
+	if s.tradeBar == nil {
+		return
+	}
+
+	if s.alpha > 0 {
+		s.updateImbalanceExpectations()
+	}
+
+	bar := s.tradeBar
+	s.tradeBar = nil
+	s.publishBar(bar)
+}
+
+// FormingBar returns the partially built bar, or nil if there is none
+func (s *TradeBarStream) FormingBar() DOHLCV {
+	if s.tradeBar == nil {
+		return nil
+	}
+	return s.tradeBar
+}
+
+// tradeSign returns +1 for a buy and -1 for a sell, trades without a side are signed by the tick rule
+func (s *TradeBarStream) tradeSign(trade Trade) float64 {
+	switch {
+	case trade.Side() == BuySide:
+		s.previousSign = 1.0
+	case trade.Side() == SellSide:
+		s.previousSign = -1.0
+	case s.previousPrice != 0 && trade.P() > s.previousPrice:
+		s.previousSign = 1.0
+	case s.previousPrice != 0 && trade.P() < s.previousPrice:
+		s.previousSign = -1.0
+	}
+	// an unchanged price keeps the previous sign
+	return s.previousSign
+}
+
+func (s *TradeBarStream) imbalanceWeight(trade Trade) float64 {
+	switch s.barType {
+	case VolumeImbalanceBar:
+		return trade.S()
+	case DollarImbalanceBar:
+		return trade.P() * trade.S()
+	}
+	return 1.0
+}
+
+func (s *TradeBarStream) imbalanceComplete() bool {
+	// the first bar runs for the initial trade count to seed the expected imbalance
+	if !s.expectedSignedValueKnown {
+		return float64(s.tradeCount) >= s.threshold
+	}
+	return math.Abs(s.sampled) >= s.ExpectedImbalance()
+}
+
+func (s *TradeBarStream) updateImbalanceExpectations() {
+	signedValue := s.sampled / float64(s.tradeCount)
+	if !s.expectedSignedValueKnown {
+		s.expectedSignedValue = signedValue
+		s.expectedSignedValueKnown = true
+		return
+	}
+	s.expectedTradeCount = s.alpha*float64(s.tradeCount) + (1.0-s.alpha)*s.expectedTradeCount
+	s.expectedSignedValue = s.alpha*signedValue + (1.0-s.alpha)*s.expectedSignedValue
+}
+
+// ExpectedImbalance returns the absolute imbalance the forming imbalance bar must reach to complete. It is floored
+// at the smallest trade value times the square root of the expected trade count, the imbalance balanced trades
+// typically reach by chance, so that balanced bars do not leave an expectation of 0 completing a bar every trade.
+func (s *TradeBarStream) ExpectedImbalance() float64 {
+	return math.Max(s.expectedTradeCount*math.Abs(s.expectedSignedValue), s.smallestTradeValue*math.Sqrt(s.expectedTradeCount))
+}
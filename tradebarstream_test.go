@@ -0,0 +1,225 @@
+package gotrade_test
+
+import (
+	. "github.com/jaybutera/gotrade"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"time"
+)
+
+// sendTrades sends a trade per price a second apart, each of the given size and side
+func sendTrades(receiver TradeReceiver, start time.Time, prices []float64, size float64, side TradeSide) {
+	for i, price := range prices {
+		receiver.ReceiveTrade(NewTradeDataItem(start.Add(time.Duration(i)*time.Second), price, size, side))
+	}
+}
+
+var _ = Describe("when creating a trade bar stream", func() {
+	It("should not accept a threshold of zero or less", func() {
+		_, err := NewTickBarStream(0)
+		Expect(err).To(Equal(ErrTradeBarThresholdMustBeGreaterThanZero))
+		_, err = NewVolumeBarStream(-1.0)
+		Expect(err).To(Equal(ErrTradeBarThresholdMustBeGreaterThanZero))
+		_, err = NewDollarBarStream(0.0)
+		Expect(err).To(Equal(ErrTradeBarThresholdMustBeGreaterThanZero))
+	})
+
+	It("should not accept an imbalance alpha outside of (0, 1]", func() {
+		_, err := NewTickImbalanceBarStream(10, 0.0)
+		Expect(err).To(Equal(ErrImbalanceBarAlphaMustBeBetweenZeroAndOne))
+		_, err = NewVolumeImbalanceBarStream(10, 1.5)
+		Expect(err).To(Equal(ErrImbalanceBarAlphaMustBeBetweenZeroAndOne))
+	})
+})
+
+var _ = Describe("when building tick bars", func() {
+	var (
+		priceStream *TradeBarStream
+		formingBars *fakeFormingBarReceiver
+		subscriber  *fakeTickReceiver
+		start       time.Time
+	)
+
+	BeforeEach(func() {
+		priceStream, _ = NewTickBarStream(3)
+		formingBars = &fakeFormingBarReceiver{}
+		subscriber = &fakeTickReceiver{}
+		priceStream.AddFormingBarSubscription(formingBars)
+		priceStream.AddTickSubscription(subscriber)
+		start = time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC)
+		sendTrades(priceStream, start, []float64{10, 12, 9, 11, 13, 14, 8}, 2.0, UnknownSide)
+	})
+
+	It("should publish a bar every three trades", func() {
+		Expect(len(priceStream.Data)).To(Equal(2))
+		Expect(subscriber.streamBarIndexs).To(Equal([]int{1, 2}))
+	})
+
+	It("should date each bar at its first trade", func() {
+		Expect(priceStream.Data[0].D()).To(Equal(start))
+		Expect(priceStream.Data[1].D()).To(Equal(start.Add(3 * time.Second)))
+	})
+
+	It("should take the first, highest, lowest and last prices and the summed size", func() {
+		Expect(priceStream.Data[0].O()).To(Equal(10.0))
+		Expect(priceStream.Data[0].H()).To(Equal(12.0))
+		Expect(priceStream.Data[0].L()).To(Equal(9.0))
+		Expect(priceStream.Data[0].C()).To(Equal(9.0))
+		Expect(priceStream.Data[0].V()).To(Equal(6.0))
+	})
+
+	It("should make the partial bar visible through the forming bar subscription", func() {
+		formingBar := formingBars.formingBars[len(formingBars.formingBars)-1]
+		Expect(formingBar.C()).To(Equal(8.0))
+		Expect(formingBars.streamBarIndexs[len(formingBars.streamBarIndexs)-1]).To(Equal(3))
+		Expect(priceStream.FormingBar().O()).To(Equal(8.0))
+	})
+
+	Context("and the stream is flushed", func() {
+		BeforeEach(func() {
+			priceStream.Flush()
+		})
+
+		It("should publish the partial bar", func() {
+			Expect(len(priceStream.Data)).To(Equal(3))
+			Expect(priceStream.Data[2].V()).To(Equal(2.0))
+			Expect(priceStream.FormingBar()).To(BeNil())
+		})
+	})
+})
+
+var _ = Describe("when building volume and dollar bars", func() {
+	var (
+		volumeStream *TradeBarStream
+		dollarStream *TradeBarStream
+	)
+
+	BeforeEach(func() {
+		volumeStream, _ = NewVolumeBarStream(5.0)
+		dollarStream, _ = NewDollarBarStream(100.0)
+		start := time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC)
+		for i, size := range []float64{1, 3, 2, 4, 1, 2} {
+			trade := NewTradeDataItem(start.Add(time.Duration(i)*time.Second), 10.0+float64(i), size, UnknownSide)
+			volumeStream.ReceiveTrade(trade)
+			dollarStream.ReceiveTrade(trade)
+		}
+	})
+
+	It("should complete a volume bar with the trade that reaches the volume, without splitting it", func() {
+		Expect(len(volumeStream.Data)).To(Equal(2))
+		Expect(volumeStream.Data[0].V()).To(Equal(6.0))
+		Expect(volumeStream.Data[0].C()).To(Equal(12.0))
+		Expect(volumeStream.Data[1].V()).To(Equal(5.0))
+		Expect(volumeStream.Data[1].O()).To(Equal(13.0))
+	})
+
+	It("should complete a dollar bar when the traded value reaches the threshold", func() {
+		// 10*1 + 11*3 + 12*2 + 13*4 = 119
+		Expect(len(dollarStream.Data)).To(Equal(1))
+		Expect(dollarStream.Data[0].V()).To(Equal(10.0))
+		Expect(dollarStream.FormingBar().V()).To(Equal(3.0))
+	})
+})
+
+var _ = Describe("when building tick imbalance bars", func() {
+	var (
+		priceStream *TradeBarStream
+	)
+
+	BeforeEach(func() {
+		priceStream, _ = NewTickImbalanceBarStream(4, 0.5)
+		start := time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC)
+		// the first bar seeds an expected imbalance of 4 trades * |(3 - 1) / 4| = 2
+		sendTrades(priceStream, start, []float64{10, 11, 10, 12}, 1.0, UnknownSide)
+	})
+
+	It("should publish the first bar after the initial trade count", func() {
+		Expect(len(priceStream.Data)).To(Equal(1))
+		Expect(priceStream.ExpectedImbalance()).To(Equal(2.0))
+	})
+
+	Context("and buys and sells alternate", func() {
+		BeforeEach(func() {
+			start := time.Date(2017, 6, 1, 13, 0, 0, 0, time.UTC)
+			for i := 0; i < 6; i++ {
+				side := BuySide
+				if i%2 == 1 {
+					side = SellSide
+				}
+				priceStream.ReceiveTrade(NewTradeDataItem(start.Add(time.Duration(i)*time.Second), 12.0, 1.0, side))
+			}
+		})
+
+		It("should not publish a bar whilst the trades are balanced", func() {
+			Expect(len(priceStream.Data)).To(Equal(1))
+		})
+	})
+
+	Context("and the trades are all buys", func() {
+		BeforeEach(func() {
+			start := time.Date(2017, 6, 1, 13, 0, 0, 0, time.UTC)
+			sendTrades(priceStream, start, []float64{12, 12, 12}, 1.0, BuySide)
+		})
+
+		It("should publish a bar once the imbalance reaches the expectation", func() {
+			Expect(len(priceStream.Data)).To(Equal(2))
+			Expect(priceStream.Data[1].V()).To(Equal(2.0))
+			// the expectations are updated with the 2 trade bar and its imbalance of 1 per trade
+			Expect(priceStream.ExpectedImbalance()).To(Equal(3.0 * 0.75))
+		})
+	})
+})
+
+var _ = Describe("when building tick imbalance bars from a balanced first bar", func() {
+	var (
+		priceStream *TradeBarStream
+		start       time.Time
+	)
+
+	BeforeEach(func() {
+		priceStream, _ = NewTickImbalanceBarStream(4, 0.5)
+		start = time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC)
+		for i, side := range []TradeSide{BuySide, SellSide, BuySide, SellSide, BuySide, SellSide} {
+			priceStream.ReceiveTrade(NewTradeDataItem(start.Add(time.Duration(i)*time.Second), 10.0, 1.0, side))
+		}
+	})
+
+	It("should floor the expected imbalance at the imbalance of balanced trades", func() {
+		// the first bar of 4 trades seeds no imbalance, so the floor of 1 * sqrt(4) applies
+		Expect(len(priceStream.Data)).To(Equal(1))
+		Expect(priceStream.ExpectedImbalance()).To(Equal(2.0))
+	})
+
+	Context("and the trades turn to buys", func() {
+		BeforeEach(func() {
+			sendTrades(priceStream, start.Add(time.Minute), []float64{10, 10}, 1.0, BuySide)
+		})
+
+		It("should publish a bar once the imbalance reaches the floor", func() {
+			Expect(len(priceStream.Data)).To(Equal(2))
+			Expect(priceStream.Data[1].V()).To(Equal(4.0))
+		})
+	})
+})
+
+var _ = Describe("when aggregating trades into a one minute stream", func() {
+	var (
+		priceStream *IntraDayDOHLCVStream
+	)
+
+	BeforeEach(func() {
+		priceStream = NewIntraDayDOHLCVStream(1)
+		start := time.Date(2017, 6, 1, 12, 0, 30, 0, time.UTC)
+		sendTrades(priceStream, start, []float64{10, 11, 9, 10}, 1.5, BuySide)
+		sendTrades(priceStream, start.Add(time.Minute), []float64{12}, 1.0, SellSide)
+	})
+
+	It("should build time bars from the trade prices and sizes", func() {
+		Expect(len(priceStream.Data)).To(Equal(1))
+		Expect(priceStream.Data[0].D()).To(Equal(time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC)))
+		Expect(priceStream.Data[0].H()).To(Equal(11.0))
+		Expect(priceStream.Data[0].L()).To(Equal(9.0))
+		Expect(priceStream.Data[0].C()).To(Equal(10.0))
+		Expect(priceStream.Data[0].V()).To(Equal(6.0))
+	})
+})
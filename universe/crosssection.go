@@ -0,0 +1,126 @@
+package universe
+
+import (
+	"github.com/jaybutera/gotrade"
+	"github.com/jaybutera/gotrade/indicators"
+	"math"
+	"time"
+)
+
+// A CrossSection is the bars of every symbol of a universe for a date
+type CrossSection struct {
+	Date           time.Time
+	StreamBarIndex int
+	Bars           map[string]gotrade.DOHLCV
+}
+
+// Consumer of the cross sections of a universe
+type CrossSectionReceiver interface {
+	ReceiveCrossSection(section *CrossSection)
+}
+
+// A SymbolIndicatorFactory creates the indicator of a symbol on its price stream
+type SymbolIndicatorFactory func(symbol string, priceStream *gotrade.DOHLCVStream) (indicator gotrade.FloatStreamSubscriber, err error)
+
+// A CrossSectionalRank ranks the symbols of a universe by the result of an indicator of each symbol on each date,
+// the symbol with the highest result ranking 1 and symbols with equal results sharing the better rank
+type CrossSectionalRank struct {
+	symbols []string
+	latest  []*latestResult
+
+	// the dates ranked, the first being the first date any symbol has a result for
+	Dates []time.Time
+	// the rank of each symbol on each date, NaN for a symbol without a result for the date
+	Data map[string][]float64
+}
+
+// NewCrossSectionalRank creates an indicator for each symbol of the universe and ranks the symbols by their results
+func NewCrossSectionalRank(u *Universe, newIndicator SymbolIndicatorFactory) (indicator *CrossSectionalRank, err error) {
+	ind := CrossSectionalRank{
+		symbols: u.Symbols(),
+		latest:  make([]*latestResult, len(u.Symbols())),
+		Data:    make(map[string][]float64, len(u.Symbols())),
+	}
+
+	for i, symbol := range ind.symbols {
+		symbolIndicator, err := newIndicator(symbol, u.Stream(symbol))
+		if err != nil {
+			return nil, err
+		}
+
+		ind.latest[i] = &latestResult{}
+		symbolIndicator.AddTickSubscription(ind.latest[i])
+	}
+
+	u.AddCrossSectionSubscription(&ind)
+	return &ind, nil
+}
+
+// NewRelativeStrengthRank ranks the symbols of the universe by the rate of change of their close over the time period
+func NewRelativeStrengthRank(u *Universe, timePeriod int) (indicator *CrossSectionalRank, err error) {
+	return NewCrossSectionalRank(u, func(symbol string, priceStream *gotrade.DOHLCVStream) (gotrade.FloatStreamSubscriber, error) {
+		roc, err := indicators.NewRoc(timePeriod, gotrade.UseClosePrice)
+		if err != nil {
+			return nil, err
+		}
+
+		priceStream.AddTickSubscription(roc)
+		return roc, nil
+	})
+}
+
+// Length returns the number of dates ranked
+func (ind *CrossSectionalRank) Length() int {
+	return len(ind.Dates)
+}
+
+// Ranks returns the rank of each symbol on the date index
+func (ind *CrossSectionalRank) Ranks(dateIndex int) map[string]float64 {
+	ranks := make(map[string]float64, len(ind.symbols))
+	for _, symbol := range ind.symbols {
+		ranks[symbol] = ind.Data[symbol][dateIndex]
+	}
+	return ranks
+}
+
+func (ind *CrossSectionalRank) ReceiveCrossSection(section *CrossSection) {
+	results := make([]float64, len(ind.symbols))
+	hasResult := false
+	for i, latest := range ind.latest {
+		results[i] = math.NaN()
+		if latest.streamBarIndex == section.StreamBarIndex && !math.IsNaN(latest.value) {
+			results[i] = latest.value
+			hasResult = true
+		}
+	}
+
+	// wait for the indicators' lookback periods
+	if !hasResult && len(ind.Dates) == 0 {
+		return
+	}
+
+	ind.Dates = append(ind.Dates, section.Date)
+	for i, symbol := range ind.symbols {
+		rank := math.NaN()
+		if !math.IsNaN(results[i]) {
+			rank = 1.0
+			for _, result := range results {
+				if result > results[i] {
+					rank += 1.0
+				}
+			}
+		}
+		ind.Data[symbol] = append(ind.Data[symbol], rank)
+	}
+}
+
+// latestResult holds the latest result of a symbol's indicator
+type latestResult struct {
+	value          float64
+	streamBarIndex int
+}
+
+func (r *latestResult) ReceiveTick(tickData float64, streamBarIndex int) {
+	r.value = tickData
+	r.streamBarIndex = streamBarIndex
+}
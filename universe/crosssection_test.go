@@ -0,0 +1,80 @@
+package universe_test
+
+import (
+	. "github.com/jaybutera/gotrade/universe"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/jaybutera/gotrade"
+	"github.com/jaybutera/gotrade/indicators"
+	"math"
+	"time"
+)
+
+var _ = Describe("when ranking the relative strength of symbols", func() {
+	var (
+		u    *Universe
+		rank *CrossSectionalRank
+		err  error
+	)
+
+	BeforeEach(func() {
+		u = NewUniverse(NaNMissingBars)
+		u.AddSymbol("AAA", closes(map[int]float64{1: 10.0, 2: 11.0, 3: 12.0, 4: 11.0}))
+		u.AddSymbol("BBB", closes(map[int]float64{1: 10.0, 2: 12.0, 3: 11.0, 4: 12.0}))
+		u.AddSymbol("CCC", closes(map[int]float64{1: 10.0, 2: 10.0, 3: 12.0, 4: 13.0}))
+		u.AddSymbol("DDD", closes(map[int]float64{2: 12.0, 3: 12.0, 4: 13.0}))
+
+		rank, err = NewRelativeStrengthRank(u, 1)
+	})
+
+	It("should rank from the first date with a rate of change", func() {
+		Expect(err).To(BeNil())
+		Expect(u.Run()).To(BeNil())
+		Expect(rank.Length()).To(Equal(3))
+		Expect(rank.Dates).To(Equal([]time.Time{day(2), day(3), day(4)}))
+	})
+
+	It("should rank the highest rate of change first", func() {
+		u.Run()
+		// the rates of change on the 3rd are 9.1%, -8.3%, 20% and 0%
+		Expect(rank.Ranks(1)).To(Equal(map[string]float64{"AAA": 2.0, "BBB": 4.0, "CCC": 1.0, "DDD": 3.0}))
+	})
+
+	It("should share the better rank between equal rates of change", func() {
+		u.Run()
+		// the rates of change on the 4th are -8.3%, 9.1%, 8.3% and 8.3%
+		Expect(rank.Ranks(2)).To(Equal(map[string]float64{"AAA": 4.0, "BBB": 1.0, "CCC": 2.0, "DDD": 2.0}))
+	})
+
+	It("should not rank a symbol without a rate of change", func() {
+		u.Run()
+		Expect(math.IsNaN(rank.Data["DDD"][0])).To(BeTrue())
+		Expect(rank.Data["BBB"][0]).To(Equal(1.0))
+		Expect(rank.Data["CCC"][0]).To(Equal(3.0))
+	})
+
+	It("should return the error creating an indicator", func() {
+		rank, err = NewRelativeStrengthRank(u, 0)
+		Expect(rank).To(BeNil())
+		Expect(err).To(MatchError("timePeriod is less than the minimum (1)"))
+	})
+})
+
+var _ = Describe("when ranking symbols by an indicator", func() {
+	It("should rank by the results of the indicator created for each symbol", func() {
+		u := NewUniverse(SkipMissingBars)
+		u.AddSymbol("AAA", closes(map[int]float64{1: 10.0, 2: 30.0, 3: 40.0}))
+		u.AddSymbol("BBB", closes(map[int]float64{1: 20.0, 2: 20.0, 3: 20.0}))
+
+		var created []string
+		rank, err := NewCrossSectionalRank(u, func(symbol string, priceStream *gotrade.DOHLCVStream) (gotrade.FloatStreamSubscriber, error) {
+			created = append(created, symbol)
+			return indicators.NewSmaForStream(priceStream, 2, gotrade.UseClosePrice)
+		})
+		Expect(err).To(BeNil())
+		Expect(u.Run()).To(BeNil())
+
+		Expect(created).To(Equal([]string{"AAA", "BBB"}))
+		Expect(rank.Data).To(Equal(map[string][]float64{"AAA": {1.0, 1.0}, "BBB": {1.0, 2.0}}))
+	})
+})
@@ -0,0 +1,216 @@
+/*
+	import "github.com/jaybutera/gotrade/universe"
+
+	Package universe aligns the bars of many symbols by date, so that indicators can be calculated for each
+	symbol and compared across the symbols on each date.
+	A universe:
+		- loads the feed of each symbol and publishes its bars to a price stream of the symbol.
+		- publishes the bars of a date to every symbol's stream before any bar of a later date.
+		- fills the dates a symbol has no bar for according to its missing bar policy.
+		- publishes a cross section of the bars of every symbol after each date, e.g. to rank the symbols.
+
+	As the bars are aligned, the stream bar index of a date is the same for every symbol, e.g.
+
+		u := universe.NewUniverse(universe.ForwardFillMissingBars)
+		u.AddSymbol("AAA", aaaFeed)
+		u.AddSymbol("BBB", bbbFeed)
+
+		sma, err := indicators.NewSmaForStream(u.Stream("AAA"), 20, gotrade.UseClosePrice)
+		rank, err := universe.NewRelativeStrengthRank(u, 60)
+
+		err = u.Run()
+*/
+package universe
+
+import (
+	"errors"
+	"fmt"
+	"github.com/jaybutera/gotrade"
+	"github.com/jaybutera/gotrade/feeds"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	ErrSymbolIsEmpty       = errors.New("symbol must not be empty")
+	ErrSymbolAlreadyAdded  = errors.New("symbol has already been added")
+	ErrUniverseHasRun      = errors.New("universe has already been run")
+	ErrUniverseHasNoSymbol = errors.New("universe must contain at least 1 symbol")
+)
+
+// A Feed fills a price stream with the bars of a symbol, such as a CSVFileFeed
+type Feed interface {
+	FillDOHLCVStream(priceStream gotrade.DOHLCVStreamTickReceiver) error
+}
+
+// The MissingBarPolicy determines what a symbol's stream receives for a date it has no bar for
+type MissingBarPolicy int
+
+const (
+	// the date is dropped for every symbol, so only the dates all the symbols have a bar for are published
+	SkipMissingBars MissingBarPolicy = iota
+	// the symbol receives a bar at its previous close without volume, the dates before every symbol
+	// has received a bar are dropped
+	ForwardFillMissingBars
+	// the symbol receives a bar with NaN prices and volume, which an indicator receives as any other bar,
+	// so an indicator keeping a running total or smoothed value, such as an Sma or Ema, gives NaN results
+	// for every later bar
+	NaNMissingBars
+)
+
+func (p MissingBarPolicy) String() string {
+	switch p {
+	case ForwardFillMissingBars:
+		return "forward fill"
+	case NaNMissingBars:
+		return "NaN"
+	}
+	return "skip"
+}
+
+// A Universe of symbols with their bars aligned by date
+type Universe struct {
+	policy  MissingBarPolicy
+	symbols []string
+	feeds   map[string]Feed
+	streams map[string]*gotrade.DOHLCVStream
+	hasRun  bool
+
+	subscriberMutex sync.RWMutex
+	subscribers     []CrossSectionReceiver
+}
+
+// NewUniverse creates a universe without any symbols
+func NewUniverse(policy MissingBarPolicy) *Universe {
+	u := Universe{
+		policy:  policy,
+		feeds:   make(map[string]Feed),
+		streams: make(map[string]*gotrade.DOHLCVStream),
+	}
+	return &u
+}
+
+// Policy returns the missing bar policy of the universe
+func (u *Universe) Policy() MissingBarPolicy {
+	return u.policy
+}
+
+// AddSymbol adds a symbol and the feed of its bars, returning the stream its bars will be published to
+func (u *Universe) AddSymbol(symbol string, feed Feed) (stream *gotrade.DOHLCVStream, err error) {
+	if symbol == "" {
+		return nil, ErrSymbolIsEmpty
+	}
+
+	if _, ok := u.streams[symbol]; ok {
+		return nil, ErrSymbolAlreadyAdded
+	}
+
+	stream = gotrade.NewDOHLCVStream()
+	u.symbols = append(u.symbols, symbol)
+	u.feeds[symbol] = feed
+	u.streams[symbol] = stream
+	return stream, nil
+}
+
+// Symbols returns the symbols in the order they were added
+func (u *Universe) Symbols() []string {
+	return u.symbols
+}
+
+// Stream returns the price stream of a symbol, or nil for a symbol not in the universe
+func (u *Universe) Stream(symbol string) *gotrade.DOHLCVStream {
+	return u.streams[symbol]
+}
+
+// AddCrossSectionSubscription attaches a subscriber to the cross sections of the universe, a subscriber
+// receives a cross section after every symbol's stream has published its bar for the date
+func (u *Universe) AddCrossSectionSubscription(subscriber CrossSectionReceiver) {
+	u.subscriberMutex.Lock()
+	defer u.subscriberMutex.Unlock()
+	u.subscribers = append(u.subscribers, subscriber)
+}
+
+// Run loads the feed of every symbol and publishes their bars date by date, a universe can only be run once
+func (u *Universe) Run() (err error) {
+	if u.hasRun {
+		return ErrUniverseHasRun
+	}
+
+	if len(u.symbols) == 0 {
+		return ErrUniverseHasNoSymbol
+	}
+	u.hasRun = true
+
+	// the bars of each symbol by date
+	barsByDate := make([]map[int64]gotrade.DOHLCV, len(u.symbols))
+	dates := make(map[int64]time.Time)
+	for i, symbol := range u.symbols {
+		memoryFeed, err := feeds.LoadMemoryFeed(u.feeds[symbol].FillDOHLCVStream)
+		if err != nil {
+			return fmt.Errorf("%s: %v", symbol, err)
+		}
+
+		barsByDate[i] = make(map[int64]gotrade.DOHLCV, memoryFeed.Len())
+		for _, bar := range memoryFeed.Bars() {
+			key := bar.D().UnixNano()
+			barsByDate[i][key] = bar
+			if _, ok := dates[key]; !ok {
+				dates[key] = bar.D()
+			}
+		}
+	}
+
+	keys := make([]int64, 0, len(dates))
+	for key := range dates {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	previous := make([]gotrade.DOHLCV, len(u.symbols))
+	bars := make([]gotrade.DOHLCV, len(u.symbols))
+	streamBarIndex := 0
+	for _, key := range keys {
+		if !u.alignBars(barsByDate, key, dates[key], previous, bars) {
+			continue
+		}
+
+		streamBarIndex++
+		section := CrossSection{Date: dates[key], StreamBarIndex: streamBarIndex, Bars: make(map[string]gotrade.DOHLCV, len(u.symbols))}
+		for i, symbol := range u.symbols {
+			u.streams[symbol].ReceiveTick(bars[i])
+			section.Bars[symbol] = bars[i]
+		}
+
+		u.subscriberMutex.RLock()
+		subscribers := u.subscribers
+		u.subscriberMutex.RUnlock()
+		for _, subscriber := range subscribers {
+			subscriber.ReceiveCrossSection(&section)
+		}
+	}
+	return nil
+}
+
+// alignBars sets the bar of each symbol for the date, returning false when the date is dropped
+func (u *Universe) alignBars(barsByDate []map[int64]gotrade.DOHLCV, key int64, date time.Time, previous []gotrade.DOHLCV, bars []gotrade.DOHLCV) bool {
+	published := true
+	for i := range u.symbols {
+		bar, ok := barsByDate[i][key]
+		switch {
+		case ok:
+			previous[i] = bar
+		case u.policy == ForwardFillMissingBars && previous[i] != nil:
+			closePrice := previous[i].C()
+			bar = gotrade.NewDOHLCVDataItem(date, closePrice, closePrice, closePrice, closePrice, 0.0)
+		case u.policy == NaNMissingBars:
+			bar = gotrade.NewDOHLCVDataItem(date, math.NaN(), math.NaN(), math.NaN(), math.NaN(), math.NaN())
+		default:
+			// keep going so that a forward fill has the previous bar of every symbol
+			published = false
+		}
+		bars[i] = bar
+	}
+	return published
+}
@@ -0,0 +1,40 @@
+package universe_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/jaybutera/gotrade"
+	"testing"
+	"time"
+)
+
+func TestUniverse(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Universe Suite")
+}
+
+// barFeed fills a stream with bars given in the test
+type barFeed []gotrade.DOHLCV
+
+func (f barFeed) FillDOHLCVStream(priceStream gotrade.DOHLCVStreamTickReceiver) error {
+	for _, bar := range f {
+		priceStream.ReceiveTick(bar)
+	}
+	return nil
+}
+
+// day returns the date of a day in January 2014
+func day(dayOfMonth int) time.Time {
+	return time.Date(2014, time.January, dayOfMonth, 0, 0, 0, 0, time.UTC)
+}
+
+// closes creates a bar at each close for the days given as keys
+func closes(closesByDay map[int]float64) barFeed {
+	var feed barFeed
+	for dayOfMonth := 1; dayOfMonth <= 31; dayOfMonth++ {
+		if closePrice, ok := closesByDay[dayOfMonth]; ok {
+			feed = append(feed, gotrade.NewDOHLCVDataItem(day(dayOfMonth), closePrice, closePrice, closePrice, closePrice, 1000.0))
+		}
+	}
+	return feed
+}
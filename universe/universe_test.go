@@ -0,0 +1,163 @@
+package universe_test
+
+import (
+	. "github.com/jaybutera/gotrade/universe"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"errors"
+	"github.com/jaybutera/gotrade"
+	"github.com/jaybutera/gotrade/feeds"
+	"github.com/jaybutera/gotrade/indicators"
+	"math"
+	"time"
+)
+
+// sectionRecorder records the cross sections it receives
+type sectionRecorder []*CrossSection
+
+func (r *sectionRecorder) ReceiveCrossSection(section *CrossSection) {
+	*r = append(*r, section)
+}
+
+func dates(stream *gotrade.DOHLCVStream) []time.Time {
+	streamDates := make([]time.Time, len(stream.Data))
+	for i, bar := range stream.Data {
+		streamDates[i] = bar.D()
+	}
+	return streamDates
+}
+
+var _ = Describe("when aligning the bars of symbols", func() {
+	var (
+		u        *Universe
+		sections sectionRecorder
+	)
+
+	// AAA trades every day, BBB misses the 3rd and CCC starts on the 2nd
+	newUniverse := func(policy MissingBarPolicy) {
+		u = NewUniverse(policy)
+		u.AddSymbol("AAA", closes(map[int]float64{1: 10.0, 2: 11.0, 3: 12.0, 4: 13.0}))
+		u.AddSymbol("BBB", closes(map[int]float64{1: 20.0, 2: 21.0, 4: 23.0}))
+		u.AddSymbol("CCC", closes(map[int]float64{2: 31.0, 3: 32.0, 4: 33.0}))
+
+		sections = nil
+		u.AddCrossSectionSubscription(&sections)
+		Expect(u.Run()).To(BeNil())
+	}
+
+	Context("and skipping missing bars", func() {
+		BeforeEach(func() {
+			newUniverse(SkipMissingBars)
+		})
+
+		It("should only publish the dates every symbol has a bar for", func() {
+			for _, symbol := range u.Symbols() {
+				Expect(dates(u.Stream(symbol))).To(Equal([]time.Time{day(2), day(4)}))
+			}
+		})
+
+		It("should publish a cross section of each date", func() {
+			Expect(len(sections)).To(Equal(2))
+			Expect(sections[1].Date).To(Equal(day(4)))
+			Expect(sections[1].StreamBarIndex).To(Equal(2))
+			Expect(sections[1].Bars["BBB"].C()).To(Equal(23.0))
+		})
+	})
+
+	Context("and forward filling missing bars", func() {
+		BeforeEach(func() {
+			newUniverse(ForwardFillMissingBars)
+		})
+
+		It("should publish the dates from when every symbol has a bar", func() {
+			for _, symbol := range u.Symbols() {
+				Expect(dates(u.Stream(symbol))).To(Equal([]time.Time{day(2), day(3), day(4)}))
+			}
+		})
+
+		It("should fill a missing bar at the previous close without volume", func() {
+			filled := u.Stream("BBB").Data[1]
+			Expect(filled.D()).To(Equal(day(3)))
+			Expect([]float64{filled.O(), filled.H(), filled.L(), filled.C(), filled.V()}).To(Equal([]float64{21.0, 21.0, 21.0, 21.0, 0.0}))
+		})
+	})
+
+	Context("and emitting NaN for missing bars", func() {
+		BeforeEach(func() {
+			newUniverse(NaNMissingBars)
+		})
+
+		It("should publish every date", func() {
+			for _, symbol := range u.Symbols() {
+				Expect(dates(u.Stream(symbol))).To(Equal([]time.Time{day(1), day(2), day(3), day(4)}))
+			}
+		})
+
+		It("should publish a bar of NaN prices for a missing bar", func() {
+			Expect(math.IsNaN(u.Stream("CCC").Data[0].C())).To(BeTrue())
+			Expect(math.IsNaN(u.Stream("BBB").Data[2].C())).To(BeTrue())
+			Expect(u.Stream("BBB").Data[3].C()).To(Equal(23.0))
+		})
+	})
+
+	It("should return an error for a symbol added twice", func() {
+		u = NewUniverse(SkipMissingBars)
+		u.AddSymbol("AAA", barFeed{})
+		stream, err := u.AddSymbol("AAA", barFeed{})
+		Expect(stream).To(BeNil())
+		Expect(err).To(Equal(ErrSymbolAlreadyAdded))
+	})
+
+	It("should return the error of a symbol's feed", func() {
+		u = NewUniverse(SkipMissingBars)
+		u.AddSymbol("AAA", failingFeed{})
+		Expect(u.Run()).To(MatchError("AAA: unreadable"))
+	})
+
+	It("should return an error when run again", func() {
+		newUniverse(SkipMissingBars)
+		Expect(u.Run()).To(Equal(ErrUniverseHasRun))
+	})
+})
+
+// failingFeed fails to fill a stream
+type failingFeed struct{}
+
+func (failingFeed) FillDOHLCVStream(priceStream gotrade.DOHLCVStreamTickReceiver) error {
+	return errors.New("unreadable")
+}
+
+var _ = Describe("when attaching indicators to the symbols of a universe", func() {
+	It("should calculate each symbol's indicator on the aligned bars", func() {
+		csvFeed := feeds.NewCSVFileFeedWithDOHLCVFormat("../testdata/JSETOPI.ALL.data",
+			feeds.DashedYearDayMonthDateParserForLocation(time.Local))
+		memoryFeed, _ := feeds.LoadMemoryFeed(csvFeed.FillDOHLCVStream)
+
+		// the second symbol is missing the first 100 bars
+		u := NewUniverse(SkipMissingBars)
+		u.AddSymbol("FULL", memoryFeed)
+		u.AddSymbol("LATE", memoryFeed.Slice(100, memoryFeed.Len()))
+
+		full, _ := indicators.NewSmaForStream(u.Stream("FULL"), 10, gotrade.UseClosePrice)
+		late, _ := indicators.NewSmaForStream(u.Stream("LATE"), 10, gotrade.UseClosePrice)
+		Expect(u.Run()).To(BeNil())
+
+		Expect(full.Data).To(Equal(late.Data))
+		Expect(len(u.Stream("FULL").Data)).To(Equal(memoryFeed.Len() - 100))
+	})
+
+	It("should give NaN results from a missing bar onwards to an sma of a symbol with a gap", func() {
+		u := NewUniverse(NaNMissingBars)
+		u.AddSymbol("AAA", closes(map[int]float64{1: 10.0, 2: 11.0, 3: 12.0, 4: 13.0, 5: 14.0, 6: 15.0}))
+		u.AddSymbol("BBB", closes(map[int]float64{1: 20.0, 2: 21.0, 4: 23.0, 5: 24.0, 6: 25.0}))
+
+		sma, _ := indicators.NewSmaForStream(u.Stream("BBB"), 2, gotrade.UseClosePrice)
+		Expect(u.Run()).To(BeNil())
+
+		Expect(sma.Data).To(HaveLen(5))
+		Expect(sma.Data[0]).To(Equal(20.5))
+		for _, result := range sma.Data[1:] {
+			Expect(math.IsNaN(result)).To(BeTrue())
+		}
+	})
+})